@@ -21,6 +21,7 @@ type BridgeTypeRequest struct {
 	URL                    models.WebURL `json:"url"`
 	Confirmations          uint32        `json:"confirmations"`
 	MinimumContractPayment *assets.Link  `json:"minimumContractPayment"`
+	PoolConfig
 }
 
 // GetID returns the ID of this structure for jsonapi serialization.
@@ -60,8 +61,23 @@ type BridgeType struct {
 	Salt                   string
 	OutgoingToken          string
 	MinimumContractPayment *assets.Link
-	CreatedAt              time.Time
-	UpdatedAt              time.Time
+	PoolConfig
+	// EncryptedSigningSecret signs the requests to the bridge, and its callbacks, if it is
+	// set. It is encrypted by the SecretCipher of the ORM.
+	EncryptedSigningSecret         []byte
+	EncryptedPreviousSigningSecret []byte
+	CreatedAt                      time.Time
+	UpdatedAt                      time.Time
+}
+
+// SigningEnabled returns true if the requests to the bridge, and its callbacks, are signed.
+func (bt BridgeType) SigningEnabled() bool {
+	return len(bt.EncryptedSigningSecret) > 0
+}
+
+// Backends returns the URLs of the bridge's backends, starting with its primary URL.
+func (bt BridgeType) Backends() []models.WebURL {
+	return append([]models.WebURL{bt.URL}, bt.BackendURLs...)
 }
 
 // NewBridgeType returns a bridge type authentication (with plaintext
@@ -92,6 +108,7 @@ func NewBridgeType(btr *BridgeTypeRequest) (*BridgeTypeAuthentication,
 			Salt:                   salt,
 			OutgoingToken:          outgoingToken,
 			MinimumContractPayment: btr.MinimumContractPayment,
+			PoolConfig:             btr.PoolConfig.withDefaults(),
 		}, nil
 }
 
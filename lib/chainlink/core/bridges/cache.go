@@ -52,7 +52,7 @@ func NewCache(base ORM, lggr logger.Logger, upsertInterval time.Duration) *Cache
 }
 
 func (c *Cache) WithDataSource(ds sqlutil.DataSource) ORM {
-	return NewCache(NewORM(ds), c.eng, c.interval)
+	return NewCache(c.ORM.WithDataSource(ds), c.eng, c.interval)
 }
 
 func (c *Cache) FindBridge(ctx context.Context, name BridgeName) (BridgeType, error) {
@@ -148,6 +148,16 @@ func (c *Cache) UpdateBridgeType(ctx context.Context, bt *BridgeType, btr *Bridg
 	return nil
 }
 
+func (c *Cache) UpdateBridgeSigningSecret(ctx context.Context, bt *BridgeType, secret string) error {
+	if err := c.ORM.UpdateBridgeSigningSecret(ctx, bt, secret); err != nil {
+		return err
+	}
+
+	c.bridgeTypesCache.Store(bt.Name, *bt)
+
+	return nil
+}
+
 func (c *Cache) GetCachedResponse(ctx context.Context, dotId string, specId int32, maxElapsed time.Duration) ([]byte, error) {
 	// prefer to get latest value from cache
 	cached, inCache := c.latestValue(dotId, specId)
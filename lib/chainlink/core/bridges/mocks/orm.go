@@ -758,6 +758,113 @@ func (_c *ORM_GetCachedResponseWithFinished_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// SigningSecrets provides a mock function with given fields: ctx, bt
+func (_m *ORM) SigningSecrets(ctx context.Context, bt bridges.BridgeType) ([]string, error) {
+	ret := _m.Called(ctx, bt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SigningSecrets")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bridges.BridgeType) ([]string, error)); ok {
+		return rf(ctx, bt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bridges.BridgeType) []string); ok {
+		r0 = rf(ctx, bt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bridges.BridgeType) error); ok {
+		r1 = rf(ctx, bt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_SigningSecrets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SigningSecrets'
+type ORM_SigningSecrets_Call struct {
+	*mock.Call
+}
+
+// SigningSecrets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bt bridges.BridgeType
+func (_e *ORM_Expecter) SigningSecrets(ctx interface{}, bt interface{}) *ORM_SigningSecrets_Call {
+	return &ORM_SigningSecrets_Call{Call: _e.mock.On("SigningSecrets", ctx, bt)}
+}
+
+func (_c *ORM_SigningSecrets_Call) Run(run func(ctx context.Context, bt bridges.BridgeType)) *ORM_SigningSecrets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bridges.BridgeType))
+	})
+	return _c
+}
+
+func (_c *ORM_SigningSecrets_Call) Return(_a0 []string, _a1 error) *ORM_SigningSecrets_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_SigningSecrets_Call) RunAndReturn(run func(context.Context, bridges.BridgeType) ([]string, error)) *ORM_SigningSecrets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateBridgeSigningSecret provides a mock function with given fields: ctx, bt, secret
+func (_m *ORM) UpdateBridgeSigningSecret(ctx context.Context, bt *bridges.BridgeType, secret string) error {
+	ret := _m.Called(ctx, bt, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBridgeSigningSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *bridges.BridgeType, string) error); ok {
+		r0 = rf(ctx, bt, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ORM_UpdateBridgeSigningSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateBridgeSigningSecret'
+type ORM_UpdateBridgeSigningSecret_Call struct {
+	*mock.Call
+}
+
+// UpdateBridgeSigningSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bt *bridges.BridgeType
+//   - secret string
+func (_e *ORM_Expecter) UpdateBridgeSigningSecret(ctx interface{}, bt interface{}, secret interface{}) *ORM_UpdateBridgeSigningSecret_Call {
+	return &ORM_UpdateBridgeSigningSecret_Call{Call: _e.mock.On("UpdateBridgeSigningSecret", ctx, bt, secret)}
+}
+
+func (_c *ORM_UpdateBridgeSigningSecret_Call) Run(run func(ctx context.Context, bt *bridges.BridgeType, secret string)) *ORM_UpdateBridgeSigningSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*bridges.BridgeType), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ORM_UpdateBridgeSigningSecret_Call) Return(_a0 error) *ORM_UpdateBridgeSigningSecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ORM_UpdateBridgeSigningSecret_Call) RunAndReturn(run func(context.Context, *bridges.BridgeType, string) error) *ORM_UpdateBridgeSigningSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateBridgeType provides a mock function with given fields: ctx, bt, btr
 func (_m *ORM) UpdateBridgeType(ctx context.Context, bt *bridges.BridgeType, btr *bridges.BridgeTypeRequest) error {
 	ret := _m.Called(ctx, bt, btr)
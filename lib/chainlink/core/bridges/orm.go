@@ -20,6 +20,8 @@ type ORM interface {
 	BridgeTypes(ctx context.Context, offset int, limit int) ([]BridgeType, int, error)
 	CreateBridgeType(ctx context.Context, bt *BridgeType) error
 	UpdateBridgeType(ctx context.Context, bt *BridgeType, btr *BridgeTypeRequest) error
+	UpdateBridgeSigningSecret(ctx context.Context, bt *BridgeType, secret string) error
+	SigningSecrets(ctx context.Context, bt BridgeType) ([]string, error)
 
 	GetCachedResponse(ctx context.Context, dotId string, specId int32, maxElapsed time.Duration) ([]byte, error)
 	UpsertBridgeResponse(ctx context.Context, dotId string, specId int32, response []byte) error
@@ -37,20 +39,28 @@ type ORM interface {
 }
 
 type orm struct {
-	ds sqlutil.DataSource
+	ds     sqlutil.DataSource
+	cipher *SecretCipher
 }
 
 var _ ORM = (*orm)(nil)
 
+// NewORM returns an ORM that can't set or read the signing secrets of bridges, see
+// NewORMWithSecretCipher.
 func NewORM(ds sqlutil.DataSource) ORM {
 	return &orm{ds: ds}
 }
 
-func (o *orm) WithDataSource(ds sqlutil.DataSource) ORM { return NewORM(ds) }
+// NewORMWithSecretCipher returns an ORM that encrypts the signing secrets of bridges with cipher.
+func NewORMWithSecretCipher(ds sqlutil.DataSource, cipher *SecretCipher) ORM {
+	return &orm{ds: ds, cipher: cipher}
+}
+
+func (o *orm) WithDataSource(ds sqlutil.DataSource) ORM { return &orm{ds: ds, cipher: o.cipher} }
 
 func (o *orm) transact(ctx context.Context, readOnly bool, fn func(tx *orm) error) error {
 	opts := sqlutil.TxOptions{TxOptions: sql.TxOptions{ReadOnly: readOnly}}
-	return sqlutil.Transact(ctx, func(ds sqlutil.DataSource) *orm { return &orm{ds: ds} }, o.ds, &opts, fn)
+	return sqlutil.Transact(ctx, func(ds sqlutil.DataSource) *orm { return &orm{ds: ds, cipher: o.cipher} }, o.ds, &opts, fn)
 }
 
 // FindBridge looks up a Bridge by its Name.
@@ -124,9 +134,12 @@ func (o *orm) BridgeTypes(ctx context.Context, offset int, limit int) (bridges [
 
 // CreateBridgeType saves the bridge type.
 func (o *orm) CreateBridgeType(ctx context.Context, bt *BridgeType) error {
-	stmt := `INSERT INTO bridge_types (name, url, confirmations, incoming_token_hash, salt, outgoing_token, minimum_contract_payment, created_at, updated_at)
-	VALUES (:name, :url, :confirmations, :incoming_token_hash, :salt, :outgoing_token, :minimum_contract_payment, now(), now())
+	stmt := `INSERT INTO bridge_types (name, url, confirmations, incoming_token_hash, salt, outgoing_token, minimum_contract_payment,
+		backend_urls, selection_policy, health_check_path, health_check_interval, circuit_breaker_threshold, circuit_breaker_cooldown, created_at, updated_at)
+	VALUES (:name, :url, :confirmations, :incoming_token_hash, :salt, :outgoing_token, :minimum_contract_payment,
+		:backend_urls, :selection_policy, :health_check_path, :health_check_interval, :circuit_breaker_threshold, :circuit_breaker_cooldown, now(), now())
 	RETURNING *;`
+	bt.PoolConfig = bt.PoolConfig.withDefaults()
 	err := o.transact(ctx, false, func(tx *orm) error {
 		stmt, err := tx.ds.PrepareNamedContext(ctx, stmt)
 		if err != nil {
@@ -141,12 +154,59 @@ func (o *orm) CreateBridgeType(ctx context.Context, bt *BridgeType) error {
 
 // UpdateBridgeType updates the bridge type.
 func (o *orm) UpdateBridgeType(ctx context.Context, bt *BridgeType, btr *BridgeTypeRequest) error {
-	stmt := "UPDATE bridge_types SET url = $1, confirmations = $2, minimum_contract_payment = $3 WHERE name = $4 RETURNING *"
-	err := o.ds.GetContext(ctx, bt, stmt, btr.URL, btr.Confirmations, btr.MinimumContractPayment, bt.Name)
+	stmt := `UPDATE bridge_types SET url = $1, confirmations = $2, minimum_contract_payment = $3,
+		backend_urls = $4, selection_policy = $5, health_check_path = $6, health_check_interval = $7, circuit_breaker_threshold = $8, circuit_breaker_cooldown = $9
+	WHERE name = $10 RETURNING *`
+	cfg := btr.PoolConfig.withDefaults()
+	err := o.ds.GetContext(ctx, bt, stmt, btr.URL, btr.Confirmations, btr.MinimumContractPayment,
+		cfg.BackendURLs, cfg.SelectionPolicy, cfg.HealthCheckPath, cfg.HealthCheckInterval, cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown, bt.Name)
 
 	return err
 }
 
+// UpdateBridgeSigningSecret encrypts and replaces the signing secret of the bridge, keeping the
+// current secret as the previous one. An empty secret disables signing, and clears both.
+func (o *orm) UpdateBridgeSigningSecret(ctx context.Context, bt *BridgeType, secret string) error {
+	var encrypted []byte
+	if secret != "" {
+		if o.cipher == nil {
+			return ErrNoSecretCipher
+		}
+		var err error
+		if encrypted, err = o.cipher.Encrypt(ctx, secret); err != nil {
+			return pkgerrors.Wrap(err, "UpdateBridgeSigningSecret failed")
+		}
+	}
+	stmt := `UPDATE bridge_types SET
+		encrypted_previous_signing_secret = CASE WHEN $1::bytea IS NULL THEN NULL ELSE encrypted_signing_secret END,
+		encrypted_signing_secret = $1,
+		updated_at = now()
+	WHERE name = $2 RETURNING *`
+	err := o.ds.GetContext(ctx, bt, stmt, encrypted, bt.Name)
+
+	return pkgerrors.Wrap(err, "UpdateBridgeSigningSecret failed")
+}
+
+// SigningSecrets returns the decrypted secrets callbacks from the bridge can be signed with:
+// the current secret, and the previous one, so that the adapter can be updated after a rotation.
+func (o *orm) SigningSecrets(ctx context.Context, bt BridgeType) ([]string, error) {
+	var secrets []string
+	for _, encrypted := range [][]byte{bt.EncryptedSigningSecret, bt.EncryptedPreviousSigningSecret} {
+		if len(encrypted) == 0 {
+			continue
+		}
+		if o.cipher == nil {
+			return nil, ErrNoSecretCipher
+		}
+		secret, err := o.cipher.Decrypt(ctx, encrypted)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
 func (o *orm) GetCachedResponse(ctx context.Context, dotId string, specId int32, maxElapsed time.Duration) ([]byte, error) {
 	response, _, err := o.GetCachedResponseWithFinished(ctx, dotId, specId, maxElapsed)
 	if err != nil {
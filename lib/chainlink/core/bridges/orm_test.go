@@ -1,6 +1,7 @@
 package bridges_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -123,6 +124,25 @@ func TestORM_UpdateBridgeType(t *testing.T) {
 	foundbridge, err := orm.FindBridge(ctx, "UniqueName")
 	require.NoError(t, err)
 	require.Equal(t, updateBridge.URL, foundbridge.URL)
+	require.Equal(t, bridges.SelectionPolicyFailover, foundbridge.SelectionPolicy)
+	require.Empty(t, foundbridge.BackendURLs)
+
+	updateBridge.PoolConfig = bridges.PoolConfig{
+		BackendURLs:             bridges.BackendURLs{cltest.WebURL(t, "http://backupurl.com")},
+		SelectionPolicy:         bridges.SelectionPolicyRoundRobin,
+		HealthCheckPath:         "/health",
+		CircuitBreakerThreshold: 3,
+	}
+	require.NoError(t, orm.UpdateBridgeType(ctx, firstBridge, updateBridge))
+
+	foundbridge, err = orm.FindBridge(ctx, "UniqueName")
+	require.NoError(t, err)
+	require.Equal(t, updateBridge.BackendURLs, foundbridge.BackendURLs)
+	require.Equal(t, bridges.SelectionPolicyRoundRobin, foundbridge.SelectionPolicy)
+	require.Equal(t, "/health", foundbridge.HealthCheckPath)
+	require.Equal(t, bridges.DefaultHealthCheckInterval, foundbridge.HealthCheckInterval.Duration())
+	require.Equal(t, uint32(3), foundbridge.CircuitBreakerThreshold)
+	require.Equal(t, bridges.DefaultCircuitBreakerCooldown, foundbridge.CircuitBreakerCooldown.Duration())
 
 	bs, count, err := orm.BridgeTypes(ctx, 0, 10)
 	require.NoError(t, err)
@@ -137,6 +157,51 @@ func TestORM_UpdateBridgeType(t *testing.T) {
 	require.Empty(t, bs)
 }
 
+func TestORM_UpdateBridgeSigningSecret(t *testing.T) {
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	key := make([]byte, 32)
+	orm := bridges.NewORMWithSecretCipher(db, bridges.NewSecretCipher(func(context.Context) ([]byte, error) { return key, nil }))
+
+	bt := &bridges.BridgeType{
+		Name: "signed",
+		URL:  cltest.WebURL(t, "http://signed.com"),
+	}
+	require.NoError(t, orm.CreateBridgeType(ctx, bt))
+	require.False(t, bt.SigningEnabled())
+
+	require.NoError(t, orm.UpdateBridgeSigningSecret(ctx, bt, "first"))
+	require.NoError(t, orm.UpdateBridgeSigningSecret(ctx, bt, "second"))
+	found, err := orm.FindBridge(ctx, "signed")
+	require.NoError(t, err)
+	require.True(t, found.SigningEnabled())
+	secrets, err := orm.SigningSecrets(ctx, found)
+	require.NoError(t, err)
+	require.Equal(t, []string{"second", "first"}, secrets)
+
+	// The secrets are not stored in plaintext
+	var stored []byte
+	require.NoError(t, db.GetContext(ctx, &stored, `SELECT encrypted_signing_secret FROM bridge_types WHERE name = 'signed'`))
+	require.NotContains(t, string(stored), "second")
+
+	// ORMs without the key can't read or set them
+	_, err = bridges.NewORM(db).SigningSecrets(ctx, found)
+	require.ErrorIs(t, err, bridges.ErrNoSecretCipher)
+	require.ErrorIs(t, bridges.NewORM(db).UpdateBridgeSigningSecret(ctx, bt, "third"), bridges.ErrNoSecretCipher)
+	otherKey := make([]byte, 32)
+	otherKey[0] = 1
+	_, err = bridges.NewORMWithSecretCipher(db, bridges.NewSecretCipher(func(context.Context) ([]byte, error) { return otherKey, nil })).SigningSecrets(ctx, found)
+	require.ErrorIs(t, err, bridges.ErrUndecryptableSigningSecret)
+
+	require.NoError(t, orm.UpdateBridgeSigningSecret(ctx, bt, ""))
+	found, err = orm.FindBridge(ctx, "signed")
+	require.NoError(t, err)
+	require.False(t, found.SigningEnabled())
+	secrets, err = orm.SigningSecrets(ctx, found)
+	require.NoError(t, err)
+	require.Empty(t, secrets)
+}
+
 func TestORM_TestCachedResponse(t *testing.T) {
 	ctx := testutils.Context(t)
 	cfg := configtest.NewGeneralConfig(t, nil)
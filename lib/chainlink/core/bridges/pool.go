@@ -0,0 +1,444 @@
+package bridges
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+const (
+	PoolsServiceName = "BridgePools"
+
+	// DefaultHealthCheckInterval is how often backends are probed when a health check path is
+	// set without an interval.
+	DefaultHealthCheckInterval = 30 * time.Second
+	// DefaultCircuitBreakerCooldown is how long a circuit stays open when a circuit breaker
+	// threshold is set without a cooldown.
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+
+	healthCheckTimeout = 5 * time.Second
+	healthCheckTick    = 5 * time.Second
+	// idlePoolTimeout is how long a pool is kept, and its backends probed, after it was last used.
+	idlePoolTimeout = time.Hour
+	// latencyWeight is the weight of the latest request in the moving average of a backend's latency.
+	latencyWeight = 0.2
+)
+
+// ErrNoBackendAvailable is returned when every backend of a bridge is unhealthy or has an open circuit.
+var ErrNoBackendAvailable = errors.New("no bridge backend available")
+
+var (
+	promBridgeBackendLatency = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bridge_backend_latency_seconds",
+		Help: "Latency of the last request to a bridge backend in seconds, scoped by bridge name and backend URL",
+	},
+		[]string{"name", "backend"},
+	)
+	promBridgeBackendErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "bridge_backend_errors_total",
+		Help: "Failed requests to a bridge backend, scoped by bridge name and backend URL",
+	},
+		[]string{"name", "backend"},
+	)
+	promBridgeBackendHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bridge_backend_healthy",
+		Help: "Whether the last health probe of a bridge backend succeeded (1) or failed (0)",
+	},
+		[]string{"name", "backend"},
+	)
+	promBridgeBackendCircuitOpen = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "bridge_backend_circuit_open",
+		Help: "Whether the circuit breaker of a bridge backend is open (1) or closed (0)",
+	},
+		[]string{"name", "backend"},
+	)
+)
+
+// SelectionPolicy is the order in which the backends of a bridge are tried.
+type SelectionPolicy string
+
+const (
+	// SelectionPolicyFailover tries the primary URL first, then the backend URLs in order.
+	SelectionPolicyFailover SelectionPolicy = "failover"
+	// SelectionPolicyRoundRobin starts with the next backend on every request.
+	SelectionPolicyRoundRobin SelectionPolicy = "round_robin"
+	// SelectionPolicyFastestHealthy tries the backends with the lowest average latency first.
+	SelectionPolicyFastestHealthy SelectionPolicy = "fastest_healthy"
+)
+
+// Validate returns an error if the policy is unknown. Empty is the default, failover.
+func (p SelectionPolicy) Validate() error {
+	switch p {
+	case "", SelectionPolicyFailover, SelectionPolicyRoundRobin, SelectionPolicyFastestHealthy:
+		return nil
+	}
+	return fmt.Errorf("selectionPolicy must be one of %s, %s or %s, got %q",
+		SelectionPolicyFailover, SelectionPolicyRoundRobin, SelectionPolicyFastestHealthy, p)
+}
+
+// BackendURLs are the URLs of the backends of a bridge besides its primary URL.
+type BackendURLs []models.WebURL
+
+// Value implements the driver.Valuer interface.
+func (u BackendURLs) Value() (driver.Value, error) {
+	arr := make(pq.StringArray, len(u))
+	for i, backend := range u {
+		arr[i] = backend.String()
+	}
+	return arr.Value()
+}
+
+// Scan implements the sql.Scanner interface.
+func (u *BackendURLs) Scan(value interface{}) error {
+	var arr pq.StringArray
+	if err := arr.Scan(value); err != nil {
+		return err
+	}
+	urls := make(BackendURLs, len(arr))
+	for i, s := range arr {
+		parsed, err := url.Parse(s)
+		if err != nil {
+			return fmt.Errorf("invalid backend URL %q: %w", s, err)
+		}
+		urls[i] = models.WebURL(*parsed)
+	}
+	*u = urls
+	return nil
+}
+
+// PoolConfig configures the backends of a bridge, how they are health checked, and the
+// order in which they are tried. Without backend URLs, health check or circuit breaker, a
+// bridge only sends requests to its primary URL.
+type PoolConfig struct {
+	BackendURLs     BackendURLs     `json:"backendURLs" db:"backend_urls"`
+	SelectionPolicy SelectionPolicy `json:"selectionPolicy"`
+	// HealthCheckPath is probed with a GET request on each backend. Backends are not
+	// probed if it is empty.
+	HealthCheckPath     string          `json:"healthCheckPath"`
+	HealthCheckInterval models.Interval `json:"healthCheckInterval"`
+	// CircuitBreakerThreshold is the number of consecutive failed requests after which a
+	// backend is skipped for the cooldown. The circuit breaker is disabled if it is zero.
+	CircuitBreakerThreshold uint32          `json:"circuitBreakerThreshold"`
+	CircuitBreakerCooldown  models.Interval `json:"circuitBreakerCooldown"`
+}
+
+// Validate returns an error if the config is invalid.
+func (c PoolConfig) Validate() error {
+	var errs []error
+	for i, backend := range c.BackendURLs {
+		if backend.Host == "" {
+			errs = append(errs, fmt.Errorf("backendURLs[%d] must be an absolute URL", i))
+		}
+	}
+	if err := c.SelectionPolicy.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.HealthCheckPath != "" && c.HealthCheckPath[0] != '/' {
+		errs = append(errs, errors.New("healthCheckPath must start with /"))
+	}
+	if c.HealthCheckInterval < 0 || c.CircuitBreakerCooldown < 0 {
+		errs = append(errs, errors.New("healthCheckInterval and circuitBreakerCooldown must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c PoolConfig) withDefaults() PoolConfig {
+	if c.BackendURLs == nil {
+		c.BackendURLs = BackendURLs{}
+	}
+	if c.SelectionPolicy == "" {
+		c.SelectionPolicy = SelectionPolicyFailover
+	}
+	if c.HealthCheckPath != "" && c.HealthCheckInterval == 0 {
+		c.HealthCheckInterval = models.Interval(DefaultHealthCheckInterval)
+	}
+	if c.CircuitBreakerThreshold > 0 && c.CircuitBreakerCooldown == 0 {
+		c.CircuitBreakerCooldown = models.Interval(DefaultCircuitBreakerCooldown)
+	}
+	return c
+}
+
+// Backend is a URL serving a bridge. It tracks the latency and the errors of the requests
+// sent to it, and the state of its circuit breaker.
+type Backend struct {
+	URL models.WebURL
+
+	bridge   string
+	label    string
+	cfg      PoolConfig
+	mu       sync.Mutex
+	healthy  bool
+	failures uint32
+	openTill time.Time
+	latency  time.Duration
+}
+
+func newBackend(bridge BridgeName, u models.WebURL, cfg PoolConfig) *Backend {
+	b := &Backend{
+		URL:     u,
+		bridge:  bridge.String(),
+		label:   redactURL(u),
+		cfg:     cfg,
+		healthy: true,
+	}
+	promBridgeBackendHealthy.WithLabelValues(b.bridge, b.label).Set(1)
+	promBridgeBackendCircuitOpen.WithLabelValues(b.bridge, b.label).Set(0)
+	return b
+}
+
+// Report records the outcome of a request to the backend. failed must only be true when the
+// backend itself is at fault, e.g. it is unreachable or returned a server error.
+func (b *Backend) Report(latency time.Duration, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	promBridgeBackendLatency.WithLabelValues(b.bridge, b.label).Set(latency.Seconds())
+	if !failed {
+		if b.latency == 0 {
+			b.latency = latency
+		} else {
+			b.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(b.latency))
+		}
+		b.failures = 0
+		b.setOpenTill(time.Time{})
+		return
+	}
+
+	promBridgeBackendErrors.WithLabelValues(b.bridge, b.label).Inc()
+	b.failures++
+	if b.cfg.CircuitBreakerThreshold > 0 && b.failures >= b.cfg.CircuitBreakerThreshold {
+		b.setOpenTill(time.Now().Add(b.cfg.CircuitBreakerCooldown.Duration()))
+	}
+}
+
+func (b *Backend) setOpenTill(t time.Time) {
+	b.openTill = t
+	open := 0.0
+	if !t.IsZero() {
+		open = 1
+	}
+	promBridgeBackendCircuitOpen.WithLabelValues(b.bridge, b.label).Set(open)
+}
+
+func (b *Backend) setHealthy(healthy bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.healthy = healthy
+	v := 0.0
+	if healthy {
+		v = 1
+	}
+	promBridgeBackendHealthy.WithLabelValues(b.bridge, b.label).Set(v)
+}
+
+// available returns true if the backend passed its last health probe, and its circuit is
+// closed. Once the cooldown is over, requests are let through again, and the circuit opens
+// again on the first failure.
+func (b *Backend) available(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.healthy && !now.Before(b.openTill)
+}
+
+func (b *Backend) averageLatency() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.latency
+}
+
+// Pool is the set of backends of a bridge.
+type Pool struct {
+	name     BridgeName
+	url      models.WebURL
+	cfg      PoolConfig
+	backends []*Backend
+
+	next      atomic.Uint64
+	lastUsed  atomic.Int64
+	lastProbe time.Time
+}
+
+// NewPool returns a pool of the backends of the bridge, all of them initially healthy.
+func NewPool(bt BridgeType) *Pool {
+	cfg := bt.PoolConfig.withDefaults()
+	p := &Pool{name: bt.Name, url: bt.URL, cfg: cfg}
+	for _, u := range bt.Backends() {
+		p.backends = append(p.backends, newBackend(bt.Name, u, cfg))
+	}
+	return p
+}
+
+func (p *Pool) matches(bt BridgeType) bool {
+	return p.url == bt.URL && reflect.DeepEqual(p.cfg, bt.PoolConfig.withDefaults())
+}
+
+// Select returns the available backends in the order they should be tried, according to
+// the selection policy. It returns ErrNoBackendAvailable if there are none, so that
+// requests fail fast instead of waiting for unhealthy backends to time out.
+func (p *Pool) Select() ([]*Backend, error) {
+	now := time.Now()
+	p.lastUsed.Store(now.UnixNano())
+
+	ordered := make([]*Backend, len(p.backends))
+	copy(ordered, p.backends)
+	switch p.cfg.SelectionPolicy {
+	case SelectionPolicyRoundRobin:
+		start := int(p.next.Add(1)-1) % len(ordered) //nolint:gosec // G115
+		ordered = append(ordered[start:], ordered[:start]...)
+	case SelectionPolicyFastestHealthy:
+		// Backends without a measured latency go first, so that they get one.
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return ordered[i].averageLatency() < ordered[j].averageLatency()
+		})
+	}
+
+	available := ordered[:0]
+	for _, b := range ordered {
+		if b.available(now) {
+			available = append(available, b)
+		}
+	}
+	if len(available) == 0 {
+		return nil, fmt.Errorf("%w: all %d backends of bridge %s are unhealthy", ErrNoBackendAvailable, len(p.backends), p.name)
+	}
+	return available, nil
+}
+
+// probe checks the health of every backend if the pool has a health check and is due.
+func (p *Pool) probe(ctx context.Context, lggr logger.Logger, client *http.Client) {
+	if p.cfg.HealthCheckPath == "" || time.Since(p.lastProbe) < p.cfg.HealthCheckInterval.Duration() {
+		return
+	}
+	p.lastProbe = time.Now()
+
+	var wg sync.WaitGroup
+	for _, b := range p.backends {
+		wg.Add(1)
+		go func(b *Backend) {
+			defer wg.Done()
+			err := probeBackend(ctx, client, b.URL, p.cfg.HealthCheckPath)
+			if err != nil {
+				logger.Sugared(lggr).Warnw("Bridge backend failed health check", "bridge", p.name, "backend", b.label, "err", err)
+			}
+			b.setHealthy(err == nil)
+		}(b)
+	}
+	wg.Wait()
+}
+
+func probeBackend(ctx context.Context, client *http.Client, u models.WebURL, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	base := url.URL(u)
+	target := base.ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("got status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// redactURL drops the credentials and the query of a URL, so it can be used as a metric label.
+func redactURL(u models.WebURL) string {
+	redacted := url.URL{Scheme: u.Scheme, Host: u.Host, Path: u.Path}
+	return redacted.String()
+}
+
+// Pools keeps the backend pools of the bridges used by the node's jobs, and probes the health
+// of their backends in the background.
+type Pools struct {
+	services.Service
+	eng *services.Engine
+
+	client *http.Client
+	mu     sync.RWMutex
+	pools  map[BridgeName]*Pool
+}
+
+var _ services.Service = (*Pools)(nil)
+
+func NewPools(lggr logger.Logger, client *http.Client) *Pools {
+	p := &Pools{
+		client: client,
+		pools:  make(map[BridgeName]*Pool),
+	}
+	p.Service, p.eng = services.Config{
+		Name:  PoolsServiceName,
+		Start: p.start,
+	}.NewServiceEngine(lggr)
+	return p
+}
+
+// Get returns the pool of the bridge. The pool is replaced when the bridge's URLs or pool
+// config changed.
+func (p *Pools) Get(bt BridgeType) *Pool {
+	p.mu.RLock()
+	pool, ok := p.pools[bt.Name]
+	p.mu.RUnlock()
+	if ok && pool.matches(bt) {
+		return pool
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pool, ok = p.pools[bt.Name]; ok && pool.matches(bt) {
+		return pool
+	}
+	pool = NewPool(bt)
+	p.pools[bt.Name] = pool
+	return pool
+}
+
+func (p *Pools) start(_ context.Context) error {
+	ticker := services.TickerConfig{
+		Initial:   healthCheckTick,
+		JitterPct: services.DefaultJitter,
+	}.NewTicker(healthCheckTick)
+	p.eng.GoTick(ticker, p.probe)
+
+	return nil
+}
+
+func (p *Pools) probe(ctx context.Context) {
+	idleSince := time.Now().Add(-idlePoolTimeout).UnixNano()
+
+	p.mu.Lock()
+	var pools []*Pool
+	for name, pool := range p.pools {
+		if pool.lastUsed.Load() < idleSince {
+			delete(p.pools, name)
+			continue
+		}
+		pools = append(pools, pool)
+	}
+	p.mu.Unlock()
+
+	for _, pool := range pools {
+		pool.probe(ctx, p.eng, p.client)
+	}
+}
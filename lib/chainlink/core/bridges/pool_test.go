@@ -0,0 +1,138 @@
+package bridges_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+func backendURLs(backends []*bridges.Backend) []string {
+	var urls []string
+	for _, b := range backends {
+		urls = append(urls, b.URL.String())
+	}
+	return urls
+}
+
+func newPoolBridge(t *testing.T, cfg bridges.PoolConfig) bridges.BridgeType {
+	cfg.BackendURLs = bridges.BackendURLs{cltest.WebURL(t, "http://b.com"), cltest.WebURL(t, "http://c.com")}
+	return bridges.BridgeType{
+		Name:       "pooled",
+		URL:        cltest.WebURL(t, "http://a.com"),
+		PoolConfig: cfg,
+	}
+}
+
+func TestPool_Select(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failover", func(t *testing.T) {
+		t.Parallel()
+
+		pool := bridges.NewPool(newPoolBridge(t, bridges.PoolConfig{}))
+		for i := 0; i < 2; i++ {
+			backends, err := pool.Select()
+			require.NoError(t, err)
+			assert.Equal(t, []string{"http://a.com", "http://b.com", "http://c.com"}, backendURLs(backends))
+		}
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		t.Parallel()
+
+		pool := bridges.NewPool(newPoolBridge(t, bridges.PoolConfig{SelectionPolicy: bridges.SelectionPolicyRoundRobin}))
+		var first []string
+		for i := 0; i < 4; i++ {
+			backends, err := pool.Select()
+			require.NoError(t, err)
+			require.Len(t, backends, 3)
+			first = append(first, backends[0].URL.String())
+		}
+		assert.Equal(t, []string{"http://a.com", "http://b.com", "http://c.com", "http://a.com"}, first)
+	})
+
+	t.Run("fastest healthy", func(t *testing.T) {
+		t.Parallel()
+
+		pool := bridges.NewPool(newPoolBridge(t, bridges.PoolConfig{SelectionPolicy: bridges.SelectionPolicyFastestHealthy}))
+		backends, err := pool.Select()
+		require.NoError(t, err)
+		backends[0].Report(300*time.Millisecond, false)
+		backends[1].Report(100*time.Millisecond, false)
+		backends[2].Report(200*time.Millisecond, false)
+
+		backends, err = pool.Select()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"http://b.com", "http://c.com", "http://a.com"}, backendURLs(backends))
+	})
+}
+
+func TestPool_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	pool := bridges.NewPool(newPoolBridge(t, bridges.PoolConfig{
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  models.Interval(time.Hour),
+	}))
+	backends, err := pool.Select()
+	require.NoError(t, err)
+	primary := backends[0]
+
+	primary.Report(time.Second, true)
+	backends, err = pool.Select()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://a.com", "http://b.com", "http://c.com"}, backendURLs(backends))
+
+	primary.Report(time.Second, true)
+	backends, err = pool.Select()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://b.com", "http://c.com"}, backendURLs(backends))
+
+	backends[0].Report(time.Second, true)
+	backends[0].Report(time.Second, true)
+	backends[1].Report(time.Second, true)
+	backends[1].Report(time.Second, true)
+	_, err = pool.Select()
+	require.ErrorIs(t, err, bridges.ErrNoBackendAvailable)
+}
+
+func TestPoolConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, bridges.PoolConfig{}.Validate())
+	require.NoError(t, bridges.PoolConfig{
+		BackendURLs:     bridges.BackendURLs{cltest.WebURL(t, "http://b.com")},
+		SelectionPolicy: bridges.SelectionPolicyFastestHealthy,
+		HealthCheckPath: "/health",
+	}.Validate())
+
+	err := bridges.PoolConfig{
+		BackendURLs:     bridges.BackendURLs{cltest.WebURL(t, "/relative")},
+		SelectionPolicy: "random",
+		HealthCheckPath: "health",
+	}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "backendURLs[0] must be an absolute URL")
+	assert.Contains(t, err.Error(), `selectionPolicy must be one of failover, round_robin or fastest_healthy, got "random"`)
+	assert.Contains(t, err.Error(), "healthCheckPath must start with /")
+}
+
+func TestPools_Get(t *testing.T) {
+	t.Parallel()
+
+	pools := bridges.NewPools(logger.TestLogger(t), http.DefaultClient)
+	bt := newPoolBridge(t, bridges.PoolConfig{})
+	pool := pools.Get(bt)
+	assert.Same(t, pool, pools.Get(bt))
+
+	bt.SelectionPolicy = bridges.SelectionPolicyRoundRobin
+	assert.NotSame(t, pool, pools.Get(bt))
+}
@@ -0,0 +1,84 @@
+package bridges
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUndecryptableSigningSecret is returned when a signing secret was encrypted with a key
+// other than the one of this node, e.g. after the key ring was replaced. The secret must be set
+// again.
+var ErrUndecryptableSigningSecret = errors.New("signing secret was encrypted with another key, and must be set again")
+
+// ErrNoSecretCipher is returned when setting or reading a signing secret without a cipher to
+// encrypt them.
+var ErrNoSecretCipher = errors.New("signing secrets can't be encrypted or decrypted without a secret cipher")
+
+// SecretCipher encrypts signing secrets, of bridges and of webhook public triggers, with
+// AES-256-GCM, so that they are not stored in the database in plaintext. Its key must not be stored in the database either.
+type SecretCipher struct {
+	keyFn func(context.Context) ([]byte, error)
+
+	mu   sync.Mutex
+	aead cipher.AEAD
+}
+
+// NewSecretCipher returns a cipher with the 32 byte key returned by keyFn. The key is only
+// fetched when it is first needed, since it may come from the keystore, which is unlocked after
+// the ORMs are created.
+func NewSecretCipher(keyFn func(context.Context) ([]byte, error)) *SecretCipher {
+	return &SecretCipher{keyFn: keyFn}
+}
+
+func (c *SecretCipher) getAEAD(ctx context.Context) (cipher.AEAD, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.aead != nil {
+		return c.aead, nil
+	}
+	key, err := c.keyFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the key of the signing secrets: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	c.aead, err = cipher.NewGCM(block)
+	return c.aead, err
+}
+
+// Encrypt returns the secret encrypted, prefixed with the nonce it was encrypted with.
+func (c *SecretCipher) Encrypt(ctx context.Context, secret string) ([]byte, error) {
+	aead, err := c.getAEAD(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, []byte(secret), nil), nil
+}
+
+// Decrypt returns the secret encrypted by Encrypt.
+func (c *SecretCipher) Decrypt(ctx context.Context, ciphertext []byte) (string, error) {
+	aead, err := c.getAEAD(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return "", errors.New("encrypted signing secret is too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	secret, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUndecryptableSigningSecret, err)
+	}
+	return string(secret), nil
+}
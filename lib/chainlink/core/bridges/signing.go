@@ -0,0 +1,161 @@
+package bridges
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Requests to bridges with a signing secret are signed with an HMAC-SHA256 of
+// "chainlink-request.<timestamp>.<nonce>.<body>", sent hex encoded in SignatureHeader along
+// with the unix timestamp and the nonce. Callbacks from bridges are signed the same way, with
+// a message that starts with "chainlink-callback" and binds the method, path and task run of
+// the callback, so that a captured request to the bridge can't be replayed as a callback.
+const (
+	SignatureHeader = "X-Chainlink-Signature"
+	TimestampHeader = "X-Chainlink-Timestamp"
+	NonceHeader     = "X-Chainlink-Nonce"
+
+	// DefaultSignatureReplayWindow is how old, or far in the future, the timestamp of a
+	// signed callback can be.
+	DefaultSignatureReplayWindow = 5 * time.Minute
+
+	nonceSize = 16
+
+	requestSignaturePrefix  = "chainlink-request"
+	callbackSignaturePrefix = "chainlink-callback"
+)
+
+var (
+	// ErrMissingSignature is returned when a bridge with a signing secret gets an unsigned callback.
+	ErrMissingSignature = errors.New("missing request signature")
+	// ErrInvalidSignature is returned when a signature does not match any of the bridge's secrets.
+	ErrInvalidSignature = errors.New("invalid request signature")
+	// ErrReplayedRequest is returned when a signed request is outside of the replay window, or
+	// its nonce was already used.
+	ErrReplayedRequest = errors.New("request timestamp is outside of the replay window, or its nonce was already used")
+)
+
+// SignRequest returns the headers signing the body of a request with the secret.
+func SignRequest(secret string, body []byte, now time.Time) (http.Header, error) {
+	return sign(secret, []string{requestSignaturePrefix}, body, now)
+}
+
+// SignCallback returns the headers signing a callback resuming the task run with the secret.
+// The method and path are those of the callback request, e.g. PATCH /v2/resume/<taskID>.
+func SignCallback(secret string, method, path string, taskID uuid.UUID, body []byte, now time.Time) (http.Header, error) {
+	return sign(secret, callbackPrefix(method, path, taskID), body, now)
+}
+
+// VerifyRequestSignature checks that the body of a request was signed with one of the
+// secrets, and that the request is not a replay.
+func VerifyRequestSignature(secrets []string, header http.Header, body []byte, guard *ReplayGuard) error {
+	return verify(secrets, []string{requestSignaturePrefix}, header, body, guard)
+}
+
+// VerifyCallbackSignature checks that a callback resuming the task run was signed with one of
+// the secrets, for its method and path, and that it is not a replay.
+func VerifyCallbackSignature(secrets []string, method, path string, taskID uuid.UUID, header http.Header, body []byte, guard *ReplayGuard) error {
+	return verify(secrets, callbackPrefix(method, path, taskID), header, body, guard)
+}
+
+func callbackPrefix(method, path string, taskID uuid.UUID) []string {
+	return []string{callbackSignaturePrefix, strings.ToUpper(method), path, taskID.String()}
+}
+
+func sign(secret string, prefix []string, body []byte, now time.Time) (http.Header, error) {
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	header := make(http.Header)
+	header.Set(TimestampHeader, timestamp)
+	header.Set(NonceHeader, hex.EncodeToString(nonce))
+	header.Set(SignatureHeader, hex.EncodeToString(signature(secret, prefix, timestamp, header.Get(NonceHeader), body)))
+	return header, nil
+}
+
+func verify(secrets []string, prefix []string, header http.Header, body []byte, guard *ReplayGuard) error {
+	timestamp, nonce, sig := header.Get(TimestampHeader), header.Get(NonceHeader), header.Get(SignatureHeader)
+	if timestamp == "" || nonce == "" || sig == "" {
+		return ErrMissingSignature
+	}
+	decoded, err := hex.DecodeString(sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	var valid bool
+	for _, secret := range secrets {
+		if hmac.Equal(decoded, signature(secret, prefix, timestamp, nonce, body)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	return guard.Check(time.Unix(unix, 0), nonce, time.Now())
+}
+
+func signature(secret string, prefix []string, timestamp, nonce string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	for _, field := range prefix {
+		mac.Write([]byte(field + "."))
+	}
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// ReplayGuard rejects signed requests whose timestamp is outside of the replay window, or
+// whose nonce was already seen within it.
+type ReplayGuard struct {
+	window time.Duration
+
+	mu     sync.Mutex
+	nonces map[string]time.Time
+}
+
+func NewReplayGuard(window time.Duration) *ReplayGuard {
+	return &ReplayGuard{
+		window: window,
+		nonces: make(map[string]time.Time),
+	}
+}
+
+// Check returns ErrReplayedRequest if the request is a replay, and records its nonce otherwise.
+func (g *ReplayGuard) Check(timestamp time.Time, nonce string, now time.Time) error {
+	if timestamp.Before(now.Add(-g.window)) || timestamp.After(now.Add(g.window)) {
+		return ErrReplayedRequest
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for n, expiry := range g.nonces {
+		if now.After(expiry) {
+			delete(g.nonces, n)
+		}
+	}
+	if _, ok := g.nonces[nonce]; ok {
+		return ErrReplayedRequest
+	}
+	// The timestamp can't be used again after the window, so neither can the nonce.
+	g.nonces[nonce] = timestamp.Add(g.window)
+	return nil
+}
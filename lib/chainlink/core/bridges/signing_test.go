@@ -0,0 +1,94 @@
+package bridges_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+)
+
+func TestVerifyRequestSignature(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"data":{"from":"ETH","to":"USD"}}`)
+	header, err := bridges.SignRequest("secret", body, time.Now())
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.NoError(t, bridges.VerifyRequestSignature([]string{"new", "secret"}, header, body, guard))
+		require.ErrorIs(t, bridges.VerifyRequestSignature([]string{"secret"}, header, body, guard), bridges.ErrReplayedRequest)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.ErrorIs(t, bridges.VerifyRequestSignature([]string{"other"}, header, body, guard), bridges.ErrInvalidSignature)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.ErrorIs(t, bridges.VerifyRequestSignature([]string{"secret"}, header, []byte(`{}`), guard), bridges.ErrInvalidSignature)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.ErrorIs(t, bridges.VerifyRequestSignature([]string{"secret"}, nil, body, guard), bridges.ErrMissingSignature)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		old, err := bridges.SignRequest("secret", body, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.ErrorIs(t, bridges.VerifyRequestSignature([]string{"secret"}, old, body, guard), bridges.ErrReplayedRequest)
+	})
+
+	t.Run("tampered timestamp", func(t *testing.T) {
+		tampered := header.Clone()
+		tampered.Set(bridges.TimestampHeader, strconv.FormatInt(time.Now().Unix()+1, 10))
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.ErrorIs(t, bridges.VerifyRequestSignature([]string{"secret"}, tampered, body, guard), bridges.ErrInvalidSignature)
+	})
+}
+
+func TestVerifyCallbackSignature(t *testing.T) {
+	t.Parallel()
+
+	taskID := uuid.New()
+	path := "/v2/resume/" + taskID.String()
+	body := []byte(`{"value":"123"}`)
+	header, err := bridges.SignCallback("secret", "PATCH", path, taskID, body, time.Now())
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.NoError(t, bridges.VerifyCallbackSignature([]string{"secret"}, "PATCH", path, taskID, header, body, guard))
+		require.ErrorIs(t, bridges.VerifyCallbackSignature([]string{"secret"}, "PATCH", path, taskID, header, body, guard), bridges.ErrReplayedRequest)
+	})
+
+	t.Run("request to the bridge replayed as a callback", func(t *testing.T) {
+		request, err := bridges.SignRequest("secret", body, time.Now())
+		require.NoError(t, err)
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.ErrorIs(t, bridges.VerifyCallbackSignature([]string{"secret"}, "PATCH", path, taskID, request, body, guard), bridges.ErrInvalidSignature)
+	})
+
+	t.Run("callback replayed as a request to the bridge", func(t *testing.T) {
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.ErrorIs(t, bridges.VerifyRequestSignature([]string{"secret"}, header, body, guard), bridges.ErrInvalidSignature)
+	})
+
+	t.Run("other task run", func(t *testing.T) {
+		other := uuid.New()
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.ErrorIs(t, bridges.VerifyCallbackSignature([]string{"secret"}, "PATCH", "/v2/resume/"+other.String(), other, header, body, guard), bridges.ErrInvalidSignature)
+	})
+
+	t.Run("other method", func(t *testing.T) {
+		guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+		require.ErrorIs(t, bridges.VerifyCallbackSignature([]string{"secret"}, "POST", path, taskID, header, body, guard), bridges.ErrInvalidSignature)
+	})
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	cutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initAdminAuditSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "verify",
+			Usage:  "Verify the hash chain of the local audit log, fails if an entry was modified or removed",
+			Action: s.VerifyAuditLog,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "head",
+					Usage: "the last hash of a previous verification, which the chain must still contain",
+				},
+			},
+		},
+	}
+}
+
+type AuditLogVerificationPresenter struct {
+	JAID
+	presenters.AuditLogVerificationResource
+}
+
+var auditLogVerificationTableHeaders = []string{"Entries", "Valid", "Last entry", "Last hash", "First invalid entry", "Error"}
+
+// RenderTable implements TableRenderer
+func (p *AuditLogVerificationPresenter) RenderTable(rt RendererTable) error {
+	firstInvalid := ""
+	if p.FirstInvalidID != 0 {
+		firstInvalid = strconv.FormatInt(p.FirstInvalidID, 10)
+	}
+	renderList(auditLogVerificationTableHeaders, [][]string{{
+		strconv.FormatInt(p.Entries, 10),
+		strconv.FormatBool(p.Valid),
+		strconv.FormatInt(p.LastID, 10),
+		p.LastHash,
+		firstInvalid,
+		p.Error,
+	}}, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+// VerifyAuditLog verifies the hash chain of the audit log of the node, and returns an error if
+// it is broken
+func (s *Shell) VerifyAuditLog(c *cli.Context) (err error) {
+	path := "/v2/audit/verify"
+	if head := c.String("head"); head != "" {
+		path += "?head=" + url.QueryEscape(head)
+	}
+	resp, err := s.HTTP.Get(s.ctx(), path, nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var verification AuditLogVerificationPresenter
+	if err = s.renderAPIResponse(resp, &verification); err != nil {
+		return err
+	}
+	if !verification.Valid {
+		return s.errorOut(errors.Errorf("the audit log chain is broken: %s", verification.Error))
+	}
+	return nil
+}
@@ -131,6 +131,26 @@ func initAdminSubCmds(s *Shell) []cli.Command {
 				},
 			},
 		},
+		{
+			Name:        "roles",
+			Usage:       "Create, delete, or grant custom roles made of permissions",
+			Subcommands: initAdminRolesSubCmds(s),
+		},
+		{
+			Name:        "tokens",
+			Usage:       "Create, list, or revoke your named API tokens",
+			Subcommands: initAdminTokensSubCmds(s),
+		},
+		{
+			Name:        "audit",
+			Usage:       "Commands for the local audit log",
+			Subcommands: initAdminAuditSubCmds(s),
+		},
+		{
+			Name:        "keystore",
+			Usage:       "Commands for the encryption of the node's keystore",
+			Subcommands: initAdminKeystoreSubCmds(s),
+		},
 	}
 }
 
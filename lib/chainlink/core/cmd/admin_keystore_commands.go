@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	cutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initAdminKeystoreSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "rotate-password",
+			Usage:  "Re-encrypt the keystore under a new password, optionally raising the scrypt parameters",
+			Action: s.RotateKeystorePassword,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "old-password",
+					Usage: "text file holding the current keystore password, prompted for if not set",
+				},
+				cli.StringFlag{
+					Name:  "new-password",
+					Usage: "text file holding the new keystore password, prompted for if not set",
+				},
+				cli.IntFlag{
+					Name:  "scrypt-n",
+					Usage: "scrypt cost parameter N, a power of 2. Defaults to the current one, and cannot be lowered",
+				},
+				cli.IntFlag{
+					Name:  "scrypt-p",
+					Usage: "scrypt parallelization parameter P. Defaults to the current one, and cannot be lowered",
+				},
+				cli.BoolFlag{
+					Name:  "purge-backups",
+					Usage: "delete the backups of the keystore encrypted under previous passwords, once it is re-encrypted",
+				},
+			},
+		},
+	}
+}
+
+type KeystorePasswordPresenter struct {
+	JAID
+	presenters.KeystorePasswordResource
+}
+
+var keystorePasswordTableHeaders = []string{"Scrypt N", "Scrypt P"}
+
+// RenderTable implements TableRenderer
+func (p *KeystorePasswordPresenter) RenderTable(rt RendererTable) error {
+	renderList(keystorePasswordTableHeaders, [][]string{{
+		strconv.Itoa(p.ScryptN),
+		strconv.Itoa(p.ScryptP),
+	}}, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+// RotateKeystorePassword re-encrypts the keystore of the node under a new password
+func (s *Shell) RotateKeystorePassword(c *cli.Context) (err error) {
+	oldPassword, err := s.keystorePassword(c.String("old-password"), "Enter the current keystore password")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	newPassword, err := s.keystorePassword(c.String("new-password"), "Enter the new keystore password")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	if c.String("new-password") == "" {
+		fmt.Println("Confirm the new keystore password")
+		if s.PasswordPrompter.Prompt() != newPassword {
+			return s.errorOut(errors.New("new password and confirmation did not match"))
+		}
+	}
+
+	requestData, err := json.Marshal(web.RotateKeystorePasswordRequest{
+		OldPassword:  oldPassword,
+		NewPassword:  newPassword,
+		ScryptN:      c.Int("scrypt-n"),
+		ScryptP:      c.Int("scrypt-p"),
+		PurgeBackups: c.Bool("purge-backups"),
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Patch(s.ctx(), "/v2/keystore/password", bytes.NewBuffer(requestData))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	if resp.StatusCode == http.StatusConflict {
+		return s.errorOut(errors.New("old keystore password did not match"))
+	}
+	if err = s.renderAPIResponse(resp, &KeystorePasswordPresenter{}); err != nil {
+		return err
+	}
+	fmt.Println("Keystore password rotated. Update the keystore password in your secrets before the node restarts.")
+	return nil
+}
+
+// keystorePassword reads the password from file, or prompts for it if file is empty.
+func (s *Shell) keystorePassword(file string, prompt string) (string, error) {
+	if file != "" {
+		return utils.PasswordFromFile(file)
+	}
+	fmt.Println(prompt)
+	return s.PasswordPrompter.Prompt(), nil
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	cutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initAdminRolesSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "Lists all custom roles and their permissions",
+			Action: s.ListRoles,
+		},
+		{
+			Name:   "create",
+			Usage:  "Create a custom role, or replace the permissions of an existing one",
+			Action: s.CreateRole,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:     "name",
+					Usage:    "Name of the role",
+					Required: true,
+				},
+				cli.StringFlag{
+					Name:     "permissions",
+					Usage:    "Comma separated permissions of the role, e.g. 'jobs:create,jobs:delete,keys:vrf:export'",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "delete",
+			Usage:  "Delete a custom role and revoke its grants",
+			Action: s.DeleteRole,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:     "name",
+					Usage:    "Name of the role to delete",
+					Required: true,
+				},
+			},
+		},
+		{
+			Name:   "grants",
+			Usage:  "Lists the grants of custom roles",
+			Action: s.ListRoleGrants,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "email",
+					Usage: "only list the grants of this user",
+				},
+			},
+		},
+		{
+			Name:   "grant",
+			Usage:  "Grant a custom role to an API user",
+			Action: s.GrantRole,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:     "email",
+					Usage:    "Email of the user",
+					Required: true,
+				},
+				cli.StringFlag{
+					Name:     "role",
+					Usage:    "Name of the custom role",
+					Required: true,
+				},
+				cli.StringFlag{
+					Name:  "scope",
+					Usage: "Resources the role applies to. Options: 'node', 'job:<id>', 'job_type:<type>', 'key:<id or address>'.",
+					Value: "node",
+				},
+			},
+		},
+		{
+			Name:   "revoke",
+			Usage:  "Revoke a grant of a custom role",
+			Action: s.RevokeRole,
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:     "id",
+					Usage:    "ID of the grant to revoke",
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+type RolePresenter struct {
+	JAID
+	presenters.RoleResource
+}
+
+var roleTableHeaders = []string{"Name", "Permissions", "Created at"}
+
+func (p *RolePresenter) ToRow() []string {
+	perms := make([]string, len(p.Permissions))
+	for i, perm := range p.Permissions {
+		perms[i] = string(perm)
+	}
+	return []string{
+		p.ID,
+		strings.Join(perms, ", "),
+		p.CreatedAt.String(),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *RolePresenter) RenderTable(rt RendererTable) error {
+	renderList(roleTableHeaders, [][]string{p.ToRow()}, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+type RolePresenters []RolePresenter
+
+// RenderTable implements TableRenderer
+func (ps RolePresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	if _, err := rt.Write([]byte("Roles\n")); err != nil {
+		return err
+	}
+	renderList(roleTableHeaders, rows, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+type RoleGrantPresenter struct {
+	JAID
+	presenters.RoleGrantResource
+}
+
+var roleGrantTableHeaders = []string{"ID", "Email", "Role", "Scope", "Created at"}
+
+func (p *RoleGrantPresenter) ToRow() []string {
+	return []string{
+		p.ID,
+		p.Email,
+		p.Role,
+		sessions.Scope{Type: p.ScopeType, Value: p.ScopeValue}.String(),
+		p.CreatedAt.String(),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *RoleGrantPresenter) RenderTable(rt RendererTable) error {
+	renderList(roleGrantTableHeaders, [][]string{p.ToRow()}, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+type RoleGrantPresenters []RoleGrantPresenter
+
+// RenderTable implements TableRenderer
+func (ps RoleGrantPresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	if _, err := rt.Write([]byte("Role grants\n")); err != nil {
+		return err
+	}
+	renderList(roleGrantTableHeaders, rows, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+// ListRoles renders the custom roles and their permissions
+func (s *Shell) ListRoles(_ *cli.Context) (err error) {
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/roles", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &RolePresenters{})
+}
+
+// CreateRole creates a custom role, or replaces the permissions of an existing one
+func (s *Shell) CreateRole(c *cli.Context) (err error) {
+	request := web.CreateRoleRequest{Name: c.String("name")}
+	for _, p := range strings.Split(c.String("permissions"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			request.Permissions = append(request.Permissions, p)
+		}
+	}
+	if _, err = sessions.NewCustomRole(request.Name, request.Permissions); err != nil {
+		return s.errorOut(err)
+	}
+
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/roles", bytes.NewBuffer(requestData))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &RolePresenter{}, "Successfully saved role")
+}
+
+// DeleteRole deletes a custom role by name
+func (s *Shell) DeleteRole(c *cli.Context) (err error) {
+	name := c.String("name")
+	resp, err := s.HTTP.Delete(s.ctx(), "/v2/roles/"+url.PathEscape(name))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	if _, err = s.parseResponse(resp); err != nil {
+		return s.errorOut(err)
+	}
+
+	fmt.Printf("Role %s deleted\n", name)
+	return nil
+}
+
+// ListRoleGrants renders the grants of custom roles, optionally of a single user
+func (s *Shell) ListRoleGrants(c *cli.Context) (err error) {
+	q := url.Values{}
+	if c.IsSet("email") {
+		q.Set("email", c.String("email"))
+	}
+	uri := url.URL{Path: "/v2/roles/grants", RawQuery: q.Encode()}
+	resp, err := s.HTTP.Get(s.ctx(), uri.String(), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &RoleGrantPresenters{})
+}
+
+// GrantRole grants a custom role to a user on a scope, given as node or <type>:<value>
+func (s *Shell) GrantRole(c *cli.Context) (err error) {
+	scopeType, scopeValue, _ := strings.Cut(c.String("scope"), ":")
+	if _, err = sessions.ParseScope(scopeType, scopeValue); err != nil {
+		return s.errorOut(err)
+	}
+	request := web.CreateRoleGrantRequest{
+		Email:      c.String("email"),
+		Role:       c.String("role"),
+		ScopeType:  scopeType,
+		ScopeValue: scopeValue,
+	}
+
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/roles/grants", bytes.NewBuffer(requestData))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &RoleGrantPresenter{}, "Successfully granted role")
+}
+
+// RevokeRole revokes a grant of a custom role by ID
+func (s *Shell) RevokeRole(c *cli.Context) (err error) {
+	if !c.IsSet("id") {
+		return s.errorOut(errors.New("must pass the ID of the grant"))
+	}
+	id := strconv.FormatInt(c.Int64("id"), 10)
+	resp, err := s.HTTP.Delete(s.ctx(), "/v2/roles/grants/"+id)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	if _, err = s.parseResponse(resp); err != nil {
+		return s.errorOut(err)
+	}
+
+	fmt.Printf("Grant %s revoked\n", id)
+	return nil
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	cutils "github.com/smartcontractkit/chainlink-common/pkg/utils"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initAdminTokensSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "Lists your named API tokens",
+			Action: s.ListAPITokens,
+		},
+		{
+			Name:   "create",
+			Usage:  "Create a named API token, the secret is only displayed once",
+			Action: s.CreateAPIToken,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:     "name",
+					Usage:    "Name of the token",
+					Required: true,
+				},
+				cli.StringFlag{
+					Name:  "role",
+					Usage: "Role of the token, no higher than your own. Options: 'admin', 'edit', 'run', 'view'. Defaults to your role.",
+				},
+				cli.StringFlag{
+					Name:  "expires-in",
+					Usage: "Duration after which the token expires, at most 8760h",
+					Value: "720h",
+				},
+				cli.StringFlag{
+					Name:  "ip-allowlist",
+					Usage: "Comma separated IPs or CIDRs the token can be used from, e.g. '10.0.0.0/8,192.168.1.10'",
+				},
+			},
+		},
+		{
+			Name:   "revoke",
+			Usage:  "Revoke a named API token",
+			Action: s.RevokeAPIToken,
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:     "id",
+					Usage:    "ID of the token to revoke",
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+type APITokenPresenter struct {
+	JAID
+	presenters.APITokenResource
+}
+
+var apiTokenTableHeaders = []string{"ID", "Name", "Access key", "Role", "IP allowlist", "Expires at", "Last used at"}
+
+func (p *APITokenPresenter) ToRow() []string {
+	lastUsedAt := "never"
+	if p.LastUsedAt.Valid {
+		lastUsedAt = p.LastUsedAt.Time.String()
+	}
+	ipAllowlist := "any"
+	if len(p.IPAllowlist) > 0 {
+		ipAllowlist = strings.Join(p.IPAllowlist, ", ")
+	}
+	return []string{
+		p.ID,
+		p.Name,
+		p.AccessKey,
+		string(p.Role),
+		ipAllowlist,
+		p.ExpiresAt.String(),
+		lastUsedAt,
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *APITokenPresenter) RenderTable(rt RendererTable) error {
+	renderList(apiTokenTableHeaders, [][]string{p.ToRow()}, rt.Writer)
+	if p.Secret != "" {
+		if _, err := rt.Write([]byte("\nSecret (only displayed once): " + p.Secret + "\n")); err != nil {
+			return err
+		}
+	}
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+type APITokenPresenters []APITokenPresenter
+
+// RenderTable implements TableRenderer
+func (ps APITokenPresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	if _, err := rt.Write([]byte("API tokens\n")); err != nil {
+		return err
+	}
+	renderList(apiTokenTableHeaders, rows, rt.Writer)
+
+	return cutils.JustError(rt.Write([]byte("\n")))
+}
+
+// ListAPITokens renders the named API tokens of the current user
+func (s *Shell) ListAPITokens(_ *cli.Context) (err error) {
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/user/tokens", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &APITokenPresenters{})
+}
+
+// CreateAPIToken creates a named API token of the current user, after prompting for the password
+func (s *Shell) CreateAPIToken(c *cli.Context) (err error) {
+	request := sessions.NewAPITokenRequest{
+		Name:      c.String("name"),
+		Role:      c.String("role"),
+		ExpiresIn: c.String("expires-in"),
+	}
+	if _, err = time.ParseDuration(request.ExpiresIn); err != nil {
+		return s.errorOut(errors.Wrap(err, "invalid expiry"))
+	}
+	for _, ip := range strings.Split(c.String("ip-allowlist"), ",") {
+		if ip = strings.TrimSpace(ip); ip != "" {
+			request.IPAllowlist = append(request.IPAllowlist, ip)
+		}
+	}
+
+	fmt.Println("Password:")
+	request.Password = s.PasswordPrompter.Prompt()
+
+	requestData, err := json.Marshal(request)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/user/tokens", bytes.NewBuffer(requestData))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &APITokenPresenter{}, "Successfully created API token")
+}
+
+// RevokeAPIToken revokes a named API token of the current user by ID
+func (s *Shell) RevokeAPIToken(c *cli.Context) (err error) {
+	if !c.IsSet("id") {
+		return s.errorOut(errors.New("must pass the ID of the token"))
+	}
+	id := strconv.FormatInt(c.Int64("id"), 10)
+	resp, err := s.HTTP.Delete(s.ctx(), "/v2/user/tokens/"+id)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+	if _, err = s.parseResponse(resp); err != nil {
+		return s.errorOut(err)
+	}
+
+	fmt.Printf("API token %s revoked\n", id)
+	return nil
+}
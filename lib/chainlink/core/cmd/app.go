@@ -283,6 +283,16 @@ func NewApp(s *Shell) *cli.App {
 			Usage:       "Commands for managing External Initiators",
 			Subcommands: initInitiatorsSubCmds(s),
 		},
+		{
+			Name:        "vrf",
+			Usage:       "Commands for inspecting VRF requests",
+			Subcommands: initVRFSubCmds(s),
+		},
+		{
+			Name:        "workflows",
+			Usage:       "Commands for inspecting workflow executions",
+			Subcommands: initWorkflowsSubCmds(s),
+		},
 		{
 			Name:  "txs",
 			Usage: "Commands for handling transactions",
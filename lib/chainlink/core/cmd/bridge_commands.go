@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"errors"
+	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/urfave/cli"
 	"go.uber.org/multierr"
@@ -33,11 +35,27 @@ func initBrideSubCmds(s *Shell) []cli.Command {
 				},
 			},
 		},
+		{
+			Name:   "rotate-secret",
+			Usage:  "Rotate the secret signing a Bridge's requests and callbacks, enabling signing",
+			Action: s.RotateBridgeSigningSecret,
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "disable",
+					Usage: "disable signing and remove the secrets instead",
+				},
+			},
+		},
 		{
 			Name:   "show",
 			Usage:  "Show a Bridge's details",
 			Action: s.ShowBridge,
 		},
+		{
+			Name:   "update",
+			Usage:  "Update a Bridge's URLs, backend pool and settings",
+			Action: s.UpdateBridge,
+		},
 	}
 }
 
@@ -52,10 +70,12 @@ func (p *BridgePresenter) FriendlyConfirmations() string {
 
 // RenderTable implements TableRenderer
 func (p *BridgePresenter) RenderTable(rt RendererTable) error {
-	table := rt.newTable([]string{"Name", "URL", "Default Confirmations", "Outgoing Token"})
+	table := rt.newTable([]string{"Name", "URL", "Backend URLs", "Selection Policy", "Default Confirmations", "Outgoing Token"})
 	table.Append([]string{
 		p.Name,
 		p.URL,
+		strings.Join(p.BackendURLs, "\n"),
+		p.SelectionPolicy,
 		p.FriendlyConfirmations(),
 		p.OutgoingToken,
 	})
@@ -63,6 +83,22 @@ func (p *BridgePresenter) RenderTable(rt RendererTable) error {
 	return nil
 }
 
+type BridgeSigningSecretPresenter struct {
+	presenters.BridgeResource
+}
+
+// RenderTable implements TableRenderer
+func (p *BridgeSigningSecretPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Name", "Signing Enabled", "Signing Secret"})
+	table.Append([]string{
+		p.Name,
+		strconv.FormatBool(p.SigningEnabled),
+		p.SigningSecret,
+	})
+	render("Bridge Signing Secret", table)
+	return nil
+}
+
 type BridgePresenters []BridgePresenter
 
 // RenderTable implements TableRenderer
@@ -128,6 +164,7 @@ func (s *Shell) CreateBridge(c *cli.Context) (err error) {
 	return s.renderAPIResponse(resp, &BridgePresenter{})
 }
 
+// UpdateBridge updates the URLs, backend pool and settings of a bridge
 func (s *Shell) UpdateBridge(c *cli.Context) (err error) {
 	if !c.Args().Present() {
 		return s.errorOut(errors.New("must pass the name of the bridge to be updated"))
@@ -169,3 +206,28 @@ func (s *Shell) RemoveBridge(c *cli.Context) (err error) {
 
 	return s.renderAPIResponse(resp, &BridgePresenter{})
 }
+
+// RotateBridgeSigningSecret rotates the signing secret of a bridge, or disables signing.
+func (s *Shell) RotateBridgeSigningSecret(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the name of the bridge"))
+	}
+	path := "/v2/bridge_types/" + c.Args().First() + "/signing_secret"
+
+	var resp *http.Response
+	if c.Bool("disable") {
+		resp, err = s.HTTP.Delete(s.ctx(), path)
+	} else {
+		resp, err = s.HTTP.Post(s.ctx(), path, nil)
+	}
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &BridgeSigningSecretPresenter{})
+}
@@ -24,6 +24,7 @@ func TestBridgePresenter_RenderTable(t *testing.T) {
 	var (
 		name          = "Bridge 1"
 		url           = "http://example.com"
+		backendURL    = "http://backup.example.com"
 		createdAt     = time.Now()
 		outgoingToken = "anoutgoingtoken"
 		buffer        = bytes.NewBufferString("")
@@ -32,12 +33,14 @@ func TestBridgePresenter_RenderTable(t *testing.T) {
 
 	p := cmd.BridgePresenter{
 		BridgeResource: presenters.BridgeResource{
-			JAID:          presenters.NewJAID(name),
-			Name:          name,
-			URL:           url,
-			Confirmations: 10,
-			OutgoingToken: outgoingToken,
-			CreatedAt:     createdAt,
+			JAID:            presenters.NewJAID(name),
+			Name:            name,
+			URL:             url,
+			Confirmations:   10,
+			OutgoingToken:   outgoingToken,
+			BackendURLs:     []string{backendURL},
+			SelectionPolicy: "round_robin",
+			CreatedAt:       createdAt,
 		},
 	}
 
@@ -49,6 +52,8 @@ func TestBridgePresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, url)
 	assert.Contains(t, output, "10")
 	assert.Contains(t, output, outgoingToken)
+	assert.Contains(t, output, backendURL)
+	assert.Contains(t, output, "round_robin")
 
 	// Render many resources
 	buffer.Reset()
@@ -62,6 +67,30 @@ func TestBridgePresenter_RenderTable(t *testing.T) {
 	assert.NotContains(t, output, outgoingToken)
 }
 
+func TestBridgeSigningSecretPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		buffer = bytes.NewBufferString("")
+		r      = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.BridgeSigningSecretPresenter{
+		BridgeResource: presenters.BridgeResource{
+			JAID:           presenters.NewJAID("bridge"),
+			Name:           "bridge",
+			SigningEnabled: true,
+			SigningSecret:  "asigningsecret",
+		},
+	}
+	require.NoError(t, p.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "bridge")
+	assert.Contains(t, output, "true")
+	assert.Contains(t, output, "asigningsecret")
+}
+
 func TestShell_IndexBridges(t *testing.T) {
 	t.Parallel()
 	ctx := testutils.Context(t)
@@ -214,6 +243,8 @@ func TestShell_UpdateBridge(t *testing.T) {
 		{"OnlyName", []string{name}, true},
 		{"ValidUpdate", []string{name, fmt.Sprintf(`{ "name": "%s", "url": "http://localhost:3000/updated" }`, name)}, false},
 		{"InvalidJSON", []string{name, `{ "url": "http://localhost:3000/updated"`}, true},
+		{"ValidPoolUpdate", []string{name, fmt.Sprintf(`{ "name": "%s", "url": "http://localhost:3000/updated", "backendURLs": ["http://localhost:3001/updated"], "selectionPolicy": "round_robin", "circuitBreakerThreshold": 3 }`, name)}, false},
+		{"InvalidSelectionPolicy", []string{name, fmt.Sprintf(`{ "name": "%s", "url": "http://localhost:3000/updated", "selectionPolicy": "random" }`, name)}, true},
 	}
 
 	for _, tt := range tests {
@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
 	"github.com/urfave/cli"
+	"go.uber.org/multierr"
 
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
@@ -23,32 +28,45 @@ func initInitiatorsSubCmds(s *Shell) []cli.Command {
 			Usage:  "List all external initiators",
 			Action: s.IndexExternalInitiators,
 		},
+		{
+			Name:   "resync",
+			Usage:  "Announce all jobs to an external initiator again, retrying its failed notifications",
+			Action: s.ResyncExternalInitiator,
+		},
 	}
 }
 
+var externalInitiatorHeaders = []string{"ID", "Name", "URL", "AccessKey", "OutgoingToken", "Pending", "Dead", "LastError", "CreatedAt", "UpdatedAt"}
+
 type ExternalInitiatorPresenter struct {
 	JAID
 	presenters.ExternalInitiatorResource
 }
 
 func (eip *ExternalInitiatorPresenter) RenderTable(rt RendererTable) error {
-	table := rt.newTable([]string{"ID", "Name", "URL", "AccessKey", "OutgoingToken", "CreatedAt", "UpdatedAt"})
+	table := rt.newTable(externalInitiatorHeaders)
 	table.Append(eip.ToRow())
 	render("External Initiator:", table)
 	return nil
 }
 
 func (eip *ExternalInitiatorPresenter) ToRow() []string {
-	var urlS string
+	var urlS, lastErr string
 	if eip.URL != nil {
 		urlS = eip.URL.String()
 	}
+	if eip.LastNotificationError != nil {
+		lastErr = *eip.LastNotificationError
+	}
 	return []string{
 		eip.ID,
 		eip.Name,
 		urlS,
 		eip.AccessKey,
 		eip.OutgoingToken,
+		fmt.Sprint(eip.PendingNotifications),
+		fmt.Sprint(eip.DeadNotifications),
+		lastErr,
 		eip.CreatedAt.String(),
 		eip.UpdatedAt.String(),
 	}
@@ -57,7 +75,7 @@ func (eip *ExternalInitiatorPresenter) ToRow() []string {
 type ExternalInitiatorPresenters []ExternalInitiatorPresenter
 
 func (eips *ExternalInitiatorPresenters) RenderTable(rt RendererTable) error {
-	table := rt.newTable([]string{"ID", "Name", "URL", "AccessKey", "OutgoingToken", "CreatedAt", "UpdatedAt"})
+	table := rt.newTable(externalInitiatorHeaders)
 	for _, eip := range *eips {
 		table.Append(eip.ToRow())
 	}
@@ -69,3 +87,34 @@ func (eips *ExternalInitiatorPresenters) RenderTable(rt RendererTable) error {
 func (s *Shell) IndexExternalInitiators(c *cli.Context) (err error) {
 	return s.getPage("/v2/external_initiators", c.Int("page"), &ExternalInitiatorPresenters{})
 }
+
+type ExternalInitiatorResyncPresenter struct {
+	JAID
+	presenters.ExternalInitiatorResyncResource
+}
+
+func (p *ExternalInitiatorResyncPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Name", "Jobs"})
+	table.Append([]string{p.ID, fmt.Sprint(p.Jobs)})
+	render("External Initiator Resync:", table)
+	return nil
+}
+
+// ResyncExternalInitiator announces all jobs to an external initiator again
+func (s *Shell) ResyncExternalInitiator(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the name of the external initiator to resync"))
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/external_initiators/"+url.PathEscape(c.Args().First())+"/resync", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &ExternalInitiatorResyncPresenter{})
+}
@@ -23,19 +23,22 @@ func TestExternalInitiatorPresenter_RenderTable(t *testing.T) {
 		updatedAt     = time.Now()
 		outgoingToken = "anoutgoingtoken"
 		accessKey     = "anaccesskey"
+		lastError     = "connection refused"
 		buffer        = bytes.NewBufferString("")
 		r             = cmd.RendererTable{Writer: buffer}
 	)
 
 	p := cmd.ExternalInitiatorPresenter{
 		ExternalInitiatorResource: presenters.ExternalInitiatorResource{
-			JAID:          presenters.NewJAID(name),
-			Name:          name,
-			URL:           url,
-			AccessKey:     accessKey,
-			OutgoingToken: outgoingToken,
-			CreatedAt:     createdAt,
-			UpdatedAt:     updatedAt,
+			JAID:                  presenters.NewJAID(name),
+			Name:                  name,
+			URL:                   url,
+			AccessKey:             accessKey,
+			OutgoingToken:         outgoingToken,
+			DeadNotifications:     1,
+			LastNotificationError: &lastError,
+			CreatedAt:             createdAt,
+			UpdatedAt:             updatedAt,
 		},
 	}
 
@@ -47,6 +50,7 @@ func TestExternalInitiatorPresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, url.String())
 	assert.Contains(t, output, accessKey)
 	assert.Contains(t, output, outgoingToken)
+	assert.Contains(t, output, lastError)
 
 	// Render many resources
 	buffer.Reset()
@@ -58,4 +62,5 @@ func TestExternalInitiatorPresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, url.String())
 	assert.Contains(t, output, accessKey)
 	assert.Contains(t, output, outgoingToken)
+	assert.Contains(t, output, lastError)
 }
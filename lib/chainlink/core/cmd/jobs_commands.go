@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,6 +14,8 @@ import (
 	"github.com/urfave/cli"
 	"go.uber.org/multierr"
 
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
@@ -40,6 +44,30 @@ func initJobsSubCmds(s *Shell) []cli.Command {
 			Usage:  "Create a job",
 			Action: s.CreateJob,
 		},
+		{
+			Name:   "lint",
+			Usage:  "Validate a job spec and statically analyze its pipeline, without a running node",
+			Action: s.LintJob,
+		},
+		{
+			Name:   "simulate",
+			Usage:  "Run the pipeline of a job spec locally, with http, bridge, eth and vrf tasks served from fixtures, without creating the job or saving the run",
+			Action: s.SimulateJob,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "fixtures",
+					Usage: "directory with a <task>.json fixture for each task that is simulated",
+				},
+				cli.BoolFlag{
+					Name:  "record",
+					Usage: "run the pipeline on the node, with http, bridge, eth read and vrf tasks run for real, and record their results to the fixtures directory",
+				},
+				cli.StringFlag{
+					Name:  "vars",
+					Usage: "JSON object of variables to run the pipeline with, such as jobRun",
+				},
+			},
+		},
 		{
 			Name:   "delete",
 			Usage:  "Delete a job",
@@ -50,6 +78,11 @@ func initJobsSubCmds(s *Shell) []cli.Command {
 			Usage:  "Trigger a job run",
 			Action: s.TriggerPipelineRun,
 		},
+		{
+			Name:   "rotate-trigger-secret",
+			Usage:  "Generate a new secret for the hmac public trigger of a webhook job",
+			Action: s.RotateJobPublicTriggerSecret,
+		},
 	}
 }
 
@@ -213,6 +246,59 @@ func (ps JobPresenters) RenderTable(rt RendererTable) error {
 	return nil
 }
 
+// JobValidationPresenter wraps the JSONAPI Job Validation Resource and adds rendering functionality
+type JobValidationPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.JobValidationResource
+}
+
+// RenderTable implements TableRenderer
+func (p *JobValidationPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Severity", "Task", "Rule", "Message"})
+	for _, issue := range p.Issues {
+		table.Append([]string{string(issue.Severity), issue.DotID, issue.Rule, issue.Message})
+	}
+
+	render(fmt.Sprintf("Lint (%s job, %d issues)", p.Type, len(p.Issues)), table)
+	return nil
+}
+
+// JobSimulationPresenter wraps the JSONAPI Job Simulation Resource and adds rendering functionality
+type JobSimulationPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.JobSimulationResource
+}
+
+// RenderTable implements TableRenderer
+func (p *JobSimulationPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Task", "Type", "Simulated", "Inputs", "Output", "Error", "Duration"})
+	for _, tr := range p.TaskRuns {
+		var duration string
+		if tr.FinishedAt.Valid {
+			duration = tr.FinishedAt.Time.Sub(tr.CreatedAt).String()
+		}
+		table.Append([]string{
+			tr.DotID,
+			string(tr.Type),
+			strconv.FormatBool(tr.Simulated),
+			strings.Join(tr.Inputs, "\n"),
+			stringOrEmpty(tr.Output),
+			stringOrEmpty(tr.Error),
+			duration,
+		})
+	}
+
+	render(fmt.Sprintf("Simulated Run (%s)", p.State), table)
+	return nil
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // ListJobs lists all jobs
 func (s *Shell) ListJobs(c *cli.Context) (err error) {
 	return s.getPage("/v2/jobs", c.Int("page"), &JobPresenters{})
@@ -280,6 +366,132 @@ func (s *Shell) CreateJob(c *cli.Context) (err error) {
 	return err
 }
 
+// LintJob validates a job spec and statically analyzes its pipeline, and fails if any errors
+// are found. It does not need a running node.
+func (s *Shell) LintJob(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass in TOML or filepath"))
+	}
+
+	tomlString, err := getTOMLString(c.Args().First())
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	jobType, issues, err := job.LintSpec(tomlString)
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "invalid job spec"))
+	}
+	if issues == nil {
+		issues = pipeline.LintIssues{}
+	}
+
+	err = s.Render(&JobValidationPresenter{JobValidationResource: presenters.JobValidationResource{
+		Type:   presenters.JobSpecType(jobType),
+		Valid:  !issues.HasErrors(),
+		Issues: issues,
+	}})
+	if err != nil {
+		return s.errorOut(err)
+	}
+	if issues.HasErrors() {
+		return s.errorOut(errors.New("job spec has lint errors"))
+	}
+	return nil
+}
+
+// SimulateJob runs the pipeline of a job spec in memory without creating the job. Tasks that
+// interact with the outside world are served from fixtures, so the run needs no node. With
+// --record, the pipeline is run on the node instead, and the results of the tasks that read from
+// the outside world are recorded to the fixtures.
+func (s *Shell) SimulateJob(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass in TOML or filepath"))
+	}
+
+	tomlString, err := getTOMLString(c.Args().First())
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	record := c.Bool("record")
+	fixturesDir := c.String("fixtures")
+	if record && fixturesDir == "" {
+		return s.errorOut(errors.New("--fixtures must be set to record"))
+	}
+	var fixtures pipeline.Fixtures
+	if fixturesDir != "" {
+		if _, err = os.Stat(fixturesDir); err == nil || !record {
+			fixtures, err = pipeline.LoadFixtures(fixturesDir)
+			if err != nil {
+				return s.errorOut(err)
+			}
+		}
+	}
+
+	var vars map[string]interface{}
+	if v := c.String("vars"); v != "" {
+		if err = json.Unmarshal([]byte(v), &vars); err != nil {
+			return s.errorOut(errors.Wrap(err, "invalid vars"))
+		}
+	}
+
+	if record {
+		return s.recordJobSimulation(tomlString, vars, fixtures, fixturesDir)
+	}
+
+	jb, err := job.ParseSpec(tomlString)
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "invalid job spec"))
+	}
+	spec, pipelineVars, err := job.SimulationSpec(jb, vars)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	sim := pipeline.NewSimulation(fixtures, false)
+	run, _, err := pipeline.SimulateRun(s.ctx(), logger.NullLogger, spec, pipelineVars, sim)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	return s.Render(&JobSimulationPresenter{
+		JobSimulationResource: *presenters.NewJobSimulationResource(jb.ExternalJobID.String(), run, sim, logger.NullLogger),
+	})
+}
+
+// recordJobSimulation runs the pipeline of a job spec on the node, and saves the fixtures it
+// recorded to fixturesDir.
+func (s *Shell) recordJobSimulation(tomlString string, vars map[string]interface{}, fixtures pipeline.Fixtures, fixturesDir string) (err error) {
+	request, err := json.Marshal(web.SimulateJobRequest{
+		TOML:     tomlString,
+		Vars:     vars,
+		Fixtures: fixtures,
+		Record:   true,
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/jobs/simulate", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var presenter JobSimulationPresenter
+	if err = s.renderAPIResponse(resp, &presenter); err != nil {
+		return err
+	}
+	if err = presenter.Fixtures.Save(fixturesDir); err != nil {
+		return s.errorOut(err)
+	}
+	fmt.Printf("Recorded %d fixtures to %s\n", len(presenter.Fixtures), fixturesDir)
+	return nil
+}
+
 // DeleteJob deletes a job
 func (s *Shell) DeleteJob(c *cli.Context) error {
 	if !c.Args().Present() {
@@ -298,6 +510,37 @@ func (s *Shell) DeleteJob(c *cli.Context) error {
 	return nil
 }
 
+// WebhookPublicTriggerSecretPresenter wraps the JSONAPI resource of a public trigger secret
+type WebhookPublicTriggerSecretPresenter struct {
+	presenters.WebhookPublicTriggerSecretResource
+}
+
+// RenderTable implements TableRenderer
+func (p *WebhookPublicTriggerSecretPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Job ID", "Secret"})
+	table.Append([]string{p.ID, p.Secret})
+	render("Webhook Public Trigger Secret", table)
+	return nil
+}
+
+// RotateJobPublicTriggerSecret generates a new secret for the public trigger of a webhook job
+func (s *Shell) RotateJobPublicTriggerSecret(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the job id"))
+	}
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/jobs/"+c.Args().First()+"/public_trigger_secret", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &WebhookPublicTriggerSecretPresenter{})
+}
+
 // TriggerPipelineRun triggers a job run based on a job ID
 func (s *Shell) TriggerPipelineRun(c *cli.Context) error {
 	if !c.Args().Present() {
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/freeport"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
@@ -89,6 +91,64 @@ func TestJobPresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, createdAt.Format(time.RFC3339))
 }
 
+func TestJobValidationPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBufferString("")
+	r := cmd.RendererTable{Writer: buffer}
+
+	p := cmd.JobValidationPresenter{
+		JobValidationResource: presenters.JobValidationResource{
+			Type: presenters.WebhookJobSpec,
+			Issues: []pipeline.LintIssue{{
+				Severity: pipeline.LintSeverityError,
+				Rule:     pipeline.LintRuleUndefinedVariable,
+				DotID:    "ds1_parse",
+				Message:  "data references undefined variable $(ds)",
+			}},
+		},
+	}
+	require.NoError(t, p.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "webhook")
+	assert.Contains(t, output, "ds1_parse")
+	assert.Contains(t, output, pipeline.LintRuleUndefinedVariable)
+	assert.Contains(t, output, "data references undefined variable $(ds)")
+}
+
+func TestJobSimulationPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBufferString("")
+	r := cmd.RendererTable{Writer: buffer}
+
+	output := `"{\"price\": 21}"`
+	createdAt := time.Now()
+	p := cmd.JobSimulationPresenter{
+		JobSimulationResource: presenters.JobSimulationResource{
+			State: pipeline.RunStatusCompleted,
+			TaskRuns: []presenters.SimulatedTaskRunResource{{
+				Type:       pipeline.TaskTypeHTTP,
+				DotID:      "ds1",
+				Simulated:  true,
+				Output:     &output,
+				CreatedAt:  createdAt,
+				FinishedAt: null.TimeFrom(createdAt.Add(time.Second)),
+			}},
+		},
+	}
+	require.NoError(t, p.RenderTable(r))
+
+	rendered := buffer.String()
+	assert.Contains(t, rendered, "completed")
+	assert.Contains(t, rendered, "ds1")
+	assert.Contains(t, rendered, "http")
+	assert.Contains(t, rendered, "true")
+	assert.Contains(t, rendered, "price")
+	assert.Contains(t, rendered, "1s")
+}
+
 func TestJobRenderer_GetTasks(t *testing.T) {
 	t.Parallel()
 
@@ -404,6 +464,48 @@ func TestShell_CreateJobV2(t *testing.T) {
 	assert.Equal(t, "0x27548a32b9aD5D64c5945EaE9Da5337bc3169D15", output.OffChainReportingSpec.ContractAddress.String())
 }
 
+func TestShell_SimulateJob(t *testing.T) {
+	t.Parallel()
+
+	const spec = `
+type = "webhook"
+schemaVersion = 1
+observationSource = """
+ds    [type=http method=GET url="https://chain.link/eth_usd"];
+parse [type=jsonparse path="price"];
+mult  [type=multiply times=2];
+ds -> parse -> mult;
+"""
+`
+	price, err := pipeline.NewFixtureValue(`{"price": 21}`)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	require.NoError(t, pipeline.Fixtures{
+		"ds": {Type: pipeline.TaskTypeHTTP, Value: price},
+	}.Save(dir))
+
+	// No node is needed to replay fixtures
+	r := &cltest.RendererMock{}
+	client := cmd.Shell{Renderer: r}
+
+	fs := flag.NewFlagSet("", flag.ExitOnError)
+	flagSetApplyFromAction(client.SimulateJob, fs, "")
+	require.NoError(t, fs.Parse([]string{"--fixtures", dir, spec}))
+	require.NoError(t, client.SimulateJob(cli.NewContext(nil, fs, nil)))
+
+	require.Len(t, r.Renders, 1)
+	output := *r.Renders[0].(*cmd.JobSimulationPresenter)
+	assert.Equal(t, pipeline.RunStatusCompleted, output.State)
+	require.Len(t, output.Outputs, 1)
+	assert.Equal(t, "42", *output.Outputs[0])
+	require.Len(t, output.TaskRuns, 3)
+	for _, tr := range output.TaskRuns {
+		// Only the http task is served from fixtures
+		assert.Equal(t, tr.DotID == "ds", tr.Simulated, tr.DotID)
+	}
+	assert.Nil(t, output.Fixtures)
+}
+
 func TestShell_DeleteJob(t *testing.T) {
 	t.Parallel()
 
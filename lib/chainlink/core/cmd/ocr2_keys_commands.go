@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 
 	"github.com/pkg/errors"
@@ -24,8 +25,14 @@ func initOCR2KeysSubCmd(s *Shell) cli.Command {
 		Usage: "Remote commands for administering the node's off chain reporting keys",
 		Subcommands: cli.Commands{
 			{
-				Name:   "create",
-				Usage:  format(`Create an OCR2 key bundle, encrypted with password from the password file, and store it in the database`),
+				Name:  "create",
+				Usage: format(`Create an OCR2 key bundle, encrypted with password from the password file, and store it in the database`),
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "external-onchain-address",
+						Usage: "onchain signing `ADDRESS` of an OCR2 key held by the external signer, to use as the onchain key of an EVM bundle",
+					},
+				},
 				Action: s.CreateOCR2KeyBundle,
 			},
 			{
@@ -148,8 +155,15 @@ func (s *Shell) CreateOCR2KeyBundle(c *cli.Context) error {
 			errors.Errorf(`must pass the type to create, options are: %s`, chaintype.SupportedChainTypes.String()),
 		)
 	}
-	chainType := c.Args().Get(0)
-	resp, err := s.HTTP.Post(s.ctx(), "/v2/keys/ocr2/"+chainType, nil)
+	createURL := url.URL{
+		Path: "/v2/keys/ocr2/" + c.Args().Get(0),
+	}
+	if c.IsSet("external-onchain-address") {
+		query := createURL.Query()
+		query.Set("externalOnchainAddress", c.String("external-onchain-address"))
+		createURL.RawQuery = query.Encode()
+	}
+	resp, err := s.HTTP.Post(s.ctx(), createURL.String(), nil)
 	if err != nil {
 		return s.errorOut(err)
 	}
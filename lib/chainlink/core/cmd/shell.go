@@ -46,6 +46,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/external"
 	"github.com/smartcontractkit/chainlink/v2/core/services/llo"
 	"github.com/smartcontractkit/chainlink/v2/core/services/llo/retirement"
 	"github.com/smartcontractkit/chainlink/v2/core/services/periodicbackup"
@@ -207,7 +208,12 @@ func (n ChainlinkAppFactory) NewApplication(ctx context.Context, cfg chainlink.G
 	}
 
 	ds := sqlutil.WrapDataSource(db, appLggr, sqlutil.TimeoutHook(cfg.Database().DefaultQueryTimeout), sqlutil.MonitorHook(cfg.Database().LogSQL))
-	keyStore := keystore.New(ds, utils.GetScryptParams(cfg), appLggr)
+	var externalSigner external.Backend
+	if es := cfg.ExternalSigner(); es.Enabled() {
+		appLggr.Infow("Using external signer", "socketPath", es.SocketPath())
+		externalSigner = external.NewClient(es.SocketPath(), es.Timeout())
+	}
+	keyStore := keystore.NewWithExternalSigner(ds, utils.GetScryptParams(cfg), appLggr, externalSigner)
 
 	err = keyStoreAuthenticator.Authenticate(ctx, keyStore, cfg.Password())
 	if err != nil {
@@ -255,7 +261,7 @@ func (n ChainlinkAppFactory) NewApplication(ctx context.Context, cfg chainlink.G
 		Logger:                   appLggr,
 		Registerer:               appRegisterer,
 		AuditLogger:              auditLogger,
-		ExternalInitiatorManager: webhook.NewExternalInitiatorManager(ds, unrestrictedClient),
+		ExternalInitiatorManager: webhook.NewExternalInitiatorManager(ds, unrestrictedClient, appLggr),
 		Version:                  static.Version,
 		RestrictedHTTPClient:     clhttp.NewRestrictedClient(cfg.Database(), appLggr),
 		UnrestrictedHTTPClient:   unrestrictedClient,
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initVRFSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "requests",
+			Usage: "Commands for inspecting VRF requests handled by the node",
+			Subcommands: cli.Commands{
+				{
+					Name:   "show",
+					Usage:  "Show the lifecycle of a VRF request, given its request ID in decimal or 0x prefixed hex",
+					Action: s.ShowVRFRequest,
+				},
+			},
+		},
+		{
+			Name:  "proof",
+			Usage: "Commands for VRF proofs",
+			Subcommands: cli.Commands{
+				{
+					Name: "verify",
+					Usage: "Verify the proof of a fulfilled VRF v2 or v2plus request against its request commitment. " +
+						"Runs offline, unless --attest is set",
+					Action: s.VerifyVRFProof,
+					Flags: []cli.Flag{
+						cli.StringFlag{Name: "public-key", Usage: "compressed public key of the VRF key the request was made to"},
+						cli.StringFlag{Name: "calldata", Usage: "0x prefixed hex input of the fulfillment transaction, to the coordinator or batch coordinator"},
+						cli.StringFlag{Name: "block-hash", Usage: "hash of the block the request was made in"},
+						cli.Uint64Flag{Name: "block-number", Usage: "number of the block the request was made in"},
+						cli.StringFlag{Name: "sub-id", Usage: "subscription ID of the request"},
+						cli.UintFlag{Name: "callback-gas-limit", Usage: "callback gas limit of the request"},
+						cli.UintFlag{Name: "num-words", Usage: "number of random words requested"},
+						cli.StringFlag{Name: "sender", Usage: "address of the consumer which made the request"},
+						cli.StringFlag{Name: "extra-args", Usage: "0x prefixed hex extra args of a v2plus request"},
+						cli.StringFlag{Name: "request-id", Usage: "ID of the request, required if the calldata fulfills several requests"},
+						cli.BoolFlag{Name: "attest", Usage: "have the node verify the proof and sign an attestation of the outcome with its CSA key"},
+						cli.StringFlag{Name: "output, o", Usage: "path to write the signed attestation to, requires --attest"},
+					},
+				},
+			},
+		},
+	}
+}
+
+type VRFRequestLifecyclePresenter struct {
+	JAID // Include this to overwrite the presenter JAID so it can correctly render the ID in JSON
+	presenters.VRFRequestLifecycleResource
+}
+
+// RenderTable implements TableRenderer
+func (p *VRFRequestLifecyclePresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Chain ID", "Job ID", "Stage", "Occurrences", "First Seen", "Last Seen", "Details"})
+	for _, e := range p.Events {
+		details, err := json.Marshal(e.Details)
+		if err != nil {
+			return err
+		}
+		table.Append([]string{
+			e.EVMChainID.String(),
+			strconv.FormatInt(int64(e.JobID), 10),
+			string(e.Stage),
+			strconv.FormatInt(e.Occurrences, 10),
+			e.FirstSeenAt.Format(time.RFC3339),
+			e.LastSeenAt.Format(time.RFC3339),
+			string(details),
+		})
+	}
+	render(fmt.Sprintf("VRF Request %s (%s)", p.RequestID, p.CurrentStage), table)
+
+	if len(p.Transactions) == 0 {
+		return nil
+	}
+	txs := make(EthTxPresenters, len(p.Transactions))
+	for i, tx := range p.Transactions {
+		txs[i] = EthTxPresenter{EthTxResource: tx}
+	}
+	return txs.RenderTable(rt)
+}
+
+// ShowVRFRequest shows the recorded lifecycle of the VRF request with the given ID.
+func (s *Shell) ShowVRFRequest(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the ID of the VRF request"))
+	}
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/vrf/requests/"+url.PathEscape(c.Args().First()))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &VRFRequestLifecyclePresenter{})
+}
+
+var errVRFProofInvalid = errors.New("VRF proof is invalid")
+
+type VRFProofAttestationPresenter struct {
+	JAID // Include this to overwrite the presenter JAID so it can correctly render the ID in JSON
+	presenters.VRFProofAttestationResource
+}
+
+// RenderTable implements TableRenderer
+func (p *VRFProofAttestationPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Check", "Passed", "Error"})
+	for _, c := range p.Checks {
+		table.Append([]string{c.Name, strconv.FormatBool(c.Passed), c.Error})
+	}
+	render(fmt.Sprintf("VRF Proof of Request %s (valid: %t)", p.RequestID, p.Valid), table)
+	return nil
+}
+
+// VerifyVRFProof verifies the proof of a fulfilled VRF request. With --attest, the
+// node verifies it and signs an attestation of the outcome, which is optionally
+// written to a file. Otherwise the proof is verified locally, without a node.
+// Either way, an invalid proof is reported as an error after rendering the checks.
+func (s *Shell) VerifyVRFProof(c *cli.Context) (err error) {
+	request, err := vrfProofVerificationRequest(c)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	if !c.Bool("attest") {
+		if c.IsSet("output") {
+			return s.errorOut(errors.New("--output requires --attest"))
+		}
+		v, err2 := proof.VerifyFulfillment(proof.FulfillmentVerificationRequest{
+			PublicKey:  request.PublicKey,
+			RequestID:  request.RequestID.ToInt(),
+			BlockHash:  request.BlockHash,
+			Commitment: request.Commitment,
+			Calldata:   request.Calldata,
+		})
+		if err2 != nil {
+			return s.errorOut(err2)
+		}
+		if err2 = s.Render(&VRFProofAttestationPresenter{
+			VRFProofAttestationResource: presenters.NewVRFProofAttestationResource(v, proof.SignedAttestation{}),
+		}); err2 != nil {
+			return s.errorOut(err2)
+		}
+		if !v.Valid {
+			return s.errorOut(errVRFProofInvalid)
+		}
+		return nil
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/vrf/proofs/verify", bytes.NewReader(body))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var p VRFProofAttestationPresenter
+	if err = s.renderAPIResponse(resp, &p); err != nil {
+		return err
+	}
+
+	output := c.String("output")
+	if output == "" {
+		if !p.Valid {
+			return s.errorOut(errVRFProofInvalid)
+		}
+		return nil
+	}
+	attestation, err := json.MarshalIndent(proof.SignedAttestation{
+		Attestation: p.Attestation,
+		PublicKey:   p.PublicKey,
+		Signature:   p.Signature,
+	}, "", "  ")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	if err = utils.WriteFileWithMaxPerms(output, attestation, 0o600); err != nil {
+		return s.errorOut(errors.Wrapf(err, "Could not write %v", output))
+	}
+	if _, err = os.Stderr.WriteString(fmt.Sprintf("Wrote signed attestation to %s\n", output)); err != nil {
+		return err
+	}
+	if !p.Valid {
+		return s.errorOut(errVRFProofInvalid)
+	}
+	return nil
+}
+
+func vrfProofVerificationRequest(c *cli.Context) (web.VerifyVRFProofRequest, error) {
+	var request web.VerifyVRFProofRequest
+	for _, flag := range []string{"public-key", "calldata", "block-hash", "block-number", "sub-id", "callback-gas-limit", "num-words", "sender"} {
+		if !c.IsSet(flag) {
+			return request, errors.Errorf("must pass --%s", flag)
+		}
+	}
+
+	var err error
+	if request.PublicKey, err = secp256k1.NewPublicKeyFromHex(c.String("public-key")); err != nil {
+		return request, errors.Wrap(err, "failed to parse public key")
+	}
+	if request.Calldata, err = hexutil.Decode(c.String("calldata")); err != nil {
+		return request, errors.Wrap(err, "failed to parse calldata")
+	}
+	if !common.IsHexAddress(c.String("sender")) {
+		return request, errors.Errorf("invalid sender address %q", c.String("sender"))
+	}
+	subID, ok := new(big.Int).SetString(c.String("sub-id"), 0)
+	if !ok {
+		return request, errors.Errorf("invalid subscription ID %q", c.String("sub-id"))
+	}
+	if c.IsSet("request-id") {
+		requestID, ok := new(big.Int).SetString(c.String("request-id"), 0)
+		if !ok {
+			return request, errors.Errorf("invalid request ID %q", c.String("request-id"))
+		}
+		request.RequestID = ubig.New(requestID)
+	}
+
+	request.BlockHash = common.HexToHash(c.String("block-hash"))
+	request.Commitment = proof.RequestCommitment{
+		BlockNum:         c.Uint64("block-number"),
+		SubID:            ubig.New(subID),
+		CallbackGasLimit: uint32(c.Uint("callback-gas-limit")),
+		NumWords:         uint32(c.Uint("num-words")),
+		Sender:           common.HexToAddress(c.String("sender")),
+	}
+	if extraArgs := strings.TrimSpace(c.String("extra-args")); extraArgs != "" {
+		if request.Commitment.ExtraArgs, err = hexutil.Decode(extraArgs); err != nil {
+			return request, errors.Wrap(err, "failed to parse extra args")
+		}
+	}
+	return request, nil
+}
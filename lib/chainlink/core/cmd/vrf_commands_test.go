@@ -0,0 +1,87 @@
+package cmd_test
+
+import (
+	"bytes"
+	"flag"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2plus_interface"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+)
+
+func TestShell_VerifyVRFProof_Offline(t *testing.T) {
+	t.Parallel()
+
+	key := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1))
+	seed, err := proof.BigToSeed(big.NewInt(1))
+	require.NoError(t, err)
+	preSeed := proof.PreSeedDataV2Plus{
+		PreSeed:          seed,
+		BlockHash:        common.HexToHash("0x1234"),
+		BlockNum:         10,
+		SubId:            big.NewInt(123),
+		CallbackGasLimit: 100_000,
+		NumWords:         2,
+		Sender:           common.HexToAddress("0xabc"),
+	}
+	p, err := key.GenerateProof(proof.FinalSeedV2Plus(preSeed))
+	require.NoError(t, err)
+	onChainProof, rc, err := proof.GenerateProofResponseFromProofV2Plus(p, preSeed)
+	require.NoError(t, err)
+	calldata, err := evmtypes.MustGetABI(vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalABI).
+		Pack("fulfillRandomWords", onChainProof, rc, false)
+	require.NoError(t, err)
+
+	verify := func(t *testing.T, flags map[string]string) (string, error) {
+		buffer := bytes.NewBufferString("")
+		// The shell has no HTTP client, so verification must not need a node.
+		shell := cmd.Shell{Renderer: cmd.RendererTable{Writer: buffer}}
+		set := flag.NewFlagSet("test", 0)
+		flagSetApplyFromAction(shell.VerifyVRFProof, set, "vrf proof")
+		for name, value := range map[string]string{
+			"public-key":         key.PublicKey.String(),
+			"calldata":           hexutil.Encode(calldata),
+			"block-hash":         preSeed.BlockHash.Hex(),
+			"block-number":       "10",
+			"sub-id":             "123",
+			"callback-gas-limit": "100000",
+			"num-words":          "2",
+			"sender":             preSeed.Sender.Hex(),
+		} {
+			require.NoError(t, set.Set(name, value))
+		}
+		for name, value := range flags {
+			require.NoError(t, set.Set(name, value))
+		}
+		err := shell.VerifyVRFProof(cli.NewContext(nil, set, nil))
+		return buffer.String(), err
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		output, err := verify(t, nil)
+		require.NoError(t, err)
+		assert.Contains(t, output, "(valid: true)")
+	})
+
+	t.Run("wrong commitment", func(t *testing.T) {
+		output, err := verify(t, map[string]string{"num-words": "3"})
+		require.ErrorContains(t, err, "VRF proof is invalid")
+		assert.Contains(t, output, "(valid: false)")
+	})
+
+	t.Run("output requires attest", func(t *testing.T) {
+		_, err := verify(t, map[string]string{"output": "attestation.json"})
+		require.ErrorContains(t, err, "--output requires --attest")
+	})
+}
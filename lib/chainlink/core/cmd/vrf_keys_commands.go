@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
@@ -73,6 +76,27 @@ func initVRFKeysSubCmd(s *Shell) cli.Command {
 				Name: "list", Usage: "List the VRF keys",
 				Action: s.ListVRFKeys,
 			},
+			{
+				Name: "rotate",
+				Usage: "Rotate every VRF job using the given key to a new key. Requests for both keys are answered " +
+					"until the overlap has passed and no pending request references the old key, which is then retired. " +
+					"This only changes the node, the new key must be registered on the coordinator separately before the overlap ends.",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "new-key",
+						Usage: "compressed public key of an existing key to rotate to, a new key is created if not set",
+					},
+					cli.DurationFlag{
+						Name:  "overlap",
+						Usage: "how long requests for the old key are answered, defaults to each job's keyRotationOverlap",
+					},
+				},
+				Action: s.RotateVRFKey,
+			},
+			{
+				Name: "rotations", Usage: "List VRF key rotations and their status",
+				Action: s.ListVRFKeyRotations,
+			},
 		},
 	}
 }
@@ -289,3 +313,93 @@ func (s *Shell) ListVRFKeys(_ *cli.Context) error {
 	var presenters VRFKeyPresenters
 	return s.renderAPIResponse(resp, &presenters, "🔑 VRF Keys")
 }
+
+type VRFKeyRotationPresenter struct {
+	JAID // Include this to overwrite the presenter JAID so it can correctly render the ID in JSON
+	presenters.VRFKeyRotationResource
+}
+
+func (p *VRFKeyRotationPresenter) ToRow() []string {
+	retiredAt := ""
+	if p.RetiredAt != nil {
+		retiredAt = p.RetiredAt.Format(time.RFC3339)
+	}
+	return []string{
+		p.ID,
+		strconv.Itoa(int(p.JobID)),
+		p.OldPublicKey,
+		p.NewPublicKey,
+		p.Status,
+		p.OverlapUntil.Format(time.RFC3339),
+		strconv.FormatInt(p.PendingRequests, 10),
+		retiredAt,
+	}
+}
+
+var vrfKeyRotationHeaders = []string{"ID", "Job ID", "Old Key", "New Key", "Status", "Overlap Until", "Pending Requests", "Retired At"}
+
+type VRFKeyRotationPresenters []VRFKeyRotationPresenter
+
+// RenderTable implements TableRenderer
+func (ps VRFKeyRotationPresenters) RenderTable(rt RendererTable) error {
+	rows := [][]string{}
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+
+	renderList(vrfKeyRotationHeaders, rows, rt.Writer)
+	_, err := rt.Write([]byte("\n"))
+	return err
+}
+
+// RotateVRFKey starts rotating the VRF jobs using the given key to a new key.
+func (s *Shell) RotateVRFKey(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("Must pass the key ID (compressed public key) to rotate"))
+	}
+	pk, err := getPublicKey(c)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	v := url.Values{}
+	if newKey := c.String("new-key"); newKey != "" {
+		newPK, err2 := secp256k1.NewPublicKeyFromHex(newKey)
+		if err2 != nil {
+			return s.errorOut(errors.Wrap(err2, "failed to parse new public key"))
+		}
+		v.Add("newKeyID", newPK.String())
+	}
+	if c.IsSet("overlap") {
+		v.Add("overlap", c.Duration("overlap").String())
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), fmt.Sprintf("/v2/keys/vrf/%s/rotate?%s", pk, v.Encode()), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var presenters VRFKeyRotationPresenters
+	return s.renderAPIResponse(resp, &presenters, "VRF key rotation started")
+}
+
+// ListVRFKeyRotations lists the VRF key rotations and their status.
+func (s *Shell) ListVRFKeyRotations(_ *cli.Context) (err error) {
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/keys/vrf/rotations", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	var presenters VRFKeyRotationPresenters
+	return s.renderAPIResponse(resp, &presenters, "🔑 VRF Key Rotations")
+}
@@ -56,6 +56,35 @@ func TestVRFKeyPresenter_RenderTable(t *testing.T) {
 	assert.Contains(t, output, hash)
 }
 
+func TestVRFKeyRotationPresenters_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		oldKey = "0xe2c659dd73ded1663c0caf02304aac5ccd247047b3993d273a8920bba0402f4d01"
+		newKey = "0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f8179800"
+		buffer = bytes.NewBufferString("")
+		r      = cmd.RendererTable{Writer: buffer}
+	)
+
+	ps := cmd.VRFKeyRotationPresenters{{
+		JAID: cmd.JAID{ID: "1"},
+		VRFKeyRotationResource: presenters.VRFKeyRotationResource{
+			JobID:           7,
+			OldPublicKey:    oldKey,
+			NewPublicKey:    newKey,
+			Status:          "draining",
+			PendingRequests: 3,
+		},
+	}}
+	require.NoError(t, ps.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, oldKey)
+	assert.Contains(t, output, newKey)
+	assert.Contains(t, output, "draining")
+	assert.Contains(t, output, "Pending Requests")
+}
+
 func AssertKeysEqual(t *testing.T, k1, k2 cmd.VRFKeyPresenter) {
 	AssertKeysEqualNoTimestamps(t, k1, k2)
 }
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initWorkflowsSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "executions",
+			Usage: "Commands for inspecting and canceling workflow executions",
+			Subcommands: cli.Commands{
+				{
+					Name:   "list",
+					Usage:  "List the workflow executions, most recent first",
+					Action: s.ListWorkflowExecutions,
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "page",
+							Usage: "page of results to display",
+						},
+						cli.StringFlag{
+							Name:  "workflow-id",
+							Usage: "only list the executions of this workflow",
+						},
+						cli.StringFlag{
+							Name:  "status",
+							Usage: "only list the executions with this status, e.g. started",
+						},
+					},
+				},
+				{
+					Name:   "show",
+					Usage:  "Show a workflow execution with its trigger, capability calls, spend and user logs",
+					Action: s.ShowWorkflowExecution,
+				},
+				{
+					Name:   "cancel",
+					Usage:  "Cancel a workflow execution that has not finished",
+					Action: s.CancelWorkflowExecution,
+				},
+			},
+		},
+	}
+}
+
+var workflowExecutionHeaders = []string{"ID", "Workflow ID", "Status", "Created At", "Updated At", "Finished At"}
+
+type WorkflowExecutionPresenter struct {
+	JAID // Include this to overwrite the presenter JAID so it can correctly render the ID in JSON
+	presenters.WorkflowExecutionResource
+}
+
+// ToRow presents the WorkflowExecutionResource as a slice of strings.
+func (p *WorkflowExecutionPresenter) ToRow() []string {
+	return []string{
+		p.ID,
+		p.WorkflowID,
+		p.Status,
+		formatOptionalTime(p.CreatedAt),
+		formatOptionalTime(p.UpdatedAt),
+		formatOptionalTime(p.FinishedAt),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *WorkflowExecutionPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable(workflowExecutionHeaders)
+	table.Append(p.ToRow())
+	render("Workflow Execution", table)
+
+	if p.DecodeError != "" {
+		_, err := rt.Write([]byte(fmt.Sprintf("Failed to decode the steps of the execution: %s\n", p.DecodeError)))
+		return err
+	}
+
+	if t := p.Trigger; t != nil {
+		table = rt.newTable([]string{"Trigger ID", "Event ID", "Type", "Payload", "Created At"})
+		table.Append([]string{t.TriggerID, t.EventID, t.TriggerType, formatWorkflowPayload(t.Payload), formatOptionalTime(t.CreatedAt)})
+		render("Trigger", table)
+	}
+
+	if len(p.CapabilityCalls) > 0 {
+		table = rt.newTable([]string{"Callback ID", "Capability ID", "Method", "Status", "Error", "Request", "Response", "Started At", "Updated At"})
+		for _, c := range p.CapabilityCalls {
+			table.Append([]string{
+				strconv.FormatInt(int64(c.CallbackID), 10),
+				c.CapabilityID,
+				c.Method,
+				c.Status,
+				c.Error,
+				formatWorkflowPayload(c.Request),
+				formatWorkflowPayload(c.Response),
+				formatOptionalTime(c.CreatedAt),
+				formatOptionalTime(c.UpdatedAt),
+			})
+		}
+		render("Capability Calls", table)
+	}
+
+	if len(p.Spend) > 0 {
+		table = rt.newTable([]string{"Step", "Peer ID", "Unit", "Value"})
+		for _, sp := range p.Spend {
+			table.Append([]string{sp.Step, sp.PeerID, sp.Unit, sp.Value})
+		}
+		render("Spend", table)
+	}
+
+	if len(p.UserLogs) > 0 {
+		table = rt.newTable([]string{"Timestamp", "Message"})
+		for _, l := range p.UserLogs {
+			table.Append([]string{l.Timestamp, l.Message})
+		}
+		render("User Logs", table)
+	}
+	return nil
+}
+
+// formatWorkflowPayload shows the type, size and hash of a payload, its contents are only
+// available in the JSON output.
+func formatWorkflowPayload(p *presenters.WorkflowPayload) string {
+	if p == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%d bytes, sha256 %s)", p.TypeURL, p.Size, p.SHA256)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+type WorkflowExecutionPresenters []WorkflowExecutionPresenter
+
+// RenderTable implements TableRenderer
+func (ps WorkflowExecutionPresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable(workflowExecutionHeaders)
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+	render("Workflow Executions", table)
+	return nil
+}
+
+// ListWorkflowExecutions lists the workflow executions, optionally filtered by workflow and status.
+func (s *Shell) ListWorkflowExecutions(c *cli.Context) (err error) {
+	q := url.Values{}
+	if c.IsSet("workflow-id") {
+		q.Set("workflowID", c.String("workflow-id"))
+	}
+	if c.IsSet("status") {
+		q.Set("status", c.String("status"))
+	}
+	uri := url.URL{Path: "/v2/workflows/executions", RawQuery: q.Encode()}
+	return s.getPage(uri.String(), c.Int("page"), &WorkflowExecutionPresenters{})
+}
+
+// ShowWorkflowExecution shows a workflow execution, given its ID.
+func (s *Shell) ShowWorkflowExecution(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the ID of the workflow execution"))
+	}
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/workflows/executions/"+url.PathEscape(c.Args().First()))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &WorkflowExecutionPresenter{})
+}
+
+// CancelWorkflowExecution stops a workflow execution that has not finished, given its ID.
+func (s *Shell) CancelWorkflowExecution(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the ID of the workflow execution"))
+	}
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/workflows/executions/"+url.PathEscape(c.Args().First())+"/cancel", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = multierr.Append(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &WorkflowExecutionPresenter{})
+}
@@ -62,6 +62,7 @@ type AppConfig interface {
 	Telemetry() Telemetry
 	CRE() CRE
 	Billing() Billing
+	ExternalSigner() ExternalSigner
 }
 
 type DatabaseBackupMode string
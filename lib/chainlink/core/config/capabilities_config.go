@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/types"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
@@ -17,6 +19,20 @@ type EngineExecutionRateLimit interface {
 	GlobalBurst() int
 	PerSenderRPS() float64
 	PerSenderBurst() int
+	SenderTTL() time.Duration
+	MaxSenders() int
+	PerSenderDailyQuota() int64
+	PerSenderMonthlyQuota() int64
+	Overrides() map[string]EngineExecutionRateLimitOverride
+}
+
+// EngineExecutionRateLimitOverride replaces the per sender limits for a workflow owner.
+// Nil fields keep the per sender defaults.
+type EngineExecutionRateLimitOverride struct {
+	PerSenderRPS   *float64
+	PerSenderBurst *int
+	DailyQuota     *int64
+	MonthlyQuota   *int64
 }
 
 type CapabilitiesWorkflowRegistry interface {
@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+type ExternalSigner interface {
+	Enabled() bool
+	SocketPath() string
+	Timeout() time.Duration
+}
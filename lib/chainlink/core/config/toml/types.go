@@ -64,6 +64,7 @@ type Core struct {
 	Workflows        Workflows        `toml:",omitempty"`
 	CRE              CreConfig        `toml:",omitempty"`
 	Billing          Billing          `toml:",omitempty"`
+	ExternalSigner   ExternalSigner   `toml:",omitempty"`
 }
 
 // SetFrom updates c with any non-nil values from f. (currently TOML field only!)
@@ -108,6 +109,7 @@ func (c *Core) SetFrom(f *Core) {
 	c.Telemetry.setFrom(&f.Telemetry)
 	c.CRE.setFrom(&f.CRE)
 	c.Billing.setFrom(&f.Billing)
+	c.ExternalSigner.setFrom(&f.ExternalSigner)
 }
 
 func (c *Core) ValidateConfig() (err error) {
@@ -1817,10 +1819,23 @@ func (c *CreSecrets) validateMerge(f *CreSecrets) (err error) {
 }
 
 type EngineExecutionRateLimit struct {
-	GlobalRPS      *float64
-	GlobalBurst    *int
+	GlobalRPS             *float64
+	GlobalBurst           *int
+	PerSenderRPS          *float64
+	PerSenderBurst        *int
+	SenderTTL             *commonconfig.Duration
+	MaxSenders            *int
+	PerSenderDailyQuota   *int64
+	PerSenderMonthlyQuota *int64
+	Overrides             map[string]EngineExecutionRateLimitOverride
+}
+
+// EngineExecutionRateLimitOverride replaces the per sender limits for a workflow owner.
+type EngineExecutionRateLimitOverride struct {
 	PerSenderRPS   *float64
 	PerSenderBurst *int
+	DailyQuota     *int64
+	MonthlyQuota   *int64
 }
 
 func (eerl *EngineExecutionRateLimit) setFrom(f *EngineExecutionRateLimit) {
@@ -1836,6 +1851,22 @@ func (eerl *EngineExecutionRateLimit) setFrom(f *EngineExecutionRateLimit) {
 	if f.PerSenderBurst != nil {
 		eerl.PerSenderBurst = f.PerSenderBurst
 	}
+	if f.SenderTTL != nil {
+		eerl.SenderTTL = f.SenderTTL
+	}
+	if f.MaxSenders != nil {
+		eerl.MaxSenders = f.MaxSenders
+	}
+	if f.PerSenderDailyQuota != nil {
+		eerl.PerSenderDailyQuota = f.PerSenderDailyQuota
+	}
+	if f.PerSenderMonthlyQuota != nil {
+		eerl.PerSenderMonthlyQuota = f.PerSenderMonthlyQuota
+	}
+	if f.Overrides != nil {
+		eerl.Overrides = make(map[string]EngineExecutionRateLimitOverride)
+		maps.Copy(eerl.Overrides, f.Overrides)
+	}
 }
 
 type ExternalRegistry struct {
@@ -2264,6 +2295,38 @@ func (b *Billing) ValidateConfig() error {
 	return nil
 }
 
+// ExternalSigner configures a signer process holding keys outside of the keystore.
+type ExternalSigner struct {
+	Enabled    *bool
+	SocketPath *string
+	Timeout    *commonconfig.Duration
+}
+
+func (e *ExternalSigner) setFrom(f *ExternalSigner) {
+	if f.Enabled != nil {
+		e.Enabled = f.Enabled
+	}
+	if f.SocketPath != nil {
+		e.SocketPath = f.SocketPath
+	}
+	if f.Timeout != nil {
+		e.Timeout = f.Timeout
+	}
+}
+
+func (e *ExternalSigner) ValidateConfig() (err error) {
+	if e.Enabled == nil || !*e.Enabled {
+		return
+	}
+	if e.SocketPath == nil || *e.SocketPath == "" {
+		err = multierr.Append(err, configutils.ErrEmpty{Name: "SocketPath", Msg: "must be provided when the external signer is enabled"})
+	}
+	if e.Timeout != nil && e.Timeout.Duration() <= 0 {
+		err = multierr.Append(err, configutils.ErrInvalid{Name: "Timeout", Value: e.Timeout.String(), Msg: "must be positive"})
+	}
+	return
+}
+
 type JobDistributor struct {
 	DisplayName *string
 }
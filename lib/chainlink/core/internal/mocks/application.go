@@ -27,20 +27,30 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	http "net/http"
+
 	pipeline "github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 
 	plugins "github.com/smartcontractkit/chainlink/v2/plugins"
 
+	ratelimiter "github.com/smartcontractkit/chainlink/v2/core/services/workflows/ratelimiter"
+
 	services "github.com/smartcontractkit/chainlink/v2/core/services"
 
 	sessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
 
 	sqlutil "github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 
+	store "github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+
+	syncerlimiter "github.com/smartcontractkit/chainlink/v2/core/services/workflows/syncerlimiter"
+
 	txmgr "github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
 
 	uuid "github.com/google/uuid"
 
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+
 	webhook "github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 
 	zapcore "go.uber.org/zap/zapcore"
@@ -59,6 +69,53 @@ func (_m *Application) EXPECT() *Application_Expecter {
 	return &Application_Expecter{mock: &_m.Mock}
 }
 
+// APITokensORM provides a mock function with no fields
+func (_m *Application) APITokensORM() sessions.APITokensORM {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for APITokensORM")
+	}
+
+	var r0 sessions.APITokensORM
+	if rf, ok := ret.Get(0).(func() sessions.APITokensORM); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sessions.APITokensORM)
+		}
+	}
+
+	return r0
+}
+
+// Application_APITokensORM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APITokensORM'
+type Application_APITokensORM_Call struct {
+	*mock.Call
+}
+
+// APITokensORM is a helper method to define mock.On call
+func (_e *Application_Expecter) APITokensORM() *Application_APITokensORM_Call {
+	return &Application_APITokensORM_Call{Call: _e.mock.On("APITokensORM")}
+}
+
+func (_c *Application_APITokensORM_Call) Run(run func()) *Application_APITokensORM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_APITokensORM_Call) Return(_a0 sessions.APITokensORM) *Application_APITokensORM_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_APITokensORM_Call) RunAndReturn(run func() sessions.APITokensORM) *Application_APITokensORM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddJobV2 provides a mock function with given fields: ctx, _a1
 func (_m *Application) AddJobV2(ctx context.Context, _a1 *job.Job) error {
 	ret := _m.Called(ctx, _a1)
@@ -247,6 +304,53 @@ func (_c *Application_BridgeORM_Call) RunAndReturn(run func() bridges.ORM) *Appl
 	return _c
 }
 
+// CancelWorkflowExecution provides a mock function with given fields: ctx, executionID
+func (_m *Application) CancelWorkflowExecution(ctx context.Context, executionID string) error {
+	ret := _m.Called(ctx, executionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelWorkflowExecution")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, executionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Application_CancelWorkflowExecution_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelWorkflowExecution'
+type Application_CancelWorkflowExecution_Call struct {
+	*mock.Call
+}
+
+// CancelWorkflowExecution is a helper method to define mock.On call
+//   - ctx context.Context
+//   - executionID string
+func (_e *Application_Expecter) CancelWorkflowExecution(ctx interface{}, executionID interface{}) *Application_CancelWorkflowExecution_Call {
+	return &Application_CancelWorkflowExecution_Call{Call: _e.mock.On("CancelWorkflowExecution", ctx, executionID)}
+}
+
+func (_c *Application_CancelWorkflowExecution_Call) Run(run func(ctx context.Context, executionID string)) *Application_CancelWorkflowExecution_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Application_CancelWorkflowExecution_Call) Return(_a0 error) *Application_CancelWorkflowExecution_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_CancelWorkflowExecution_Call) RunAndReturn(run func(context.Context, string) error) *Application_CancelWorkflowExecution_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteJob provides a mock function with given fields: ctx, jobID
 func (_m *Application) DeleteJob(ctx context.Context, jobID int32) error {
 	ret := _m.Called(ctx, jobID)
@@ -401,6 +505,53 @@ func (_c *Application_FindLCA_Call) RunAndReturn(run func(context.Context, *big.
 	return _c
 }
 
+// GetAuditLogStore provides a mock function with no fields
+func (_m *Application) GetAuditLogStore() audit.Store {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuditLogStore")
+	}
+
+	var r0 audit.Store
+	if rf, ok := ret.Get(0).(func() audit.Store); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(audit.Store)
+		}
+	}
+
+	return r0
+}
+
+// Application_GetAuditLogStore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuditLogStore'
+type Application_GetAuditLogStore_Call struct {
+	*mock.Call
+}
+
+// GetAuditLogStore is a helper method to define mock.On call
+func (_e *Application_Expecter) GetAuditLogStore() *Application_GetAuditLogStore_Call {
+	return &Application_GetAuditLogStore_Call{Call: _e.mock.On("GetAuditLogStore")}
+}
+
+func (_c *Application_GetAuditLogStore_Call) Run(run func()) *Application_GetAuditLogStore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_GetAuditLogStore_Call) Return(_a0 audit.Store) *Application_GetAuditLogStore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_GetAuditLogStore_Call) RunAndReturn(run func() audit.Store) *Application_GetAuditLogStore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAuditLogger provides a mock function with no fields
 func (_m *Application) GetAuditLogger() audit.AuditLogger {
 	ret := _m.Called()
@@ -918,6 +1069,53 @@ func (_c *Application_GetRelayers_Call) RunAndReturn(run func() chainlink.Relaye
 	return _c
 }
 
+// GetVRFDryRunner provides a mock function with no fields
+func (_m *Application) GetVRFDryRunner() v2.FulfillmentDryRunner {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVRFDryRunner")
+	}
+
+	var r0 v2.FulfillmentDryRunner
+	if rf, ok := ret.Get(0).(func() v2.FulfillmentDryRunner); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v2.FulfillmentDryRunner)
+		}
+	}
+
+	return r0
+}
+
+// Application_GetVRFDryRunner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVRFDryRunner'
+type Application_GetVRFDryRunner_Call struct {
+	*mock.Call
+}
+
+// GetVRFDryRunner is a helper method to define mock.On call
+func (_e *Application_Expecter) GetVRFDryRunner() *Application_GetVRFDryRunner_Call {
+	return &Application_GetVRFDryRunner_Call{Call: _e.mock.On("GetVRFDryRunner")}
+}
+
+func (_c *Application_GetVRFDryRunner_Call) Run(run func()) *Application_GetVRFDryRunner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_GetVRFDryRunner_Call) Return(_a0 v2.FulfillmentDryRunner) *Application_GetVRFDryRunner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_GetVRFDryRunner_Call) RunAndReturn(run func() v2.FulfillmentDryRunner) *Application_GetVRFDryRunner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetWebAuthnConfiguration provides a mock function with no fields
 func (_m *Application) GetWebAuthnConfiguration() sessions.WebAuthnConfiguration {
 	ret := _m.Called()
@@ -963,6 +1161,147 @@ func (_c *Application_GetWebAuthnConfiguration_Call) RunAndReturn(run func() ses
 	return _c
 }
 
+// GetWorkflowExecutionsStore provides a mock function with no fields
+func (_m *Application) GetWorkflowExecutionsStore() *store.DBStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowExecutionsStore")
+	}
+
+	var r0 *store.DBStore
+	if rf, ok := ret.Get(0).(func() *store.DBStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*store.DBStore)
+		}
+	}
+
+	return r0
+}
+
+// Application_GetWorkflowExecutionsStore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowExecutionsStore'
+type Application_GetWorkflowExecutionsStore_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowExecutionsStore is a helper method to define mock.On call
+func (_e *Application_Expecter) GetWorkflowExecutionsStore() *Application_GetWorkflowExecutionsStore_Call {
+	return &Application_GetWorkflowExecutionsStore_Call{Call: _e.mock.On("GetWorkflowExecutionsStore")}
+}
+
+func (_c *Application_GetWorkflowExecutionsStore_Call) Run(run func()) *Application_GetWorkflowExecutionsStore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_GetWorkflowExecutionsStore_Call) Return(_a0 *store.DBStore) *Application_GetWorkflowExecutionsStore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_GetWorkflowExecutionsStore_Call) RunAndReturn(run func() *store.DBStore) *Application_GetWorkflowExecutionsStore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowLimits provides a mock function with no fields
+func (_m *Application) GetWorkflowLimits() *syncerlimiter.Limits {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowLimits")
+	}
+
+	var r0 *syncerlimiter.Limits
+	if rf, ok := ret.Get(0).(func() *syncerlimiter.Limits); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*syncerlimiter.Limits)
+		}
+	}
+
+	return r0
+}
+
+// Application_GetWorkflowLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowLimits'
+type Application_GetWorkflowLimits_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowLimits is a helper method to define mock.On call
+func (_e *Application_Expecter) GetWorkflowLimits() *Application_GetWorkflowLimits_Call {
+	return &Application_GetWorkflowLimits_Call{Call: _e.mock.On("GetWorkflowLimits")}
+}
+
+func (_c *Application_GetWorkflowLimits_Call) Run(run func()) *Application_GetWorkflowLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_GetWorkflowLimits_Call) Return(_a0 *syncerlimiter.Limits) *Application_GetWorkflowLimits_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_GetWorkflowLimits_Call) RunAndReturn(run func() *syncerlimiter.Limits) *Application_GetWorkflowLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowRateLimiter provides a mock function with no fields
+func (_m *Application) GetWorkflowRateLimiter() *ratelimiter.RateLimiter {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowRateLimiter")
+	}
+
+	var r0 *ratelimiter.RateLimiter
+	if rf, ok := ret.Get(0).(func() *ratelimiter.RateLimiter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ratelimiter.RateLimiter)
+		}
+	}
+
+	return r0
+}
+
+// Application_GetWorkflowRateLimiter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowRateLimiter'
+type Application_GetWorkflowRateLimiter_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowRateLimiter is a helper method to define mock.On call
+func (_e *Application_Expecter) GetWorkflowRateLimiter() *Application_GetWorkflowRateLimiter_Call {
+	return &Application_GetWorkflowRateLimiter_Call{Call: _e.mock.On("GetWorkflowRateLimiter")}
+}
+
+func (_c *Application_GetWorkflowRateLimiter_Call) Run(run func()) *Application_GetWorkflowRateLimiter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_GetWorkflowRateLimiter_Call) Return(_a0 *ratelimiter.RateLimiter) *Application_GetWorkflowRateLimiter_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_GetWorkflowRateLimiter_Call) RunAndReturn(run func() *ratelimiter.RateLimiter) *Application_GetWorkflowRateLimiter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ID provides a mock function with no fields
 func (_m *Application) ID() uuid.UUID {
 	ret := _m.Called()
@@ -1249,6 +1588,53 @@ func (_c *Application_ResumeJobV2_Call) RunAndReturn(run func(context.Context, u
 	return _c
 }
 
+// RolesORM provides a mock function with no fields
+func (_m *Application) RolesORM() sessions.RolesORM {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RolesORM")
+	}
+
+	var r0 sessions.RolesORM
+	if rf, ok := ret.Get(0).(func() sessions.RolesORM); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sessions.RolesORM)
+		}
+	}
+
+	return r0
+}
+
+// Application_RolesORM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RolesORM'
+type Application_RolesORM_Call struct {
+	*mock.Call
+}
+
+// RolesORM is a helper method to define mock.On call
+func (_e *Application_Expecter) RolesORM() *Application_RolesORM_Call {
+	return &Application_RolesORM_Call{Call: _e.mock.On("RolesORM")}
+}
+
+func (_c *Application_RolesORM_Call) Run(run func()) *Application_RolesORM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_RolesORM_Call) Return(_a0 sessions.RolesORM) *Application_RolesORM_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_RolesORM_Call) RunAndReturn(run func() sessions.RolesORM) *Application_RolesORM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RunJobV2 provides a mock function with given fields: ctx, jobID, meta
 func (_m *Application) RunJobV2(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error) {
 	ret := _m.Called(ctx, jobID, meta)
@@ -1307,6 +1693,65 @@ func (_c *Application_RunJobV2_Call) RunAndReturn(run func(context.Context, int3
 	return _c
 }
 
+// RunPublicWebhookJobV2 provides a mock function with given fields: ctx, jobUUID, header, requestBody
+func (_m *Application) RunPublicWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, header http.Header, requestBody []byte) (int64, error) {
+	ret := _m.Called(ctx, jobUUID, header, requestBody)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunPublicWebhookJobV2")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, http.Header, []byte) (int64, error)); ok {
+		return rf(ctx, jobUUID, header, requestBody)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, http.Header, []byte) int64); ok {
+		r0 = rf(ctx, jobUUID, header, requestBody)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, http.Header, []byte) error); ok {
+		r1 = rf(ctx, jobUUID, header, requestBody)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_RunPublicWebhookJobV2_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunPublicWebhookJobV2'
+type Application_RunPublicWebhookJobV2_Call struct {
+	*mock.Call
+}
+
+// RunPublicWebhookJobV2 is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobUUID uuid.UUID
+//   - header http.Header
+//   - requestBody []byte
+func (_e *Application_Expecter) RunPublicWebhookJobV2(ctx interface{}, jobUUID interface{}, header interface{}, requestBody interface{}) *Application_RunPublicWebhookJobV2_Call {
+	return &Application_RunPublicWebhookJobV2_Call{Call: _e.mock.On("RunPublicWebhookJobV2", ctx, jobUUID, header, requestBody)}
+}
+
+func (_c *Application_RunPublicWebhookJobV2_Call) Run(run func(ctx context.Context, jobUUID uuid.UUID, header http.Header, requestBody []byte)) *Application_RunPublicWebhookJobV2_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(http.Header), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *Application_RunPublicWebhookJobV2_Call) Return(_a0 int64, _a1 error) *Application_RunPublicWebhookJobV2_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_RunPublicWebhookJobV2_Call) RunAndReturn(run func(context.Context, uuid.UUID, http.Header, []byte) (int64, error)) *Application_RunPublicWebhookJobV2_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RunWebhookJobV2 provides a mock function with given fields: ctx, jobUUID, requestBody, meta
 func (_m *Application) RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta jsonserializable.JSONSerializable) (int64, error) {
 	ret := _m.Called(ctx, jobUUID, requestBody, meta)
@@ -1459,6 +1904,76 @@ func (_c *Application_SetLogLevel_Call) RunAndReturn(run func(zapcore.Level) err
 	return _c
 }
 
+// SimulateJobV2 provides a mock function with given fields: ctx, jb, vars, sim
+func (_m *Application) SimulateJobV2(ctx context.Context, jb job.Job, vars map[string]interface{}, sim *pipeline.Simulation) (*pipeline.Run, pipeline.TaskRunResults, error) {
+	ret := _m.Called(ctx, jb, vars, sim)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateJobV2")
+	}
+
+	var r0 *pipeline.Run
+	var r1 pipeline.TaskRunResults
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, job.Job, map[string]interface{}, *pipeline.Simulation) (*pipeline.Run, pipeline.TaskRunResults, error)); ok {
+		return rf(ctx, jb, vars, sim)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, job.Job, map[string]interface{}, *pipeline.Simulation) *pipeline.Run); ok {
+		r0 = rf(ctx, jb, vars, sim)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipeline.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, job.Job, map[string]interface{}, *pipeline.Simulation) pipeline.TaskRunResults); ok {
+		r1 = rf(ctx, jb, vars, sim)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(pipeline.TaskRunResults)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, job.Job, map[string]interface{}, *pipeline.Simulation) error); ok {
+		r2 = rf(ctx, jb, vars, sim)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Application_SimulateJobV2_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SimulateJobV2'
+type Application_SimulateJobV2_Call struct {
+	*mock.Call
+}
+
+// SimulateJobV2 is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jb job.Job
+//   - vars map[string]interface{}
+//   - sim *pipeline.Simulation
+func (_e *Application_Expecter) SimulateJobV2(ctx interface{}, jb interface{}, vars interface{}, sim interface{}) *Application_SimulateJobV2_Call {
+	return &Application_SimulateJobV2_Call{Call: _e.mock.On("SimulateJobV2", ctx, jb, vars, sim)}
+}
+
+func (_c *Application_SimulateJobV2_Call) Run(run func(ctx context.Context, jb job.Job, vars map[string]interface{}, sim *pipeline.Simulation)) *Application_SimulateJobV2_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(job.Job), args[2].(map[string]interface{}), args[3].(*pipeline.Simulation))
+	})
+	return _c
+}
+
+func (_c *Application_SimulateJobV2_Call) Return(_a0 *pipeline.Run, _a1 pipeline.TaskRunResults, _a2 error) *Application_SimulateJobV2_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Application_SimulateJobV2_Call) RunAndReturn(run func(context.Context, job.Job, map[string]interface{}, *pipeline.Simulation) (*pipeline.Run, pipeline.TaskRunResults, error)) *Application_SimulateJobV2_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function with given fields: ctx
 func (_m *Application) Start(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -1629,6 +2144,53 @@ func (_c *Application_WakeSessionReaper_Call) RunAndReturn(run func()) *Applicat
 	return _c
 }
 
+// WebhookORM provides a mock function with no fields
+func (_m *Application) WebhookORM() webhook.ORM {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WebhookORM")
+	}
+
+	var r0 webhook.ORM
+	if rf, ok := ret.Get(0).(func() webhook.ORM); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(webhook.ORM)
+		}
+	}
+
+	return r0
+}
+
+// Application_WebhookORM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WebhookORM'
+type Application_WebhookORM_Call struct {
+	*mock.Call
+}
+
+// WebhookORM is a helper method to define mock.On call
+func (_e *Application_Expecter) WebhookORM() *Application_WebhookORM_Call {
+	return &Application_WebhookORM_Call{Call: _e.mock.On("WebhookORM")}
+}
+
+func (_c *Application_WebhookORM_Call) Run(run func()) *Application_WebhookORM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_WebhookORM_Call) Return(_a0 webhook.ORM) *Application_WebhookORM_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_WebhookORM_Call) RunAndReturn(run func() webhook.ORM) *Application_WebhookORM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewApplication creates a new instance of Application. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewApplication(t interface {
@@ -15,10 +15,20 @@ const (
 	PasswordResetAttemptFailedMismatch EventID = "PASSWORD_RESET_ATTEMPT_FAILED_MISMATCH"
 	PasswordResetSuccess               EventID = "PASSWORD_RESET_SUCCESS"
 
+	KeystorePasswordRotateAttemptFailedMismatch EventID = "KEYSTORE_PASSWORD_ROTATE_ATTEMPT_FAILED_MISMATCH"
+	KeystorePasswordRotated                     EventID = "KEYSTORE_PASSWORD_ROTATED"
+
 	APITokenCreateAttemptPasswordMismatch EventID = "API_TOKEN_CREATE_ATTEMPT_PASSWORD_MISMATCH"
 	APITokenCreated                       EventID = "API_TOKEN_CREATED"
 	APITokenDeleteAttemptPasswordMismatch EventID = "API_TOKEN_DELETE_ATTEMPT_PASSWORD_MISMATCH"
 	APITokenDeleted                       EventID = "API_TOKEN_DELETED"
+	APITokenUsed                          EventID = "API_TOKEN_USED"
+	APITokenRejected                      EventID = "API_TOKEN_REJECTED"
+
+	RoleCreated      EventID = "ROLE_CREATED"
+	RoleDeleted      EventID = "ROLE_DELETED"
+	RoleGrantCreated EventID = "ROLE_GRANT_CREATED"
+	RoleGrantDeleted EventID = "ROLE_GRANT_DELETED"
 
 	FeedsManCreated EventID = "FEEDS_MAN_CREATED"
 	FeedsManUpdated EventID = "FEEDS_MAN_UPDATED"
@@ -47,6 +57,7 @@ const (
 
 	KeyCreated  EventID = "KEY_CREATED"
 	KeyUpdated  EventID = "KEY_UPDATED"
+	KeyRotated  EventID = "KEY_ROTATED"
 	KeyImported EventID = "KEY_IMPORTED"
 	KeyExported EventID = "KEY_EXPORTED"
 	KeyDeleted  EventID = "KEY_DELETED"
@@ -56,8 +67,11 @@ const (
 	SolanaTransactionCreated EventID = "SOLANA_TRANSACTION_CREATED"
 
 	JobCreated EventID = "JOB_CREATED"
+	JobUpdated EventID = "JOB_UPDATED"
 	JobDeleted EventID = "JOB_DELETED"
 
+	WebhookPublicTriggerSecretRotated EventID = "WEBHOOK_PUBLIC_TRIGGER_SECRET_ROTATED"
+
 	ChainAdded       EventID = "CHAIN_ADDED"
 	ChainSpecUpdated EventID = "CHAIN_SPEC_UPDATED"
 	ChainDeleted     EventID = "CHAIN_DELETED"
@@ -69,11 +83,17 @@ const (
 	BridgeUpdated EventID = "BRIDGE_UPDATED"
 	BridgeDeleted EventID = "BRIDGE_DELETED"
 
+	BridgeSigningSecretRotated EventID = "BRIDGE_SIGNING_SECRET_ROTATED"
+	BridgeSigningDisabled      EventID = "BRIDGE_SIGNING_DISABLED"
+
 	ForwarderCreated EventID = "FORWARDER_CREATED"
 	ForwarderDeleted EventID = "FORWARDER_DELETED"
 
-	ExternalInitiatorCreated EventID = "EXTERNAL_INITIATOR_CREATED"
-	ExternalInitiatorDeleted EventID = "EXTERNAL_INITIATOR_DELETED"
+	ExternalInitiatorCreated  EventID = "EXTERNAL_INITIATOR_CREATED"
+	ExternalInitiatorDeleted  EventID = "EXTERNAL_INITIATOR_DELETED"
+	ExternalInitiatorResynced EventID = "EXTERNAL_INITIATOR_RESYNCED"
+
+	WorkflowExecutionCanceled EventID = "WORKFLOW_EXECUTION_CANCELED"
 
 	JobProposalSpecApproved EventID = "JOB_PROPOSAL_SPEC_APPROVED"
 	JobProposalSpecUpdated  EventID = "JOB_PROPOSAL_SPEC_UPDATED"
@@ -0,0 +1,199 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jpillora/backoff"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+const (
+	// persistTimeout bounds the time spent persisting an audit event.
+	persistTimeout = 5 * time.Second
+	// persistMinBackoff and persistMaxBackoff bound the delay between attempts to persist an
+	// audit event, while the store is failing.
+	persistMinBackoff = 100 * time.Millisecond
+	persistMaxBackoff = 30 * time.Second
+)
+
+// durableLogger persists every audit event to the local audit log, then hands it to the
+// forwarding logger. Events are persisted asynchronously from a bounded queue, so that callers
+// don't wait on the lock of the chain. When the queue is full, events are persisted
+// synchronously rather than dropped, which slows callers down until the queue drains. An event
+// which fails to persist is retried with backoff, in order, and holds back the ones queued
+// after it, so that the queue fills up rather than events being lost.
+type durableLogger struct {
+	services.StateMachine
+
+	forward AuditLogger
+	store   Store
+	logger  logger.Logger
+
+	queue  chan wrappedAuditLog
+	chStop services.StopChan
+	chDone chan struct{}
+
+	// closed is set once the queue is no longer drained, after which events are persisted
+	// synchronously.
+	closedMu sync.RWMutex
+	closed   bool
+	// unpersisted is the event run was retrying when it was stopped, persisted on close.
+	unpersisted *wrappedAuditLog
+
+	persistErrMu sync.RWMutex
+	persistErr   error
+}
+
+// NewDurableLogger returns an AuditLogger which records events in the store before forwarding
+// them. It starts and closes the forwarding logger, if it is enabled.
+func NewDurableLogger(forward AuditLogger, store Store, lggr logger.Logger) AuditLogger {
+	return &durableLogger{
+		forward: forward,
+		store:   store,
+		logger:  lggr.Named("AuditLog"),
+		queue:   make(chan wrappedAuditLog, bufferCapacity),
+		chStop:  make(services.StopChan),
+		chDone:  make(chan struct{}),
+	}
+}
+
+func (l *durableLogger) Audit(eventID EventID, data Data) {
+	l.closedMu.RLock()
+	queued := false
+	if !l.closed {
+		select {
+		case l.queue <- wrappedAuditLog{eventID: eventID, data: data}:
+			queued = true
+		default:
+			l.logger.Warnw("Audit log queue is full, persisting synchronously", "eventID", eventID)
+		}
+	}
+	l.closedMu.RUnlock()
+	if !queued {
+		l.persist(eventID, data)
+	}
+}
+
+// persist makes a single attempt to persist the event, then forwards it.
+func (l *durableLogger) persist(eventID EventID, data Data) {
+	if err := l.append(eventID, data); err != nil {
+		l.logger.Errorw("Failed to persist audit log entry", "eventID", eventID, "err", err)
+	}
+	l.forward.Audit(eventID, data)
+}
+
+// persistWithRetry persists the event, retrying with backoff until it succeeds, then forwards
+// it. It returns false if it was stopped before the event was persisted.
+func (l *durableLogger) persistWithRetry(eventID EventID, data Data) bool {
+	b := backoff.Backoff{Min: persistMinBackoff, Max: persistMaxBackoff, Factor: 2}
+	for {
+		err := l.append(eventID, data)
+		if err == nil {
+			break
+		}
+		delay := b.Duration()
+		l.logger.Errorw("Failed to persist audit log entry, retrying", "eventID", eventID, "attempts", b.Attempt(), "retryIn", delay, "err", err)
+		select {
+		case <-l.chStop:
+			return false
+		case <-time.After(delay):
+		}
+	}
+	l.forward.Audit(eventID, data)
+	return true
+}
+
+func (l *durableLogger) append(eventID EventID, data Data) error {
+	// not bound to chStop, the queue is drained on close
+	ctx, cancel := context.WithTimeout(context.Background(), persistTimeout)
+	defer cancel()
+	_, err := l.store.Append(ctx, eventID, data)
+
+	l.persistErrMu.Lock()
+	defer l.persistErrMu.Unlock()
+	l.persistErr = err
+	return err
+}
+
+func (l *durableLogger) Start(ctx context.Context) error {
+	return l.StartOnce("AuditLog", func() error {
+		if l.forward.Ready() == nil {
+			if err := l.forward.Start(ctx); err != nil {
+				return err
+			}
+		}
+		go l.run()
+		return nil
+	})
+}
+
+func (l *durableLogger) run() {
+	defer close(l.chDone)
+	for {
+		select {
+		case <-l.chStop:
+			return
+		case event := <-l.queue:
+			if !l.persistWithRetry(event.eventID, event.data) {
+				l.unpersisted = &event
+				return
+			}
+		}
+	}
+}
+
+// Close persists the queued events, then closes the forwarding logger. Events audited after
+// Close are persisted synchronously.
+func (l *durableLogger) Close() error {
+	return l.StopOnce("AuditLog", func() error {
+		close(l.chStop)
+		<-l.chDone
+
+		l.closedMu.Lock()
+		defer l.closedMu.Unlock()
+		l.closed = true
+		if l.unpersisted != nil {
+			l.persist(l.unpersisted.eventID, l.unpersisted.data)
+			l.unpersisted = nil
+		}
+		for {
+			select {
+			case event := <-l.queue:
+				l.persist(event.eventID, event.data)
+			default:
+				if l.forward.Ready() == nil {
+					return l.forward.Close()
+				}
+				return nil
+			}
+		}
+	})
+}
+
+func (l *durableLogger) Name() string {
+	return l.logger.Name()
+}
+
+func (l *durableLogger) HealthReport() map[string]error {
+	l.persistErrMu.RLock()
+	err := l.persistErr
+	l.persistErrMu.RUnlock()
+	if err != nil {
+		err = fmt.Errorf("failed to persist audit log entry: %w", err)
+	}
+	if len(l.queue) == cap(l.queue) {
+		err = errors.Join(err, errors.New("queue is full"))
+	}
+	report := map[string]error{l.Name(): err}
+	if l.forward.Ready() == nil {
+		services.CopyHealth(report, l.forward.HealthReport())
+	}
+	return report
+}
@@ -0,0 +1,88 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+)
+
+type fakeStore struct {
+	audit.Store
+
+	mu       sync.Mutex
+	err      error
+	appended []audit.EventID
+}
+
+func (s *fakeStore) Append(_ context.Context, eventID audit.EventID, _ audit.Data) (audit.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return audit.Entry{}, s.err
+	}
+	s.appended = append(s.appended, eventID)
+	return audit.Entry{EventID: eventID}, nil
+}
+
+func (s *fakeStore) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *fakeStore) getAppended() []audit.EventID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]audit.EventID(nil), s.appended...)
+}
+
+func TestDurableLogger_RetriesFailedEvents(t *testing.T) {
+	t.Parallel()
+	store := &fakeStore{err: errors.New("database is down")}
+	auditLogger := audit.NewDurableLogger(audit.NoopLogger, store, logger.TestLogger(t))
+	require.NoError(t, auditLogger.Start(testutils.Context(t)))
+
+	auditLogger.Audit(audit.JobCreated, audit.Data{"id": 1})
+	auditLogger.Audit(audit.JobDeleted, audit.Data{"id": 1})
+	require.Eventually(t, func() bool {
+		return auditLogger.HealthReport()[auditLogger.Name()] != nil
+	}, testutils.WaitTimeout(t), testutils.TestInterval, "failures are reported")
+	assert.ErrorContains(t, auditLogger.HealthReport()[auditLogger.Name()], "database is down")
+	assert.Empty(t, store.getAppended())
+
+	store.setErr(nil)
+	require.Eventually(t, func() bool {
+		return len(store.getAppended()) == 2
+	}, testutils.WaitTimeout(t), testutils.TestInterval, "the events are kept queued until they are persisted")
+	assert.Equal(t, []audit.EventID{audit.JobCreated, audit.JobDeleted}, store.getAppended())
+	assert.NoError(t, auditLogger.HealthReport()[auditLogger.Name()])
+
+	require.NoError(t, auditLogger.Close())
+	auditLogger.Audit(audit.APITokenCreated, audit.Data{})
+	assert.Equal(t, []audit.EventID{audit.JobCreated, audit.JobDeleted, audit.APITokenCreated}, store.getAppended(), "events audited after close are persisted synchronously")
+}
+
+func TestDurableLogger_CloseWhileRetrying(t *testing.T) {
+	t.Parallel()
+	store := &fakeStore{err: errors.New("database is down")}
+	auditLogger := audit.NewDurableLogger(audit.NoopLogger, store, logger.TestLogger(t))
+	require.NoError(t, auditLogger.Start(testutils.Context(t)))
+
+	auditLogger.Audit(audit.JobCreated, audit.Data{"id": 1})
+	auditLogger.Audit(audit.JobDeleted, audit.Data{"id": 1})
+	require.Eventually(t, func() bool {
+		return auditLogger.HealthReport()[auditLogger.Name()] != nil
+	}, testutils.WaitTimeout(t), testutils.TestInterval)
+
+	store.setErr(nil)
+	require.NoError(t, auditLogger.Close())
+	assert.Equal(t, []audit.EventID{audit.JobCreated, audit.JobDeleted}, store.getAppended(), "closing persists the event being retried, then the queued ones")
+}
@@ -0,0 +1,260 @@
+package audit
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+)
+
+// GenesisHash is the previous hash of the first entry of the audit log.
+var GenesisHash = strings.Repeat("0", 64)
+
+// chainLockID is the advisory lock serializing appends to the audit log, so that nodes sharing a
+// database can not fork the chain.
+const chainLockID = 0x61756469746c6f67 // "auditlog"
+
+// verifyPageSize is the number of entries loaded at once by Verify.
+const verifyPageSize = 1000
+
+// Entry is an audit event in the local audit log. Each entry commits to the previous one through
+// PrevHash, so modifying, reordering or removing entries breaks the chain. The hashes are keyed
+// with a secret which is not stored in the database, so the chain can't be recomputed by someone
+// who can only write to the database.
+type Entry struct {
+	ID      int64
+	EventID EventID
+	// User is the email of the user in the data of the event, if any.
+	User      string
+	Data      json.RawMessage
+	CreatedAt time.Time
+	PrevHash  string
+	Hash      string
+}
+
+// ComputeHash returns the HMAC-SHA256 of the entry with the key, which covers every field but the
+// ID and the hash.
+func (e Entry) ComputeHash(key []byte) string {
+	h := hmac.New(sha256.New, key)
+	for _, field := range []string{e.PrevHash, string(e.EventID), e.User, e.CreatedAt.UTC().Format(time.RFC3339Nano), string(e.Data)} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify returns an error if the entry does not follow prevHash, or if its content does not match
+// its hash with the key.
+func (e Entry) Verify(key []byte, prevHash string) error {
+	if e.PrevHash != prevHash {
+		return fmt.Errorf("entry %d does not follow the previous entry: expected previous hash %s, got %s", e.ID, prevHash, e.PrevHash)
+	}
+	if hash := e.ComputeHash(key); !hmac.Equal([]byte(hash), []byte(e.Hash)) {
+		return fmt.Errorf("entry %d was modified: expected hash %s, got %s", e.ID, hash, e.Hash)
+	}
+	return nil
+}
+
+// Filter selects entries of the audit log. Zero values match every entry.
+type Filter struct {
+	EventID EventID
+	User    string
+	From    time.Time
+	To      time.Time
+}
+
+// VerifyResult is the outcome of the verification of the chain of the audit log.
+type VerifyResult struct {
+	// Entries is the number of entries verified before the first invalid one, if any.
+	Entries int64
+	Valid   bool
+	// LastID and LastHash are the head of the verified chain. Recording them elsewhere allows a
+	// later verification to detect that entries were removed from the end of the chain.
+	LastID   int64
+	LastHash string
+	// FirstInvalidID is the ID of the entry breaking the chain, when it is not valid.
+	FirstInvalidID int64
+	Error          string
+}
+
+// Store is the local, append-only audit log.
+type Store interface {
+	// Append adds an event at the end of the chain.
+	Append(ctx context.Context, eventID EventID, data Data) (Entry, error)
+	// List returns the entries matching the filter, most recent first, and their total count.
+	List(ctx context.Context, filter Filter, offset, limit int) ([]Entry, int, error)
+	// Verify checks the whole chain, from the first entry. If head is not empty, the chain must
+	// also contain the entry with this hash, which was the head of a previous verification.
+	Verify(ctx context.Context, head string) (VerifyResult, error)
+}
+
+type orm struct {
+	ds    sqlutil.DataSource
+	keyFn func(context.Context) ([]byte, error)
+
+	mu  sync.Mutex
+	key []byte
+}
+
+var _ Store = (*orm)(nil)
+
+// NewORM returns the Store of the audit log in the database, chained with the key returned by
+// keyFn. The key is only fetched when it is first needed, since it may come from the keystore,
+// which is unlocked after the ORMs are created.
+func NewORM(ds sqlutil.DataSource, keyFn func(context.Context) ([]byte, error)) Store {
+	return &orm{ds: ds, keyFn: keyFn}
+}
+
+func (o *orm) getKey(ctx context.Context) ([]byte, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.key != nil {
+		return o.key, nil
+	}
+	key, err := o.keyFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the key of the audit log: %w", err)
+	}
+	o.key = key
+	return key, nil
+}
+
+type entryRow struct {
+	ID        int64
+	EventID   string
+	UserEmail string
+	Data      string
+	CreatedAt time.Time
+	PrevHash  string
+	Hash      string
+}
+
+func (r entryRow) toEntry() Entry {
+	return Entry{
+		ID:        r.ID,
+		EventID:   EventID(r.EventID),
+		User:      r.UserEmail,
+		Data:      json.RawMessage(r.Data),
+		CreatedAt: r.CreatedAt,
+		PrevHash:  r.PrevHash,
+		Hash:      r.Hash,
+	}
+}
+
+// userOf returns the email of the user in the data of an event, under the keys used by the
+// authentication events.
+func userOf(data Data) string {
+	for _, key := range []string{"user", "email"} {
+		if user, ok := data[key].(string); ok {
+			return user
+		}
+	}
+	return ""
+}
+
+func (o *orm) Append(ctx context.Context, eventID EventID, data Data) (Entry, error) {
+	if data == nil {
+		data = Data{}
+	}
+	serialized, err := json.Marshal(data)
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to serialize audit log data: %w", err)
+	}
+	entry := Entry{EventID: eventID, User: userOf(data), Data: serialized}
+	key, err := o.getKey(ctx)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	err = sqlutil.TransactDataSource(ctx, o.ds, nil, func(tx sqlutil.DataSource) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", chainLockID); err != nil {
+			return fmt.Errorf("failed to lock the audit log: %w", err)
+		}
+		err := tx.GetContext(ctx, &entry.PrevHash, "SELECT hash FROM audit_log ORDER BY id DESC LIMIT 1")
+		if errors.Is(err, sql.ErrNoRows) {
+			entry.PrevHash = GenesisHash
+		} else if err != nil {
+			return fmt.Errorf("failed to get the last audit log entry: %w", err)
+		}
+		// Postgres stores microseconds, the hash must cover the stored time
+		entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+		entry.Hash = entry.ComputeHash(key)
+
+		return tx.GetContext(ctx, &entry.ID, `INSERT INTO audit_log (event_id, user_email, data, created_at, prev_hash, hash)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, string(entry.EventID), entry.User, string(entry.Data), entry.CreatedAt, entry.PrevHash, entry.Hash)
+	})
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to append audit log entry: %w", err)
+	}
+	return entry, nil
+}
+
+func (o *orm) List(ctx context.Context, filter Filter, offset, limit int) ([]Entry, int, error) {
+	from := sql.NullTime{Time: filter.From, Valid: !filter.From.IsZero()}
+	to := sql.NullTime{Time: filter.To, Valid: !filter.To.IsZero()}
+	const where = `WHERE ($1 = '' OR event_id = $1) AND ($2 = '' OR lower(user_email) = lower($2))
+		AND ($3::timestamptz IS NULL OR created_at >= $3) AND ($4::timestamptz IS NULL OR created_at < $4)`
+
+	var count int
+	err := o.ds.GetContext(ctx, &count, `SELECT COUNT(*) FROM audit_log `+where, string(filter.EventID), filter.User, from, to)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit log entries: %w", err)
+	}
+	var rows []entryRow
+	err = o.ds.SelectContext(ctx, &rows, `SELECT * FROM audit_log `+where+` ORDER BY id DESC LIMIT $5 OFFSET $6`,
+		string(filter.EventID), filter.User, from, to, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit log entries: %w", err)
+	}
+	entries := make([]Entry, len(rows))
+	for i, r := range rows {
+		entries[i] = r.toEntry()
+	}
+	return entries, count, nil
+}
+
+func (o *orm) Verify(ctx context.Context, head string) (VerifyResult, error) {
+	key, err := o.getKey(ctx)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+	result := VerifyResult{Valid: true, LastHash: GenesisHash}
+	headFound := head == "" || head == GenesisHash
+	for {
+		var rows []entryRow
+		err := o.ds.SelectContext(ctx, &rows, "SELECT * FROM audit_log WHERE id > $1 ORDER BY id LIMIT $2", result.LastID, verifyPageSize)
+		if err != nil {
+			return VerifyResult{}, fmt.Errorf("failed to load audit log entries: %w", err)
+		}
+		for _, r := range rows {
+			entry := r.toEntry()
+			if err := entry.Verify(key, result.LastHash); err != nil {
+				result.Valid = false
+				result.FirstInvalidID = entry.ID
+				result.Error = err.Error()
+				return result, nil
+			}
+			result.LastID = entry.ID
+			result.LastHash = entry.Hash
+			result.Entries++
+			headFound = headFound || entry.Hash == head
+		}
+		if len(rows) < verifyPageSize {
+			break
+		}
+	}
+	if !headFound {
+		result.Valid = false
+		result.Error = fmt.Sprintf("the chain does not contain the head %s, entries were removed from its end", head)
+	}
+	return result, nil
+}
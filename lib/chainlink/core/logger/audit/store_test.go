@@ -0,0 +1,129 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+)
+
+func testKeyFn(key string) func(context.Context) ([]byte, error) {
+	return func(context.Context) ([]byte, error) { return []byte(key), nil }
+}
+
+func TestEntry_Verify(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("key")
+	first := audit.Entry{
+		ID:        1,
+		EventID:   audit.JobDeleted,
+		Data:      json.RawMessage(`{"id":1}`),
+		CreatedAt: time.Now(),
+		PrevHash:  audit.GenesisHash,
+	}
+	first.Hash = first.ComputeHash(key)
+	second := audit.Entry{
+		ID:        2,
+		EventID:   audit.APITokenCreated,
+		User:      "user@chainlink.test",
+		Data:      json.RawMessage(`{"user":"user@chainlink.test"}`),
+		CreatedAt: time.Now(),
+		PrevHash:  first.Hash,
+	}
+	second.Hash = second.ComputeHash(key)
+
+	require.NoError(t, first.Verify(key, audit.GenesisHash))
+	require.NoError(t, second.Verify(key, first.Hash))
+	require.ErrorContains(t, second.Verify(key, audit.GenesisHash), "does not follow")
+
+	modified := second
+	modified.User = "other@chainlink.test"
+	require.ErrorContains(t, modified.Verify(key, first.Hash), "was modified")
+
+	// the hash can't be recomputed without the key
+	modified.Hash = modified.ComputeHash([]byte("other key"))
+	require.ErrorContains(t, modified.Verify(key, first.Hash), "was modified")
+}
+
+func TestORM_AuditLog(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	store := audit.NewORM(db, testKeyFn("key"))
+	auditLogger := audit.NewDurableLogger(audit.NoopLogger, store, logger.TestLogger(t))
+	require.NoError(t, auditLogger.Start(ctx))
+
+	start := time.Now().Add(-time.Second)
+	auditLogger.Audit(audit.APITokenCreated, audit.Data{"user": "user@chainlink.test"})
+	auditLogger.Audit(audit.JobDeleted, audit.Data{"id": 1})
+	auditLogger.Audit(audit.APITokenDeleted, audit.Data{"user": "User@chainlink.test", "id": 2})
+	// closing persists the queued events
+	require.NoError(t, auditLogger.Close())
+
+	entries, count, err := store.List(ctx, audit.Filter{}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+	require.Len(t, entries, 3)
+	assert.Equal(t, audit.APITokenDeleted, entries[0].EventID)
+	assert.Equal(t, entries[1].Hash, entries[0].PrevHash)
+	assert.Equal(t, audit.GenesisHash, entries[2].PrevHash)
+	jobDeleted := entries[1]
+
+	entries, count, err = store.List(ctx, audit.Filter{User: "USER@chainlink.test"}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, entries, 2)
+
+	_, count, err = store.List(ctx, audit.Filter{EventID: audit.JobDeleted, From: start}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	_, count, err = store.List(ctx, audit.Filter{To: start}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	result, err := store.Verify(ctx, "")
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, int64(3), result.Entries)
+	assert.Equal(t, entries[0].ID, result.LastID)
+	assert.Equal(t, entries[0].Hash, result.LastHash)
+	head := result.LastHash
+
+	result, err = audit.NewORM(db, testKeyFn("other key")).Verify(ctx, "")
+	require.NoError(t, err)
+	assert.False(t, result.Valid, "the chain is keyed")
+	assert.Zero(t, result.Entries)
+
+	_, err = store.Append(ctx, audit.JobDeleted, audit.Data{"id": 3})
+	require.NoError(t, err)
+	result, err = store.Verify(ctx, head)
+	require.NoError(t, err)
+	assert.True(t, result.Valid, "the head of a previous verification is still in the chain")
+
+	result, err = store.Verify(ctx, audit.GenesisHash[:63]+"1")
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Contains(t, result.Error, "does not contain the head")
+
+	// the table is append-only, tampering requires bypassing its trigger
+	_, err = db.ExecContext(ctx, `ALTER TABLE audit_log DISABLE TRIGGER audit_log_append_only`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `UPDATE audit_log SET data = '{"id":2}' WHERE id = $1`, jobDeleted.ID)
+	require.NoError(t, err)
+
+	result, err = store.Verify(ctx, "")
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, int64(1), result.Entries)
+	assert.Equal(t, jobDeleted.ID, result.FirstInvalidID)
+}
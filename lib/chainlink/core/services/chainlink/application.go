@@ -80,6 +80,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/streams"
 	"github.com/smartcontractkit/chainlink/v2/core/services/telemetry"
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf"
+	vrfv2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/artifacts"
@@ -89,9 +90,11 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/syncer"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/syncerlimiter"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/apitokens"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions/ldapauth"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions/localauth"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions/oidcauth"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/rbac"
 	"github.com/smartcontractkit/chainlink/v2/core/static"
 	"github.com/smartcontractkit/chainlink/v2/plugins"
 )
@@ -102,6 +105,8 @@ type Application interface {
 	Stop() error
 	GetLogger() logger.SugaredLogger
 	GetAuditLogger() audit.AuditLogger
+	// GetAuditLogStore returns the local, hash chained audit log.
+	GetAuditLogStore() audit.Store
 	GetHealthChecker() services.Checker
 	GetDB() sqlutil.DataSource
 	GetConfig() GeneralConfig
@@ -111,6 +116,14 @@ type Application interface {
 	GetWebAuthnConfiguration() sessions.WebAuthnConfiguration
 
 	GetExternalInitiatorManager() webhook.ExternalInitiatorManager
+	// GetWorkflowRateLimiter returns the limiter of workflow executions, and GetWorkflowLimits
+	// the limiter of running workflows.
+	GetWorkflowRateLimiter() *ratelimiter.RateLimiter
+	GetWorkflowLimits() *syncerlimiter.Limits
+	// GetWorkflowExecutionsStore returns the store of workflow executions, and
+	// CancelWorkflowExecution stops one that has not finished.
+	GetWorkflowExecutionsStore() *workflowstore.DBStore
+	CancelWorkflowExecution(ctx context.Context, executionID string) error
 	GetRelayers() RelayerChainInteroperators
 	GetLoopRegistry() *plugins.LoopRegistry
 	GetLoopRegistrarConfig() plugins.RegistrarConfig
@@ -120,15 +133,23 @@ type Application interface {
 	JobORM() job.ORM
 	PipelineORM() pipeline.ORM
 	BridgeORM() bridges.ORM
+	WebhookORM() webhook.ORM
 	BasicAdminUsersORM() sessions.BasicAdminUsersORM
 	AuthenticationProvider() sessions.AuthenticationProvider
+	RolesORM() sessions.RolesORM
+	APITokensORM() sessions.APITokensORM
 	TxmStorageService() txmgr.EvmTxStore
 	AddJobV2(ctx context.Context, job *job.Job) error
 	DeleteJob(ctx context.Context, jobID int32) error
 	RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta jsonserializable.JSONSerializable) (int64, error)
+	// RunPublicWebhookJobV2 runs a webhook job from a request to its public trigger.
+	RunPublicWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, header http.Header, requestBody []byte) (int64, error)
 	ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error
 	// Testing only
 	RunJobV2(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
+	// SimulateJobV2 runs the pipeline of a job in memory, with the tasks that interact with the
+	// outside world simulated by sim. Nothing is saved.
+	SimulateJobV2(ctx context.Context, jb job.Job, vars map[string]interface{}, sim *pipeline.Simulation) (*pipeline.Run, pipeline.TaskRunResults, error)
 
 	// Feeds
 	GetFeedsService() feeds.Service
@@ -142,6 +163,9 @@ type Application interface {
 
 	SecretGenerator() SecretGenerator
 
+	// GetVRFDryRunner returns the runner used to dry-run VRF v2plus fulfillments.
+	GetVRFDryRunner() vrfv2.FulfillmentDryRunner
+
 	// FindLCA - finds last common ancestor for LogPoller's chain available in the database and RPC chain
 	FindLCA(ctx context.Context, chainID *big.Int) (*logpoller.Block, error)
 	// DeleteLogPollerDataAfter - delete LogPoller state starting from the specified block
@@ -158,8 +182,11 @@ type ChainlinkApplication struct {
 	pipelineORM              pipeline.ORM
 	pipelineRunner           pipeline.Runner
 	bridgeORM                bridges.ORM
+	webhookORM               webhook.ORM
 	localAdminUsersORM       sessions.BasicAdminUsersORM
 	authenticationProvider   sessions.AuthenticationProvider // Note: this will be OIDC instance
+	rolesORM                 sessions.RolesORM
+	apiTokensORM             sessions.APITokensORM
 	txmStorageService        txmgr.EvmTxStore
 	FeedsService             feeds.Service
 	webhookJobRunner         webhook.JobRunner
@@ -172,12 +199,18 @@ type ChainlinkApplication struct {
 	HealthChecker            services.Checker
 	logger                   logger.SugaredLogger
 	AuditLogger              audit.AuditLogger
+	auditLogStore            audit.Store
 	closeLogger              func() error
 	ds                       sqlutil.DataSource
 	secretGenerator          SecretGenerator
 	profiler                 *pyroscope.Profiler
 	loopRegistry             *plugins.LoopRegistry
 	loopRegistrarConfig      plugins.RegistrarConfig
+	vrfDryRunner             vrfv2.FulfillmentDryRunner
+	workflowRateLimiter      *ratelimiter.RateLimiter
+	workflowLimits           *syncerlimiter.Limits
+	workflowExecutionsStore  *workflowstore.DBStore
+	workflowEngineRegistry   *syncer.EngineRegistry
 
 	started     bool
 	startStopMu sync.Mutex
@@ -218,10 +251,16 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 	heartbeat := NewHeartbeat(NewHeartbeatConfig(opts))
 	srvcs = append(srvcs, &heartbeat)
 
-	auditLogger := opts.AuditLogger
 	cfg := opts.Config
 	externalInitiatorManager := opts.ExternalInitiatorManager
 	globalLogger := logger.Sugared(opts.Logger)
+	// Every audit event is recorded locally, whether or not it is forwarded. The chain is keyed
+	// with a dedicated key of the key ring, so it can't be recomputed from the database alone, and
+	// stays verifiable when the CSA key is replaced.
+	auditLogStore := audit.NewORM(opts.DS, func(ctx context.Context) ([]byte, error) {
+		return opts.KeyStore.DataEncryptionKey(ctx, "audit log")
+	})
+	auditLogger := audit.NewDurableLogger(opts.AuditLogger, auditLogStore, globalLogger)
 	keyStore := opts.KeyStore
 	restrictedHTTPClient := opts.RestrictedHTTPClient
 	unrestrictedHTTPClient := opts.UnrestrictedHTTPClient
@@ -325,10 +364,8 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 		loopRegistry = plugins.NewLoopRegistry(globalLogger, opts.Config.AppID().String(), opts.Config.Feature().LogPoller(), opts.Config.Database(), opts.Config.Mercury(), opts.Config.Tracing(), opts.Config.Telemetry(), beholderAuthHeaders, csaPubKeyHex)
 	}
 
-	// If the audit logger is enabled
-	if auditLogger.Ready() == nil {
-		srvcs = append(srvcs, auditLogger)
-	}
+	// The audit log is always recorded locally, the logger starts forwarding if it is enabled
+	srvcs = append(srvcs, auditLogger)
 
 	var profiler *pyroscope.Profiler
 	if cfg.Pyroscope().ServerAddress() != "" {
@@ -386,11 +423,17 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 	}
 
 	srvcs = append(srvcs, mailMon)
+	if externalInitiatorManager != nil {
+		srvcs = append(srvcs, externalInitiatorManager)
+	}
 	srvcs = append(srvcs, relayChainInterops.Services()...)
 
 	// Initialize Local Users ORM and Authentication Provider specified in config
 	// BasicAdminUsersORM is initialized and required regardless of separate Authentication Provider
 	localAdminUsersORM := localauth.NewORM(opts.DS, cfg.WebServer().SessionTimeout().Duration(), globalLogger, auditLogger)
+	// Custom roles and named API tokens apply to the users of every authentication provider
+	rolesORM := rbac.NewORM(opts.DS, globalLogger)
+	apiTokensORM := apitokens.NewORM(opts.DS)
 
 	// Initialize Sessions ORM based on environment configured authenticator
 	// localDB auth, LDAP auth, or OIDC auth
@@ -426,9 +469,19 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 		return nil, errors.Errorf("NewApplication: Unexpected 'AuthenticationMethod': %s supported values: %s, %s", authMethod, sessions.LocalAuth, sessions.LDAPAuth)
 	}
 
+	// Bridge signing secrets are encrypted with a dedicated key of the key ring, so that they stay
+	// readable when the CSA key is replaced
+	bridgeSecretCipher := bridges.NewSecretCipher(func(ctx context.Context) ([]byte, error) {
+		return keyStore.DataEncryptionKey(ctx, "bridge signing secrets")
+	})
+	// and so are the secrets of webhook public triggers, with a key of their own
+	publicTriggerSecretCipher := bridges.NewSecretCipher(func(ctx context.Context) ([]byte, error) {
+		return keyStore.DataEncryptionKey(ctx, "webhook public trigger secrets")
+	})
 	var (
 		pipelineORM    = pipeline.NewORM(opts.DS, globalLogger, cfg.JobPipeline().MaxSuccessfulRuns())
-		bridgeORM      = bridges.NewORM(opts.DS)
+		bridgeORM      = bridges.NewORMWithSecretCipher(opts.DS, bridgeSecretCipher)
+		webhookORM     = webhook.NewORM(opts.DS, publicTriggerSecretCipher)
 		mercuryORM     = mercury.NewORM(opts.DS)
 		pipelineRunner = pipeline.NewRunner(pipelineORM, bridgeORM, cfg.JobPipeline(), cfg.WebServer(), legacyEVMChains, keyStore.Eth(), keyStore.VRF(), globalLogger, restrictedHTTPClient, unrestrictedHTTPClient)
 		jobORM         = job.NewORM(opts.DS, pipelineORM, bridgeORM, keyStore, globalLogger)
@@ -461,6 +514,17 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 
 	loopRegistrarConfig := plugins.NewRegistrarConfig(opts.GRPCOpts, loopRegistry.Register, loopRegistry.Unregister)
 
+	vrfDelegate := vrf.NewDelegate(
+		opts.DS,
+		keyStore,
+		pipelineRunner,
+		pipelineORM,
+		legacyEVMChains,
+		globalLogger,
+		mailMon,
+		restrictedHTTPClient,
+		unrestrictedHTTPClient)
+
 	var (
 		delegates = map[job.Type]job.Delegate{
 			job.DirectRequest: directrequest.NewDelegate(
@@ -477,20 +541,16 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 				globalLogger,
 				legacyEVMChains,
 				mailMon),
-			job.VRF: vrf.NewDelegate(
-				opts.DS,
-				keyStore,
-				pipelineRunner,
-				pipelineORM,
-				legacyEVMChains,
-				globalLogger,
-				mailMon),
+			job.VRF: vrfDelegate,
 			job.Webhook: webhook.NewDelegate(
 				pipelineRunner,
 				externalInitiatorManager,
+				webhookORM,
+				publicTriggerSecretCipher,
 				globalLogger),
 			job.Cron: cron.NewDelegate(
 				pipelineRunner,
+				opts.DS,
 				globalLogger),
 			job.BlockhashStore: blockhashstore.NewDelegate(
 				cfg,
@@ -708,8 +768,11 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 		pipelineRunner:           pipelineRunner,
 		pipelineORM:              pipelineORM,
 		bridgeORM:                bridgeORM,
+		webhookORM:               webhookORM,
 		localAdminUsersORM:       localAdminUsersORM,
 		authenticationProvider:   authenticationProvider,
+		rolesORM:                 rolesORM,
+		apiTokensORM:             apiTokensORM,
 		txmStorageService:        txmORM,
 		FeedsService:             feedsService,
 		Config:                   cfg,
@@ -717,14 +780,20 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 		KeyStore:                 keyStore,
 		SessionReaper:            sessionReaper,
 		ExternalInitiatorManager: externalInitiatorManager,
+		workflowRateLimiter:      creServices.workflowRateLimiter,
+		workflowLimits:           creServices.workflowLimits,
+		workflowExecutionsStore:  creServices.workflowExecutionsStore,
+		workflowEngineRegistry:   creServices.engineRegistry,
 		HealthChecker:            healthChecker,
 		logger:                   globalLogger,
 		AuditLogger:              auditLogger,
+		auditLogStore:            auditLogStore,
 		closeLogger:              opts.CloseLogger,
 		secretGenerator:          opts.SecretGenerator,
 		profiler:                 profiler,
 		loopRegistry:             loopRegistry,
 		loopRegistrarConfig:      loopRegistrarConfig,
+		vrfDryRunner:             vrfDelegate.DryRunners(),
 
 		ds: opts.DS,
 
@@ -773,6 +842,14 @@ type CREServices struct {
 	// it will specify the amount of global an per owner workflows that can be registered
 	workflowLimits *syncerlimiter.Limits
 
+	// workflowExecutionsStore is the store of the executions of v2 workflows
+	// it is exposed to inspect and cancel executions
+	workflowExecutionsStore *workflowstore.DBStore
+
+	// engineRegistry holds the engines of the workflows from the workflow registry
+	// it is exposed to cancel executions in progress
+	engineRegistry *syncer.EngineRegistry
+
 	// gatewayConnectorWrapper is the wrapper for the gateway connector
 	// it is exposed because there are contingent services in the application
 	gatewayConnectorWrapper *gatewayconnector.ServiceWrapper
@@ -797,15 +874,30 @@ func newCREServices(
 	billingClient metering.BillingClient,
 ) (*CREServices, error) {
 	var srvcs []services.ServiceCtx
+	rateLimitOverrides := make(map[string]ratelimiter.SenderLimits)
+	for owner, o := range capCfg.RateLimit().Overrides() {
+		rateLimitOverrides[owner] = ratelimiter.SenderLimits{
+			RPS:          o.PerSenderRPS,
+			Burst:        o.PerSenderBurst,
+			DailyQuota:   o.DailyQuota,
+			MonthlyQuota: o.MonthlyQuota,
+		}
+	}
 	workflowRateLimiter, err := ratelimiter.NewRateLimiter(ratelimiter.Config{
-		GlobalRPS:      capCfg.RateLimit().GlobalRPS(),
-		GlobalBurst:    capCfg.RateLimit().GlobalBurst(),
-		PerSenderRPS:   capCfg.RateLimit().PerSenderRPS(),
-		PerSenderBurst: capCfg.RateLimit().PerSenderBurst(),
+		GlobalRPS:             capCfg.RateLimit().GlobalRPS(),
+		GlobalBurst:           capCfg.RateLimit().GlobalBurst(),
+		PerSenderRPS:          capCfg.RateLimit().PerSenderRPS(),
+		PerSenderBurst:        capCfg.RateLimit().PerSenderBurst(),
+		SenderTTL:             capCfg.RateLimit().SenderTTL(),
+		MaxSenders:            capCfg.RateLimit().MaxSenders(),
+		PerSenderDailyQuota:   capCfg.RateLimit().PerSenderDailyQuota(),
+		PerSenderMonthlyQuota: capCfg.RateLimit().PerSenderMonthlyQuota(),
+		PerSenderOverrides:    rateLimitOverrides,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("could not instantiate workflow rate limiter: %w", err)
 	}
+	srvcs = append(srvcs, ratelimiter.NewQuotaSyncer(globalLogger, workflowRateLimiter, ratelimiter.NewORM(ds)))
 
 	if len(wCfg.Limits().PerOwnerOverrides()) > 0 {
 		globalLogger.Debugw("loaded per owner overrides", "overrides", wCfg.Limits().PerOwnerOverrides())
@@ -820,6 +912,10 @@ func newCREServices(
 		return nil, fmt.Errorf("could not instantiate workflow syncer limiter: %w", err)
 	}
 
+	workflowExecutionsStore := workflowstore.NewDBStore(ds, globalLogger, clockwork.NewRealClock())
+	srvcs = append(srvcs, workflowExecutionsStore)
+	engineRegistry := syncer.NewEngineRegistry()
+
 	var gatewayConnectorWrapper *gatewayconnector.ServiceWrapper
 	if capCfg.GatewayConnector().DonID() != "" {
 		globalLogger.Debugw("Creating GatewayConnector wrapper", "donID", capCfg.GatewayConnector().DonID())
@@ -921,8 +1017,6 @@ func newCREServices(
 						},
 					))
 
-				engineRegistry := syncer.NewEngineRegistry()
-
 				eventHandler, err := syncer.NewEventHandler(
 					lggr,
 					workflowstore.NewInMemoryStore(lggr, clockwork.NewRealClock()),
@@ -933,6 +1027,7 @@ func newCREServices(
 					workflowLimits,
 					artifactsStore,
 					syncer.WithBillingClient(billingClient),
+					syncer.WithV2ExecutionsStore(workflowExecutionsStore),
 				)
 				if err != nil {
 					return nil, fmt.Errorf("unable to create workflow registry event handler: %w", err)
@@ -972,6 +1067,8 @@ func newCREServices(
 	return &CREServices{
 		workflowRateLimiter:     workflowRateLimiter,
 		workflowLimits:          workflowLimits,
+		workflowExecutionsStore: workflowExecutionsStore,
+		engineRegistry:          engineRegistry,
 		gatewayConnectorWrapper: gatewayConnectorWrapper,
 		externalPeerWrapper:     externalPeerWrapper,
 		srvs:                    srvcs,
@@ -1051,6 +1148,10 @@ func (app *ChainlinkApplication) GetLoopRegistrarConfig() plugins.RegistrarConfi
 	return app.loopRegistrarConfig
 }
 
+func (app *ChainlinkApplication) GetVRFDryRunner() vrfv2.FulfillmentDryRunner {
+	return app.vrfDryRunner
+}
+
 // Stop allows the application to exit by halting schedules, closing
 // logs, and closing the DB connection.
 func (app *ChainlinkApplication) Stop() error {
@@ -1118,6 +1219,10 @@ func (app *ChainlinkApplication) GetAuditLogger() audit.AuditLogger {
 	return app.AuditLogger
 }
 
+func (app *ChainlinkApplication) GetAuditLogStore() audit.Store {
+	return app.auditLogStore
+}
+
 func (app *ChainlinkApplication) GetHealthChecker() services.Checker {
 	return app.HealthChecker
 }
@@ -1134,6 +1239,10 @@ func (app *ChainlinkApplication) BridgeORM() bridges.ORM {
 	return app.bridgeORM
 }
 
+func (app *ChainlinkApplication) WebhookORM() webhook.ORM {
+	return app.webhookORM
+}
+
 func (app *ChainlinkApplication) BasicAdminUsersORM() sessions.BasicAdminUsersORM {
 	return app.localAdminUsersORM
 }
@@ -1142,6 +1251,14 @@ func (app *ChainlinkApplication) AuthenticationProvider() sessions.Authenticatio
 	return app.authenticationProvider
 }
 
+func (app *ChainlinkApplication) RolesORM() sessions.RolesORM {
+	return app.rolesORM
+}
+
+func (app *ChainlinkApplication) APITokensORM() sessions.APITokensORM {
+	return app.apiTokensORM
+}
+
 func (app *ChainlinkApplication) PipelineORM() pipeline.ORM {
 	return app.pipelineORM
 }
@@ -1154,6 +1271,47 @@ func (app *ChainlinkApplication) GetExternalInitiatorManager() webhook.ExternalI
 	return app.ExternalInitiatorManager
 }
 
+func (app *ChainlinkApplication) GetWorkflowRateLimiter() *ratelimiter.RateLimiter {
+	return app.workflowRateLimiter
+}
+
+func (app *ChainlinkApplication) GetWorkflowLimits() *syncerlimiter.Limits {
+	return app.workflowLimits
+}
+
+func (app *ChainlinkApplication) GetWorkflowExecutionsStore() *workflowstore.DBStore {
+	return app.workflowExecutionsStore
+}
+
+// ErrWorkflowExecutionFinished is returned when cancelling a workflow execution that has already finished.
+var ErrWorkflowExecutionFinished = errors.New("workflow execution has already finished")
+
+// CancelWorkflowExecution stops the workflow execution if it is running, and otherwise marks it as
+// canceled so that it is not resumed.
+func (app *ChainlinkApplication) CancelWorkflowExecution(ctx context.Context, executionID string) error {
+	execution, err := app.workflowExecutionsStore.Get(ctx, executionID)
+	if err != nil {
+		return err
+	}
+	if execution.Status != workflowstore.StatusStarted {
+		return ErrWorkflowExecutionFinished
+	}
+	for _, engine := range app.workflowEngineRegistry.GetAll() {
+		if engine.WorkflowID.Hex() != execution.WorkflowID {
+			continue
+		}
+		if canceler, ok := engine.Service.(interface{ CancelExecution(string) bool }); ok && canceler.CancelExecution(executionID) {
+			app.logger.Infow("Canceled workflow execution", "executionID", executionID, "workflowID", execution.WorkflowID)
+			return nil
+		}
+	}
+	if _, err = app.workflowExecutionsStore.FinishExecution(ctx, executionID, workflowstore.StatusCanceled); err != nil {
+		return errors.Wrap(err, "failed to cancel workflow execution")
+	}
+	app.logger.Infow("Canceled workflow execution that was not running", "executionID", executionID, "workflowID", execution.WorkflowID)
+	return nil
+}
+
 func (app *ChainlinkApplication) SecretGenerator() SecretGenerator {
 	return app.secretGenerator
 }
@@ -1185,6 +1343,10 @@ func (app *ChainlinkApplication) RunWebhookJobV2(ctx context.Context, jobUUID uu
 	return app.webhookJobRunner.RunJob(ctx, jobUUID, requestBody, meta)
 }
 
+func (app *ChainlinkApplication) RunPublicWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, header http.Header, requestBody []byte) (int64, error) {
+	return app.webhookJobRunner.RunPublicJob(ctx, jobUUID, header, requestBody)
+}
+
 // Only used for local testing, not supported by the UI.
 func (app *ChainlinkApplication) RunJobV2(
 	ctx context.Context,
@@ -1250,6 +1412,21 @@ func (app *ChainlinkApplication) RunJobV2(
 	return runID, err
 }
 
+// SimulateJobV2 runs the pipeline of a job, which need not exist, in memory. Unless set in vars,
+// the jobSpec variables are set from the job.
+func (app *ChainlinkApplication) SimulateJobV2(
+	ctx context.Context,
+	jb job.Job,
+	vars map[string]interface{},
+	sim *pipeline.Simulation,
+) (*pipeline.Run, pipeline.TaskRunResults, error) {
+	spec, pipelineVars, err := job.SimulationSpec(jb, vars)
+	if err != nil {
+		return nil, nil, err
+	}
+	return app.pipelineRunner.ExecuteRun(pipeline.WithSimulation(ctx, sim), spec, pipelineVars)
+}
+
 func (app *ChainlinkApplication) ResumeJobV2(
 	ctx context.Context,
 	taskID uuid.UUID,
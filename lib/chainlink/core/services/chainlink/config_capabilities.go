@@ -1,6 +1,8 @@
 package chainlink
 
 import (
+	"time"
+
 	"github.com/smartcontractkit/chainlink-common/pkg/types"
 	"github.com/smartcontractkit/chainlink/v2/core/config"
 	"github.com/smartcontractkit/chainlink/v2/core/config/toml"
@@ -55,6 +57,47 @@ func (rl *engineExecutionRateLimit) PerSenderBurst() int {
 	return *rl.rl.PerSenderBurst
 }
 
+func (rl *engineExecutionRateLimit) SenderTTL() time.Duration {
+	if rl.rl.SenderTTL == nil {
+		return 0
+	}
+	return rl.rl.SenderTTL.Duration()
+}
+
+func (rl *engineExecutionRateLimit) MaxSenders() int {
+	if rl.rl.MaxSenders == nil {
+		return 0
+	}
+	return *rl.rl.MaxSenders
+}
+
+func (rl *engineExecutionRateLimit) PerSenderDailyQuota() int64 {
+	if rl.rl.PerSenderDailyQuota == nil {
+		return 0
+	}
+	return *rl.rl.PerSenderDailyQuota
+}
+
+func (rl *engineExecutionRateLimit) PerSenderMonthlyQuota() int64 {
+	if rl.rl.PerSenderMonthlyQuota == nil {
+		return 0
+	}
+	return *rl.rl.PerSenderMonthlyQuota
+}
+
+func (rl *engineExecutionRateLimit) Overrides() map[string]config.EngineExecutionRateLimitOverride {
+	overrides := make(map[string]config.EngineExecutionRateLimitOverride, len(rl.rl.Overrides))
+	for owner, o := range rl.rl.Overrides {
+		overrides[owner] = config.EngineExecutionRateLimitOverride{
+			PerSenderRPS:   o.PerSenderRPS,
+			PerSenderBurst: o.PerSenderBurst,
+			DailyQuota:     o.DailyQuota,
+			MonthlyQuota:   o.MonthlyQuota,
+		}
+	}
+	return overrides
+}
+
 func (c *capabilitiesConfig) Dispatcher() config.Dispatcher {
 	return &dispatcher{d: c.c.Dispatcher}
 }
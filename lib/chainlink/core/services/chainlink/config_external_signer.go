@@ -0,0 +1,35 @@
+package chainlink
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+	"github.com/smartcontractkit/chainlink/v2/core/config/toml"
+)
+
+// defaultExternalSignerTimeout bounds each request to the external signer when Timeout is unset.
+const defaultExternalSignerTimeout = 10 * time.Second
+
+var _ config.ExternalSigner = (*externalSignerConfig)(nil)
+
+type externalSignerConfig struct {
+	c toml.ExternalSigner
+}
+
+func (e *externalSignerConfig) Enabled() bool {
+	return e.c.Enabled != nil && *e.c.Enabled
+}
+
+func (e *externalSignerConfig) SocketPath() string {
+	if e.c.SocketPath == nil {
+		return ""
+	}
+	return *e.c.SocketPath
+}
+
+func (e *externalSignerConfig) Timeout() time.Duration {
+	if e.c.Timeout == nil {
+		return defaultExternalSignerTimeout
+	}
+	return e.c.Timeout.Duration()
+}
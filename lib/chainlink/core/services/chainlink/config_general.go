@@ -566,4 +566,8 @@ func (g *generalConfig) Billing() coreconfig.Billing {
 	return &billingConfig{t: g.c.Billing}
 }
 
+func (g *generalConfig) ExternalSigner() coreconfig.ExternalSigner {
+	return &externalSignerConfig{c: g.c.ExternalSigner}
+}
+
 var zeroSha256Hash = models.Sha256Hash{}
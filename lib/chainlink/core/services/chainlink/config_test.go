@@ -469,10 +469,14 @@ func TestConfig_Marshal(t *testing.T) {
 	}
 	full.Capabilities = toml.Capabilities{
 		RateLimit: toml.EngineExecutionRateLimit{
-			GlobalRPS:      ptr(200.00),
-			GlobalBurst:    ptr(200),
-			PerSenderRPS:   ptr(200.0),
-			PerSenderBurst: ptr(200),
+			GlobalRPS:             ptr(200.00),
+			GlobalBurst:           ptr(200),
+			PerSenderRPS:          ptr(200.0),
+			PerSenderBurst:        ptr(200),
+			SenderTTL:             commoncfg.MustNewDuration(time.Hour),
+			MaxSenders:            ptr(10000),
+			PerSenderDailyQuota:   ptr[int64](100000),
+			PerSenderMonthlyQuota: ptr[int64](2000000),
 		},
 
 		Peering: toml.P2P{
@@ -597,6 +601,11 @@ func TestConfig_Marshal(t *testing.T) {
 	full.Billing = toml.Billing{
 		URL: ptr("localhost:4319"),
 	}
+	full.ExternalSigner = toml.ExternalSigner{
+		Enabled:    ptr(true),
+		SocketPath: ptr("/run/chainlink/signer.sock"),
+		Timeout:    commoncfg.MustNewDuration(5 * time.Second),
+	}
 	full.JobDistributor = toml.JobDistributor{
 		DisplayName: ptr("test-node"),
 	}
@@ -2,7 +2,9 @@ package cron
 
 import (
 	"context"
-	"fmt"
+	"math/rand"
+	"sync"
+	"time"
 
 	"github.com/robfig/cron/v3"
 
@@ -13,19 +15,40 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 )
 
+const (
+	// maxCatchUpRuns is the most missed runs that are run with the runAllMissed catch-up policy.
+	// Only the latest ones are run when more were missed.
+	maxCatchUpRuns = 100
+	// maxCatchUpScan bounds how many scheduled times are scanned for missed runs, so that a
+	// frequent schedule that was down for a long time does not stall the start of the job.
+	maxCatchUpScan = 100_000
+	// periodSamples is how many scheduled times are sampled to find the shortest period of a
+	// schedule.
+	periodSamples = 1000
+)
+
+var scheduleParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 // Cron runs a cron jobSpec from a CronSpec
 type Cron struct {
-	cronRunner     *cron.Cron
+	schedule       cron.Schedule
 	logger         logger.Logger
 	jobSpec        job.Job
 	pipelineRunner pipeline.Runner
+	orm            ORM
 	chStop         services.StopChan
+	wg             sync.WaitGroup
+
+	mu        sync.Mutex
+	active    map[int64]context.CancelFunc
+	nextRunID int64
 }
 
 // NewCronFromJobSpec instantiates a job that executes on a predefined schedule.
 func NewCronFromJobSpec(
 	jobSpec job.Job,
 	pipelineRunner pipeline.Runner,
+	orm ORM,
 	logger logger.Logger,
 ) (*Cron, error) {
 	cronLogger := logger.Named("Cron").With(
@@ -33,28 +56,39 @@ func NewCronFromJobSpec(
 		"schedule", jobSpec.CronSpec.CronSchedule,
 	)
 	if id := jobSpec.CronSpec.EVMChainID; id != nil {
-		cronLogger = logger.With("evmChainID", id)
+		cronLogger = cronLogger.With("evmChainID", id)
+	}
+
+	schedule, err := scheduleParser.Parse(scheduleWithTimeZone(*jobSpec.CronSpec))
+	if err != nil {
+		return nil, err
 	}
 
 	return &Cron{
-		cronRunner:     cronRunner(),
+		schedule:       schedule,
 		logger:         cronLogger,
 		jobSpec:        jobSpec,
 		pipelineRunner: pipelineRunner,
+		orm:            orm,
 		chStop:         make(chan struct{}),
+		active:         make(map[int64]context.CancelFunc),
 	}, nil
 }
 
+// scheduleWithTimeZone returns the schedule of the spec in its time zone.
+func scheduleWithTimeZone(spec job.CronSpec) string {
+	if spec.TimeZone == "" {
+		return spec.CronSchedule
+	}
+	return "CRON_TZ=" + spec.TimeZone + " " + spec.CronSchedule
+}
+
 // Start implements the job.Service interface.
 func (cr *Cron) Start(context.Context) error {
 	cr.logger.Debug("Starting")
 
-	_, err := cr.cronRunner.AddFunc(cr.jobSpec.CronSpec.CronSchedule, cr.runPipeline)
-	if err != nil {
-		cr.logger.Errorw(fmt.Sprintf("Error running cron job %d", cr.jobSpec.ID), "err", err)
-		return err
-	}
-	cr.cronRunner.Start()
+	cr.wg.Add(1)
+	go cr.run()
 	return nil
 }
 
@@ -62,13 +96,180 @@ func (cr *Cron) Start(context.Context) error {
 // running and cleans up resources.
 func (cr *Cron) Close() error {
 	cr.logger.Debug("Closing")
-	cr.cronRunner.Stop()
+	close(cr.chStop)
+	cr.wg.Wait()
 	return nil
 }
 
-func (cr *Cron) runPipeline() {
+func (cr *Cron) run() {
+	defer cr.wg.Done()
+
+	now := time.Now()
+	cr.catchUp(now)
+
+	next := cr.schedule.Next(now)
+	for {
+		timer := time.NewTimer(time.Until(next) + cr.jitter())
+		select {
+		case <-cr.chStop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		cr.startRun(next)
+		next = cr.schedule.Next(time.Now())
+	}
+}
+
+// catchUp handles the runs missed since the last scheduled run, according to the catch-up policy.
+// Missed runs are run one after the other, before the regular schedule resumes. Jobs with the
+// skip policy don't record their last scheduled run, and just resume the regular schedule.
+func (cr *Cron) catchUp(now time.Time) {
+	if !cr.catchesUp() {
+		return
+	}
+	since := cr.jobSpec.CronSpec.CreatedAt
+	if last := cr.jobSpec.CronSpec.LastScheduledAt; last != nil {
+		since = *last
+	}
+	if since.IsZero() {
+		return
+	}
+	missed, total := missedRuns(cr.schedule, since, now)
+	if total == 0 {
+		return
+	}
+
+	switch cr.jobSpec.CronSpec.CatchUpPolicy {
+	case job.CronCatchUpRunOnce:
+		cr.logger.Infow("Running latest missed run", "missed", total, "since", since)
+		missed = missed[len(missed)-1:]
+	case job.CronCatchUpRunAllMissed:
+		if total > len(missed) {
+			cr.logger.Warnw("Too many missed runs, only running the latest ones", "missed", total, "running", len(missed), "since", since)
+		} else {
+			cr.logger.Infow("Running missed runs", "missed", total, "since", since)
+		}
+	}
+
+	for _, scheduledAt := range missed {
+		select {
+		case <-cr.chStop:
+			return
+		default:
+		}
+		ctx, done := cr.track()
+		cr.runPipeline(ctx, scheduledAt, true)
+		done()
+	}
+}
+
+// catchesUp returns true if the job runs the runs it missed while the node was down.
+func (cr *Cron) catchesUp() bool {
+	switch cr.jobSpec.CronSpec.CatchUpPolicy {
+	case job.CronCatchUpRunOnce, job.CronCatchUpRunAllMissed:
+		return true
+	default:
+		return false
+	}
+}
+
+// missedRuns returns the latest maxCatchUpRuns scheduled times after since and before now, and
+// the total number of missed runs.
+func missedRuns(schedule cron.Schedule, since, now time.Time) (missed []time.Time, total int) {
+	for t := schedule.Next(since); !t.IsZero() && t.Before(now) && total < maxCatchUpScan; t = schedule.Next(t) {
+		missed = append(missed, t)
+		if len(missed) > maxCatchUpRuns {
+			missed = missed[1:]
+		}
+		total++
+	}
+	return missed, total
+}
+
+// shortestPeriod returns the shortest time between two of the next scheduled times after from.
+func shortestPeriod(schedule cron.Schedule, from time.Time) time.Duration {
+	var period time.Duration
+	prev := schedule.Next(from)
+	for i := 0; i < periodSamples && !prev.IsZero(); i++ {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if d := next.Sub(prev); period == 0 || d < period {
+			period = d
+		}
+		prev = next
+	}
+	return period
+}
+
+func (cr *Cron) jitter() time.Duration {
+	jitter := cr.jobSpec.CronSpec.Jitter.Duration()
+	if jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(jitter))) //nolint:gosec // jitter does not need to be cryptographically random
+}
+
+// startRun starts a run in the background, according to the concurrency policy.
+func (cr *Cron) startRun(scheduledAt time.Time) {
+	cr.mu.Lock()
+	switch cr.jobSpec.CronSpec.ConcurrencyPolicy {
+	case job.CronConcurrencyForbid:
+		if len(cr.active) > 0 {
+			cr.mu.Unlock()
+			cr.logger.Warnw("Skipping run, the previous run is still running", "scheduledAt", scheduledAt)
+			return
+		}
+	case job.CronConcurrencyReplace:
+		if len(cr.active) > 0 {
+			cr.logger.Warnw("Cancelling the previous run, which is still running", "scheduledAt", scheduledAt)
+		}
+		for _, cancel := range cr.active {
+			cancel()
+		}
+	}
+	cr.mu.Unlock()
+
+	ctx, done := cr.track()
+	cr.wg.Add(1)
+	go func() {
+		defer cr.wg.Done()
+		defer done()
+		cr.runPipeline(ctx, scheduledAt, false)
+	}()
+}
+
+// track returns the context of a new run, which is cancelled when the run is replaced or the
+// job is closed, and a func to call once the run is done.
+func (cr *Cron) track() (context.Context, func()) {
 	ctx, cancel := cr.chStop.NewCtx()
-	defer cancel()
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	id := cr.nextRunID
+	cr.nextRunID++
+	cr.active[id] = cancel
+
+	return ctx, func() {
+		cr.mu.Lock()
+		delete(cr.active, id)
+		cr.mu.Unlock()
+		cancel()
+	}
+}
+
+func (cr *Cron) runPipeline(ctx context.Context, scheduledAt time.Time, catchUp bool) {
+	// The last scheduled time is only read to catch up missed runs. It must be written for every
+	// run of jobs that catch up: a later write would lose the runs missed in between if the node
+	// went down before it, and runs are never more frequent than the pipeline runs they start,
+	// which are written anyway.
+	if cr.catchesUp() {
+		if err := cr.orm.UpdateLastScheduledAt(ctx, cr.jobSpec.CronSpec.ID, scheduledAt); err != nil {
+			cr.logger.Errorw("Failed to record last scheduled time", "scheduledAt", scheduledAt, "err", err)
+		}
+	}
 
 	jobSpec := map[string]interface{}{
 		"databaseID":    cr.jobSpec.ID,
@@ -82,7 +283,10 @@ func (cr *Cron) runPipeline() {
 	vars := pipeline.NewVarsFrom(map[string]interface{}{
 		"jobSpec": jobSpec,
 		"jobRun": map[string]interface{}{
-			"meta": map[string]interface{}{},
+			"meta": map[string]interface{}{
+				"scheduledAt": scheduledAt.UTC().Format(time.RFC3339),
+				"catchUp":     catchUp,
+			},
 		},
 	})
 
@@ -93,7 +297,3 @@ func (cr *Cron) runPipeline() {
 		cr.logger.Errorf("Error executing new run for jobSpec ID %v", cr.jobSpec.ID)
 	}
 }
-
-func cronRunner() *cron.Cron {
-	return cron.New(cron.WithSeconds())
-}
@@ -1,7 +1,10 @@
 package cron_test
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -38,7 +41,7 @@ func TestCronV2Pipeline(t *testing.T) {
 		PipelineSpec:  &pipeline.Spec{},
 		ExternalJobID: uuid.New(),
 	}
-	delegate := cron.NewDelegate(runner, lggr)
+	delegate := cron.NewDelegate(runner, db, lggr)
 
 	require.NoError(t, jobORM.CreateJob(testutils.Context(t), jb))
 	serviceArray, err := delegate.ServicesForSpec(testutils.Context(t), *jb)
@@ -67,7 +70,7 @@ func TestCronV2Schedule(t *testing.T) {
 		Return(false, nil).
 		Once()
 
-	service, err := cron.NewCronFromJobSpec(spec, runner, logger.TestLogger(t))
+	service, err := cron.NewCronFromJobSpec(spec, runner, cron.NewORM(pgtest.NewSqlxDB(t)), logger.TestLogger(t))
 	require.NoError(t, err)
 	err = service.Start(testutils.Context(t))
 	require.NoError(t, err)
@@ -75,3 +78,131 @@ func TestCronV2Schedule(t *testing.T) {
 
 	awaiter.AwaitOrFail(t)
 }
+
+type lastScheduledORM struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (o *lastScheduledORM) UpdateLastScheduledAt(_ context.Context, _ int32, scheduledAt time.Time) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.times = append(o.times, scheduledAt)
+	return nil
+}
+
+func (o *lastScheduledORM) count() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.times)
+}
+
+func TestCronV2LastScheduledAt(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		policy job.CronCatchUpPolicy
+		writes bool
+	}{
+		{job.CronCatchUpSkip, false},
+		{job.CronCatchUpRunOnce, true},
+		{job.CronCatchUpRunAllMissed, true},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			t.Parallel()
+
+			spec := job.Job{
+				Type:          job.Cron,
+				SchemaVersion: 1,
+				CronSpec:      &job.CronSpec{CronSchedule: "@every 1s", CatchUpPolicy: tc.policy},
+				PipelineSpec:  &pipeline.Spec{},
+			}
+			runner := pipelinemocks.NewRunner(t)
+			awaiter := cltest.NewAwaiter()
+			runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { awaiter.ItHappened() }).
+				Return(false, nil).
+				Once()
+
+			orm := &lastScheduledORM{}
+			service, err := cron.NewCronFromJobSpec(spec, runner, orm, logger.TestLogger(t))
+			require.NoError(t, err)
+			require.NoError(t, service.Start(testutils.Context(t)))
+			awaiter.AwaitOrFail(t)
+			require.NoError(t, service.Close())
+
+			// Only jobs that catch up read the last scheduled time, so only they write it
+			assert.Equal(t, tc.writes, orm.count() > 0)
+		})
+	}
+}
+
+func TestCronV2CatchUp(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		policy job.CronCatchUpPolicy
+		runs   int
+	}{
+		{job.CronCatchUpSkip, 0},
+		{job.CronCatchUpRunOnce, 1},
+		{job.CronCatchUpRunAllMissed, 3},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			t.Parallel()
+
+			lastScheduledAt := time.Now().Add(-3*time.Hour - 30*time.Minute)
+			spec := job.Job{
+				Type:          job.Cron,
+				SchemaVersion: 1,
+				CronSpec: &job.CronSpec{
+					CronSchedule:    "@every 1h",
+					CatchUpPolicy:   tc.policy,
+					LastScheduledAt: &lastScheduledAt,
+				},
+				PipelineSpec: &pipeline.Spec{},
+			}
+
+			var mu sync.Mutex
+			var metas []map[string]interface{}
+			runner := pipelinemocks.NewRunner(t)
+			if tc.runs > 0 {
+				runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						vars := args.Get(1).(*pipeline.Run).Inputs.Val.(map[string]interface{})
+						mu.Lock()
+						defer mu.Unlock()
+						metas = append(metas, vars["jobRun"].(map[string]interface{})["meta"].(map[string]interface{}))
+					}).
+					Return(false, nil).
+					Times(tc.runs)
+			}
+
+			service, err := cron.NewCronFromJobSpec(spec, runner, cron.NewORM(pgtest.NewSqlxDB(t)), logger.TestLogger(t))
+			require.NoError(t, err)
+			require.NoError(t, service.Start(testutils.Context(t)))
+
+			if tc.runs == 0 {
+				time.Sleep(100 * time.Millisecond)
+			} else {
+				require.Eventually(t, func() bool {
+					mu.Lock()
+					defer mu.Unlock()
+					return len(metas) == tc.runs
+				}, testutils.WaitTimeout(t), 10*time.Millisecond)
+			}
+			require.NoError(t, service.Close())
+
+			for i, meta := range metas {
+				assert.Equal(t, true, meta["catchUp"])
+				scheduledAt, err := time.Parse(time.RFC3339, meta["scheduledAt"].(string))
+				require.NoError(t, err)
+				missed := tc.runs - i
+				if tc.policy == job.CronCatchUpRunOnce {
+					missed = 1
+				}
+				assert.WithinDuration(t, time.Now().Add(-time.Duration(missed)*time.Hour+30*time.Minute), scheduledAt, time.Minute)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package cron
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+)
+
+type ORM interface {
+	// UpdateLastScheduledAt records the scheduled time of the last run started by a cron job,
+	// which missed runs are caught up from when the node restarts.
+	UpdateLastScheduledAt(ctx context.Context, specID int32, scheduledAt time.Time) error
+}
+
+type orm struct {
+	ds sqlutil.DataSource
+}
+
+var _ ORM = (*orm)(nil)
+
+func NewORM(ds sqlutil.DataSource) ORM {
+	return &orm{ds: ds}
+}
+
+func (o *orm) UpdateLastScheduledAt(ctx context.Context, specID int32, scheduledAt time.Time) error {
+	// Runs can overlap, so never move the timestamp back.
+	_, err := o.ds.ExecContext(ctx, `UPDATE cron_specs SET last_scheduled_at = $2, updated_at = NOW()
+		WHERE id = $1 AND (last_scheduled_at IS NULL OR last_scheduled_at < $2)`, specID, scheduledAt)
+	return errors.Wrap(err, "failed to update last scheduled time of cron spec")
+}
@@ -1,6 +1,9 @@
 package cron
 
 import (
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
@@ -34,9 +37,45 @@ func ValidatedCronSpec(tomlString string) (job.Job, error) {
 	if jb.Type != job.Cron {
 		return jb, errors.Errorf("unsupported type %s", jb.Type)
 	}
-	if err := utils.ValidateCronSchedule(spec.CronSchedule); err != nil {
+	if spec.TimeZone != "" {
+		if _, err := time.LoadLocation(spec.TimeZone); err != nil {
+			return jb, errors.Wrapf(err, "invalid timeZone '%v'", spec.TimeZone)
+		}
+		if strings.HasPrefix(spec.CronSchedule, "CRON_TZ=") || strings.HasPrefix(spec.CronSchedule, "TZ=") || strings.HasPrefix(spec.CronSchedule, "@every ") {
+			return jb, errors.New("timeZone cannot be used with a CRON_TZ schedule or the @every syntax")
+		}
+	}
+	if err := utils.ValidateCronSchedule(scheduleWithTimeZone(spec)); err != nil {
 		return jb, errors.Wrapf(err, "while validating cron schedule '%v'", spec.CronSchedule)
 	}
 
+	switch spec.CatchUpPolicy {
+	case "":
+		spec.CatchUpPolicy = job.CronCatchUpSkip
+	case job.CronCatchUpSkip, job.CronCatchUpRunOnce, job.CronCatchUpRunAllMissed:
+	default:
+		return jb, errors.Errorf("catchUpPolicy must be one of %s, %s or %s, got %q", job.CronCatchUpSkip, job.CronCatchUpRunOnce, job.CronCatchUpRunAllMissed, spec.CatchUpPolicy)
+	}
+	switch spec.ConcurrencyPolicy {
+	case "":
+		spec.ConcurrencyPolicy = job.CronConcurrencyAllow
+	case job.CronConcurrencyAllow, job.CronConcurrencyForbid, job.CronConcurrencyReplace:
+	default:
+		return jb, errors.Errorf("concurrencyPolicy must be one of %s, %s or %s, got %q", job.CronConcurrencyAllow, job.CronConcurrencyForbid, job.CronConcurrencyReplace, spec.ConcurrencyPolicy)
+	}
+	if spec.Jitter.Duration() < 0 {
+		return jb, errors.New("jitter cannot be negative")
+	}
+	if jitter := spec.Jitter.Duration(); jitter > 0 {
+		schedule, err := scheduleParser.Parse(scheduleWithTimeZone(spec))
+		if err != nil {
+			return jb, errors.Wrapf(err, "while parsing cron schedule '%v'", spec.CronSchedule)
+		}
+		// Otherwise a run could be delayed past the next one, and the schedule would drift
+		if period := shortestPeriod(schedule, time.Now()); jitter >= period {
+			return jb, errors.Errorf("jitter must be shorter than the shortest period of the schedule, %s", period)
+		}
+	}
+
 	return jb, nil
 }
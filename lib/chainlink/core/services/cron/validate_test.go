@@ -2,6 +2,7 @@ package cron_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/manyminds/api2go/jsonapi"
 	"github.com/stretchr/testify/assert"
@@ -76,6 +77,125 @@ ds -> ds_parse -> ds_multiply;
 				assert.Contains(t, err.Error(), "invalid cron schedule")
 			},
 		},
+		{
+			name: "scheduling options",
+			toml: `
+type              = "cron"
+schemaVersion     = 1
+schedule          = "0 0 9 * * MON-FRI"
+timeZone          = "America/New_York"
+catchUpPolicy     = "runAllMissed"
+concurrencyPolicy = "forbid"
+jitter            = "30s"
+observationSource = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, s.CronSpec)
+				assert.Equal(t, "America/New_York", s.CronSpec.TimeZone)
+				assert.Equal(t, job.CronCatchUpRunAllMissed, s.CronSpec.CatchUpPolicy)
+				assert.Equal(t, job.CronConcurrencyForbid, s.CronSpec.ConcurrencyPolicy)
+				assert.Equal(t, 30*time.Second, s.CronSpec.Jitter.Duration())
+			},
+		},
+		{
+			name: "jitter as long as the period",
+			toml: `
+type              = "cron"
+schemaVersion     = 1
+schedule          = "0 */5 * * * *"
+jitter            = "5m"
+observationSource = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "jitter must be shorter than the shortest period of the schedule, 5m0s")
+			},
+		},
+		{
+			name: "jitter longer than the shortest period",
+			toml: `
+type              = "cron"
+schemaVersion     = 1
+schedule          = "0 0 9,10 * * *"
+jitter            = "2h"
+observationSource = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "shortest period of the schedule, 1h0m0s")
+			},
+		},
+		{
+			name: "default policies",
+			toml: `
+type              = "cron"
+schemaVersion     = 1
+schedule          = "@every 1h"
+observationSource = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, job.CronCatchUpSkip, s.CronSpec.CatchUpPolicy)
+				assert.Equal(t, job.CronConcurrencyAllow, s.CronSpec.ConcurrencyPolicy)
+			},
+		},
+		{
+			name: "invalid time zone",
+			toml: `
+type              = "cron"
+schemaVersion     = 1
+schedule          = "0 0 9 * * *"
+timeZone          = "Mars/Olympus_Mons"
+observationSource = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid timeZone")
+			},
+		},
+		{
+			name: "time zone with CRON_TZ",
+			toml: `
+type              = "cron"
+schemaVersion     = 1
+schedule          = "CRON_TZ=UTC 0 0 9 * * *"
+timeZone          = "Europe/London"
+observationSource = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "timeZone cannot be used with a CRON_TZ schedule")
+			},
+		},
+		{
+			name: "invalid policies",
+			toml: `
+type              = "cron"
+schemaVersion     = 1
+schedule          = "CRON_TZ=UTC 0 0 9 * * *"
+catchUpPolicy     = "always"
+observationSource = """
+ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), `catchUpPolicy must be one of skip, runOnce or runAllMissed, got "always"`)
+			},
+		},
 	}
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
@@ -189,6 +189,7 @@ type Job struct {
 	SchemaVersion                 uint32        `toml:"schemaVersion"`
 	GasLimit                      clnull.Uint32 `toml:"gasLimit"`
 	ForwardingAllowed             bool          `toml:"forwardingAllowed"`
+	CheckpointTasks               bool          `toml:"checkpointTasks"`
 	Name                          null.String   `toml:"name"`
 	MaxTaskDuration               models.Interval
 	Pipeline                      pipeline.Pipeline `toml:"observationSource"`
@@ -470,8 +471,53 @@ type ExternalInitiatorWebhookSpec struct {
 type WebhookSpec struct {
 	ID                            int32 `toml:"-"`
 	ExternalInitiatorWebhookSpecs []ExternalInitiatorWebhookSpec
-	CreatedAt                     time.Time `json:"createdAt" toml:"-"`
-	UpdatedAt                     time.Time `json:"updatedAt" toml:"-"`
+	// PublicTrigger is the public trigger endpoint of the job, if enabled.
+	PublicTrigger *WebhookPublicTrigger `json:"publicTrigger" toml:"-"`
+	// EncryptedPublicTriggerSecret is the encrypted secret requests to the public trigger
+	// endpoint are signed with when it uses HMAC authentication.
+	EncryptedPublicTriggerSecret []byte    `json:"-" toml:"-"`
+	CreatedAt                    time.Time `json:"createdAt" toml:"-"`
+	UpdatedAt                    time.Time `json:"updatedAt" toml:"-"`
+}
+
+// WebhookPublicTriggerAuth is how requests to the public trigger endpoint of a webhook job are
+// authenticated.
+type WebhookPublicTriggerAuth string
+
+const (
+	// WebhookPublicTriggerAuthHMAC requires requests signed with the job's secret.
+	WebhookPublicTriggerAuthHMAC WebhookPublicTriggerAuth = "hmac"
+	// WebhookPublicTriggerAuthJWT requires a bearer JWT signed with a key of the configured JWKS.
+	WebhookPublicTriggerAuthJWT WebhookPublicTriggerAuth = "jwt"
+)
+
+// WebhookPublicTrigger configures an endpoint triggering a webhook job without a user session or
+// an External Initiator.
+type WebhookPublicTrigger struct {
+	Auth WebhookPublicTriggerAuth `json:"auth" toml:"auth"`
+	// JWKSURL, Issuer and Audience are the keys, and the expected iss and aud claims, of JWTs.
+	// They are all required with jwt auth.
+	JWKSURL  string `json:"jwksURL,omitempty" toml:"jwksURL"`
+	Issuer   string `json:"issuer,omitempty" toml:"issuer"`
+	Audience string `json:"audience,omitempty" toml:"audience"`
+	// RequestSchema is the JSON schema request bodies must match, if any.
+	RequestSchema string `json:"requestSchema,omitempty" toml:"requestSchema"`
+	// RateLimit is the number of requests allowed per RateLimitPeriod.
+	RateLimit       uint32          `json:"rateLimit" toml:"rateLimit"`
+	RateLimitPeriod models.Interval `json:"rateLimitPeriod" toml:"rateLimitPeriod"`
+}
+
+func (t WebhookPublicTrigger) Value() (driver.Value, error) {
+	return json.Marshal(t)
+}
+
+// Scan reads the database value and returns an instance.
+func (t *WebhookPublicTrigger) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", value)
+	}
+	return json.Unmarshal(b, t)
 }
 
 func (w WebhookSpec) GetID() string {
@@ -498,12 +544,45 @@ type DirectRequestSpec struct {
 	UpdatedAt                time.Time                `toml:"-"`
 }
 
+// CronCatchUpPolicy is what a cron job does about the runs it missed while the node was down.
+type CronCatchUpPolicy string
+
+const (
+	// CronCatchUpSkip drops the missed runs.
+	CronCatchUpSkip CronCatchUpPolicy = "skip"
+	// CronCatchUpRunOnce runs once for the latest missed run.
+	CronCatchUpRunOnce CronCatchUpPolicy = "runOnce"
+	// CronCatchUpRunAllMissed runs every missed run, one after the other.
+	CronCatchUpRunAllMissed CronCatchUpPolicy = "runAllMissed"
+)
+
+// CronConcurrencyPolicy is what a cron job does when a run is due while the previous one
+// is still running.
+type CronConcurrencyPolicy string
+
+const (
+	// CronConcurrencyAllow starts the run anyway.
+	CronConcurrencyAllow CronConcurrencyPolicy = "allow"
+	// CronConcurrencyForbid skips the run.
+	CronConcurrencyForbid CronConcurrencyPolicy = "forbid"
+	// CronConcurrencyReplace cancels the previous run, and starts the new one.
+	CronConcurrencyReplace CronConcurrencyPolicy = "replace"
+)
+
 type CronSpec struct {
-	ID           int32     `toml:"-"`
-	CronSchedule string    `toml:"schedule"`
-	EVMChainID   *big.Big  `toml:"evmChainID"`
-	CreatedAt    time.Time `toml:"-"`
-	UpdatedAt    time.Time `toml:"-"`
+	ID           int32    `toml:"-"`
+	CronSchedule string   `toml:"schedule"`
+	EVMChainID   *big.Big `toml:"evmChainID"`
+	// TimeZone is the time zone of the schedule, when it is not set with CRON_TZ.
+	TimeZone          string                `toml:"timeZone"`
+	CatchUpPolicy     CronCatchUpPolicy     `toml:"catchUpPolicy"`
+	ConcurrencyPolicy CronConcurrencyPolicy `toml:"concurrencyPolicy"`
+	// Jitter is the maximum random delay added to each scheduled run.
+	Jitter models.Interval `toml:"jitter"`
+	// LastScheduledAt is the scheduled time of the last run started by the job.
+	LastScheduledAt *time.Time `toml:"-"`
+	CreatedAt       time.Time  `toml:"-"`
+	UpdatedAt       time.Time  `toml:"-"`
 }
 
 func (s CronSpec) GetID() string {
@@ -600,10 +679,93 @@ type VRFSpec struct {
 	// only.
 	BackoffMaxDelay time.Duration `toml:"backoffMaxDelay"`
 
+	// FulfillmentScheduler selects the strategy used to order pending requests across
+	// subscriptions. Optional, defaults to VRFSchedulerFIFO. V2 only.
+	FulfillmentScheduler VRFSchedulerType `toml:"fulfillmentScheduler"`
+
+	// SubscriptionSchedules holds optional per-subscription scheduling settings, such as
+	// priority and max in-flight fulfillments. V2 only.
+	SubscriptionSchedules VRFSubscriptionSchedules `toml:"subscriptionSchedules"`
+
+	// KeyRotationOverlap is how long requests for both the old and the new key are
+	// answered after a rotation of PublicKey is started. The old key is only retired
+	// once the overlap has passed and no pending request references it. Optional,
+	// defaults to RequestTimeout. V2 only.
+	KeyRotationOverlap time.Duration `toml:"keyRotationOverlap"`
+
+	// LowBalanceAlertThreshold is how long before a subscription is forecast to run
+	// out of funds an alert is raised. Optional, defaults to 24h if an alert target is
+	// set. V2 only.
+	LowBalanceAlertThreshold time.Duration `toml:"lowBalanceAlertThreshold"`
+
+	// LowBalanceWebhookURL is called with a low balance alert when a subscription is
+	// forecast to run out of funds within LowBalanceAlertThreshold. Optional. V2 only.
+	LowBalanceWebhookURL string `toml:"lowBalanceWebhookURL"`
+
+	// LowBalanceExternalInitiator is the name of the external initiator notified of
+	// low balance alerts, in addition to or instead of LowBalanceWebhookURL.
+	// Optional. V2 only.
+	LowBalanceExternalInitiator string `toml:"lowBalanceExternalInitiator"`
+
 	CreatedAt time.Time `toml:"-"`
 	UpdatedAt time.Time `toml:"-"`
 }
 
+// VRFSchedulerType names a strategy for ordering pending VRF requests across subscriptions.
+type VRFSchedulerType string
+
+const (
+	// VRFSchedulerFIFO processes each subscription's requests in full, one subscription
+	// after another.
+	VRFSchedulerFIFO VRFSchedulerType = "fifo"
+	// VRFSchedulerWeightedFair interleaves subscriptions using weighted fair queuing, so that
+	// a single busy subscription cannot starve the others.
+	VRFSchedulerWeightedFair VRFSchedulerType = "weighted_fair"
+)
+
+// VRFSubscriptionSchedule configures how the fulfillment scheduler treats a single subscription.
+type VRFSubscriptionSchedule struct {
+	// SubID is the decimal subscription ID.
+	SubID string `toml:"subID" json:"subID"`
+	// Priority is the weight of the subscription under weighted fair queuing. Higher
+	// priorities get a larger share of each processing round and are served first.
+	// Optional, defaults to 1.
+	Priority uint32 `toml:"priority" json:"priority"`
+	// MaxInFlight caps the number of fulfillments for the subscription that may be pending
+	// in the transaction manager at once. Optional, 0 means unlimited.
+	MaxInFlight uint32 `toml:"maxInFlight" json:"maxInFlight"`
+}
+
+// VRFSubscriptionSchedules is a list of VRFSubscriptionSchedule stored as JSON in the database.
+type VRFSubscriptionSchedules []VRFSubscriptionSchedule
+
+// Value returns this instance serialized for database storage.
+func (s VRFSubscriptionSchedules) Value() (driver.Value, error) {
+	if s == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan reads the database value and returns an instance.
+func (s *VRFSubscriptionSchedules) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", value)
+	}
+	return json.Unmarshal(b, s)
+}
+
+// ForSub returns the schedule configured for the given subscription, if any.
+func (s VRFSubscriptionSchedules) ForSub(subID string) (VRFSubscriptionSchedule, bool) {
+	for _, sched := range s {
+		if sched.SubID == subID {
+			return sched, true
+		}
+	}
+	return VRFSubscriptionSchedule{}, false
+}
+
 // BlockhashStoreSpec defines the job spec for the blockhash store feeder.
 type BlockhashStoreSpec struct {
 	ID int32
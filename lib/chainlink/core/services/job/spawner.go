@@ -218,6 +218,7 @@ func (js *spawner) StartService(ctx context.Context, jb Job) error {
 	jb.PipelineSpec.JobID = jb.ID
 	jb.PipelineSpec.JobType = string(jb.Type)
 	jb.PipelineSpec.ForwardingAllowed = jb.ForwardingAllowed
+	jb.PipelineSpec.CheckpointTasks = jb.CheckpointTasks
 	if jb.GasLimit.Valid {
 		jb.PipelineSpec.GasLimit = &jb.GasLimit.Uint32
 	}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 )
 
 var (
@@ -38,3 +40,33 @@ func SendingKeysForJob(spec *OCR2OracleSpec) ([]string, error) {
 
 	return sendingKeys, nil
 }
+
+// SimulationSpec returns the pipeline spec and the vars to simulate a run of the pipeline of the
+// job with. Unless set in vars, the jobSpec variables are set from the job.
+func SimulationSpec(jb Job, vars map[string]interface{}) (pipeline.Spec, pipeline.Vars, error) {
+	if jb.Pipeline.Source == "" {
+		return pipeline.Spec{}, pipeline.Vars{}, errors.New("job has no pipeline to simulate")
+	}
+	spec := pipeline.Spec{
+		DotDagSource:      jb.Pipeline.Source,
+		MaxTaskDuration:   jb.MaxTaskDuration,
+		ForwardingAllowed: jb.ForwardingAllowed,
+		JobID:             jb.ID,
+		JobName:           jb.Name.ValueOrZero(),
+		JobType:           string(jb.Type),
+	}
+	if jb.GasLimit.Valid {
+		spec.GasLimit = &jb.GasLimit.Uint32
+	}
+	if vars == nil {
+		vars = make(map[string]interface{})
+	}
+	if _, ok := vars["jobSpec"]; !ok {
+		vars["jobSpec"] = map[string]interface{}{
+			"databaseID":    jb.ID,
+			"externalJobID": jb.ExternalJobID,
+			"name":          jb.Name.ValueOrZero(),
+		}
+	}
+	return spec, pipeline.NewVarsFrom(vars), nil
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 )
 
 var (
@@ -75,3 +77,35 @@ func ValidateSpec(ts string) (Type, error) {
 
 	return jb.Type, nil
 }
+
+// ParseSpec validates the spec, and decodes the fields common to all job types. The fields
+// specific to the job type are neither decoded nor validated.
+func ParseSpec(ts string) (Job, error) {
+	jobType, err := ValidateSpec(ts)
+	if err != nil {
+		return Job{}, err
+	}
+	var jb Job
+	tree, err := toml.Load(ts)
+	if err != nil {
+		return Job{}, err
+	}
+	if err = tree.Unmarshal(&jb); err != nil {
+		return Job{}, err
+	}
+	jb.Type = jobType
+	return jb, nil
+}
+
+// LintSpec validates the spec, and statically analyzes its pipeline for problems that would
+// otherwise only show up when the job runs.
+func LintSpec(ts string) (Type, pipeline.LintIssues, error) {
+	jb, err := ParseSpec(ts)
+	if err != nil {
+		return "", nil, err
+	}
+	if jb.Pipeline.Source == "" {
+		return jb.Type, nil, nil
+	}
+	return jb.Type, jb.Pipeline.Lint(), nil
+}
@@ -112,3 +112,25 @@ ds [type=http]
 		})
 	}
 }
+
+func TestLintSpec(t *testing.T) {
+	jobType, issues, err := LintSpec(`
+type="webhook"
+schemaVersion=1
+observationSource="""
+ds    [type=http method=GET url="https://chain.link/eth_usd"];
+parse [type=jsonparse path="data,price" data="$(ds_typo)"];
+ds -> parse;
+"""
+`)
+	require.NoError(t, err)
+	require.Equal(t, Webhook, jobType)
+	require.True(t, issues.HasErrors())
+	require.Len(t, issues, 3)
+
+	_, _, err = LintSpec(`
+type="blah"
+schemaVersion=1
+`)
+	require.True(t, errors.Is(errors.Cause(err), ErrInvalidJobType))
+}
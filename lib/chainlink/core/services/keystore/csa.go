@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/loop"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/external"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/csakey"
 )
 
@@ -40,11 +41,20 @@ func (c CSASigner) Accounts(ctx context.Context) (accounts []string, err error)
 	for _, key := range keys {
 		accounts = append(accounts, key.ID())
 	}
+	if signer := externalSignerOf(c.CSA); signer != nil {
+		return appendExternalAccounts(ctx, signer, external.KeyTypeCSA, accounts)
+	}
 	return
 }
 
 func (c CSASigner) Sign(ctx context.Context, account string, data []byte) (signed []byte, err error) {
 	k, err := c.CSA.Get(account)
+	var notFound KeyNotFoundError
+	if errors.As(err, &notFound) {
+		if signer := externalSignerOf(c.CSA); signer != nil {
+			return (&external.Keystore{Backend: signer, KeyType: external.KeyTypeCSA}).Sign(ctx, account, data)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
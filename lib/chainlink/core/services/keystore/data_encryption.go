@@ -0,0 +1,72 @@
+package keystore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// dataEncryptionKeySize is the size of data encryption keys, for AES-256 and HMAC-SHA256.
+const dataEncryptionKeySize = 32
+
+// dataEncryptionKey is a random key the node encrypts or authenticates data of a purpose at rest
+// with. It is kept in the key ring, so that it never is stored in the database in plaintext, and
+// does not depend on any other key of the node.
+type dataEncryptionKey struct {
+	Purpose string
+	Key     []byte
+}
+
+func (k dataEncryptionKey) raw() []byte {
+	// a string and a byte slice always marshal
+	b, _ := json.Marshal(k)
+	return b
+}
+
+func dataEncryptionKeyFor(raw []byte) (k dataEncryptionKey, err error) {
+	if err = json.Unmarshal(raw, &k); err != nil {
+		return k, errors.Wrap(err, "failed to unmarshal data encryption key")
+	}
+	if len(k.Key) != dataEncryptionKeySize {
+		return k, errors.Errorf("invalid data encryption key of %d bytes for %q", len(k.Key), k.Purpose)
+	}
+	return k, nil
+}
+
+// DataEncryptionKey returns the 32 byte key of purpose, creating it on first use. Unlike keys
+// derived from other keys, it does not change when they are deleted or replaced, so the data
+// it protects stays readable for as long as the key ring is kept.
+func (km *keyManager) DataEncryptionKey(ctx context.Context, purpose string) ([]byte, error) {
+	km.lock.RLock()
+	if km.isLocked() {
+		km.lock.RUnlock()
+		return nil, ErrLocked
+	}
+	k, ok := km.keyRing.DataEncryption[purpose]
+	km.lock.RUnlock()
+	if ok {
+		return k.Key, nil
+	}
+
+	km.lock.Lock()
+	defer km.lock.Unlock()
+	if km.isLocked() {
+		return nil, ErrLocked
+	}
+	if k, ok = km.keyRing.DataEncryption[purpose]; ok {
+		return k.Key, nil
+	}
+	k = dataEncryptionKey{Purpose: purpose, Key: make([]byte, dataEncryptionKeySize)}
+	if _, err := rand.Read(k.Key); err != nil {
+		return nil, errors.Wrap(err, "failed to generate data encryption key")
+	}
+	km.keyRing.DataEncryption[purpose] = k
+	if err := km.save(ctx); err != nil {
+		delete(km.keyRing.DataEncryption, purpose)
+		return nil, err
+	}
+	km.logger.Infow("Created data encryption key", "purpose", purpose)
+	return k.Key, nil
+}
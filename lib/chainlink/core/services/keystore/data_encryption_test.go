@@ -0,0 +1,60 @@
+package keystore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+func TestMasterKeystore_DataEncryptionKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	ks := keystore.New(db, utils.FastScryptParams, logger.TestLogger(t))
+
+	_, err := ks.DataEncryptionKey(ctx, "purpose")
+	require.ErrorIs(t, err, keystore.ErrLocked)
+
+	require.NoError(t, ks.Unlock(ctx, cltest.Password))
+	key, err := ks.DataEncryptionKey(ctx, "purpose")
+	require.NoError(t, err)
+	require.Len(t, key, 32)
+	again, err := ks.DataEncryptionKey(ctx, "purpose")
+	require.NoError(t, err)
+	assert.Equal(t, key, again)
+	other, err := ks.DataEncryptionKey(ctx, "other purpose")
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+
+	// the key doesn't depend on the CSA key
+	csaKey, err := ks.CSA().Create(ctx)
+	require.NoError(t, err)
+	_, err = ks.CSA().Delete(ctx, csaKey.ID())
+	require.NoError(t, err)
+	require.NoError(t, ks.CSA().EnsureKey(ctx))
+
+	reloaded := keystore.New(db, utils.FastScryptParams, logger.TestLogger(t))
+	require.NoError(t, reloaded.Unlock(ctx, cltest.Password))
+	again, err = reloaded.DataEncryptionKey(ctx, "purpose")
+	require.NoError(t, err)
+	assert.Equal(t, key, again)
+
+	// and survives password rotations
+	const newPassword = "16charlengthp4SsW0rD1!@#_"
+	_, err = reloaded.RotatePassword(ctx, cltest.Password, newPassword, utils.ScryptParams{}, false)
+	require.NoError(t, err)
+	reloaded = keystore.New(db, utils.FastScryptParams, logger.TestLogger(t))
+	require.NoError(t, reloaded.Unlock(ctx, newPassword))
+	again, err = reloaded.DataEncryptionKey(ctx, "other purpose")
+	require.NoError(t, err)
+	assert.Equal(t, other, again)
+}
@@ -0,0 +1,102 @@
+// Package external lets the node delegate signing to a process that holds the
+// private keys, so that the raw key material never enters the node's keystore.
+//
+// A Backend is reached over a Unix socket using the protocol described in
+// README.md. Client is the node side of that protocol, Handler serves any
+// Backend over it, and LocalBackend is an in-memory reference implementation
+// used for testing (see cmd/signerd).
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/loop"
+)
+
+// KeyType identifies the family of keys, and so the signing scheme, of an account.
+type KeyType string
+
+const (
+	// KeyTypeCSA signs arbitrary messages with ed25519.
+	KeyTypeCSA KeyType = "csa"
+	// KeyTypeOCR2 signs 32 byte EVM report digests with the onchain secp256k1 key of an OCR2 key bundle.
+	KeyTypeOCR2 KeyType = "ocr2"
+	// KeyTypeVRF generates VRF proofs for 32 byte big endian seeds.
+	KeyTypeVRF KeyType = "vrf"
+)
+
+// KeyTypes lists every key type a Backend may serve.
+var KeyTypes = []KeyType{KeyTypeCSA, KeyTypeOCR2, KeyTypeVRF}
+
+// ParseKeyType returns the KeyType named by s.
+func ParseKeyType(s string) (KeyType, error) {
+	kt := KeyType(s)
+	if !slices.Contains(KeyTypes, kt) {
+		return "", fmt.Errorf("unknown key type %q", s)
+	}
+	return kt, nil
+}
+
+// ErrAccountNotFound is returned when a Backend holds no key for the requested account.
+var ErrAccountNotFound = errors.New("account not found")
+
+// Account is a key held by a Backend.
+type Account struct {
+	// ID is the identifier the node uses for the key: the hex public key for
+	// csa, the EIP-55 onchain signing address for ocr2 and the hex compressed
+	// public key for vrf.
+	ID string `json:"id"`
+	// PublicKey is the hex encoded public key, or the onchain signing address for ocr2.
+	PublicKey string `json:"publicKey"`
+}
+
+// Backend signs on behalf of the node with keys it never reveals.
+type Backend interface {
+	// Accounts returns the keys of the given type held by the backend.
+	Accounts(ctx context.Context, keyType KeyType) ([]Account, error)
+	// Sign signs data with the key identified by account, following the
+	// scheme documented on keyType. It returns ErrAccountNotFound if the
+	// backend holds no such key.
+	Sign(ctx context.Context, keyType KeyType, account string, data []byte) ([]byte, error)
+}
+
+var _ loop.Keystore = &Keystore{}
+
+// Keystore adapts the keys of one type held by a Backend to a loop.Keystore.
+type Keystore struct {
+	Backend Backend
+	KeyType KeyType
+}
+
+func (k *Keystore) Accounts(ctx context.Context) (accounts []string, err error) {
+	as, err := k.Backend.Accounts(ctx, k.KeyType)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range as {
+		accounts = append(accounts, a.ID)
+	}
+	return
+}
+
+func (k *Keystore) Sign(ctx context.Context, account string, data []byte) ([]byte, error) {
+	// loopp spec requires passing nil hash to check existence of id
+	if data == nil {
+		accounts, err := k.Accounts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(accounts, account) {
+			return nil, accountNotFound(k.KeyType, account)
+		}
+		return nil, nil
+	}
+	return k.Backend.Sign(ctx, k.KeyType, account, data)
+}
+
+func accountNotFound(keyType KeyType, account string) error {
+	return fmt.Errorf("%s account %s: %w", keyType, account, ErrAccountNotFound)
+}
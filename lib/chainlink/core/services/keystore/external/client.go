@@ -0,0 +1,94 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// maxResponseSize bounds the body read from the signer.
+const maxResponseSize = 1 << 20
+
+var _ Backend = &Client{}
+
+// Client is a Backend served by a signer process listening on a Unix socket.
+type Client struct {
+	socketPath string
+	http       *http.Client
+}
+
+// NewClient returns a Client for the signer listening on socketPath. Each
+// request is bounded by timeout.
+func NewClient(socketPath string, timeout time.Duration) *Client {
+	var d net.Dialer
+	return &Client{
+		socketPath: socketPath,
+		http: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return d.DialContext(ctx, "unix", socketPath)
+				},
+			},
+		},
+	}
+}
+
+func (c *Client) Accounts(ctx context.Context, keyType KeyType) ([]Account, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://signer"+accountsPath+"?keyType="+url.QueryEscape(string(keyType)), nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp accountsResponse
+	if err := c.do(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list %s accounts: %w", keyType, err)
+	}
+	return resp.Accounts, nil
+}
+
+func (c *Client) Sign(ctx context.Context, keyType KeyType, account string, data []byte) ([]byte, error) {
+	body, err := json.Marshal(signRequest{KeyType: keyType, Account: account, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://signer"+signPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var resp signResponse
+	if err := c.do(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to sign with %s account %s: %w", keyType, account, err)
+	}
+	return resp.Signature, nil
+}
+
+func (c *Client) do(req *http.Request, v any) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("signer at %s unreachable: %w", c.socketPath, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var e errorResponse
+		if json.Unmarshal(b, &e) != nil || e.Error == "" {
+			e.Error = resp.Status
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("%s: %w", e.Error, ErrAccountNotFound)
+		}
+		return errors.New(e.Error)
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,105 @@
+// signerd is a reference external signer for testing. It generates
+// throwaway keys at start up, keeps them in memory and serves them on a Unix
+// socket with the protocol of the external keystore package.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/external"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/csakey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+)
+
+func main() {
+	var (
+		socketPath string
+		csaKeys    int
+		vrfKeys    int
+		ocr2Keys   int
+	)
+
+	flag.StringVar(&socketPath, "socket", "signer.sock", "Path of the Unix socket to listen on")
+	flag.IntVar(&csaKeys, "csa", 1, "Number of CSA keys to generate")
+	flag.IntVar(&vrfKeys, "vrf", 0, "Number of VRF keys to generate")
+	flag.IntVar(&ocr2Keys, "ocr2", 0, "Number of EVM OCR2 onchain keys to generate")
+	flag.Parse()
+
+	backend, err := newBackend(csaKeys, vrfKeys, ocr2Keys)
+	if err != nil {
+		fmt.Printf("Failed to generate keys: %v\n", err)
+		os.Exit(1)
+	}
+	for _, kt := range external.KeyTypes {
+		accounts, _ := backend.Accounts(context.Background(), kt)
+		for _, a := range accounts {
+			fmt.Printf("%s account %s (public key %s)\n", kt, a.ID, a.PublicKey)
+		}
+	}
+
+	if err = os.Remove(socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("Failed to remove stale socket: %v\n", err)
+		os.Exit(1)
+	}
+	l, err := net.Listen("unix", socketPath)
+	if err != nil {
+		fmt.Printf("Failed to listen on %s: %v\n", socketPath, err)
+		os.Exit(1)
+	}
+	if err = os.Chmod(socketPath, 0o600); err != nil {
+		fmt.Printf("Failed to restrict socket permissions: %v\n", err)
+		os.Exit(1)
+	}
+
+	srv := &http.Server{Handler: external.Handler(backend), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	fmt.Printf("Listening on %s\n", socketPath)
+	if err = srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Printf("Server failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newBackend(csaKeys, vrfKeys, ocr2Keys int) (*external.LocalBackend, error) {
+	backend := external.NewLocalBackend()
+	for range csaKeys {
+		k, err := csakey.NewV2()
+		if err != nil {
+			return nil, err
+		}
+		backend.AddCSA(k)
+	}
+	for range vrfKeys {
+		k, err := vrfkey.NewV2()
+		if err != nil {
+			return nil, err
+		}
+		backend.AddVRF(k)
+	}
+	for range ocr2Keys {
+		k, err := ethkey.NewV2()
+		if err != nil {
+			return nil, err
+		}
+		backend.AddOCR2(k)
+	}
+	return backend, nil
+}
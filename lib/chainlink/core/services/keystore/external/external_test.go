@@ -0,0 +1,151 @@
+package external_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"math/big"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/external"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/csakey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+)
+
+func serve(t *testing.T, backend external.Backend) *external.Client {
+	socketPath := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	srv := &http.Server{Handler: external.Handler(backend), ReadHeaderTimeout: time.Second}
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return external.NewClient(socketPath, 5*time.Second)
+}
+
+func TestClient_RoundTrip(t *testing.T) {
+	ctx := testutils.Context(t)
+	backend := external.NewLocalBackend()
+	client := serve(t, backend)
+
+	t.Run("csa", func(t *testing.T) {
+		key, err := csakey.NewV2()
+		require.NoError(t, err)
+		backend.AddCSA(key)
+
+		ks := &external.Keystore{Backend: client, KeyType: external.KeyTypeCSA}
+		accounts, err := ks.Accounts(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{key.ID()}, accounts)
+
+		sig, err := ks.Sign(ctx, key.ID(), nil)
+		require.NoError(t, err)
+		assert.Nil(t, sig)
+
+		sig, err = ks.Sign(ctx, key.ID(), []byte("message"))
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(key.PublicKey, []byte("message"), sig))
+	})
+
+	t.Run("ocr2", func(t *testing.T) {
+		remote, err := ethkey.NewV2()
+		require.NoError(t, err)
+		backend.AddOCR2(remote)
+
+		accounts, err := client.Accounts(ctx, external.KeyTypeOCR2)
+		require.NoError(t, err)
+		require.Equal(t, []external.Account{{ID: remote.ID(), PublicKey: remote.ID()}}, accounts)
+
+		_, err = client.Sign(ctx, external.KeyTypeOCR2, remote.ID(), []byte("not a digest"))
+		require.Error(t, err)
+		require.NotErrorIs(t, err, external.ErrAccountNotFound)
+
+		local, err := ocr2key.NewEVMWithExternalOnchainKey(remote.Address)
+		require.NoError(t, err)
+		assert.Equal(t, remote.Address.Bytes(), []byte(local.PublicKey()))
+		_, err = local.Sign3(ocrtypes.ConfigDigest{}, 42, []byte("report"))
+		require.ErrorIs(t, err, ocr2key.ErrExternalOnchainKey)
+
+		kb := external.NewOCR2KeyBundle(local, client)
+		assert.Equal(t, local.ID(), kb.ID())
+		assert.Equal(t, local.PublicKey(), kb.PublicKey())
+		assert.Equal(t, local.OffchainPublicKey(), kb.OffchainPublicKey())
+
+		var digest ocrtypes.ConfigDigest
+		copy(digest[:], crypto.Keccak256([]byte(t.Name())))
+		report := []byte("report")
+		sig, err := kb.Sign3(digest, 42, report)
+		require.NoError(t, err)
+		assert.True(t, kb.Verify3(kb.PublicKey(), digest, 42, report, sig))
+
+		impostor, err := ethkey.NewV2()
+		require.NoError(t, err)
+		_, err = external.NewOCR2KeyBundle(local, impostorBackend{key: impostor}).Sign3(digest, 42, report)
+		require.ErrorContains(t, err, "does not recover")
+
+		other, err := ocr2key.New(chaintype.EVM)
+		require.NoError(t, err)
+		assert.Same(t, other, external.NewOCR2KeyBundle(other, client), "bundles with a local onchain key are not wrapped")
+	})
+
+	t.Run("vrf", func(t *testing.T) {
+		key, err := vrfkey.NewV2()
+		require.NoError(t, err)
+		backend.AddVRF(key)
+
+		seed := big.NewInt(1337)
+		proof, err := external.GenerateVRFProof(ctx, client, key.ID(), seed)
+		require.NoError(t, err)
+		assert.Equal(t, 0, proof.Seed.Cmp(seed))
+		ok, err := proof.VerifyVRFProof()
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		b, err := external.MarshalVRFProof(proof)
+		require.NoError(t, err)
+		decoded, err := external.UnmarshalVRFProof(b)
+		require.NoError(t, err)
+		assert.Equal(t, proof.String(), decoded.String())
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		_, err := client.Sign(ctx, external.KeyTypeOCR2, common.Address{}.Hex(), make([]byte, 32))
+		require.ErrorIs(t, err, external.ErrAccountNotFound)
+
+		_, err = external.GenerateVRFProof(ctx, client, "0x00", big.NewInt(1))
+		require.ErrorIs(t, err, external.ErrAccountNotFound)
+
+		ks := &external.Keystore{Backend: client, KeyType: external.KeyTypeCSA}
+		_, err = ks.Sign(ctx, "missing", nil)
+		require.ErrorIs(t, err, external.ErrAccountNotFound)
+	})
+
+	t.Run("unknown key type", func(t *testing.T) {
+		_, err := client.Accounts(ctx, "p2p")
+		require.ErrorContains(t, err, `unknown key type "p2p"`)
+	})
+}
+
+// impostorBackend signs every request with its own key, whatever the account.
+type impostorBackend struct{ key ethkey.KeyV2 }
+
+func (impostorBackend) Accounts(context.Context, external.KeyType) ([]external.Account, error) {
+	return nil, nil
+}
+
+func (b impostorBackend) Sign(_ context.Context, _ external.KeyType, _ string, data []byte) ([]byte, error) {
+	return b.key.Sign(data)
+}
@@ -0,0 +1,114 @@
+package external
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/csakey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+)
+
+var _ Backend = &LocalBackend{}
+
+// LocalBackend is a reference Backend keeping its keys in memory. It is meant
+// for tests and for trying the protocol out, not for holding production keys.
+type LocalBackend struct {
+	mu   sync.RWMutex
+	csa  map[string]csakey.KeyV2
+	ocr2 map[string]ethkey.KeyV2
+	vrf  map[string]vrfkey.KeyV2
+}
+
+func NewLocalBackend() *LocalBackend {
+	return &LocalBackend{
+		csa:  make(map[string]csakey.KeyV2),
+		ocr2: make(map[string]ethkey.KeyV2),
+		vrf:  make(map[string]vrfkey.KeyV2),
+	}
+}
+
+func (b *LocalBackend) AddCSA(key csakey.KeyV2) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.csa[key.ID()] = key
+}
+
+// AddOCR2 serves key as an EVM OCR2 onchain key, identified by its address.
+func (b *LocalBackend) AddOCR2(key ethkey.KeyV2) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.ocr2[key.ID()] = key
+}
+
+func (b *LocalBackend) AddVRF(key vrfkey.KeyV2) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.vrf[key.ID()] = key
+}
+
+func (b *LocalBackend) Accounts(_ context.Context, keyType KeyType) (accounts []Account, err error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	switch keyType {
+	case KeyTypeCSA:
+		for id, k := range b.csa {
+			accounts = append(accounts, Account{ID: id, PublicKey: k.PublicKeyString()})
+		}
+	case KeyTypeOCR2:
+		for id := range b.ocr2 {
+			accounts = append(accounts, Account{ID: id, PublicKey: id})
+		}
+	case KeyTypeVRF:
+		for id := range b.vrf {
+			accounts = append(accounts, Account{ID: id, PublicKey: id})
+		}
+	default:
+		return nil, fmt.Errorf("unknown key type %q", keyType)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+	return accounts, nil
+}
+
+func (b *LocalBackend) Sign(_ context.Context, keyType KeyType, account string, data []byte) ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	switch keyType {
+	case KeyTypeCSA:
+		k, ok := b.csa[account]
+		if !ok {
+			return nil, accountNotFound(keyType, account)
+		}
+		return k.Sign(rand.Reader, data, crypto.Hash(0))
+	case KeyTypeOCR2:
+		k, ok := b.ocr2[common.HexToAddress(account).Hex()]
+		if !ok {
+			return nil, accountNotFound(keyType, account)
+		}
+		if len(data) != common.HashLength {
+			return nil, fmt.Errorf("ocr2 signing requires a %d byte digest, got %d bytes", common.HashLength, len(data))
+		}
+		return k.Sign(data)
+	case KeyTypeVRF:
+		k, ok := b.vrf[account]
+		if !ok {
+			return nil, accountNotFound(keyType, account)
+		}
+		if len(data) != common.HashLength {
+			return nil, fmt.Errorf("vrf proofs require a %d byte seed, got %d bytes", common.HashLength, len(data))
+		}
+		p, err := k.GenerateProof(new(big.Int).SetBytes(data))
+		if err != nil {
+			return nil, err
+		}
+		return MarshalVRFProof(p)
+	}
+	return nil, fmt.Errorf("unknown key type %q", keyType)
+}
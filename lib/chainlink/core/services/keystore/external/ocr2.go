@@ -0,0 +1,53 @@
+package external
+
+import (
+	"context"
+	"fmt"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
+)
+
+var _ ocr2key.KeyBundle = &ocr2KeyBundle{}
+
+// ocr2KeyBundle is an EVM key bundle whose onchain key is held by a Backend.
+// The offchain keys, which never leave the node's p2p layer, stay local.
+type ocr2KeyBundle struct {
+	ocr2key.KeyBundle
+	backend Backend
+	account string
+}
+
+// NewOCR2KeyBundle returns kb, created by ocr2key.NewEVMWithExternalOnchainKey,
+// with its onchain signing delegated to the backend's OCR2 account of its
+// signing address. Other bundles are returned as is.
+func NewOCR2KeyBundle(kb ocr2key.KeyBundle, backend Backend) ocr2key.KeyBundle {
+	address, ok := ocr2key.ExternalOnchainAddress(kb)
+	if !ok {
+		return kb
+	}
+	return &ocr2KeyBundle{KeyBundle: kb, backend: backend, account: address.Hex()}
+}
+
+func (kb *ocr2KeyBundle) Sign(reportCtx ocrtypes.ReportContext, report ocrtypes.Report) ([]byte, error) {
+	return kb.SignBlob(ocr2key.ReportToSigData(reportCtx, report))
+}
+
+func (kb *ocr2KeyBundle) Sign3(digest ocrtypes.ConfigDigest, seqNr uint64, r ocrtypes.Report) ([]byte, error) {
+	return kb.SignBlob(ocr2key.ReportToSigData3(digest, seqNr, r))
+}
+
+// SignBlob signs b with the backend, and checks that the signature is by the onchain key of the
+// bundle, so that a misconfigured or compromised signer can't have reports signed by another key.
+func (kb *ocr2KeyBundle) SignBlob(b []byte) ([]byte, error) {
+	// libocr does not pass a context to onchain keyrings, the client timeout bounds the call.
+	sig, err := kb.backend.Sign(context.Background(), KeyTypeOCR2, kb.account, b)
+	if err != nil {
+		return nil, err
+	}
+	if !kb.VerifyBlob(kb.PublicKey(), b, sig) {
+		return nil, fmt.Errorf("signature of the external signer does not recover to the onchain address %s of the key bundle", kb.account)
+	}
+	return sig, nil
+}
@@ -0,0 +1,26 @@
+package external
+
+// Wire types of the signer protocol. See README.md.
+
+const (
+	accountsPath = "/v1/accounts"
+	signPath     = "/v1/sign"
+)
+
+type accountsResponse struct {
+	Accounts []Account `json:"accounts"`
+}
+
+type signRequest struct {
+	KeyType KeyType `json:"keyType"`
+	Account string  `json:"account"`
+	Data    []byte  `json:"data"`
+}
+
+type signResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
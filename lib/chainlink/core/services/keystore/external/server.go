@@ -0,0 +1,59 @@
+package external
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// maxRequestSize bounds the body accepted by Handler.
+const maxRequestSize = 1 << 20
+
+// Handler serves backend over the signer protocol.
+func Handler(backend Backend) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+accountsPath, func(w http.ResponseWriter, r *http.Request) {
+		keyType, err := ParseKeyType(r.URL.Query().Get("keyType"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		accounts, err := backend.Accounts(r.Context(), keyType)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, accountsResponse{Accounts: accounts})
+	})
+	mux.HandleFunc("POST "+signPath, func(w http.ResponseWriter, r *http.Request) {
+		var req signRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if _, err := ParseKeyType(string(req.KeyType)); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		sig, err := backend.Sign(r.Context(), req.KeyType, req.Account, req.Data)
+		if errors.Is(err, ErrAccountNotFound) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		} else if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, signResponse{Signature: sig})
+	})
+	return mux
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
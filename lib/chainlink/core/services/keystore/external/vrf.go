@@ -0,0 +1,81 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+)
+
+// VRFProofLength is the size of an encoded VRF proof: the public key and
+// gamma as uncompressed 64 byte points, followed by C, S, Seed and Output as
+// 32 byte big endian integers.
+const VRFProofLength = 64 + 64 + 32*4
+
+// MarshalVRFProof encodes p for the signer protocol.
+func MarshalVRFProof(p vrfkey.Proof) ([]byte, error) {
+	if !p.WellFormed() {
+		return nil, errors.New("badly formatted VRF proof")
+	}
+	b := make([]byte, 0, VRFProofLength)
+	b = append(b, secp256k1.LongMarshal(p.PublicKey)...)
+	b = append(b, secp256k1.LongMarshal(p.Gamma)...)
+	for _, i := range []*big.Int{p.C, p.S, p.Seed, p.Output} {
+		b = append(b, common.BigToHash(i).Bytes()...)
+	}
+	return b, nil
+}
+
+// UnmarshalVRFProof decodes a proof encoded by MarshalVRFProof.
+func UnmarshalVRFProof(b []byte) (p vrfkey.Proof, err error) {
+	if len(b) != VRFProofLength {
+		return p, fmt.Errorf("VRF proof is %d bytes long, should be %d", len(b), VRFProofLength)
+	}
+	if p.PublicKey, err = secp256k1.LongUnmarshal(b[:64]); err != nil {
+		return vrfkey.Proof{}, fmt.Errorf("while reading proof public key: %w", err)
+	}
+	if p.Gamma, err = secp256k1.LongUnmarshal(b[64:128]); err != nil {
+		return vrfkey.Proof{}, fmt.Errorf("while reading proof gamma: %w", err)
+	}
+	p.C = new(big.Int).SetBytes(b[128:160])
+	p.S = new(big.Int).SetBytes(b[160:192])
+	p.Seed = new(big.Int).SetBytes(b[192:224])
+	p.Output = new(big.Int).SetBytes(b[224:256])
+	return p, nil
+}
+
+// GenerateVRFProof asks backend for a proof of seed under the VRF key id, and
+// checks that the proof is valid for that key and seed before returning it.
+func GenerateVRFProof(ctx context.Context, backend Backend, id string, seed *big.Int) (vrfkey.Proof, error) {
+	if seed.Sign() < 0 || seed.BitLen() > 256 {
+		return vrfkey.Proof{}, errors.New("VRF seed must fit in 256 bits")
+	}
+	b, err := backend.Sign(ctx, KeyTypeVRF, id, common.BigToHash(seed).Bytes())
+	if err != nil {
+		return vrfkey.Proof{}, err
+	}
+	p, err := UnmarshalVRFProof(b)
+	if err != nil {
+		return vrfkey.Proof{}, err
+	}
+	pk, err := secp256k1.NewPublicKeyFromHex(id)
+	if err != nil {
+		return vrfkey.Proof{}, err
+	}
+	point, err := pk.Point()
+	if err != nil {
+		return vrfkey.Proof{}, err
+	}
+	if !point.Equal(p.PublicKey) || p.Seed.Cmp(seed) != 0 {
+		return vrfkey.Proof{}, fmt.Errorf("external signer returned a VRF proof for another key or seed than %s", id)
+	}
+	if ok, err := p.VerifyVRFProof(); !ok || err != nil {
+		return vrfkey.Proof{}, fmt.Errorf("external signer returned an invalid VRF proof: %v", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,51 @@
+package keystore
+
+import (
+	"context"
+	"slices"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/external"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// NewWithExternalSigner returns a Master that falls back to signer for CSA
+// and VRF accounts missing from its key ring, and that delegates the
+// onchain signing of EVM OCR2 key bundles created by
+// OCR2.CreateWithExternalOnchainKey to signer. A nil signer behaves like New.
+func NewWithExternalSigner(ds sqlutil.DataSource, scryptParams utils.ScryptParams, lggr logger.Logger, signer external.Backend) Master {
+	ks := newMaster(ds, scryptParams, lggr)
+	ks.external = signer
+	return ks
+}
+
+type externalSignerProvider interface {
+	externalSigner() external.Backend
+}
+
+func (km *keyManager) externalSigner() external.Backend {
+	return km.external
+}
+
+// externalSignerOf returns the external signer of the key store ks, or nil if it has none.
+func externalSignerOf(ks any) external.Backend {
+	if p, ok := ks.(externalSignerProvider); ok {
+		return p.externalSigner()
+	}
+	return nil
+}
+
+// appendExternalAccounts adds the accounts of keyType held by signer to accounts, skipping duplicates.
+func appendExternalAccounts(ctx context.Context, signer external.Backend, keyType external.KeyType, accounts []string) ([]string, error) {
+	ext, err := signer.Accounts(ctx, keyType)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range ext {
+		if !slices.Contains(accounts, a.ID) {
+			accounts = append(accounts, a.ID)
+		}
+	}
+	return accounts, nil
+}
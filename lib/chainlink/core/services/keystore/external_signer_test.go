@@ -0,0 +1,88 @@
+package keystore_test
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/external"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/csakey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ethkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+func Test_ExternalSigner(t *testing.T) {
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+
+	backend := external.NewLocalBackend()
+	csaKey, err := csakey.NewV2()
+	require.NoError(t, err)
+	backend.AddCSA(csaKey)
+	vrfKey, err := vrfkey.NewV2()
+	require.NoError(t, err)
+	backend.AddVRF(vrfKey)
+	ocr2Key, err := ethkey.NewV2()
+	require.NoError(t, err)
+	backend.AddOCR2(ocr2Key)
+
+	ks := keystore.NewWithExternalSigner(db, utils.FastScryptParams, logger.TestLogger(t), backend)
+	require.NoError(t, ks.Unlock(ctx, cltest.Password))
+
+	t.Run("csa", func(t *testing.T) {
+		signer := keystore.CSASigner{CSA: ks.CSA()}
+		accounts, err := signer.Accounts(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{csaKey.ID()}, accounts)
+
+		sig, err := signer.Sign(ctx, csaKey.ID(), []byte("message"))
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(csaKey.PublicKey, []byte("message"), sig))
+	})
+
+	t.Run("vrf", func(t *testing.T) {
+		proof, err := ks.VRF().GenerateProof(vrfKey.ID(), big.NewInt(7))
+		require.NoError(t, err)
+		ok, err := proof.VerifyVRFProof()
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		_, err = ks.VRF().GenerateProof("0x00", big.NewInt(7))
+		require.ErrorContains(t, err, "unable to find VRF key")
+	})
+
+	t.Run("ocr2", func(t *testing.T) {
+		_, err := ks.OCR2().CreateWithExternalOnchainKey(ctx, testutils.NewAddress())
+		require.ErrorContains(t, err, "holds no OCR2 key")
+
+		created, err := ks.OCR2().CreateWithExternalOnchainKey(ctx, ocr2Key.Address)
+		require.NoError(t, err)
+		assert.Equal(t, ocrtypes.OnchainPublicKey(ocr2Key.Address.Bytes()), created.PublicKey())
+
+		kb, err := ks.OCR2().Get(created.ID())
+		require.NoError(t, err)
+		all, err := ks.OCR2().GetAll()
+		require.NoError(t, err)
+		ofType, err := ks.OCR2().GetAllOfType(chaintype.EVM)
+		require.NoError(t, err)
+		for _, kb := range []ocr2key.KeyBundle{created, kb, all[0], ofType[0]} {
+			report := ocrtypes.Report("report")
+			sig, err := kb.Sign3(ocrtypes.ConfigDigest{1}, 2, report)
+			require.NoError(t, err)
+			assert.True(t, kb.Verify3(kb.PublicKey(), ocrtypes.ConfigDigest{1}, 2, report, sig))
+		}
+	})
+}
@@ -35,10 +35,11 @@ func (ekr *evmKeyring) PublicKey() ocrtypes.OnchainPublicKey {
 }
 
 func (ekr *evmKeyring) Sign(reportCtx ocrtypes.ReportContext, report ocrtypes.Report) ([]byte, error) {
-	return ekr.SignBlob(ekr.reportToSigData(reportCtx, report))
+	return ekr.SignBlob(ReportToSigData(reportCtx, report))
 }
 
-func (ekr *evmKeyring) reportToSigData(reportCtx ocrtypes.ReportContext, report ocrtypes.Report) []byte {
+// ReportToSigData returns the digest signed by an EVM OCR2 onchain key for report.
+func ReportToSigData(reportCtx ocrtypes.ReportContext, report ocrtypes.Report) []byte {
 	rawReportContext := evmutil.RawReportContext(reportCtx)
 	sigData := crypto.Keccak256(report)
 	sigData = append(sigData, rawReportContext[0][:]...)
@@ -73,7 +74,7 @@ func (ekr *evmKeyring) SignBlob(b []byte) (sig []byte, err error) {
 }
 
 func (ekr *evmKeyring) Verify(publicKey ocrtypes.OnchainPublicKey, reportCtx ocrtypes.ReportContext, report ocrtypes.Report, signature []byte) bool {
-	hash := ekr.reportToSigData(reportCtx, report)
+	hash := ReportToSigData(reportCtx, report)
 	return ekr.VerifyBlob(publicKey, hash, signature)
 }
 
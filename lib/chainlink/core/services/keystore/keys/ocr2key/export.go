@@ -39,7 +39,11 @@ func FromEncryptedJSON(keyJSON []byte, password string) (KeyBundle, error) {
 			var kb KeyBundle
 			switch export.ChainType {
 			case chaintype.EVM:
-				kb = newKeyBundle(new(evmKeyring))
+				if hasExternalOnchainKey(internal.Bytes(rawPrivKey)) {
+					kb = newKeyBundle(new(externalEVMKeyring))
+				} else {
+					kb = newKeyBundle(new(evmKeyring))
+				}
 			case chaintype.Cosmos:
 				kb = newKeyBundle(new(cosmosKeyring))
 			case chaintype.Solana:
@@ -0,0 +1,95 @@
+package ocr2key
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+)
+
+// ErrExternalOnchainKey is returned when signing with the onchain key of a bundle created by
+// NewEVMWithExternalOnchainKey, which must be delegated to the external signer holding it.
+var ErrExternalOnchainKey = errors.New("the onchain key of this key bundle is held by an external signer")
+
+var _ ocrtypes.OnchainKeyring = &externalEVMKeyring{}
+
+// externalEVMKeyring is the onchain keyring of an EVM key bundle whose private key is held by an
+// external signer. Only the signing address is stored.
+type externalEVMKeyring struct {
+	address common.Address
+}
+
+// NewEVMWithExternalOnchainKey returns an EVM key bundle with new offchain keys, and the onchain
+// signing address of a key held by an external signer. The bundle can verify onchain signatures,
+// but can't sign without delegating to the signer.
+func NewEVMWithExternalOnchainKey(address common.Address) (KeyBundle, error) {
+	return newKeyBundleRand(chaintype.EVM, func(io.Reader) (*externalEVMKeyring, error) {
+		return &externalEVMKeyring{address: address}, nil
+	})
+}
+
+// ExternalOnchainAddress returns the onchain signing address of kb, if its onchain key is held by
+// an external signer.
+func ExternalOnchainAddress(kb KeyBundle) (common.Address, bool) {
+	b, ok := kb.(*keyBundle[*externalEVMKeyring])
+	if !ok {
+		return common.Address{}, false
+	}
+	return b.keyring.address, true
+}
+
+// hasExternalOnchainKey returns true if the serialized key bundle b has an external onchain key.
+func hasExternalOnchainKey(b []byte) bool {
+	var raw struct{ ExternalOnchainKey bool }
+	return json.Unmarshal(b, &raw) == nil && raw.ExternalOnchainKey
+}
+
+func (ekr *externalEVMKeyring) PublicKey() ocrtypes.OnchainPublicKey {
+	return ekr.address[:]
+}
+
+func (ekr *externalEVMKeyring) Sign(ocrtypes.ReportContext, ocrtypes.Report) ([]byte, error) {
+	return nil, ErrExternalOnchainKey
+}
+
+func (ekr *externalEVMKeyring) Sign3(ocrtypes.ConfigDigest, uint64, ocrtypes.Report) ([]byte, error) {
+	return nil, ErrExternalOnchainKey
+}
+
+func (ekr *externalEVMKeyring) SignBlob([]byte) ([]byte, error) {
+	return nil, ErrExternalOnchainKey
+}
+
+func (ekr *externalEVMKeyring) Verify(publicKey ocrtypes.OnchainPublicKey, reportCtx ocrtypes.ReportContext, report ocrtypes.Report, signature []byte) bool {
+	return (&evmKeyring{}).Verify(publicKey, reportCtx, report, signature)
+}
+
+func (ekr *externalEVMKeyring) Verify3(publicKey ocrtypes.OnchainPublicKey, cd ocrtypes.ConfigDigest, seqNr uint64, r ocrtypes.Report, signature []byte) bool {
+	return (&evmKeyring{}).Verify3(publicKey, cd, seqNr, r, signature)
+}
+
+func (ekr *externalEVMKeyring) VerifyBlob(pubkey ocrtypes.OnchainPublicKey, b, sig []byte) bool {
+	return (&evmKeyring{}).VerifyBlob(pubkey, b, sig)
+}
+
+func (ekr *externalEVMKeyring) MaxSignatureLength() int {
+	return 65
+}
+
+func (ekr *externalEVMKeyring) Marshal() ([]byte, error) {
+	return ekr.address.Bytes(), nil
+}
+
+func (ekr *externalEVMKeyring) Unmarshal(in []byte) error {
+	if len(in) != common.AddressLength {
+		return fmt.Errorf("invalid external onchain signing address of %d bytes", len(in))
+	}
+	ekr.address = common.BytesToAddress(in)
+	return nil
+}
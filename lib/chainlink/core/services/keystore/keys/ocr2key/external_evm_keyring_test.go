@@ -0,0 +1,68 @@
+package ocr2key
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ocrtypes "github.com/smartcontractkit/libocr/offchainreporting2plus/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+func TestExternalEVMKeyring(t *testing.T) {
+	address := testutils.NewAddress()
+	kb, err := NewEVMWithExternalOnchainKey(address)
+	require.NoError(t, err)
+	assert.Equal(t, chaintype.EVM, kb.ChainType())
+	assert.Equal(t, ocrtypes.OnchainPublicKey(address.Bytes()), kb.PublicKey())
+
+	_, err = kb.Sign(ocrtypes.ReportContext{}, ocrtypes.Report{})
+	require.ErrorIs(t, err, ErrExternalOnchainKey)
+	_, err = kb.Sign3(ocrtypes.ConfigDigest{}, 1, ocrtypes.Report{})
+	require.ErrorIs(t, err, ErrExternalOnchainKey)
+	_, err = kb.SignBlob([]byte("blob"))
+	require.ErrorIs(t, err, ErrExternalOnchainKey)
+
+	got, ok := ExternalOnchainAddress(kb)
+	require.True(t, ok)
+	assert.Equal(t, address, got)
+
+	local, err := New(chaintype.EVM)
+	require.NoError(t, err)
+	_, ok = ExternalOnchainAddress(local)
+	assert.False(t, ok)
+
+	t.Run("raw", func(t *testing.T) {
+		kbAfter := KeyFor(kb.Raw())
+		got, ok := ExternalOnchainAddress(kbAfter)
+		require.True(t, ok)
+		assert.Equal(t, address, got)
+		assert.Equal(t, kb.ID(), kbAfter.ID())
+		assert.Equal(t, kb.OffchainPublicKey(), kbAfter.OffchainPublicKey())
+
+		_, ok = ExternalOnchainAddress(KeyFor(local.Raw()))
+		assert.False(t, ok)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		ej, err := ToEncryptedJSON(kb, "blah", utils.FastScryptParams)
+		require.NoError(t, err)
+		kbAfter, err := FromEncryptedJSON(ej, "blah")
+		require.NoError(t, err)
+		got, ok := ExternalOnchainAddress(kbAfter)
+		require.True(t, ok)
+		assert.Equal(t, address, got)
+		assert.Equal(t, kb.ID(), kbAfter.ID())
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		var kr externalEVMKeyring
+		require.Error(t, kr.Unmarshal([]byte{1, 2, 3}))
+		require.NoError(t, kr.Unmarshal(address.Bytes()))
+		assert.Equal(t, address, kr.address)
+	})
+}
@@ -35,6 +35,9 @@ type (
 		OffchainKeyring []byte
 		Keyring         []byte
 		ID              models.Sha256Hash // tracked to preserve bundle ID in case of migrations
+		// ExternalOnchainKey is set when Keyring only holds the address of an onchain key held by an
+		// external signer
+		ExternalOnchainKey bool `json:",omitempty"`
 
 		// old chain specific format for migrating
 		EVMKeyring    []byte `json:",omitempty"`
@@ -134,6 +137,7 @@ func (kb *keyBundle[K]) Marshal() ([]byte, error) {
 		Keyring:         keyringBytes,
 		ID:              kb.id, // preserve bundle ID
 	}
+	_, rawKeyData.ExternalOnchainKey = any(kb.keyring).(*externalEVMKeyring)
 	return json.Marshal(&rawKeyData)
 }
 
@@ -115,7 +115,11 @@ func KeyFor(raw internal.Raw) (kb KeyBundle) {
 	}
 	switch temp.ChainType {
 	case chaintype.EVM:
-		kb = newKeyBundle(new(evmKeyring))
+		if hasExternalOnchainKey(internal.Bytes(raw)) {
+			kb = newKeyBundle(new(externalEVMKeyring))
+		} else {
+			kb = newKeyBundle(new(evmKeyring))
+		}
 	case chaintype.Cosmos:
 		kb = newKeyBundle(new(cosmosKeyring))
 	case chaintype.Solana:
@@ -36,6 +36,18 @@ func (o *memoryORM) saveEncryptedKeyRing(ctx context.Context, kr *encryptedKeyRi
 	return
 }
 
+func (o *memoryORM) rotateEncryptedKeyRing(ctx context.Context, kr *encryptedKeyRing, verifyBackup func(encryptedKeyRing) error, purgeBackups bool) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.keyRing != nil && len(o.keyRing.EncryptedKeys) > 0 {
+		if err := verifyBackup(*o.keyRing); err != nil {
+			return err
+		}
+	}
+	o.keyRing = kr
+	return nil
+}
+
 func (o *memoryORM) getEncryptedKeyRing(ctx context.Context) (encryptedKeyRing, error) {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
@@ -11,6 +11,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/external"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/aptoskey"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/cosmoskey"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/csakey"
@@ -53,6 +54,14 @@ type Master interface {
 	Workflow() Workflow
 	Unlock(ctx context.Context, password string) error
 	IsEmpty(ctx context.Context) (bool, error)
+	// DataEncryptionKey returns the 32 byte key the node encrypts or authenticates the data of
+	// purpose at rest with, creating it on first use. It is kept in the key ring.
+	DataEncryptionKey(ctx context.Context, purpose string) ([]byte, error)
+	// RotatePassword re-encrypts the key ring under newPassword, with scryptParams
+	// or, if they are zero, the current parameters. It returns the parameters used.
+	// If purgeBackups is set, the backups encrypted under previous passwords are
+	// deleted once the new key ring is saved.
+	RotatePassword(ctx context.Context, oldPassword, newPassword string, scryptParams utils.ScryptParams, purgeBackups bool) (utils.ScryptParams, error)
 }
 type master struct {
 	*keyManager
@@ -158,6 +167,7 @@ func (ks *master) Workflow() Workflow {
 type ORM interface {
 	isEmpty(context.Context) (bool, error)
 	saveEncryptedKeyRing(context.Context, *encryptedKeyRing, ...func(sqlutil.DataSource) error) error
+	rotateEncryptedKeyRing(ctx context.Context, kr *encryptedKeyRing, verifyBackup func(encryptedKeyRing) error, purgeBackups bool) error
 	getEncryptedKeyRing(context.Context) (encryptedKeyRing, error)
 }
 
@@ -174,6 +184,8 @@ type keyManager struct {
 	lock         *sync.RWMutex
 	password     string
 	logger       logger.Logger
+	// external signs for accounts that are not in keyRing, if set
+	external external.Backend
 }
 
 func (km *keyManager) IsEmpty(ctx context.Context) (bool, error) {
@@ -200,6 +212,10 @@ func (km *keyManager) Unlock(ctx context.Context, password string) error {
 	}
 	kr.logPubKeys(km.logger)
 	km.keyRing = kr
+	if params, ok := ekr.scryptParams(); ok && params != km.scryptParams && !weakerScryptParams(params, km.scryptParams) {
+		// keep parameters raised by RotatePassword, so that saving never weakens the key ring
+		km.scryptParams = params
+	}
 
 	ks, err := km.keystateORM.loadKeyStates(ctx)
 	if err != nil {
@@ -7,6 +7,8 @@ import (
 
 	keystore "github.com/smartcontractkit/chainlink/v2/core/services/keystore"
 	mock "github.com/stretchr/testify/mock"
+
+	utils "github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
 // Master is an autogenerated mock type for the Master type
@@ -163,6 +165,65 @@ func (_c *Master_Cosmos_Call) RunAndReturn(run func() keystore.Cosmos) *Master_C
 	return _c
 }
 
+// DataEncryptionKey provides a mock function with given fields: ctx, purpose
+func (_m *Master) DataEncryptionKey(ctx context.Context, purpose string) ([]byte, error) {
+	ret := _m.Called(ctx, purpose)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DataEncryptionKey")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]byte, error)); ok {
+		return rf(ctx, purpose)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []byte); ok {
+		r0 = rf(ctx, purpose)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, purpose)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Master_DataEncryptionKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DataEncryptionKey'
+type Master_DataEncryptionKey_Call struct {
+	*mock.Call
+}
+
+// DataEncryptionKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - purpose string
+func (_e *Master_Expecter) DataEncryptionKey(ctx interface{}, purpose interface{}) *Master_DataEncryptionKey_Call {
+	return &Master_DataEncryptionKey_Call{Call: _e.mock.On("DataEncryptionKey", ctx, purpose)}
+}
+
+func (_c *Master_DataEncryptionKey_Call) Run(run func(ctx context.Context, purpose string)) *Master_DataEncryptionKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Master_DataEncryptionKey_Call) Return(_a0 []byte, _a1 error) *Master_DataEncryptionKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Master_DataEncryptionKey_Call) RunAndReturn(run func(context.Context, string) ([]byte, error)) *Master_DataEncryptionKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Eth provides a mock function with no fields
 func (_m *Master) Eth() keystore.Eth {
 	ret := _m.Called()
@@ -407,6 +468,66 @@ func (_c *Master_P2P_Call) RunAndReturn(run func() keystore.P2P) *Master_P2P_Cal
 	return _c
 }
 
+// RotatePassword provides a mock function with given fields: ctx, oldPassword, newPassword, scryptParams, purgeBackups
+func (_m *Master) RotatePassword(ctx context.Context, oldPassword string, newPassword string, scryptParams utils.ScryptParams, purgeBackups bool) (utils.ScryptParams, error) {
+	ret := _m.Called(ctx, oldPassword, newPassword, scryptParams, purgeBackups)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotatePassword")
+	}
+
+	var r0 utils.ScryptParams
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, utils.ScryptParams, bool) (utils.ScryptParams, error)); ok {
+		return rf(ctx, oldPassword, newPassword, scryptParams, purgeBackups)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, utils.ScryptParams, bool) utils.ScryptParams); ok {
+		r0 = rf(ctx, oldPassword, newPassword, scryptParams, purgeBackups)
+	} else {
+		r0 = ret.Get(0).(utils.ScryptParams)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, utils.ScryptParams, bool) error); ok {
+		r1 = rf(ctx, oldPassword, newPassword, scryptParams, purgeBackups)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Master_RotatePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotatePassword'
+type Master_RotatePassword_Call struct {
+	*mock.Call
+}
+
+// RotatePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - oldPassword string
+//   - newPassword string
+//   - scryptParams utils.ScryptParams
+//   - purgeBackups bool
+func (_e *Master_Expecter) RotatePassword(ctx interface{}, oldPassword interface{}, newPassword interface{}, scryptParams interface{}, purgeBackups interface{}) *Master_RotatePassword_Call {
+	return &Master_RotatePassword_Call{Call: _e.mock.On("RotatePassword", ctx, oldPassword, newPassword, scryptParams, purgeBackups)}
+}
+
+func (_c *Master_RotatePassword_Call) Run(run func(ctx context.Context, oldPassword string, newPassword string, scryptParams utils.ScryptParams, purgeBackups bool)) *Master_RotatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(utils.ScryptParams), args[4].(bool))
+	})
+	return _c
+}
+
+func (_c *Master_RotatePassword_Call) Return(_a0 utils.ScryptParams, _a1 error) *Master_RotatePassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Master_RotatePassword_Call) RunAndReturn(run func(context.Context, string, string, utils.ScryptParams, bool) (utils.ScryptParams, error)) *Master_RotatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Solana provides a mock function with no fields
 func (_m *Master) Solana() keystore.Solana {
 	ret := _m.Called()
@@ -7,6 +7,8 @@ import (
 
 	chaintype "github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
 
+	common "github.com/ethereum/go-ethereum/common"
+
 	mock "github.com/stretchr/testify/mock"
 
 	ocr2key "github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
@@ -131,6 +133,63 @@ func (_c *OCR2_Create_Call) RunAndReturn(run func(context.Context, chaintype.Cha
 	return _c
 }
 
+// CreateWithExternalOnchainKey provides a mock function with given fields: ctx, address
+func (_m *OCR2) CreateWithExternalOnchainKey(ctx context.Context, address common.Address) (ocr2key.KeyBundle, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithExternalOnchainKey")
+	}
+
+	var r0 ocr2key.KeyBundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (ocr2key.KeyBundle, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) ocr2key.KeyBundle); ok {
+		r0 = rf(ctx, address)
+	} else {
+		r0 = ret.Get(0).(ocr2key.KeyBundle)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OCR2_CreateWithExternalOnchainKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWithExternalOnchainKey'
+type OCR2_CreateWithExternalOnchainKey_Call struct {
+	*mock.Call
+}
+
+// CreateWithExternalOnchainKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - address common.Address
+func (_e *OCR2_Expecter) CreateWithExternalOnchainKey(ctx interface{}, address interface{}) *OCR2_CreateWithExternalOnchainKey_Call {
+	return &OCR2_CreateWithExternalOnchainKey_Call{Call: _e.mock.On("CreateWithExternalOnchainKey", ctx, address)}
+}
+
+func (_c *OCR2_CreateWithExternalOnchainKey_Call) Run(run func(ctx context.Context, address common.Address)) *OCR2_CreateWithExternalOnchainKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address))
+	})
+	return _c
+}
+
+func (_c *OCR2_CreateWithExternalOnchainKey_Call) Return(_a0 ocr2key.KeyBundle, _a1 error) *OCR2_CreateWithExternalOnchainKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OCR2_CreateWithExternalOnchainKey_Call) RunAndReturn(run func(context.Context, common.Address) (ocr2key.KeyBundle, error)) *OCR2_CreateWithExternalOnchainKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *OCR2) Delete(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -471,6 +471,63 @@ func (_c *VRF_Import_Call) RunAndReturn(run func(context.Context, []byte, string
 	return _c
 }
 
+// Rotate provides a mock function with given fields: ctx, id
+func (_m *VRF) Rotate(ctx context.Context, id string) (vrfkey.KeyV2, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rotate")
+	}
+
+	var r0 vrfkey.KeyV2
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (vrfkey.KeyV2, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) vrfkey.KeyV2); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(vrfkey.KeyV2)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VRF_Rotate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rotate'
+type VRF_Rotate_Call struct {
+	*mock.Call
+}
+
+// Rotate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *VRF_Expecter) Rotate(ctx interface{}, id interface{}) *VRF_Rotate_Call {
+	return &VRF_Rotate_Call{Call: _e.mock.On("Rotate", ctx, id)}
+}
+
+func (_c *VRF_Rotate_Call) Run(run func(ctx context.Context, id string)) *VRF_Rotate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *VRF_Rotate_Call) Return(_a0 vrfkey.KeyV2, _a1 error) *VRF_Rotate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *VRF_Rotate_Call) RunAndReturn(run func(context.Context, string) (vrfkey.KeyV2, error)) *VRF_Rotate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewVRF creates a new instance of VRF. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewVRF(t interface {
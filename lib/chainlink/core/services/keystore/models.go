@@ -3,7 +3,9 @@ package keystore
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"math/big"
+	"slices"
 	"time"
 
 	gethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
@@ -167,6 +169,9 @@ type keyRing struct {
 	VRF        map[string]vrfkey.KeyV2
 	Workflow   map[string]workflowkey.Key
 	LegacyKeys LegacyKeyStorage
+
+	// DataEncryption holds the data encryption keys of the node, by purpose.
+	DataEncryption map[string]dataEncryptionKey
 }
 
 func newKeyRing() *keyRing {
@@ -184,7 +189,23 @@ func newKeyRing() *keyRing {
 		TON:      make(map[string]tonkey.Key),
 		VRF:      make(map[string]vrfkey.KeyV2),
 		Workflow: make(map[string]workflowkey.Key),
+
+		DataEncryption: make(map[string]dataEncryptionKey),
+	}
+}
+
+// scryptParams returns the scrypt parameters the key ring was encrypted with.
+func (ekr encryptedKeyRing) scryptParams() (params utils.ScryptParams, ok bool) {
+	var cryptoJSON gethkeystore.CryptoJSON
+	if json.Unmarshal(ekr.EncryptedKeys, &cryptoJSON) != nil || cryptoJSON.KDF != "scrypt" {
+		return params, false
 	}
+	n, nok := cryptoJSON.KDFParams["n"].(float64)
+	p, pok := cryptoJSON.KDFParams["p"].(float64)
+	if !nok || !pok {
+		return params, false
+	}
+	return utils.ScryptParams{N: int(n), P: int(p)}, true
 }
 
 func (kr *keyRing) Encrypt(password string, scryptParams utils.ScryptParams) (ekr encryptedKeyRing, err error) {
@@ -256,6 +277,9 @@ func (kr *keyRing) raw() (rawKeys rawKeyRing) {
 	for _, workflowKey := range kr.Workflow {
 		rawKeys.Workflow = append(rawKeys.Workflow, internal.RawBytes(workflowKey))
 	}
+	for _, dataEncryptionKey := range kr.DataEncryption {
+		rawKeys.DataEncryption = append(rawKeys.DataEncryption, dataEncryptionKey.raw())
+	}
 	return rawKeys
 }
 
@@ -354,6 +378,10 @@ func (kr *keyRing) logPubKeys(lggr logger.Logger) {
 	if len(workflowIDs) > 0 {
 		lggr.Infow(fmt.Sprintf("Unlocked %d Workflow keys", len(workflowIDs)), "keys", workflowIDs)
 	}
+	if len(kr.DataEncryption) > 0 {
+		purposes := slices.Sorted(maps.Keys(kr.DataEncryption))
+		lggr.Infow(fmt.Sprintf("Unlocked %d data encryption keys", len(purposes)), "purposes", purposes)
+	}
 	if len(kr.LegacyKeys.legacyRawKeys) > 0 {
 		lggr.Infow(fmt.Sprintf("%d keys stored in legacy system", kr.LegacyKeys.legacyRawKeys.len()))
 	}
@@ -377,6 +405,9 @@ type rawKeyRing struct {
 	VRF        [][]byte
 	Workflow   [][]byte
 	LegacyKeys LegacyKeyStorage `json:"-"`
+
+	// DataEncryption is omitted until a data encryption key is created.
+	DataEncryption [][]byte `json:",omitempty"`
 }
 
 func (rawKeys rawKeyRing) keys() (*keyRing, error) {
@@ -434,6 +465,13 @@ func (rawKeys rawKeyRing) keys() (*keyRing, error) {
 		workflowKey := workflowkey.KeyFor(internal.NewRaw(rawWorkflowKey))
 		keyRing.Workflow[workflowKey.ID()] = workflowKey
 	}
+	for _, rawDataEncryptionKey := range rawKeys.DataEncryption {
+		dataEncryptionKey, err := dataEncryptionKeyFor(rawDataEncryptionKey)
+		if err != nil {
+			return nil, err
+		}
+		keyRing.DataEncryption[dataEncryptionKey.Purpose] = dataEncryptionKey
+	}
 
 	keyRing.LegacyKeys = rawKeys.LegacyKeys
 	return keyRing, nil
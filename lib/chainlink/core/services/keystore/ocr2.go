@@ -3,10 +3,13 @@ package keystore
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/external"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
 )
 
@@ -15,6 +18,9 @@ type OCR2 interface {
 	GetAll() ([]ocr2key.KeyBundle, error)
 	GetAllOfType(chaintype.ChainType) ([]ocr2key.KeyBundle, error)
 	Create(context.Context, chaintype.ChainType) (ocr2key.KeyBundle, error)
+	// CreateWithExternalOnchainKey creates an EVM key bundle whose onchain key is the key of the
+	// external signer with the signing address, so that the node never holds it.
+	CreateWithExternalOnchainKey(ctx context.Context, address common.Address) (ocr2key.KeyBundle, error)
 	Add(ctx context.Context, key ocr2key.KeyBundle) error
 	Delete(ctx context.Context, id string) error
 	Import(ctx context.Context, keyJSON []byte, password string) (ocr2key.KeyBundle, error)
@@ -40,7 +46,20 @@ func (ks ocr2) Get(id string) (ocr2key.KeyBundle, error) {
 	if ks.isLocked() {
 		return nil, ErrLocked
 	}
-	return ks.getByID(id)
+	kb, err := ks.getByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return ks.withExternalOnchainKey(kb), nil
+}
+
+// withExternalOnchainKey delegates the onchain signing of kb to the external signer, if kb was
+// created with an external onchain key. Without a signer, kb fails to sign.
+func (ks ocr2) withExternalOnchainKey(kb ocr2key.KeyBundle) ocr2key.KeyBundle {
+	if _, ok := ocr2key.ExternalOnchainAddress(kb); ok && ks.external != nil {
+		return external.NewOCR2KeyBundle(kb, ks.external)
+	}
+	return kb
 }
 
 func (ks ocr2) GetAll() ([]ocr2key.KeyBundle, error) {
@@ -51,7 +70,7 @@ func (ks ocr2) GetAll() ([]ocr2key.KeyBundle, error) {
 		return keys, ErrLocked
 	}
 	for _, key := range ks.keyRing.OCR2 {
-		keys = append(keys, key)
+		keys = append(keys, ks.withExternalOnchainKey(key))
 	}
 	return keys, nil
 }
@@ -63,7 +82,14 @@ func (ks ocr2) GetAllOfType(chainType chaintype.ChainType) ([]ocr2key.KeyBundle,
 	if ks.isLocked() {
 		return keys, ErrLocked
 	}
-	return ks.getAllOfType(chainType)
+	keys, err := ks.getAllOfType(chainType)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		keys[i] = ks.withExternalOnchainKey(key)
+	}
+	return keys, nil
 }
 
 func (ks ocr2) Create(ctx context.Context, chainType chaintype.ChainType) (ocr2key.KeyBundle, error) {
@@ -75,6 +101,32 @@ func (ks ocr2) Create(ctx context.Context, chainType chaintype.ChainType) (ocr2k
 	return ks.create(ctx, chainType)
 }
 
+func (ks ocr2) CreateWithExternalOnchainKey(ctx context.Context, address common.Address) (ocr2key.KeyBundle, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if ks.isLocked() {
+		return nil, ErrLocked
+	}
+	if ks.external == nil {
+		return nil, errors.New("the external signer is not enabled")
+	}
+	accounts, err := ks.external.Accounts(ctx, external.KeyTypeOCR2)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the OCR2 accounts of the external signer")
+	}
+	if !slices.ContainsFunc(accounts, func(a external.Account) bool { return common.HexToAddress(a.ID) == address }) {
+		return nil, errors.Errorf("the external signer holds no OCR2 key with the signing address %s", address)
+	}
+	key, err := ocr2key.NewEVMWithExternalOnchainKey(address)
+	if err != nil {
+		return nil, err
+	}
+	if err = ks.safeAddKey(ctx, key); err != nil {
+		return nil, err
+	}
+	return ks.withExternalOnchainKey(key), nil
+}
+
 func (ks ocr2) Add(ctx context.Context, key ocr2key.KeyBundle) error {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
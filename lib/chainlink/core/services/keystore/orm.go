@@ -48,6 +48,46 @@ func (orm ksORM) saveEncryptedKeyRing(ctx context.Context, kr *encryptedKeyRing,
 	})
 }
 
+// rotateEncryptedKeyRing replaces the key ring with kr. The current ciphertext is
+// first copied to encrypted_key_ring_backups, and the copy must pass verifyBackup
+// for the key ring to be replaced. If purgeBackups is set, every backup, including
+// the new one, is deleted in the same transaction once the key ring is replaced.
+func (orm ksORM) rotateEncryptedKeyRing(ctx context.Context, kr *encryptedKeyRing, verifyBackup func(encryptedKeyRing) error, purgeBackups bool) error {
+	return sqlutil.TransactDataSource(ctx, orm.ds, nil, func(tx sqlutil.DataSource) error {
+		var current encryptedKeyRing
+		err := tx.GetContext(ctx, &current, `SELECT * FROM encrypted_key_rings LIMIT 1 FOR UPDATE`)
+		if err != nil {
+			return errors.Wrap(err, "while locking keyring")
+		}
+		if len(current.EncryptedKeys) > 0 {
+			var backup encryptedKeyRing
+			err = tx.GetContext(ctx, &backup, `
+			INSERT INTO encrypted_key_ring_backups (encrypted_keys, created_at)
+			VALUES ($1, NOW())
+			RETURNING encrypted_keys, created_at AS updated_at
+		`, current.EncryptedKeys)
+			if err != nil {
+				return errors.Wrap(err, "while backing up keyring")
+			}
+			if err = verifyBackup(backup); err != nil {
+				return errors.Wrap(err, "keyring backup failed verification")
+			}
+		}
+		_, err = tx.ExecContext(ctx, `
+		UPDATE encrypted_key_rings
+		SET encrypted_keys = $1, updated_at = NOW()
+	`, kr.EncryptedKeys)
+		if err != nil {
+			return errors.Wrap(err, "while saving keyring")
+		}
+		if purgeBackups {
+			_, err = tx.ExecContext(ctx, `DELETE FROM encrypted_key_ring_backups`)
+			return errors.Wrap(err, "while purging keyring backups")
+		}
+		return nil
+	})
+}
+
 func (orm ksORM) getEncryptedKeyRing(ctx context.Context) (kr encryptedKeyRing, err error) {
 	err = orm.ds.GetContext(ctx, &kr, `SELECT * FROM encrypted_key_rings LIMIT 1`)
 	if errors.Is(err, sql.ErrNoRows) {
@@ -0,0 +1,113 @@
+package keystore
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// maxScryptN bounds the scrypt cost, which needs 128 * 8 * N bytes of memory
+// on every unlock. 2^20 already needs 1GiB.
+const maxScryptN = 1 << 20
+
+var (
+	ErrPasswordMismatch    = errors.New("old keystore password does not match")
+	ErrInvalidScryptParams = errors.New("invalid scrypt parameters")
+	errKeyRingVerification = errors.New("key ring does not decrypt to the keys in use")
+)
+
+// RotatePassword re-encrypts the key ring under newPassword with scryptParams,
+// or with the current parameters if scryptParams is zero. The parameters can
+// only be raised. The previous ciphertext is kept in encrypted_key_ring_backups,
+// and the key ring is only replaced if both the backup and the new ciphertext
+// decrypt to the keys in use. Backups are kept until a rotation with
+// purgeBackups deletes them, after the new key ring is saved. On any failure the
+// stored key ring and its backups are unchanged.
+func (km *keyManager) RotatePassword(ctx context.Context, oldPassword, newPassword string, scryptParams utils.ScryptParams, purgeBackups bool) (utils.ScryptParams, error) {
+	km.lock.Lock()
+	defer km.lock.Unlock()
+	if km.isLocked() {
+		return utils.ScryptParams{}, ErrLocked
+	}
+	if subtle.ConstantTimeCompare([]byte(oldPassword), []byte(km.password)) != 1 {
+		return utils.ScryptParams{}, ErrPasswordMismatch
+	}
+	if scryptParams == (utils.ScryptParams{}) {
+		scryptParams = km.scryptParams
+	}
+	if err := validateScryptParams(scryptParams, km.scryptParams); err != nil {
+		return utils.ScryptParams{}, err
+	}
+
+	ekr, err := km.keyRing.Encrypt(newPassword, scryptParams)
+	if err != nil {
+		return utils.ScryptParams{}, errors.Wrap(err, "unable to encrypt keyRing")
+	}
+	if err = verifyKeyRing(ekr, newPassword, km.keyRing); err != nil {
+		return utils.ScryptParams{}, errors.Wrap(err, "new key ring failed verification")
+	}
+	err = km.orm.rotateEncryptedKeyRing(ctx, &ekr, func(backup encryptedKeyRing) error {
+		return verifyKeyRing(backup, oldPassword, km.keyRing)
+	}, purgeBackups)
+	if err != nil {
+		return utils.ScryptParams{}, errors.Wrap(err, "unable to rotate keystore password")
+	}
+
+	km.password = newPassword
+	km.scryptParams = scryptParams
+	km.logger.Infow("Rotated keystore password", "scryptN", scryptParams.N, "scryptP", scryptParams.P, "purgedBackups", purgeBackups)
+	return scryptParams, nil
+}
+
+func validateScryptParams(params, current utils.ScryptParams) error {
+	if params.N <= 1 || params.N&(params.N-1) != 0 || params.N > maxScryptN {
+		return errors.Wrapf(ErrInvalidScryptParams, "N must be a power of 2 between 2 and %d, got %d", maxScryptN, params.N)
+	}
+	if params.P < 1 {
+		return errors.Wrapf(ErrInvalidScryptParams, "P must be positive, got %d", params.P)
+	}
+	if weakerScryptParams(params, current) {
+		return errors.Wrapf(ErrInvalidScryptParams, "cannot lower the scrypt parameters below N=%d P=%d", current.N, current.P)
+	}
+	return nil
+}
+
+// weakerScryptParams reports whether a lowers N or P compared to b.
+func weakerScryptParams(a, b utils.ScryptParams) bool {
+	return a.N < b.N || a.P < b.P
+}
+
+// verifyKeyRing checks that ekr decrypts with password to the same keys as expected.
+func verifyKeyRing(ekr encryptedKeyRing, password string, expected *keyRing) error {
+	kr, err := ekr.Decrypt(password)
+	if err != nil {
+		return errors.Wrapf(errKeyRingVerification, "unable to decrypt: %v", err)
+	}
+	if !slices.Equal(keyRingIDs(kr), keyRingIDs(expected)) ||
+		kr.LegacyKeys.legacyRawKeys.len() != expected.LegacyKeys.legacyRawKeys.len() {
+		return errKeyRingVerification
+	}
+	return nil
+}
+
+// keyRingIDs returns the sorted "<type>:<id>" of every key in kr.
+func keyRingIDs(kr *keyRing) (ids []string) {
+	v := reflect.Indirect(reflect.ValueOf(kr))
+	for i := range v.NumField() {
+		field := v.Field(i)
+		if field.Kind() != reflect.Map {
+			continue
+		}
+		for _, id := range field.MapKeys() {
+			ids = append(ids, fmt.Sprintf("%s:%s", v.Type().Field(i).Name, id.String()))
+		}
+	}
+	slices.Sort(ids)
+	return
+}
@@ -0,0 +1,78 @@
+package keystore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+func TestMasterKeystore_RotatePassword(t *testing.T) {
+	t.Parallel()
+
+	const newPassword = "16charlengthp4SsW0rD1!@#_"
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+
+	ks := keystore.New(db, utils.FastScryptParams, logger.TestLogger(t))
+	_, err := ks.RotatePassword(ctx, cltest.Password, newPassword, utils.ScryptParams{}, false)
+	require.ErrorIs(t, err, keystore.ErrLocked)
+
+	require.NoError(t, ks.Unlock(ctx, cltest.Password))
+	key := cltest.MustInsertRandomKey(t, ks.Eth())
+
+	_, err = ks.RotatePassword(ctx, "wrong password", newPassword, utils.ScryptParams{}, false)
+	require.ErrorIs(t, err, keystore.ErrPasswordMismatch)
+
+	for _, params := range []utils.ScryptParams{
+		{N: 1, P: 1},
+		{N: 6, P: 1},
+		{N: 4, P: 0},
+		{N: 1 << 21, P: 1},
+	} {
+		_, err = ks.RotatePassword(ctx, cltest.Password, newPassword, params, false)
+		require.ErrorIs(t, err, keystore.ErrInvalidScryptParams, "N=%d P=%d", params.N, params.P)
+	}
+	cltest.AssertCount(t, db, "encrypted_key_ring_backups", 0)
+
+	upgraded := utils.ScryptParams{N: 4, P: 1}
+	params, err := ks.RotatePassword(ctx, cltest.Password, newPassword, upgraded, false)
+	require.NoError(t, err)
+	assert.Equal(t, upgraded, params)
+	cltest.AssertCount(t, db, "encrypted_key_ring_backups", 1)
+
+	_, err = ks.RotatePassword(ctx, newPassword, cltest.Password, utils.FastScryptParams, false)
+	require.ErrorIs(t, err, keystore.ErrInvalidScryptParams)
+
+	reloaded := keystore.New(db, utils.FastScryptParams, logger.TestLogger(t))
+	require.Error(t, reloaded.Unlock(ctx, cltest.Password))
+	require.NoError(t, reloaded.Unlock(ctx, newPassword))
+	_, err = reloaded.Eth().Get(ctx, key.ID())
+	require.NoError(t, err)
+
+	// stored parameters stronger than the configured ones are kept on unlock
+	params, err = reloaded.RotatePassword(ctx, newPassword, cltest.Password, utils.ScryptParams{}, false)
+	require.NoError(t, err)
+	assert.Equal(t, upgraded, params)
+	cltest.AssertCount(t, db, "encrypted_key_ring_backups", 2)
+
+	_, err = reloaded.RotatePassword(ctx, "wrong password", newPassword, utils.ScryptParams{}, true)
+	require.ErrorIs(t, err, keystore.ErrPasswordMismatch)
+	cltest.AssertCount(t, db, "encrypted_key_ring_backups", 2)
+
+	_, err = reloaded.RotatePassword(ctx, cltest.Password, newPassword, utils.ScryptParams{}, true)
+	require.NoError(t, err)
+	cltest.AssertCount(t, db, "encrypted_key_ring_backups", 0)
+
+	reloaded = keystore.New(db, utils.FastScryptParams, logger.TestLogger(t))
+	require.NoError(t, reloaded.Unlock(ctx, newPassword))
+	_, err = reloaded.Eth().Get(ctx, key.ID())
+	require.NoError(t, err)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/external"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
 )
 
@@ -18,6 +19,10 @@ type VRF interface {
 	Delete(ctx context.Context, id string) (vrfkey.KeyV2, error)
 	Import(ctx context.Context, keyJSON []byte, password string) (vrfkey.KeyV2, error)
 	Export(id string, password string) ([]byte, error)
+	// Rotate creates a new key to take over from the key with the given ID. The old
+	// key is kept, so that it can keep answering requests until it is retired. The new
+	// key is only added to the keystore, it is not registered on any coordinator.
+	Rotate(ctx context.Context, id string) (vrfkey.KeyV2, error)
 
 	GenerateProof(id string, seed *big.Int) (vrfkey.Proof, error)
 }
@@ -98,6 +103,22 @@ func (ks *vrf) Delete(ctx context.Context, id string) (vrfkey.KeyV2, error) {
 	return key, err
 }
 
+func (ks *vrf) Rotate(ctx context.Context, id string) (vrfkey.KeyV2, error) {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+	if ks.isLocked() {
+		return vrfkey.KeyV2{}, ErrLocked
+	}
+	if _, err := ks.getByID(id); err != nil {
+		return vrfkey.KeyV2{}, err
+	}
+	key, err := vrfkey.NewV2()
+	if err != nil {
+		return vrfkey.KeyV2{}, err
+	}
+	return key, ks.safeAddKey(ctx, key)
+}
+
 func (ks *vrf) Import(ctx context.Context, keyJSON []byte, password string) (vrfkey.KeyV2, error) {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
@@ -134,6 +155,13 @@ func (ks *vrf) GenerateProof(id string, seed *big.Int) (vrfkey.Proof, error) {
 		return vrfkey.Proof{}, ErrLocked
 	}
 	key, err := ks.getByID(id)
+	if err != nil && ks.external != nil {
+		proof, perr := external.GenerateVRFProof(context.Background(), ks.external, id, seed)
+		if errors.Is(perr, external.ErrAccountNotFound) {
+			return vrfkey.Proof{}, err
+		}
+		return proof, perr
+	}
 	if err != nil {
 		return vrfkey.Proof{}, err
 	}
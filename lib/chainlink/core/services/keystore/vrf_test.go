@@ -122,6 +122,28 @@ func Test_VRFKeyStore_E2E(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("rotates a key", func(t *testing.T) {
+		defer reset()
+		ctx := testutils.Context(t)
+
+		oldKey, err := ks.Create(ctx)
+		require.NoError(t, err)
+
+		newKey, err := ks.Rotate(ctx, oldKey.ID())
+		require.NoError(t, err)
+		assert.NotEqual(t, oldKey.ID(), newKey.ID())
+
+		keys, err := ks.GetAll()
+		require.NoError(t, err)
+		assert.Len(t, keys, 2)
+
+		_, err = ks.Rotate(ctx, "non-existent")
+		assert.Error(t, err)
+		keys, err = ks.GetAll()
+		require.NoError(t, err)
+		assert.Len(t, keys, 2)
+	})
+
 	t.Run("imports a key exported from a v1 keystore", func(t *testing.T) {
 		defer reset()
 		ctx := testutils.Context(t)
@@ -333,3 +333,26 @@ func convertToETHABIInteger(val interface{}, abiType abi.Type) (interface{}, err
 
 	return converted.Interface(), nil
 }
+
+// decodeETHLog decodes the non-indexed args of a log from data, and the indexed args
+// from topics following the event signature, into out.
+func decodeETHLog(out map[string]interface{}, args abi.Arguments, data []byte, topics []common.Hash) error {
+	if len(data) > 0 {
+		if err := args.UnpackIntoMap(out, data); err != nil {
+			return err
+		}
+	}
+	var indexedArgs abi.Arguments
+	for _, arg := range args {
+		if arg.Indexed {
+			indexedArgs = append(indexedArgs, arg)
+		}
+	}
+	if len(indexedArgs) > 0 {
+		if len(topics) != len(indexedArgs)+1 {
+			return errors.New("topic/field count mismatch")
+		}
+		return abi.ParseTopicsIntoMap(out, indexedArgs, topics[1:])
+	}
+	return nil
+}
@@ -58,6 +58,10 @@ func (t *ETHCallTask) HelperSetDependencies(legacyChains legacyevm.LegacyChainCo
 	t.jobType = jobType
 }
 
+func (t *ETHGetLogsTask) HelperSetDependencies(legacyChains legacyevm.LegacyChainContainer) {
+	t.legacyChains = legacyChains
+}
+
 func (t *ETHTxTask) HelperSetDependencies(legacyChains legacyevm.LegacyChainContainer, keyStore ETHKeyStore, specGasLimit *uint32, jobType string) {
 	t.legacyChains = legacyChains
 	t.keyStore = keyStore
@@ -65,4 +69,14 @@ func (t *ETHTxTask) HelperSetDependencies(legacyChains legacyevm.LegacyChainCont
 	t.jobType = jobType
 }
 
+func (t *WaitTask) HelperSetDependencies(orm ORM, legacyChains legacyevm.LegacyChainContainer, id uuid.UUID) {
+	t.orm = orm
+	t.legacyChains = legacyChains
+	t.uuid = id
+}
+
+func (t *WaitTask) HelperSetInMemoryRun() {
+	t.inMemoryRun = true
+}
+
 func (o *orm) Prune(ctx context.Context, pipelineSpecID int32) { o.prune(ctx, o.ds, pipelineSpecID) }
@@ -0,0 +1,332 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LintSeverity is how severe a LintIssue is. Pipelines with errors are expected to fail at
+// runtime, while warnings point out configurations that are likely mistakes.
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// Lint rules
+const (
+	LintRuleUndefinedVariable = "undefined-variable"
+	LintRuleTypeMismatch      = "type-mismatch"
+	LintRuleUnusedOutput      = "unused-output"
+	LintRuleUnreachableTask   = "unreachable-task"
+	LintRuleMissingTimeout    = "missing-timeout"
+	LintRuleRiskyETHTxConfig  = "risky-ethtx-config"
+)
+
+// lintGlobalVariables are the variables set by jobs, rather than by tasks.
+var lintGlobalVariables = map[string]bool{"jobSpec": true, "jobRun": true}
+
+// LintIssue is a problem found by statically analyzing a pipeline.
+type LintIssue struct {
+	Severity LintSeverity `json:"severity"`
+	Rule     string       `json:"rule"`
+	DotID    string       `json:"dotID"`
+	Message  string       `json:"message"`
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", i.Severity, i.DotID, i.Message, i.Rule)
+}
+
+// LintIssues is a list of issues found in a pipeline.
+type LintIssues []LintIssue
+
+// HasErrors returns true if any of the issues is an error.
+func (issues LintIssues) HasErrors() bool {
+	for _, issue := range issues {
+		if issue.Severity == LintSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ValueKind is the kind of a task result, as far as it can be inferred statically.
+type ValueKind string
+
+const (
+	ValueKindAny     ValueKind = "any"
+	ValueKindString  ValueKind = "string"
+	ValueKindBytes   ValueKind = "bytes"
+	ValueKindNumber  ValueKind = "number"
+	ValueKindBool    ValueKind = "bool"
+	ValueKindMap     ValueKind = "map"
+	ValueKindSlice   ValueKind = "slice"
+	ValueKindUnknown ValueKind = ""
+)
+
+// taskOutputKinds are the kinds of the results of tasks, if known.
+var taskOutputKinds = map[TaskType]ValueKind{
+	TaskTypeBase64Decode:     ValueKindBytes,
+	TaskTypeBase64Encode:     ValueKindString,
+	TaskTypeBridge:           ValueKindString,
+	TaskTypeCBORParse:        ValueKindMap,
+	TaskTypeConditional:      ValueKindBool,
+	TaskTypeDivide:           ValueKindNumber,
+	TaskTypeETHABIDecode:     ValueKindMap,
+	TaskTypeETHABIDecodeLog:  ValueKindMap,
+	TaskTypeETHABIEncode:     ValueKindBytes,
+	TaskTypeETHABIEncode2:    ValueKindBytes,
+	TaskTypeETHCall:          ValueKindBytes,
+	TaskTypeETHGetLogs:       ValueKindSlice,
+	TaskTypeEstimateGasLimit: ValueKindNumber,
+	TaskTypeHTTP:             ValueKindString,
+	TaskTypeHexDecode:        ValueKindBytes,
+	TaskTypeHexEncode:        ValueKindString,
+	TaskTypeLength:           ValueKindNumber,
+	TaskTypeLessThan:         ValueKindBool,
+	TaskTypeLowercase:        ValueKindString,
+	TaskTypeMean:             ValueKindNumber,
+	TaskTypeMedian:           ValueKindNumber,
+	TaskTypeMerge:            ValueKindMap,
+	TaskTypeMultiply:         ValueKindNumber,
+	TaskTypeSum:              ValueKindNumber,
+	TaskTypeUppercase:        ValueKindString,
+	TaskTypeWait:             ValueKindNumber,
+}
+
+// taskInput describes how a task consumes the results of its inputs: they are used in place of
+// param, if it is not set, and must be of one of kinds.
+type taskInput struct {
+	param string
+	kinds []ValueKind
+}
+
+// taskInputs are the tasks that consume the results of their inputs. Tasks that are not listed
+// ignore them.
+var taskInputs = map[TaskType]taskInput{
+	TaskTypeAny:          {"", nil},
+	TaskTypeBase64Decode: {"input", []ValueKind{ValueKindString, ValueKindBytes}},
+	TaskTypeBase64Encode: {"input", []ValueKind{ValueKindString, ValueKindBytes}},
+	TaskTypeConditional:  {"data", []ValueKind{ValueKindBool, ValueKindString}},
+	TaskTypeDivide:       {"input", []ValueKind{ValueKindNumber, ValueKindString}},
+	TaskTypeETHABIDecode: {"data", []ValueKind{ValueKindBytes, ValueKindString}},
+	TaskTypeHexDecode:    {"input", []ValueKind{ValueKindString, ValueKindBytes}},
+	TaskTypeHexEncode:    {"input", []ValueKind{ValueKindString, ValueKindBytes, ValueKindNumber}},
+	TaskTypeJSONParse:    {"data", []ValueKind{ValueKindString, ValueKindBytes}},
+	TaskTypeLength:       {"input", []ValueKind{ValueKindString, ValueKindBytes}},
+	TaskTypeLessThan:     {"left", []ValueKind{ValueKindNumber, ValueKindString}},
+	TaskTypeLookup:       {"", []ValueKind{ValueKindMap}},
+	TaskTypeLowercase:    {"input", []ValueKind{ValueKindString, ValueKindBytes}},
+	TaskTypeMean:         {"values", []ValueKind{ValueKindNumber, ValueKindString}},
+	TaskTypeMedian:       {"values", []ValueKind{ValueKindNumber, ValueKindString}},
+	TaskTypeMemo:         {"value", nil},
+	TaskTypeMerge:        {"left", []ValueKind{ValueKindMap, ValueKindString}},
+	TaskTypeMode:         {"values", nil},
+	TaskTypeMultiply:     {"input", []ValueKind{ValueKindNumber, ValueKindString}},
+	TaskTypeSum:          {"values", []ValueKind{ValueKindNumber, ValueKindString}},
+	TaskTypeUppercase:    {"input", []ValueKind{ValueKindString, ValueKindBytes}},
+	TaskTypeVRF:          {"", []ValueKind{ValueKindMap}},
+	TaskTypeVRFV2:        {"", []ValueKind{ValueKindMap}},
+	TaskTypeVRFV2Plus:    {"", []ValueKind{ValueKindMap}},
+}
+
+// taskParamKinds are the kinds that params of tasks must be of, when they are set to the result
+// of another task.
+var taskParamKinds = map[TaskType]map[string][]ValueKind{
+	TaskTypeETHABIDecode:    {"data": {ValueKindBytes, ValueKindString}},
+	TaskTypeETHABIDecodeLog: {"data": {ValueKindBytes, ValueKindString}, "topics": {ValueKindSlice}},
+	TaskTypeETHABIEncode:    {"data": {ValueKindMap}},
+	TaskTypeETHABIEncode2:   {"data": {ValueKindMap}},
+	TaskTypeETHCall:         {"data": {ValueKindBytes, ValueKindString}},
+	TaskTypeETHTx:           {"data": {ValueKindBytes, ValueKindString}},
+}
+
+// OutputKind returns the kind of the results of a task, as far as it can be inferred statically.
+func OutputKind(task Task) ValueKind {
+	switch task.Type() {
+	case TaskTypeJSONParse, TaskTypeMemo, TaskTypeLookup, TaskTypeAny:
+		// The result depends on the data
+		return ValueKindAny
+	}
+	if kind, ok := taskOutputKinds[task.Type()]; ok {
+		return kind
+	}
+	return ValueKindUnknown
+}
+
+// Lint statically analyzes the pipeline for problems that would otherwise only show up at
+// runtime: references to undefined variables, results of the wrong kind being passed to
+// tasks, results that are never used, tasks that can never run, http and bridge tasks without
+// a timeout, and ethtx tasks configured in a risky way. Tasks that can never run are only
+// detected below conditional tasks with a literal data="false".
+func (p *Pipeline) Lint() LintIssues {
+	attrs := p.taskAttributes()
+	refs := make(map[string]bool)
+
+	var issues LintIssues
+	add := func(severity LintSeverity, rule string, task Task, format string, args ...interface{}) {
+		issues = append(issues, LintIssue{Severity: severity, Rule: rule, DotID: task.DotID(), Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, task := range p.Tasks {
+		taskAttrs := attrs[task.DotID()]
+
+		// Undefined variables
+		keys := make([]string, 0, len(taskAttrs))
+		for key := range taskAttrs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			for _, match := range variableRegexp.FindAllStringSubmatch(taskAttrs[key], -1) {
+				root := strings.Split(match[1], KeypathSeparator)[0]
+				refs[root] = true
+				if lintGlobalVariables[root] {
+					continue
+				}
+				dep := p.ByDotID(root)
+				if dep == nil {
+					add(LintSeverityError, LintRuleUndefinedVariable, task, "%s references undefined variable $(%s)", key, match[1])
+					continue
+				} else if root == task.DotID() {
+					add(LintSeverityError, LintRuleUndefinedVariable, task, "%s references the result of the task itself", key)
+					continue
+				}
+				// Params set to the whole result of another task
+				kinds := taskParamKinds[task.Type()][key]
+				if kinds == nil || match[1] != root || strings.TrimSpace(taskAttrs[key]) != match[0] {
+					continue
+				}
+				if kind := OutputKind(dep); kind != ValueKindUnknown && kind != ValueKindAny && !containsKind(kinds, kind) {
+					add(LintSeverityError, LintRuleTypeMismatch, task, "%s expects %s, but %s (%s) returns %s",
+						key, joinKinds(kinds), dep.DotID(), dep.Type(), kind)
+				}
+			}
+		}
+
+		// Kinds of inputs
+		if in, consumes := taskInputs[task.Type()]; consumes && in.kinds != nil && (in.param == "" || taskAttrs[in.param] == "") {
+			for _, dep := range task.Inputs() {
+				if !dep.PropagateResult {
+					continue
+				}
+				kind := OutputKind(dep.InputTask)
+				if kind == ValueKindUnknown || kind == ValueKindAny || containsKind(in.kinds, kind) {
+					continue
+				}
+				add(LintSeverityError, LintRuleTypeMismatch, task, "%s task expects %s input, but %s (%s) returns %s",
+					task.Type(), joinKinds(in.kinds), dep.InputTask.DotID(), dep.InputTask.Type(), kind)
+			}
+		}
+
+		// Timeouts
+		if task.Type() == TaskTypeHTTP || task.Type() == TaskTypeBridge {
+			if _, set := task.TaskTimeout(); !set {
+				add(LintSeverityWarning, LintRuleMissingTimeout, task, "%s task has no timeout, and is only bounded by the job's maxTaskDuration", task.Type())
+			}
+		}
+
+		// Risky ethtx configs
+		if tx, ok := task.(*ETHTxTask); ok {
+			if tx.MinConfirmations == "" || tx.MinConfirmations == "0" {
+				add(LintSeverityWarning, LintRuleRiskyETHTxConfig, task, "minConfirmations is 0, so the run completes before the transaction is confirmed, and its result may be lost in a reorg")
+			}
+			if tx.FailOnRevert != "true" {
+				add(LintSeverityWarning, LintRuleRiskyETHTxConfig, task, "failOnRevert is not set, so reverted transactions do not fail the run")
+			}
+			if tx.From == "" {
+				add(LintSeverityWarning, LintRuleRiskyETHTxConfig, task, "from is not set, so the transaction may be sent from any enabled key")
+			}
+		}
+	}
+
+	// Unused results: only inputs of tasks that consume them, and results referenced as
+	// variables are used. Tasks without outputs are the results of the run.
+	for _, task := range p.Tasks {
+		if len(task.Outputs()) == 0 || refs[task.DotID()] {
+			continue
+		}
+		used := false
+		for _, output := range task.Outputs() {
+			in, consumes := taskInputs[output.Type()]
+			if consumes && (in.param == "" || attrs[output.DotID()][in.param] == "") {
+				used = true
+				break
+			}
+		}
+		if !used {
+			add(LintSeverityWarning, LintRuleUnusedOutput, task, "result is not used by any task")
+		}
+	}
+
+	// Unreachable tasks. Only conditions given as a literal data="false" are recognized, any
+	// other condition, including variables that always resolve to false, is assumed reachable.
+	for _, task := range p.Tasks {
+		if task.Type() != TaskTypeConditional {
+			continue
+		}
+		data := strings.TrimSpace(attrs[task.DotID()]["data"])
+		if !strings.EqualFold(data, "false") {
+			continue
+		}
+		add(LintSeverityError, LintRuleUnreachableTask, task, "condition is always false")
+		for _, descendant := range descendants(task) {
+			add(LintSeverityError, LintRuleUnreachableTask, descendant, "task can never run, because %s is always false", task.DotID())
+		}
+	}
+
+	return issues
+}
+
+// taskAttributes returns the DOT attributes of all tasks by DOT ID.
+func (p *Pipeline) taskAttributes() map[string]map[string]string {
+	attrs := make(map[string]map[string]string)
+	if p.tree == nil {
+		return attrs
+	}
+	for nodes := p.tree.Nodes(); nodes.Next(); {
+		node := nodes.Node().(*GraphNode)
+		attrs[node.dotID] = node.attrs
+	}
+	return attrs
+}
+
+// descendants returns all tasks that depend on the task, directly or indirectly, in order.
+func descendants(task Task) []Task {
+	seen := make(map[int]bool)
+	var out []Task
+	var walk func(Task)
+	walk = func(t Task) {
+		for _, output := range t.Outputs() {
+			if seen[output.ID()] {
+				continue
+			}
+			seen[output.ID()] = true
+			out = append(out, output)
+			walk(output)
+		}
+	}
+	walk(task)
+	sort.Slice(out, func(i, j int) bool { return out[i].ID() < out[j].ID() })
+	return out
+}
+
+func containsKind(kinds []ValueKind, kind ValueKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func joinKinds(kinds []ValueKind) string {
+	s := make([]string, len(kinds))
+	for i, k := range kinds {
+		s[i] = string(k)
+	}
+	return strings.Join(s, " or ")
+}
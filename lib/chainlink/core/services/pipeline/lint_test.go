@@ -0,0 +1,124 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestPipeline_Lint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		spec      string
+		expected  pipeline.LintIssues
+		hasErrors bool
+	}{
+		{
+			"valid",
+			`
+			ds    [type=http method=GET url="https://chain.link/eth_usd" timeout="10s"];
+			parse [type=jsonparse path="data,price"];
+			mult  [type=multiply times="$(jobSpec.times)"];
+			ds -> parse -> mult;
+			`,
+			nil,
+			false,
+		},
+		{
+			"undefined variable",
+			`
+			ds    [type=http method=GET url="https://chain.link/eth_usd" timeout="10s"];
+			parse [type=jsonparse path="data,price" data="$(ds_typo)"];
+			ds -> parse;
+			`,
+			pipeline.LintIssues{
+				{Severity: pipeline.LintSeverityError, Rule: pipeline.LintRuleUndefinedVariable, DotID: "parse", Message: "data references undefined variable $(ds_typo)"},
+				{Severity: pipeline.LintSeverityWarning, Rule: pipeline.LintRuleUnusedOutput, DotID: "ds", Message: "result is not used by any task"},
+			},
+			true,
+		},
+		{
+			"type mismatch",
+			`
+			a   [type=lessthan left=1 right=2];
+			enc [type=hexdecode];
+			a -> enc;
+			`,
+			pipeline.LintIssues{
+				{Severity: pipeline.LintSeverityError, Rule: pipeline.LintRuleTypeMismatch, DotID: "enc", Message: "hexdecode task expects string or bytes input, but a (lessthan) returns bool"},
+			},
+			true,
+		},
+		{
+			"param type mismatch",
+			`
+			ds  [type=http method=GET url="https://chain.link/eth_usd" timeout="10s"];
+			enc [type=ethabiencode abi="f(uint256 x)" data="$(ds)"];
+			ds -> enc;
+			`,
+			pipeline.LintIssues{
+				{Severity: pipeline.LintSeverityError, Rule: pipeline.LintRuleTypeMismatch, DotID: "enc", Message: "data expects map, but ds (http) returns string"},
+			},
+			true,
+		},
+		{
+			"missing timeout",
+			`ds [type=bridge name=voter_turnout];`,
+			pipeline.LintIssues{
+				{Severity: pipeline.LintSeverityWarning, Rule: pipeline.LintRuleMissingTimeout, DotID: "ds", Message: "bridge task has no timeout, and is only bounded by the job's maxTaskDuration"},
+			},
+			false,
+		},
+		{
+			"unreachable",
+			`
+			cond [type=conditional data="false"];
+			any  [type=any];
+			cond -> any;
+			`,
+			pipeline.LintIssues{
+				{Severity: pipeline.LintSeverityError, Rule: pipeline.LintRuleUnreachableTask, DotID: "cond", Message: "condition is always false"},
+				{Severity: pipeline.LintSeverityError, Rule: pipeline.LintRuleUnreachableTask, DotID: "any", Message: "task can never run, because cond is always false"},
+			},
+			true,
+		},
+		{
+			"risky ethtx",
+			`tx [type=ethtx to="0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF" data="0x"];`,
+			pipeline.LintIssues{
+				{Severity: pipeline.LintSeverityWarning, Rule: pipeline.LintRuleRiskyETHTxConfig, DotID: "tx", Message: "minConfirmations is 0, so the run completes before the transaction is confirmed, and its result may be lost in a reorg"},
+				{Severity: pipeline.LintSeverityWarning, Rule: pipeline.LintRuleRiskyETHTxConfig, DotID: "tx", Message: "failOnRevert is not set, so reverted transactions do not fail the run"},
+				{Severity: pipeline.LintSeverityWarning, Rule: pipeline.LintRuleRiskyETHTxConfig, DotID: "tx", Message: "from is not set, so the transaction may be sent from any enabled key"},
+			},
+			false,
+		},
+		{
+			"condition from a variable",
+			`
+			cond [type=conditional data="$(jobRun.meta.enabled)"];
+			any  [type=any];
+			cond -> any;
+			`,
+			nil,
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := pipeline.Parse(test.spec)
+			require.NoError(t, err)
+			issues := p.Lint()
+			assert.Equal(t, test.expected, issues)
+			assert.Equal(t, test.hasErrors, issues.HasErrors())
+		})
+	}
+}
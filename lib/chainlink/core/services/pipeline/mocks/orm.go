@@ -8,6 +8,8 @@ import (
 	models "github.com/smartcontractkit/chainlink/v2/core/store/models"
 	mock "github.com/stretchr/testify/mock"
 
+	null "gopkg.in/guregu/null.v4"
+
 	pipeline "github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 
 	sqlutil "github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
@@ -30,6 +32,53 @@ func (_m *ORM) EXPECT() *ORM_Expecter {
 	return &ORM_Expecter{mock: &_m.Mock}
 }
 
+// CheckpointTaskRun provides a mock function with given fields: ctx, taskRun
+func (_m *ORM) CheckpointTaskRun(ctx context.Context, taskRun pipeline.TaskRun) error {
+	ret := _m.Called(ctx, taskRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckpointTaskRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.TaskRun) error); ok {
+		r0 = rf(ctx, taskRun)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ORM_CheckpointTaskRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckpointTaskRun'
+type ORM_CheckpointTaskRun_Call struct {
+	*mock.Call
+}
+
+// CheckpointTaskRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskRun pipeline.TaskRun
+func (_e *ORM_Expecter) CheckpointTaskRun(ctx interface{}, taskRun interface{}) *ORM_CheckpointTaskRun_Call {
+	return &ORM_CheckpointTaskRun_Call{Call: _e.mock.On("CheckpointTaskRun", ctx, taskRun)}
+}
+
+func (_c *ORM_CheckpointTaskRun_Call) Run(run func(ctx context.Context, taskRun pipeline.TaskRun)) *ORM_CheckpointTaskRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pipeline.TaskRun))
+	})
+	return _c
+}
+
+func (_c *ORM_CheckpointTaskRun_Call) Return(_a0 error) *ORM_CheckpointTaskRun_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ORM_CheckpointTaskRun_Call) RunAndReturn(run func(context.Context, pipeline.TaskRun) error) *ORM_CheckpointTaskRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Close provides a mock function with no fields
 func (_m *ORM) Close() error {
 	ret := _m.Called()
@@ -180,6 +229,63 @@ func (_c *ORM_CreateSpec_Call) RunAndReturn(run func(context.Context, pipeline.P
 	return _c
 }
 
+// CreateTaskRunWait provides a mock function with given fields: ctx, wait
+func (_m *ORM) CreateTaskRunWait(ctx context.Context, wait pipeline.TaskRunWait) (pipeline.TaskRunWait, error) {
+	ret := _m.Called(ctx, wait)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTaskRunWait")
+	}
+
+	var r0 pipeline.TaskRunWait
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.TaskRunWait) (pipeline.TaskRunWait, error)); ok {
+		return rf(ctx, wait)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.TaskRunWait) pipeline.TaskRunWait); ok {
+		r0 = rf(ctx, wait)
+	} else {
+		r0 = ret.Get(0).(pipeline.TaskRunWait)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pipeline.TaskRunWait) error); ok {
+		r1 = rf(ctx, wait)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_CreateTaskRunWait_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTaskRunWait'
+type ORM_CreateTaskRunWait_Call struct {
+	*mock.Call
+}
+
+// CreateTaskRunWait is a helper method to define mock.On call
+//   - ctx context.Context
+//   - wait pipeline.TaskRunWait
+func (_e *ORM_Expecter) CreateTaskRunWait(ctx interface{}, wait interface{}) *ORM_CreateTaskRunWait_Call {
+	return &ORM_CreateTaskRunWait_Call{Call: _e.mock.On("CreateTaskRunWait", ctx, wait)}
+}
+
+func (_c *ORM_CreateTaskRunWait_Call) Run(run func(ctx context.Context, wait pipeline.TaskRunWait)) *ORM_CreateTaskRunWait_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pipeline.TaskRunWait))
+	})
+	return _c
+}
+
+func (_c *ORM_CreateTaskRunWait_Call) Return(_a0 pipeline.TaskRunWait, _a1 error) *ORM_CreateTaskRunWait_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_CreateTaskRunWait_Call) RunAndReturn(run func(context.Context, pipeline.TaskRunWait) (pipeline.TaskRunWait, error)) *ORM_CreateTaskRunWait_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DataSource provides a mock function with no fields
 func (_m *ORM) DataSource() sqlutil.DataSource {
 	ret := _m.Called()
@@ -321,6 +427,53 @@ func (_c *ORM_DeleteRunsOlderThan_Call) RunAndReturn(run func(context.Context, t
 	return _c
 }
 
+// DeleteTaskRunWait provides a mock function with given fields: ctx, taskID
+func (_m *ORM) DeleteTaskRunWait(ctx context.Context, taskID uuid.UUID) error {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTaskRunWait")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ORM_DeleteTaskRunWait_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTaskRunWait'
+type ORM_DeleteTaskRunWait_Call struct {
+	*mock.Call
+}
+
+// DeleteTaskRunWait is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID uuid.UUID
+func (_e *ORM_Expecter) DeleteTaskRunWait(ctx interface{}, taskID interface{}) *ORM_DeleteTaskRunWait_Call {
+	return &ORM_DeleteTaskRunWait_Call{Call: _e.mock.On("DeleteTaskRunWait", ctx, taskID)}
+}
+
+func (_c *ORM_DeleteTaskRunWait_Call) Run(run func(ctx context.Context, taskID uuid.UUID)) *ORM_DeleteTaskRunWait_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ORM_DeleteTaskRunWait_Call) Return(_a0 error) *ORM_DeleteTaskRunWait_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ORM_DeleteTaskRunWait_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *ORM_DeleteTaskRunWait_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindRun provides a mock function with given fields: ctx, id
 func (_m *ORM) FindRun(ctx context.Context, id int64) (pipeline.Run, error) {
 	ret := _m.Called(ctx, id)
@@ -378,6 +531,63 @@ func (_c *ORM_FindRun_Call) RunAndReturn(run func(context.Context, int64) (pipel
 	return _c
 }
 
+// FindTaskRunBridgeName provides a mock function with given fields: ctx, taskID
+func (_m *ORM) FindTaskRunBridgeName(ctx context.Context, taskID uuid.UUID) (string, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTaskRunBridgeName")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_FindTaskRunBridgeName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTaskRunBridgeName'
+type ORM_FindTaskRunBridgeName_Call struct {
+	*mock.Call
+}
+
+// FindTaskRunBridgeName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID uuid.UUID
+func (_e *ORM_Expecter) FindTaskRunBridgeName(ctx interface{}, taskID interface{}) *ORM_FindTaskRunBridgeName_Call {
+	return &ORM_FindTaskRunBridgeName_Call{Call: _e.mock.On("FindTaskRunBridgeName", ctx, taskID)}
+}
+
+func (_c *ORM_FindTaskRunBridgeName_Call) Run(run func(ctx context.Context, taskID uuid.UUID)) *ORM_FindTaskRunBridgeName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *ORM_FindTaskRunBridgeName_Call) Return(_a0 string, _a1 error) *ORM_FindTaskRunBridgeName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_FindTaskRunBridgeName_Call) RunAndReturn(run func(context.Context, uuid.UUID) (string, error)) *ORM_FindTaskRunBridgeName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAllRuns provides a mock function with given fields: ctx
 func (_m *ORM) GetAllRuns(ctx context.Context) ([]pipeline.Run, error) {
 	ret := _m.Called(ctx)
@@ -436,6 +646,65 @@ func (_c *ORM_GetAllRuns_Call) RunAndReturn(run func(context.Context) ([]pipelin
 	return _c
 }
 
+// GetTaskRunWaits provides a mock function with given fields: ctx, now
+func (_m *ORM) GetTaskRunWaits(ctx context.Context, now time.Time) ([]pipeline.TaskRunWait, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskRunWaits")
+	}
+
+	var r0 []pipeline.TaskRunWait
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]pipeline.TaskRunWait, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []pipeline.TaskRunWait); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pipeline.TaskRunWait)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_GetTaskRunWaits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskRunWaits'
+type ORM_GetTaskRunWaits_Call struct {
+	*mock.Call
+}
+
+// GetTaskRunWaits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *ORM_Expecter) GetTaskRunWaits(ctx interface{}, now interface{}) *ORM_GetTaskRunWaits_Call {
+	return &ORM_GetTaskRunWaits_Call{Call: _e.mock.On("GetTaskRunWaits", ctx, now)}
+}
+
+func (_c *ORM_GetTaskRunWaits_Call) Run(run func(ctx context.Context, now time.Time)) *ORM_GetTaskRunWaits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *ORM_GetTaskRunWaits_Call) Return(_a0 []pipeline.TaskRunWait, _a1 error) *ORM_GetTaskRunWaits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_GetTaskRunWaits_Call) RunAndReturn(run func(context.Context, time.Time) ([]pipeline.TaskRunWait, error)) *ORM_GetTaskRunWaits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUnfinishedRuns provides a mock function with given fields: _a0, _a1, _a2
 func (_m *ORM) GetUnfinishedRuns(_a0 context.Context, _a1 time.Time, _a2 func(pipeline.Run) error) error {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -767,6 +1036,62 @@ func (_c *ORM_Name_Call) RunAndReturn(run func() string) *ORM_Name_Call {
 	return _c
 }
 
+// NextTaskRunWaitUntil provides a mock function with given fields: ctx
+func (_m *ORM) NextTaskRunWaitUntil(ctx context.Context) (null.Time, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NextTaskRunWaitUntil")
+	}
+
+	var r0 null.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (null.Time, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) null.Time); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(null.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_NextTaskRunWaitUntil_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NextTaskRunWaitUntil'
+type ORM_NextTaskRunWaitUntil_Call struct {
+	*mock.Call
+}
+
+// NextTaskRunWaitUntil is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *ORM_Expecter) NextTaskRunWaitUntil(ctx interface{}) *ORM_NextTaskRunWaitUntil_Call {
+	return &ORM_NextTaskRunWaitUntil_Call{Call: _e.mock.On("NextTaskRunWaitUntil", ctx)}
+}
+
+func (_c *ORM_NextTaskRunWaitUntil_Call) Run(run func(ctx context.Context)) *ORM_NextTaskRunWaitUntil_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ORM_NextTaskRunWaitUntil_Call) Return(_a0 null.Time, _a1 error) *ORM_NextTaskRunWaitUntil_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_NextTaskRunWaitUntil_Call) RunAndReturn(run func(context.Context) (null.Time, error)) *ORM_NextTaskRunWaitUntil_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Ready provides a mock function with no fields
 func (_m *ORM) Ready() error {
 	ret := _m.Called()
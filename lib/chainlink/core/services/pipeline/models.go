@@ -26,6 +26,9 @@ type Spec struct {
 	MaxTaskDuration   models.Interval `json:"-"`
 	GasLimit          *uint32         `json:"-"`
 	ForwardingAllowed bool            `json:"-"`
+	// CheckpointTasks persists the result of every task as soon as it completes, so
+	// that runs interrupted by a node restart resume from the last completed task.
+	CheckpointTasks bool `json:"-"`
 
 	JobID   int32  `json:"-"`
 	JobName string `json:"-"`
@@ -311,6 +314,16 @@ func (tr *TaskRun) IsPending() bool {
 	return !tr.FinishedAt.Valid && tr.Output.Empty() && tr.Error.IsZero()
 }
 
+// TaskRunWait is what a suspended wait task run is waiting for: either a point in time, or
+// a block number on an EVM chain.
+type TaskRunWait struct {
+	PipelineTaskRunID uuid.UUID   `db:"pipeline_task_run_id"`
+	Until             null.Time   `db:"until"`
+	BlockNumber       null.Int    `db:"block_number"`
+	EVMChainID        null.String `db:"evm_chain_id"`
+	CreatedAt         time.Time   `db:"created_at"`
+}
+
 // RunStatus represents the status of a run
 type RunStatus string
 
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
@@ -82,7 +83,13 @@ type ORM interface {
 	InsertRun(ctx context.Context, run *Run) error
 	DeleteRun(ctx context.Context, id int64) error
 	StoreRun(ctx context.Context, run *Run) (restart bool, err error)
+	CheckpointTaskRun(ctx context.Context, taskRun TaskRun) error
 	UpdateTaskRunResult(ctx context.Context, taskID uuid.UUID, result Result) (run Run, start bool, err error)
+	FindTaskRunBridgeName(ctx context.Context, taskID uuid.UUID) (string, error)
+	CreateTaskRunWait(ctx context.Context, wait TaskRunWait) (TaskRunWait, error)
+	DeleteTaskRunWait(ctx context.Context, taskID uuid.UUID) error
+	GetTaskRunWaits(ctx context.Context, now time.Time) ([]TaskRunWait, error)
+	NextTaskRunWaitUntil(ctx context.Context) (null.Time, error)
 	InsertFinishedRun(ctx context.Context, run *Run, saveSuccessfulTaskRuns bool) (err error)
 	InsertFinishedRunWithSpec(ctx context.Context, run *Run, saveSuccessfulTaskRuns bool) (err error)
 
@@ -314,6 +321,20 @@ func (o *orm) StoreRun(ctx context.Context, run *Run) (restart bool, err error)
 	return
 }
 
+// CheckpointTaskRun persists the result of a single task of a run that is still in progress, so that
+// the run can resume from it instead of executing the task again, e.g. after a node restart.
+func (o *orm) CheckpointTaskRun(ctx context.Context, taskRun TaskRun) error {
+	sql := `
+	INSERT INTO pipeline_task_runs (pipeline_run_id, id, type, index, output, error, dot_id, created_at, finished_at)
+	VALUES (:pipeline_run_id, :id, :type, :index, :output, :error, :dot_id, :created_at, :finished_at)
+	ON CONFLICT (pipeline_run_id, dot_id) DO UPDATE SET
+	output = EXCLUDED.output, error = EXCLUDED.error, finished_at = EXCLUDED.finished_at`
+	if _, err := o.ds.NamedExecContext(ctx, sql, taskRun); err != nil {
+		return fmt.Errorf("failed to checkpoint pipeline task run %s of run %d: %w", taskRun.DotID, taskRun.PipelineRunID, err)
+	}
+	return nil
+}
+
 // DeleteRun cleans up a run that failed and is marked failEarly (should leave no trace of the run)
 func (o *orm) DeleteRun(ctx context.Context, id int64) error {
 	// NOTE: this will cascade and wipe pipeline_task_runs too
@@ -360,6 +381,88 @@ func (o *orm) UpdateTaskRunResult(ctx context.Context, taskID uuid.UUID, result
 	return run, start, err
 }
 
+// FindTaskRunBridgeName returns the name of the bridge called by a bridge task run. It
+// returns sql.ErrNoRows if there is no bridge task run with the ID.
+func (o *orm) FindTaskRunBridgeName(ctx context.Context, taskID uuid.UUID) (string, error) {
+	var taskRun struct {
+		DotID        string `db:"dot_id"`
+		DotDagSource string `db:"dot_dag_source"`
+	}
+	sql := `SELECT pipeline_task_runs.dot_id, pipeline_specs.dot_dag_source
+	FROM pipeline_task_runs
+	JOIN pipeline_runs ON (pipeline_runs.id = pipeline_task_runs.pipeline_run_id)
+	JOIN pipeline_specs ON (pipeline_specs.id = pipeline_runs.pipeline_spec_id)
+	WHERE pipeline_task_runs.id = $1 AND pipeline_task_runs.type = $2`
+	if err := o.ds.GetContext(ctx, &taskRun, sql, taskID, TaskTypeBridge); err != nil {
+		return "", fmt.Errorf("failed to find bridge task run %s: %w", taskID, err)
+	}
+
+	p, err := Parse(taskRun.DotDagSource)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse pipeline of task run %s: %w", taskID, err)
+	}
+	for _, task := range p.Tasks {
+		if bridgeTask, ok := task.(*BridgeTask); ok && task.DotID() == taskRun.DotID {
+			return bridgeTask.Name, nil
+		}
+	}
+	return "", fmt.Errorf("bridge task %s not found in pipeline of task run %s", taskRun.DotID, taskID)
+}
+
+// CreateTaskRunWait persists what a wait task run is waiting for. If the task run is already
+// waiting, e.g. because its run was resumed after a node restart, the wait persisted first is
+// kept and returned, so that relative waits are not extended.
+func (o *orm) CreateTaskRunWait(ctx context.Context, wait TaskRunWait) (TaskRunWait, error) {
+	sql := `INSERT INTO pipeline_task_run_waits (pipeline_task_run_id, until, block_number, evm_chain_id, created_at)
+	VALUES (:pipeline_task_run_id, :until, :block_number, :evm_chain_id, NOW())
+	ON CONFLICT (pipeline_task_run_id) DO UPDATE SET pipeline_task_run_id = pipeline_task_run_waits.pipeline_task_run_id
+	RETURNING *;`
+	query, args, err := o.ds.BindNamed(sql, wait)
+	if err != nil {
+		return TaskRunWait{}, fmt.Errorf("failed to prepare named query: %w", err)
+	}
+	var created TaskRunWait
+	if err = o.ds.GetContext(ctx, &created, query, args...); err != nil {
+		return TaskRunWait{}, fmt.Errorf("failed to create wait for pipeline task run %s: %w", wait.PipelineTaskRunID, err)
+	}
+	return created, nil
+}
+
+func (o *orm) DeleteTaskRunWait(ctx context.Context, taskID uuid.UUID) error {
+	if _, err := o.ds.ExecContext(ctx, `DELETE FROM pipeline_task_run_waits WHERE pipeline_task_run_id = $1`, taskID); err != nil {
+		return fmt.Errorf("failed to delete wait for pipeline task run %s: %w", taskID, err)
+	}
+	return nil
+}
+
+// GetTaskRunWaits returns the waits of pending task runs that are due by now, as well as all
+// waits for a block number, which are due depending on the chain.
+func (o *orm) GetTaskRunWaits(ctx context.Context, now time.Time) (waits []TaskRunWait, err error) {
+	sql := `SELECT pipeline_task_run_waits.* FROM pipeline_task_run_waits
+	JOIN pipeline_task_runs ON (pipeline_task_runs.id = pipeline_task_run_waits.pipeline_task_run_id)
+	JOIN pipeline_runs ON (pipeline_runs.id = pipeline_task_runs.pipeline_run_id)
+	WHERE pipeline_task_runs.finished_at IS NULL AND pipeline_runs.state IN ('running', 'suspended')
+	AND (pipeline_task_run_waits.until <= $1 OR pipeline_task_run_waits.block_number IS NOT NULL)
+	ORDER BY pipeline_task_run_waits.created_at ASC`
+	if err = o.ds.SelectContext(ctx, &waits, sql, now); err != nil {
+		return nil, fmt.Errorf("failed to load pipeline task run waits: %w", err)
+	}
+	return waits, nil
+}
+
+// NextTaskRunWaitUntil returns the earliest point in time a pending task run is waiting
+// until, if any.
+func (o *orm) NextTaskRunWaitUntil(ctx context.Context) (next null.Time, err error) {
+	sql := `SELECT MIN(pipeline_task_run_waits.until) FROM pipeline_task_run_waits
+	JOIN pipeline_task_runs ON (pipeline_task_runs.id = pipeline_task_run_waits.pipeline_task_run_id)
+	JOIN pipeline_runs ON (pipeline_runs.id = pipeline_task_runs.pipeline_run_id)
+	WHERE pipeline_task_runs.finished_at IS NULL AND pipeline_runs.state IN ('running', 'suspended')`
+	if err = o.ds.GetContext(ctx, &next, sql); err != nil {
+		return null.Time{}, fmt.Errorf("failed to load next pipeline task run wait: %w", err)
+	}
+	return next, nil
+}
+
 // InsertFinishedRuns inserts all the given runs into the database.
 func (o *orm) InsertFinishedRuns(ctx context.Context, runs []*Run, saveSuccessfulTaskRuns bool) error {
 	err := o.transact(ctx, func(tx *orm) error {
@@ -647,7 +750,8 @@ func loadAssociations(ctx context.Context, ds sqlutil.DataSource, runs []*Run) e
 			ps.max_task_duration,
 			coalesce(jobs.id, 0) "job_id",
 			coalesce(jobs.name, '') "job_name",
-			coalesce(jobs.type, '') "job_type"
+			coalesce(jobs.type, '') "job_type",
+			coalesce(jobs.checkpoint_tasks, false) "checkpoint_tasks"
 		FROM pipeline_specs ps
 		LEFT JOIN job_pipeline_specs jps ON jps.pipeline_spec_id=ps.id
 		LEFT JOIN jobs ON jobs.id=jps.job_id
@@ -555,6 +555,64 @@ func Test_PipelineORM_StoreRun_UpdateTaskRunResult(t *testing.T) {
 	require.Equal(t, jsonserializable.JSONSerializable{Val: cborOutput, Valid: true}, task2.Output)
 }
 
+func Test_PipelineORM_TaskRunWaits(t *testing.T) {
+	ctx := testutils.Context(t)
+	_, orm, jorm := setupLiteORM(t)
+
+	run := mustInsertAsyncRun(t, orm, jorm)
+	now := time.Now()
+	timeTask := uuid.New()
+	blockTask := uuid.New()
+	run.PipelineTaskRuns = []pipeline.TaskRun{
+		{ID: timeTask, PipelineRunID: run.ID, Type: "wait", DotID: "ds1", CreatedAt: now},
+		{ID: blockTask, PipelineRunID: run.ID, Type: "wait", DotID: "answer2", CreatedAt: now},
+	}
+	_, err := orm.StoreRun(ctx, run)
+	require.NoError(t, err)
+
+	until := now.Add(time.Hour).Truncate(time.Second)
+	wait, err := orm.CreateTaskRunWait(ctx, pipeline.TaskRunWait{PipelineTaskRunID: timeTask, Until: null.TimeFrom(until)})
+	require.NoError(t, err)
+	assert.True(t, until.Equal(wait.Until.Time))
+	// the wait persisted first is kept
+	wait, err = orm.CreateTaskRunWait(ctx, pipeline.TaskRunWait{PipelineTaskRunID: timeTask, Until: null.TimeFrom(until.Add(time.Hour))})
+	require.NoError(t, err)
+	assert.True(t, until.Equal(wait.Until.Time))
+
+	_, err = orm.CreateTaskRunWait(ctx, pipeline.TaskRunWait{PipelineTaskRunID: blockTask, BlockNumber: null.IntFrom(100), EVMChainID: null.StringFrom("0")})
+	require.NoError(t, err)
+
+	// block waits are always returned, time waits once due
+	waits, err := orm.GetTaskRunWaits(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, waits, 1)
+	assert.Equal(t, blockTask, waits[0].PipelineTaskRunID)
+	waits, err = orm.GetTaskRunWaits(ctx, until)
+	require.NoError(t, err)
+	require.Len(t, waits, 2)
+
+	next, err := orm.NextTaskRunWaitUntil(ctx)
+	require.NoError(t, err)
+	require.True(t, next.Valid)
+	assert.True(t, until.Equal(next.Time))
+
+	// waits of finished task runs are not returned
+	_, _, err = orm.UpdateTaskRunResult(ctx, blockTask, pipeline.Result{Value: 100})
+	require.NoError(t, err)
+	waits, err = orm.GetTaskRunWaits(ctx, until)
+	require.NoError(t, err)
+	require.Len(t, waits, 1)
+	assert.Equal(t, timeTask, waits[0].PipelineTaskRunID)
+
+	require.NoError(t, orm.DeleteTaskRunWait(ctx, timeTask))
+	waits, err = orm.GetTaskRunWaits(ctx, until)
+	require.NoError(t, err)
+	require.Empty(t, waits)
+	next, err = orm.NextTaskRunWaitUntil(ctx)
+	require.NoError(t, err)
+	assert.False(t, next.Valid)
+}
+
 func Test_PipelineORM_DeleteRun(t *testing.T) {
 	ctx := testutils.Context(t)
 	_, orm, jorm := setupLiteORM(t)
@@ -3,6 +3,7 @@ package pipeline
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -35,7 +36,7 @@ type Runner interface {
 
 	// Run is a blocking call that will execute the run until no further progress can be made.
 	// If `incomplete` is true, the run is only partially complete and is suspended, awaiting to be resumed when more data comes in.
-	// Note that `saveSuccessfulTaskRuns` value is ignored if the run contains async tasks.
+	// Note that `saveSuccessfulTaskRuns` value is ignored if the run contains async tasks, or if its spec checkpoints tasks.
 	Run(ctx context.Context, run *Run, saveSuccessfulTaskRuns bool, fn func(tx sqlutil.DataSource) error) (incomplete bool, err error)
 	ResumeRun(ctx context.Context, taskID uuid.UUID, value interface{}, err error) error
 
@@ -61,6 +62,7 @@ type runner struct {
 	services.StateMachine
 	orm                    ORM
 	btORM                  bridges.ORM
+	bridgePools            *bridges.Pools
 	config                 Config
 	bridgeConfig           BridgeConfig
 	legacyEVMChains        legacyevm.LegacyChainContainer
@@ -74,6 +76,9 @@ type runner struct {
 	// test helper
 	runFinished func(*Run)
 
+	// chWaitCreated wakes up the wait loop when a wait task run starts waiting
+	chWaitCreated chan struct{}
+
 	chStop services.StopChan
 	wgDone sync.WaitGroup
 }
@@ -124,11 +129,13 @@ func NewRunner(
 	r := &runner{
 		orm:                    orm,
 		btORM:                  bridges.NewCache(btORM, lggr, bridges.DefaultUpsertInterval),
+		bridgePools:            bridges.NewPools(lggr, unrestrictedHTTPClient),
 		config:                 cfg,
 		bridgeConfig:           bridgeCfg,
 		legacyEVMChains:        legacyChains,
 		ethKeyStore:            ethks,
 		vrfKeyStore:            vrfks,
+		chWaitCreated:          make(chan struct{}, 1),
 		chStop:                 make(chan struct{}),
 		wgDone:                 sync.WaitGroup{},
 		runFinished:            func(*Run) {},
@@ -149,11 +156,17 @@ func (r *runner) Start(ctx context.Context) error {
 	return r.StartOnce("PipelineRunner", func() error {
 		r.wgDone.Add(1)
 		go r.scheduleUnfinishedRuns()
+		r.wgDone.Add(1)
+		go r.runWaitLoop()
 		if r.config.ReaperInterval() != time.Duration(0) {
 			r.wgDone.Add(1)
 			go r.runReaperLoop()
 		}
 
+		if err := r.bridgePools.Start(ctx); err != nil {
+			return err
+		}
+
 		// the btORM can be a cache service or a static ORM if the constructor changes
 		service, isService := r.btORM.(services.Service)
 		if isService {
@@ -169,12 +182,14 @@ func (r *runner) Close() error {
 		close(r.chStop)
 		r.wgDone.Wait()
 
+		err := r.bridgePools.Close()
+
 		// the btORM can be a cache service or a static ORM if the constructor changes
 		if closer, isCloser := r.btORM.(io.Closer); isCloser {
-			return closer.Close()
+			err = errors.Join(err, closer.Close())
 		}
 
-		return nil
+		return err
 	})
 }
 
@@ -184,6 +199,7 @@ func (r *runner) Name() string {
 
 func (r *runner) HealthReport() map[string]error {
 	runnerHealth := map[string]error{r.Name(): r.Healthy()}
+	services.CopyHealth(runnerHealth, r.bridgePools.HealthReport())
 
 	service, isService := r.btORM.(services.HealthReporter)
 	if !isService {
@@ -222,6 +238,92 @@ func (r *runner) runReaperLoop() {
 	}
 }
 
+const (
+	// waitPollInterval is how often waits for a block number are checked, and how soon
+	// loading the waits is retried after a failure.
+	waitPollInterval = time.Second
+	// waitMaxSleep is the longest the wait loop sleeps without checking the waits.
+	waitMaxSleep = time.Minute
+)
+
+// runWaitLoop resumes runs suspended by wait tasks once their wait is over. It sleeps until
+// the earliest persisted deadline, unless a wait task run starts waiting in the meantime.
+// Waits for a block number are polled, as long as there are any.
+func (r *runner) runWaitLoop() {
+	defer r.wgDone.Done()
+	ctx, cancel := r.chStop.NewCtx()
+	defer cancel()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-r.chStop:
+			return
+		case <-r.chWaitCreated:
+		case <-timer.C:
+		}
+		timer.Reset(time.Until(r.resumeFinishedWaits(ctx)))
+	}
+}
+
+// wakeWaitLoop makes the wait loop check the waits, so that it sleeps until the deadline of
+// a newly created wait if it is the earliest.
+func (r *runner) wakeWaitLoop() {
+	select {
+	case r.chWaitCreated <- struct{}{}:
+	default:
+	}
+}
+
+// resumeFinishedWaits resumes the runs whose wait is over, and returns when the waits
+// should be checked next.
+func (r *runner) resumeFinishedWaits(ctx context.Context) (next time.Time) {
+	now := time.Now()
+	waits, err := r.orm.GetTaskRunWaits(ctx, now)
+	if err != nil {
+		r.lggr.Errorw("Failed to load pipeline task run waits", "err", err)
+		return now.Add(waitPollInterval)
+	}
+	next = now.Add(waitMaxSleep)
+	for _, wait := range waits {
+		if wait.BlockNumber.Valid {
+			next = now.Add(waitPollInterval)
+		}
+		l := r.lggr.With("taskRunID", wait.PipelineTaskRunID)
+		value, over, err := checkTaskRunWait(wait, time.Now(), r.legacyEVMChains)
+		if err != nil {
+			l.Warnw("Failed to check pipeline task run wait", "err", err)
+			continue
+		} else if !over {
+			continue
+		}
+		if err = r.ResumeRun(ctx, wait.PipelineTaskRunID, value, nil); err != nil {
+			l.Errorw("Failed to resume pipeline run after wait", "err", err)
+			next = now.Add(waitPollInterval)
+			continue
+		}
+		if err = r.orm.DeleteTaskRunWait(ctx, wait.PipelineTaskRunID); err != nil {
+			l.Warnw("Failed to delete pipeline task run wait", "err", err)
+		}
+	}
+
+	until, err := r.orm.NextTaskRunWaitUntil(ctx)
+	if err != nil {
+		r.lggr.Errorw("Failed to load next pipeline task run wait", "err", err)
+		return now.Add(waitPollInterval)
+	}
+	switch {
+	case !until.Valid || !until.Time.Before(next):
+	case until.Time.After(now):
+		next = until.Time
+	default:
+		// The wait is due, but could not be resumed above
+		next = now.Add(waitPollInterval)
+	}
+	return next
+}
+
 type memoryTaskRun struct {
 	task     Task
 	inputs   []Result // sorted by input index
@@ -349,11 +451,14 @@ func (r *runner) InitializePipeline(spec Spec) (pipeline *Pipeline, err error) {
 			// must use the unrestrictedHTTPClient because some node operators
 			// may run external adapters on their own hardware
 			task.(*BridgeTask).httpClient = r.unrestrictedHTTPClient
+			task.(*BridgeTask).pools = r.bridgePools
 		case TaskTypeETHCall:
 			task.(*ETHCallTask).legacyChains = r.legacyEVMChains
 			task.(*ETHCallTask).config = r.config
 			task.(*ETHCallTask).specGasLimit = spec.GasLimit
 			task.(*ETHCallTask).jobType = spec.JobType
+		case TaskTypeETHGetLogs:
+			task.(*ETHGetLogsTask).legacyChains = r.legacyEVMChains
 		case TaskTypeVRF:
 			task.(*VRFTask).keyStore = r.vrfKeyStore
 		case TaskTypeVRFV2:
@@ -370,6 +475,10 @@ func (r *runner) InitializePipeline(spec Spec) (pipeline *Pipeline, err error) {
 			task.(*ETHTxTask).specGasLimit = spec.GasLimit
 			task.(*ETHTxTask).jobType = spec.JobType
 			task.(*ETHTxTask).forwardingAllowed = spec.ForwardingAllowed
+		case TaskTypeWait:
+			task.(*WaitTask).orm = r.orm
+			task.(*WaitTask).legacyChains = r.legacyEVMChains
+			task.(*WaitTask).waitCreated = r.wakeWaitLoop
 		default:
 		}
 	}
@@ -396,6 +505,14 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 		defer cancel()
 	}
 
+	// Only runs that are persisted can be checkpointed, or suspended by wait tasks
+	checkpoint := run.PipelineSpec.CheckpointTasks && run.ID != 0
+	for _, task := range pipeline.Tasks {
+		if wait, ok := task.(*WaitTask); ok {
+			wait.inMemoryRun = run.ID == 0
+		}
+	}
+
 	for taskRun := range scheduler.taskCh {
 		taskRun := taskRun
 		// execute
@@ -404,6 +521,10 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 
 			logTaskRunToPrometheus(result, run.PipelineSpec)
 
+			if checkpoint {
+				r.checkpointTaskRun(reportCtx, run.ID, result, l)
+			}
+
 			scheduler.report(reportCtx, result)
 		}, func(err interface{}) {
 			t := time.Now()
@@ -435,19 +556,7 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 	// Update run results
 	run.PipelineTaskRuns = nil
 	for _, result := range scheduler.results {
-		output := result.Result.OutputDB()
-		run.PipelineTaskRuns = append(run.PipelineTaskRuns, TaskRun{
-			ID:            result.ID,
-			PipelineRunID: run.ID,
-			Type:          result.Task.Type(),
-			Index:         result.Task.OutputIndex(),
-			Output:        output,
-			Error:         result.Result.ErrorDB(),
-			DotID:         result.Task.DotID(),
-			CreatedAt:     result.CreatedAt,
-			FinishedAt:    result.FinishedAt,
-			task:          result.Task,
-		})
+		run.PipelineTaskRuns = append(run.PipelineTaskRuns, newTaskRun(run.ID, result))
 
 		sort.Slice(run.PipelineTaskRuns, func(i, j int) bool {
 			if run.PipelineTaskRuns[i].task.OutputIndex() == run.PipelineTaskRuns[j].task.OutputIndex() {
@@ -537,6 +646,34 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 	return taskRunResults
 }
 
+func newTaskRun(runID int64, result TaskRunResult) TaskRun {
+	return TaskRun{
+		ID:            result.ID,
+		PipelineRunID: runID,
+		Type:          result.Task.Type(),
+		Index:         result.Task.OutputIndex(),
+		Output:        result.Result.OutputDB(),
+		Error:         result.Result.ErrorDB(),
+		DotID:         result.Task.DotID(),
+		CreatedAt:     result.CreatedAt,
+		FinishedAt:    result.FinishedAt,
+		task:          result.Task,
+	}
+}
+
+// checkpointTaskRun persists the result of a task as soon as it completes. Errors are not
+// persisted, since the task may still be retried: a task that errored is executed again
+// when the run is resumed.
+func (r *runner) checkpointTaskRun(ctx context.Context, runID int64, result TaskRunResult, l logger.Logger) {
+	if result.runInfo.IsPending || result.Result.Error != nil {
+		return
+	}
+	if err := r.orm.CheckpointTaskRun(ctx, newTaskRun(runID, result)); err != nil {
+		l.Warnw("Failed to checkpoint pipeline task run, it will be executed again if the run is resumed",
+			"taskName", result.Task.DotID(), "err", err)
+	}
+}
+
 func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryTaskRun, l logger.Logger) TaskRunResult {
 	start := time.Now()
 	l = l.With("taskName", taskRun.task.DotID(),
@@ -564,7 +701,13 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryT
 		defer cancel()
 	}
 
-	result, runInfo := taskRun.task.Run(ctx, l, taskRun.vars, taskRun.inputs)
+	var result Result
+	var runInfo RunInfo
+	if sim := GetSimulation(ctx); sim != nil {
+		result, runInfo = sim.runTask(ctx, l, taskRun.task, taskRun.vars, taskRun.inputs)
+	} else {
+		result, runInfo = taskRun.task.Run(ctx, l, taskRun.vars, taskRun.inputs)
+	}
 	loggerFields := []interface{}{"runInfo", runInfo,
 		"resultValue", result.Value,
 		"resultError", result.Error,
@@ -652,7 +795,8 @@ func (r *runner) Run(ctx context.Context, run *Run, saveSuccessfulTaskRuns bool,
 		task.Base().uuid = taskRun.ID
 	}
 
-	preinsert := pipeline.RequiresPreInsert()
+	// Checkpointed runs are inserted upfront, so that their task results can be persisted as they complete
+	preinsert := pipeline.RequiresPreInsert() || run.PipelineSpec.CheckpointTasks
 
 	err = r.orm.Transact(ctx, func(tx ORM) error {
 		// OPTIMISATION: avoid an extra db write if there is no async tasks present or if this is a resumed run
@@ -661,7 +805,7 @@ func (r *runner) Run(ctx context.Context, run *Run, saveSuccessfulTaskRuns bool,
 			// initialize certain task params
 			for _, task := range pipeline.Tasks {
 				switch task.Type() {
-				case TaskTypeETHTx:
+				case TaskTypeETHTx, TaskTypeWait:
 					run.PipelineTaskRuns = append(run.PipelineTaskRuns, TaskRun{
 						ID:            task.Base().uuid,
 						PipelineRunID: run.ID,
@@ -6,9 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -893,6 +897,75 @@ ds5 [type=http method="GET" url="%s" index=2]
 	require.Len(t, errorResults, 3)
 }
 
+func Test_PipelineRunner_CheckpointTasks(t *testing.T) {
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+
+	var requests atomic.Int32
+	s1 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"data":{"result":"9700"}}`))
+		require.NoError(t, err)
+	}))
+	defer s1.Close()
+
+	r, orm := newRunner(t, db, bridgesMocks.NewORM(t), cfg)
+	transactCall := orm.On("Transact", mock.Anything, mock.Anything)
+	transactCall.Run(func(args mock.Arguments) {
+		fn := args[1].(func(orm pipeline.ORM) error)
+		transactCall.ReturnArguments = mock.Arguments{fn(orm)}
+	})
+
+	var mu sync.Mutex
+	var checkpointed []string
+	orm.On("CheckpointTaskRun", mock.Anything, mock.AnythingOfType("pipeline.TaskRun")).Return(nil).Run(func(args mock.Arguments) {
+		taskRun := args.Get(1).(pipeline.TaskRun)
+		assert.Equal(t, int64(1), taskRun.PipelineRunID)
+		assert.True(t, taskRun.FinishedAt.Valid)
+		mu.Lock()
+		defer mu.Unlock()
+		checkpointed = append(checkpointed, taskRun.DotID)
+	})
+
+	s := fmt.Sprintf(`
+ds1 [type=http method="GET" url="%s"]
+ds1_parse [type=jsonparse lax=false path="data,result"]
+ds1_multiply [type=multiply times=2]
+
+ds1->ds1_parse->ds1_multiply;
+`, s1.URL)
+	spec := pipeline.Spec{DotDagSource: s, CheckpointTasks: true}
+
+	// The run is inserted upfront, even without async tasks, and each task is checkpointed as it completes
+	run := pipeline.NewRun(spec, pipeline.NewVarsFrom(nil))
+	orm.On("CreateRun", mock.Anything, mock.AnythingOfType("*pipeline.Run")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*pipeline.Run).ID = 1
+	}).Once()
+	orm.On("StoreRun", mock.Anything, mock.AnythingOfType("*pipeline.Run")).Return(false, nil).Once()
+	incomplete, err := r.Run(testutils.Context(t), run, false, nil)
+	require.NoError(t, err)
+	require.False(t, incomplete)
+	assert.ElementsMatch(t, []string{"ds1", "ds1_parse", "ds1_multiply"}, checkpointed)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// A run interrupted after ds1 completed resumes from its checkpoint, without executing ds1 again
+	checkpointed = nil
+	ds1 := run.ByDotID("ds1")
+	require.NotNil(t, ds1)
+	resumed := pipeline.NewRun(spec, pipeline.NewVarsFrom(nil))
+	resumed.ID = 1
+	resumed.PipelineTaskRuns = []pipeline.TaskRun{*ds1}
+	orm.On("StoreRun", mock.Anything, mock.AnythingOfType("*pipeline.Run")).Return(false, nil).Once()
+	incomplete, err = r.Run(testutils.Context(t), resumed, false, nil)
+	require.NoError(t, err)
+	require.False(t, incomplete)
+	assert.ElementsMatch(t, []string{"ds1_parse", "ds1_multiply"}, checkpointed)
+	assert.Equal(t, int32(1), requests.Load())
+	require.Len(t, resumed.Outputs.Val, 1)
+	assert.Equal(t, "19400", resumed.Outputs.Val.([]interface{})[0].(decimal.Decimal).String())
+}
+
 func Test_PipelineRunner_LowercaseOutputs(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 	cfg := configtest.NewTestGeneralConfig(t)
@@ -1077,3 +1150,135 @@ succeed;
 		assert.Equal(t, "1", trrs[0].Result.Value.(pipeline.ObjectParam).DecimalValue.Decimal().String())
 	})
 }
+
+func Test_PipelineRunner_ExecuteRun_Simulation(t *testing.T) {
+	const dag = `
+ds    [type=http method=GET url="%s"];
+parse [type=jsonparse path="price"];
+mult  [type=multiply times=2];
+tx    [type=ethtx to="0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c" data="0x01"];
+ds -> parse -> mult -> tx;
+`
+	resultsByDotID := func(trrs pipeline.TaskRunResults) map[string]pipeline.Result {
+		results := make(map[string]pipeline.Result)
+		for _, trr := range trrs {
+			results[trr.Task.DotID()] = trr.Result
+		}
+		return results
+	}
+
+	cfg := configtest.NewTestGeneralConfig(t)
+	r, _ := newRunner(t, pgtest.NewSqlxDB(t), bridgesMocks.NewORM(t), cfg)
+
+	price, err := pipeline.NewFixtureValue(`{"price": 21}`)
+	require.NoError(t, err)
+
+	t.Run("replay", func(t *testing.T) {
+		sim := pipeline.NewSimulation(pipeline.Fixtures{
+			"ds": {Type: pipeline.TaskTypeHTTP, Value: price},
+		}, false)
+		ctx := pipeline.WithSimulation(testutils.Context(t), sim)
+		_, trrs, err := r.ExecuteRun(ctx, pipeline.Spec{DotDagSource: fmt.Sprintf(dag, "https://chain.link/eth_usd")}, pipeline.NewVarsFrom(nil))
+		require.NoError(t, err)
+		require.False(t, trrs.FinalResult().HasErrors())
+
+		results := resultsByDotID(trrs)
+		assert.Equal(t, `{"price": 21}`, results["ds"].Value)
+		assert.Equal(t, "42", results["mult"].Value.(decimal.Decimal).String())
+		// ethtx tasks are never run
+		assert.Nil(t, results["tx"].Value)
+		require.Len(t, sim.Inputs("parse"), 1)
+		assert.Equal(t, `{"price": 21}`, sim.Inputs("parse")[0].Value)
+		recorded, err := sim.Recorded()
+		require.NoError(t, err)
+		assert.Empty(t, recorded)
+	})
+
+	t.Run("replay without a node", func(t *testing.T) {
+		sim := pipeline.NewSimulation(pipeline.Fixtures{
+			"ds": {Type: pipeline.TaskTypeHTTP, Value: price},
+		}, false)
+		_, trrs, err := pipeline.SimulateRun(testutils.Context(t), logger.TestLogger(t), pipeline.Spec{DotDagSource: fmt.Sprintf(dag, "https://chain.link/eth_usd")}, pipeline.NewVarsFrom(nil), sim)
+		require.NoError(t, err)
+		require.False(t, trrs.FinalResult().HasErrors())
+		assert.Equal(t, "42", resultsByDotID(trrs)["mult"].Value.(decimal.Decimal).String())
+
+		_, _, err = pipeline.SimulateRun(testutils.Context(t), logger.TestLogger(t), pipeline.Spec{DotDagSource: fmt.Sprintf(dag, "https://chain.link/eth_usd")}, pipeline.NewVarsFrom(nil), pipeline.NewSimulation(nil, true))
+		require.Error(t, err)
+	})
+
+	t.Run("missing fixture", func(t *testing.T) {
+		sim := pipeline.NewSimulation(nil, false)
+		ctx := pipeline.WithSimulation(testutils.Context(t), sim)
+		_, trrs, err := r.ExecuteRun(ctx, pipeline.Spec{DotDagSource: fmt.Sprintf(dag, "https://chain.link/eth_usd")}, pipeline.NewVarsFrom(nil))
+		require.NoError(t, err)
+		results := resultsByDotID(trrs)
+		require.Error(t, results["ds"].Error)
+		assert.Contains(t, results["ds"].Error.Error(), "no fixture for http task ds")
+	})
+
+	t.Run("record", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, `{"price": 21}`)
+		}))
+		defer s.Close()
+
+		sim := pipeline.NewSimulation(nil, true)
+		ctx := pipeline.WithSimulation(testutils.Context(t), sim)
+		_, trrs, err := r.ExecuteRun(ctx, pipeline.Spec{DotDagSource: fmt.Sprintf(dag, s.URL)}, pipeline.NewVarsFrom(nil))
+		require.NoError(t, err)
+		require.False(t, trrs.FinalResult().HasErrors())
+
+		recorded, err := sim.Recorded()
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.Fixtures{
+			"ds": {Type: pipeline.TaskTypeHTTP, Value: price},
+			"tx": {Type: pipeline.TaskTypeETHTx},
+		}, recorded)
+
+		dir := t.TempDir()
+		require.NoError(t, recorded.Save(dir))
+		loaded, err := pipeline.LoadFixtures(dir)
+		require.NoError(t, err)
+		assert.Equal(t, recorded, loaded)
+	})
+
+	t.Run("typed values", func(t *testing.T) {
+		values := []interface{}{
+			nil,
+			true,
+			"foo",
+			[]byte{0x01, 0x02},
+			int(-1),
+			int32(-2),
+			int64(-3),
+			uint(1),
+			uint8(2),
+			uint32(3),
+			uint64(math.MaxUint64),
+			float64(1.5),
+			new(big.Int).Lsh(big.NewInt(1), 200),
+			decimal.RequireFromString("1.000000000000000001"),
+			common.HexToAddress("0x2"),
+			common.HexToHash("0x3"),
+			map[string]interface{}{"price": decimal.NewFromInt(21), "ok": true},
+			[]interface{}{big.NewInt(4), "bar"},
+		}
+		fixtures := make(pipeline.Fixtures)
+		for i, v := range values {
+			fv, err := pipeline.NewFixtureValue(v)
+			require.NoError(t, err)
+			fixtures[fmt.Sprintf("task%d", i)] = pipeline.TaskFixture{Type: pipeline.TaskTypeHTTP, Value: fv}
+		}
+
+		dir := t.TempDir()
+		require.NoError(t, fixtures.Save(dir))
+		loaded, err := pipeline.LoadFixtures(dir)
+		require.NoError(t, err)
+		for i, v := range values {
+			result := loaded[fmt.Sprintf("task%d", i)].Result()
+			require.NoError(t, result.Error)
+			assert.Equal(t, v, result.Value)
+		}
+	})
+}
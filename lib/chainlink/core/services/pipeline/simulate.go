@@ -0,0 +1,495 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+	"go.uber.org/multierr"
+
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// fixtureTaskTypes are the tasks that read from the outside world or the node's keys. When
+// simulating a run, their results are served from fixtures, or recorded to fixtures from real
+// responses.
+var fixtureTaskTypes = map[TaskType]bool{
+	TaskTypeBridge:           true,
+	TaskTypeETHCall:          true,
+	TaskTypeETHGetLogs:       true,
+	TaskTypeEstimateGasLimit: true,
+	TaskTypeHTTP:             true,
+	TaskTypeVRF:              true,
+	TaskTypeVRFV2:            true,
+	TaskTypeVRFV2Plus:        true,
+}
+
+// sideEffectTaskTypes are the tasks that write to the outside world or to the database. They
+// are never run when simulating a run, not even when recording: their results are served from
+// fixtures if there are any, and are nil otherwise.
+var sideEffectTaskTypes = map[TaskType]bool{
+	TaskTypeETHTx: true,
+	TaskTypeWait:  true,
+}
+
+// TaskFixture is the recorded result of a task.
+type TaskFixture struct {
+	Type  TaskType      `json:"type"`
+	Value *FixtureValue `json:"value,omitempty"`
+	Error string        `json:"error,omitempty"`
+}
+
+// NewTaskFixture records the result of a task.
+func NewTaskFixture(task Task, result Result) (TaskFixture, error) {
+	fixture := TaskFixture{Type: task.Type()}
+	if result.Error != nil {
+		fixture.Error = result.Error.Error()
+		return fixture, nil
+	}
+	if result.Value == nil {
+		return fixture, nil
+	}
+	value, err := NewFixtureValue(result.Value)
+	if err != nil {
+		return TaskFixture{}, errors.Wrapf(err, "failed to record result of %s", task.DotID())
+	}
+	fixture.Value = value
+	return fixture, nil
+}
+
+// Result returns the recorded result.
+func (f TaskFixture) Result() Result {
+	if f.Error != "" {
+		return Result{Error: errors.New(f.Error)}
+	}
+	if f.Value == nil {
+		return Result{}
+	}
+	value, err := f.Value.Decode()
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "invalid fixture")}
+	}
+	return Result{Value: value}
+}
+
+// Kinds of FixtureValue
+const (
+	FixtureKindNull    = "null"
+	FixtureKindBool    = "bool"
+	FixtureKindString  = "string"
+	FixtureKindBytes   = "bytes"
+	FixtureKindInt     = "int"
+	FixtureKindInt32   = "int32"
+	FixtureKindInt64   = "int64"
+	FixtureKindUint    = "uint"
+	FixtureKindUint8   = "uint8"
+	FixtureKindUint32  = "uint32"
+	FixtureKindUint64  = "uint64"
+	FixtureKindFloat64 = "float64"
+	FixtureKindBigInt  = "bigint"
+	FixtureKindDecimal = "decimal"
+	FixtureKindAddress = "address"
+	FixtureKindHash    = "hash"
+	FixtureKindMap     = "map"
+	FixtureKindList    = "list"
+	// FixtureKindJSON holds values of any other type as plain JSON, so they are replayed as
+	// the types JSON decodes to.
+	FixtureKindJSON = "json"
+)
+
+// FixtureValue is a task result tagged with its kind, so that it is replayed with the type it
+// was recorded with. Integers are encoded as strings, so that they keep their precision.
+type FixtureValue struct {
+	Kind  string          `json:"kind"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// NewFixtureValue encodes v with its kind.
+func NewFixtureValue(v interface{}) (*FixtureValue, error) {
+	var kind string
+	var value interface{}
+	switch t := v.(type) {
+	case nil:
+		return &FixtureValue{Kind: FixtureKindNull}, nil
+	case bool:
+		kind, value = FixtureKindBool, t
+	case string:
+		kind, value = FixtureKindString, t
+	case []byte:
+		kind, value = FixtureKindBytes, hexutil.Bytes(t)
+	case int:
+		kind, value = FixtureKindInt, strconv.FormatInt(int64(t), 10)
+	case int32:
+		kind, value = FixtureKindInt32, strconv.FormatInt(int64(t), 10)
+	case int64:
+		kind, value = FixtureKindInt64, strconv.FormatInt(t, 10)
+	case uint:
+		kind, value = FixtureKindUint, strconv.FormatUint(uint64(t), 10)
+	case uint8:
+		kind, value = FixtureKindUint8, strconv.FormatUint(uint64(t), 10)
+	case uint32:
+		kind, value = FixtureKindUint32, strconv.FormatUint(uint64(t), 10)
+	case uint64:
+		kind, value = FixtureKindUint64, strconv.FormatUint(t, 10)
+	case float64:
+		kind, value = FixtureKindFloat64, t
+	case *big.Int:
+		if t == nil {
+			return &FixtureValue{Kind: FixtureKindNull}, nil
+		}
+		kind, value = FixtureKindBigInt, t.String()
+	case decimal.Decimal:
+		kind, value = FixtureKindDecimal, t.String()
+	case common.Address:
+		kind, value = FixtureKindAddress, t
+	case common.Hash:
+		kind, value = FixtureKindHash, t
+	case map[string]interface{}:
+		m := make(map[string]*FixtureValue, len(t))
+		for key, elem := range t {
+			fv, err := NewFixtureValue(elem)
+			if err != nil {
+				return nil, errors.Wrapf(err, "key %s", key)
+			}
+			m[key] = fv
+		}
+		kind, value = FixtureKindMap, m
+	case []interface{}:
+		l := make([]*FixtureValue, len(t))
+		for i, elem := range t {
+			fv, err := NewFixtureValue(elem)
+			if err != nil {
+				return nil, errors.Wrapf(err, "index %d", i)
+			}
+			l[i] = fv
+		}
+		kind, value = FixtureKindList, l
+	default:
+		kind, value = FixtureKindJSON, t
+	}
+	bs, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to encode %T", v)
+	}
+	return &FixtureValue{Kind: kind, Value: bs}, nil
+}
+
+// Decode returns the value with the type of its kind.
+func (f *FixtureValue) Decode() (interface{}, error) {
+	switch f.Kind {
+	case FixtureKindNull:
+		return nil, nil
+	case FixtureKindBool:
+		var b bool
+		return b, f.unmarshal(&b)
+	case FixtureKindString:
+		var s string
+		return s, f.unmarshal(&s)
+	case FixtureKindBytes:
+		var bs hexutil.Bytes
+		return []byte(bs), f.unmarshal(&bs)
+	case FixtureKindInt, FixtureKindInt32, FixtureKindInt64:
+		return f.decodeInt()
+	case FixtureKindUint, FixtureKindUint8, FixtureKindUint32, FixtureKindUint64:
+		return f.decodeUint()
+	case FixtureKindFloat64:
+		var n float64
+		return n, f.unmarshal(&n)
+	case FixtureKindBigInt:
+		var s string
+		if err := f.unmarshal(&s); err != nil {
+			return nil, err
+		}
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, errors.Errorf("invalid %s %q", f.Kind, s)
+		}
+		return n, nil
+	case FixtureKindDecimal:
+		var s string
+		if err := f.unmarshal(&s); err != nil {
+			return nil, err
+		}
+		return decimal.NewFromString(s)
+	case FixtureKindAddress:
+		var a common.Address
+		return a, f.unmarshal(&a)
+	case FixtureKindHash:
+		var h common.Hash
+		return h, f.unmarshal(&h)
+	case FixtureKindMap:
+		var m map[string]*FixtureValue
+		if err := f.unmarshal(&m); err != nil {
+			return nil, err
+		}
+		out := make(map[string]interface{}, len(m))
+		for key, fv := range m {
+			v, err := fv.Decode()
+			if err != nil {
+				return nil, errors.Wrapf(err, "key %s", key)
+			}
+			out[key] = v
+		}
+		return out, nil
+	case FixtureKindList:
+		var l []*FixtureValue
+		if err := f.unmarshal(&l); err != nil {
+			return nil, err
+		}
+		out := make([]interface{}, len(l))
+		for i, fv := range l {
+			v, err := fv.Decode()
+			if err != nil {
+				return nil, errors.Wrapf(err, "index %d", i)
+			}
+			out[i] = v
+		}
+		return out, nil
+	case FixtureKindJSON:
+		var v interface{}
+		return v, f.unmarshal(&v)
+	default:
+		return nil, errors.Errorf("unknown fixture value kind %q", f.Kind)
+	}
+}
+
+func (f *FixtureValue) unmarshal(v interface{}) error {
+	if err := json.Unmarshal(f.Value, v); err != nil {
+		return errors.Wrapf(err, "invalid %s", f.Kind)
+	}
+	return nil
+}
+
+func (f *FixtureValue) decodeInt() (interface{}, error) {
+	var s string
+	if err := f.unmarshal(&s); err != nil {
+		return nil, err
+	}
+	bitSize := map[string]int{FixtureKindInt: 0, FixtureKindInt32: 32, FixtureKindInt64: 64}[f.Kind]
+	n, err := strconv.ParseInt(s, 10, bitSize)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", f.Kind)
+	}
+	switch f.Kind {
+	case FixtureKindInt:
+		return int(n), nil
+	case FixtureKindInt32:
+		return int32(n), nil
+	default:
+		return n, nil
+	}
+}
+
+func (f *FixtureValue) decodeUint() (interface{}, error) {
+	var s string
+	if err := f.unmarshal(&s); err != nil {
+		return nil, err
+	}
+	bitSize := map[string]int{FixtureKindUint: 0, FixtureKindUint8: 8, FixtureKindUint32: 32, FixtureKindUint64: 64}[f.Kind]
+	n, err := strconv.ParseUint(s, 10, bitSize)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", f.Kind)
+	}
+	switch f.Kind {
+	case FixtureKindUint:
+		return uint(n), nil
+	case FixtureKindUint8:
+		return uint8(n), nil
+	case FixtureKindUint32:
+		return uint32(n), nil
+	default:
+		return n, nil
+	}
+}
+
+// Fixtures are recorded task results by DOT ID.
+type Fixtures map[string]TaskFixture
+
+const fixtureFileExt = ".json"
+
+// LoadFixtures reads the fixtures in dir, which has a <DOT ID>.json file for each task.
+func LoadFixtures(dir string) (Fixtures, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read fixtures")
+	}
+	fixtures := make(Fixtures)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != fixtureFileExt {
+			continue
+		}
+		bs, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read fixture")
+		}
+		var fixture TaskFixture
+		if err = json.Unmarshal(bs, &fixture); err != nil {
+			return nil, errors.Wrapf(err, "invalid fixture %s", entry.Name())
+		}
+		fixtures[strings.TrimSuffix(entry.Name(), fixtureFileExt)] = fixture
+	}
+	return fixtures, nil
+}
+
+// Save writes the fixtures to dir, replacing the fixtures of the same tasks.
+func (f Fixtures) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrap(err, "failed to create fixtures directory")
+	}
+	for dotID, fixture := range f {
+		bs, err := json.MarshalIndent(fixture, "", "  ")
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode fixture of %s", dotID)
+		}
+		if err = os.WriteFile(filepath.Join(dir, dotID+fixtureFileExt), append(bs, '\n'), 0600); err != nil {
+			return errors.Wrapf(err, "failed to write fixture of %s", dotID)
+		}
+	}
+	return nil
+}
+
+// Simulation serves the results of tasks that interact with the outside world from fixtures,
+// so that a pipeline can be run without live adapters or chains, and without side effects.
+// When recording, the tasks that only read from the outside world are run, and their results
+// are recorded to fixtures instead.
+//
+// Runs are simulated by passing a context with the simulation to Runner.ExecuteRun.
+type Simulation struct {
+	fixtures Fixtures
+	record   bool
+
+	mu        sync.Mutex
+	recorded  Fixtures
+	recordErr error
+	inputs    map[string][]Result
+}
+
+// NewSimulation returns a simulation serving results from fixtures, or recording them if
+// record is true.
+func NewSimulation(fixtures Fixtures, record bool) *Simulation {
+	if fixtures == nil {
+		fixtures = make(Fixtures)
+	}
+	return &Simulation{
+		fixtures: fixtures,
+		record:   record,
+		recorded: make(Fixtures),
+		inputs:   make(map[string][]Result),
+	}
+}
+
+// Recorded returns the fixtures recorded by the simulation, and an error if the result of any
+// task could not be recorded.
+func (s *Simulation) Recorded() (Fixtures, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recorded := make(Fixtures, len(s.recorded))
+	for dotID, fixture := range s.recorded {
+		recorded[dotID] = fixture
+	}
+	return recorded, s.recordErr
+}
+
+// Inputs returns the inputs the task was run with.
+func (s *Simulation) Inputs(dotID string) []Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inputs[dotID]
+}
+
+// IsSimulated returns true if tasks of the type are not run by the simulation.
+func (s *Simulation) IsSimulated(taskType TaskType) bool {
+	return sideEffectTaskTypes[taskType] || (fixtureTaskTypes[taskType] && !s.record)
+}
+
+func (s *Simulation) runTask(ctx context.Context, lggr logger.Logger, task Task, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	s.mu.Lock()
+	s.inputs[task.DotID()] = inputs
+	s.mu.Unlock()
+
+	fixture, hasFixture := s.fixtures[task.DotID()]
+	switch {
+	case sideEffectTaskTypes[task.Type()]:
+		if hasFixture {
+			result = fixture.Result()
+		}
+	case !fixtureTaskTypes[task.Type()]:
+		return task.Run(ctx, lggr, vars, inputs)
+	case s.record:
+		result, runInfo = task.Run(ctx, lggr, vars, inputs)
+	case hasFixture:
+		result = fixture.Result()
+	default:
+		result = Result{Error: errors.Errorf("no fixture for %s task %s", task.Type(), task.DotID())}
+	}
+
+	if s.record && !runInfo.IsPending {
+		fixture, err := NewTaskFixture(task, result)
+		s.mu.Lock()
+		if err != nil {
+			s.recordErr = multierr.Append(s.recordErr, err)
+		} else {
+			s.recorded[task.DotID()] = fixture
+		}
+		s.mu.Unlock()
+	}
+	return result, runInfo
+}
+
+const ctxSimulationKey contextKey = "simulation"
+
+// WithSimulation adds a simulation to the context, so that runs executed with it are simulated.
+func WithSimulation(ctx context.Context, sim *Simulation) context.Context {
+	if sim == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxSimulationKey, sim)
+}
+
+// GetSimulation returns the simulation of the context, if any.
+func GetSimulation(ctx context.Context) *Simulation {
+	sim, ok := ctx.Value(ctxSimulationKey).(*Simulation)
+	if !ok {
+		return nil
+	}
+	return sim
+}
+
+// SimulateRun replays a simulation without a node: the pipeline is run in memory by a runner
+// that has no database, chains, keys or HTTP clients, so every task that needs them must be
+// served from fixtures. Simulations that record fixtures need a node, and are rejected.
+func SimulateRun(ctx context.Context, lggr logger.Logger, spec Spec, vars Vars, sim *Simulation) (*Run, TaskRunResults, error) {
+	if sim == nil || sim.record {
+		return nil, nil, errors.New("only simulations replaying fixtures can be run without a node")
+	}
+	r := &runner{
+		config:        simulationConfig{},
+		chWaitCreated: make(chan struct{}, 1),
+		chStop:        make(chan struct{}),
+		runFinished:   func(*Run) {},
+		lggr:          logger.Named(lggr, "PipelineRunner"),
+	}
+	return r.ExecuteRun(WithSimulation(ctx, sim), spec, vars)
+}
+
+// simulationConfig is the Config of runners replaying simulations, which never run the tasks
+// that use it.
+type simulationConfig struct{}
+
+func (simulationConfig) DefaultHTTPLimit() int64                   { return 0 }
+func (simulationConfig) DefaultHTTPTimeout() commonconfig.Duration { return commonconfig.Duration{} }
+func (simulationConfig) MaxRunDuration() time.Duration             { return 0 }
+func (simulationConfig) ReaperInterval() time.Duration             { return 0 }
+func (simulationConfig) ReaperThreshold() time.Duration            { return 0 }
+func (simulationConfig) VerboseLogging() bool                      { return false }
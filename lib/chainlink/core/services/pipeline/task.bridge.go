@@ -70,6 +70,7 @@ type BridgeTask struct {
 	config       Config
 	bridgeConfig BridgeConfig
 	httpClient   *http.Client
+	pools        *bridges.Pools
 }
 
 type BridgeTelemetry struct {
@@ -122,10 +123,15 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 		return Result{Error: errors.Errorf("headers must have an even number of elements")}, runInfo
 	}
 
+	// Simulated runs record live responses only, and must not write to the bridge cache
+	if GetSimulation(ctx) != nil {
+		cacheTTL = 0
+	}
+
 	overtimeCtx, cancel := overtimeContext(ctx)
 	defer cancel()
 
-	url, err := t.getBridgeURLFromName(overtimeCtx, name)
+	bt, err := t.getBridgeFromName(overtimeCtx, name)
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
@@ -167,16 +173,12 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
-	logger.Sugared(lggr).Tracew("Bridge task: sending request",
-		"requestData", string(requestDataJSON),
-		"url", url.String(),
-	)
 
 	requestCtx, cancel := httpRequestCtx(ctx, t, t.config)
 	defer cancel()
 
 	var cachedResponse bool
-	responseBytes, statusCode, headers, start, finish, err := makeHTTPRequest(requestCtx, lggr, "POST", url, reqHeaders, requestData, t.httpClient, t.config.DefaultHTTPLimit())
+	url, responseBytes, statusCode, headers, start, finish, err := t.sendRequest(requestCtx, lggr, bt, reqHeaders, requestData)
 	elapsed := finish.Sub(start)
 	promBridgeLatency.WithLabelValues(t.Name, statusCodeGroup(statusCode)).Set(elapsed.Seconds())
 
@@ -305,12 +307,91 @@ func (bt *BridgeTelemetry) resolveStreamID(t *BridgeTask, vars Vars, lggr logger
 	}
 }
 
-func (t *BridgeTask) getBridgeURLFromName(ctx context.Context, name StringParam) (URLParam, error) {
+func (t *BridgeTask) getBridgeFromName(ctx context.Context, name StringParam) (bridges.BridgeType, error) {
 	bt, err := t.orm.FindBridge(ctx, bridges.BridgeName(name))
 	if err != nil {
-		return URLParam{}, errors.Wrapf(err, "could not find bridge with name '%s'", name)
+		return bridges.BridgeType{}, errors.Wrapf(err, "could not find bridge with name '%s'", name)
+	}
+	return bt, nil
+}
+
+// sendRequest sends the request to the backends of the bridge in the order picked by its
+// pool, moving on to the next backend when one is unreachable or returns a server error.
+// All the attempts share the request's timeout.
+func (t *BridgeTask) sendRequest(
+	ctx context.Context,
+	lggr logger.Logger,
+	bt bridges.BridgeType,
+	reqHeaders []string,
+	requestData MapParam,
+) (url URLParam, responseBytes []byte, statusCode int, headers http.Header, start, finish time.Time, err error) {
+	var pool *bridges.Pool
+	if t.pools != nil {
+		pool = t.pools.Get(bt)
+	} else {
+		pool = bridges.NewPool(bt)
+	}
+	backends, err := pool.Select()
+	if err != nil {
+		start = time.Now()
+		finish = start
+		return
+	}
+
+	var signingSecret string
+	if bt.SigningEnabled() {
+		var secrets []string
+		if secrets, err = t.orm.SigningSecrets(ctx, bt); err != nil {
+			if errors.Is(err, bridges.ErrUndecryptableSigningSecret) {
+				lggr.Errorw("Bridge task: signing secret can't be decrypted, set it again to sign requests to the bridge", "bridge", bt.Name, "err", err)
+			}
+			start = time.Now()
+			finish = start
+			return
+		}
+		signingSecret = secrets[0]
+	}
+
+	for _, backend := range backends {
+		url = URLParam(backend.URL)
+		logger.Sugared(lggr).Tracew("Bridge task: sending request",
+			"requestData", requestData,
+			"url", url.String(),
+		)
+		var signed []string
+		if signed, err = signRequest(signingSecret, reqHeaders, requestData); err != nil {
+			return
+		}
+		responseBytes, statusCode, headers, start, finish, err = makeHTTPRequest(ctx, lggr, "POST", url, signed, requestData, t.httpClient, t.config.DefaultHTTPLimit())
+		failed := statusCode >= http.StatusInternalServerError || (err != nil && statusCode == 0)
+		backend.Report(finish.Sub(start), failed)
+		if !failed || ctx.Err() != nil {
+			return
+		}
+	}
+	return
+}
+
+// signRequest adds the headers signing the request body to reqHeaders, if the bridge has a
+// signing secret. Each request gets its own nonce, so that it can't be replayed to another backend.
+func signRequest(secret string, reqHeaders []string, requestData MapParam) ([]string, error) {
+	if secret == "" {
+		return reqHeaders, nil
+	}
+	// This is the body makeHTTPRequest sends, since maps are encoded with sorted keys
+	body, err := json.Marshal(requestData)
+	if err != nil {
+		return nil, err
+	}
+	header, err := bridges.SignRequest(secret, body, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	signed := append([]string{}, reqHeaders...)
+	for _, name := range []string{bridges.TimestampHeader, bridges.NonceHeader, bridges.SignatureHeader} {
+		signed = append(signed, name, header.Get(name))
 	}
-	return URLParam(bt.URL), nil
+	return signed, nil
 }
 
 func withRunInfo(request MapParam, meta MapParam) MapParam {
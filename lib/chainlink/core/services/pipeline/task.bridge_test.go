@@ -20,6 +20,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
 
 	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
 	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
@@ -869,6 +870,47 @@ func TestBridgeTask_OnlyErrorMessage(t *testing.T) {
 	require.Nil(t, result.Value)
 }
 
+func TestBridgeTask_FailsOverToBackend(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"data":{"result":"1234.5"}}`))
+		require.NoError(t, err)
+	}))
+	defer up.Close()
+
+	ctx := testutils.Context(t)
+	orm := bridges.NewORM(db)
+	_, bridge := cltest.MustCreateBridge(t, db, cltest.BridgeOpts{URL: down.URL})
+	require.NoError(t, orm.UpdateBridgeType(ctx, bridge, &bridges.BridgeTypeRequest{
+		URL:        bridge.URL,
+		PoolConfig: bridges.PoolConfig{BackendURLs: bridges.BackendURLs{cltest.WebURL(t, up.URL)}},
+	}))
+
+	task := pipeline.BridgeTask{
+		Name:        bridge.Name.String(),
+		RequestData: ethUSDPairing,
+	}
+	c := clhttptest.NewTestLocalOnlyHTTPClient()
+	trORM := pipeline.NewORM(db, logger.TestLogger(t), cfg.JobPipeline().MaxSuccessfulRuns())
+	specID, err := trORM.CreateSpec(ctx, pipeline.Pipeline{}, *models.NewInterval(5 * time.Minute))
+	require.NoError(t, err)
+	task.HelperSetDependencies(cfg.JobPipeline(), cfg.WebServer(), orm, specID, uuid.UUID{}, c)
+
+	result, runInfo := task.Run(ctx, logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+	assert.False(t, runInfo.IsPending)
+	require.NoError(t, result.Error)
+	assert.Equal(t, `{"data":{"result":"1234.5"}}`, result.Value)
+}
+
 func TestBridgeTask_ErrorIfBridgeMissing(t *testing.T) {
 	t.Parallel()
 
@@ -920,6 +962,85 @@ func TestAdapterResponse_UnmarshalJSON_Happy(t *testing.T) {
 	}
 }
 
+func TestBridgeTask_SignsRequests(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+
+	guard := bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if err = bridges.VerifyRequestSignature([]string{"secret"}, r.Header, body, guard); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte(`{"data":{"result":"1234.5"}}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	ctx := testutils.Context(t)
+	orm := bridges.NewORMWithSecretCipher(db, bridges.NewSecretCipher(func(context.Context) ([]byte, error) { return make([]byte, 32), nil }))
+	_, bridge := cltest.MustCreateBridge(t, db, cltest.BridgeOpts{URL: server.URL})
+	require.NoError(t, orm.UpdateBridgeSigningSecret(ctx, bridge, "secret"))
+
+	task := pipeline.BridgeTask{
+		Name:        bridge.Name.String(),
+		RequestData: ethUSDPairing,
+	}
+	c := clhttptest.NewTestLocalOnlyHTTPClient()
+	trORM := pipeline.NewORM(db, logger.TestLogger(t), cfg.JobPipeline().MaxSuccessfulRuns())
+	specID, err := trORM.CreateSpec(ctx, pipeline.Pipeline{}, *models.NewInterval(5 * time.Minute))
+	require.NoError(t, err)
+	task.HelperSetDependencies(cfg.JobPipeline(), cfg.WebServer(), orm, specID, uuid.UUID{}, c)
+
+	result, _ := task.Run(ctx, logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+	require.NoError(t, result.Error)
+	assert.Equal(t, `{"data":{"result":"1234.5"}}`, result.Value)
+}
+
+func TestBridgeTask_UndecryptableSigningSecret(t *testing.T) {
+	t.Parallel()
+
+	db := pgtest.NewSqlxDB(t)
+	cfg := configtest.NewTestGeneralConfig(t)
+
+	var called atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called.Store(true)
+	}))
+	defer server.Close()
+
+	ctx := testutils.Context(t)
+	key := make([]byte, 32)
+	_, bridge := cltest.MustCreateBridge(t, db, cltest.BridgeOpts{URL: server.URL})
+	require.NoError(t, bridges.NewORMWithSecretCipher(db, bridges.NewSecretCipher(func(context.Context) ([]byte, error) { return key, nil })).UpdateBridgeSigningSecret(ctx, bridge, "secret"))
+
+	// The key ring was replaced, so the secret can't be decrypted anymore
+	otherKey := make([]byte, 32)
+	otherKey[0] = 1
+	orm := bridges.NewORMWithSecretCipher(db, bridges.NewSecretCipher(func(context.Context) ([]byte, error) { return otherKey, nil }))
+
+	task := pipeline.BridgeTask{
+		Name:        bridge.Name.String(),
+		RequestData: ethUSDPairing,
+	}
+	c := clhttptest.NewTestLocalOnlyHTTPClient()
+	trORM := pipeline.NewORM(db, logger.TestLogger(t), cfg.JobPipeline().MaxSuccessfulRuns())
+	specID, err := trORM.CreateSpec(ctx, pipeline.Pipeline{}, *models.NewInterval(5 * time.Minute))
+	require.NoError(t, err)
+	task.HelperSetDependencies(cfg.JobPipeline(), cfg.WebServer(), orm, specID, uuid.UUID{}, c)
+
+	lggr, observed := logger.TestLoggerObserved(t, zapcore.ErrorLevel)
+	result, _ := task.Run(ctx, lggr, pipeline.NewVarsFrom(nil), nil)
+	require.ErrorIs(t, result.Error, bridges.ErrUndecryptableSigningSecret)
+	assert.False(t, called.Load(), "the request must not be sent unsigned")
+	assert.Equal(t, 1, observed.FilterMessageSnippet("signing secret can't be decrypted").Len())
+}
+
 func TestBridgeTask_Headers(t *testing.T) {
 	db := pgtest.NewSqlxDB(t)
 	cfg := configtest.NewTestGeneralConfig(t)
@@ -0,0 +1,263 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/chains/legacyevm"
+)
+
+const (
+	// defaultETHGetLogsMaxResults is the most logs an ethgetlogs task returns if maxResults is not set.
+	defaultETHGetLogsMaxResults = 1000
+	// defaultETHGetLogsMaxBlockRange is the most blocks an ethgetlogs task queries if maxBlockRange
+	// is not set.
+	defaultETHGetLogsMaxBlockRange = 10000
+)
+
+// ETHGetLogsTask queries the logs emitted by a contract in a block range, given either as
+// fromBlock and toBlock (block numbers or "latest"), or as the lastBlocks up to the latest
+// block. Topics are positional: each is a hash, a list of alternative hashes, or null to
+// match any topic. If an event abi is given, only its logs are returned, and they are decoded.
+// The block range is limited to maxBlockRange blocks, which is checked before the logs are
+// queried.
+//
+// Return types:
+//
+//	[]interface{} of map[string]interface{} with the address, topics, data, blockNumber,
+//	blockHash, transactionHash and logIndex of each log, and its decoded fields if an abi
+//	was given.
+type ETHGetLogsTask struct {
+	BaseTask      `mapstructure:",squash"`
+	Address       string `json:"address"`
+	Topics        string `json:"topics"`
+	ABI           string `json:"abi"`
+	FromBlock     string `json:"fromBlock"`
+	ToBlock       string `json:"toBlock"`
+	LastBlocks    string `json:"lastBlocks"`
+	MaxResults    string `json:"maxResults"`
+	MaxBlockRange string `json:"maxBlockRange"`
+	EVMChainID    string `json:"evmChainID" mapstructure:"evmChainID"`
+
+	legacyChains legacyevm.LegacyChainContainer
+}
+
+var _ Task = (*ETHGetLogsTask)(nil)
+
+func (t *ETHGetLogsTask) Type() TaskType {
+	return TaskTypeETHGetLogs
+}
+
+func (t *ETHGetLogsTask) getEvmChainID() string {
+	if t.EVMChainID == "" {
+		t.EVMChainID = "$(jobSpec.evmChainID)"
+	}
+	return t.EVMChainID
+}
+
+func (t *ETHGetLogsTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, -1, -1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var (
+		address    AddressParam
+		topics     SliceParam
+		theABI     StringParam
+		fromBlock  StringParam
+		toBlock    StringParam
+		lastBlocks MaybeUint64Param
+		maxResults MaybeUint64Param
+		maxRange   MaybeUint64Param
+		chainID    StringParam
+	)
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&address, From(VarExpr(t.Address, vars), NonemptyString(t.Address))), "address"),
+		errors.Wrap(ResolveParam(&topics, From(VarExpr(t.Topics, vars), JSONWithVarExprs(t.Topics, vars, false), nil)), "topics"),
+		errors.Wrap(ResolveParam(&theABI, From(t.ABI)), "abi"),
+		errors.Wrap(ResolveParam(&fromBlock, From(VarExpr(t.FromBlock, vars), t.FromBlock)), "fromBlock"),
+		errors.Wrap(ResolveParam(&toBlock, From(VarExpr(t.ToBlock, vars), t.ToBlock)), "toBlock"),
+		errors.Wrap(ResolveParam(&lastBlocks, From(VarExpr(t.LastBlocks, vars), t.LastBlocks)), "lastBlocks"),
+		errors.Wrap(ResolveParam(&maxResults, From(VarExpr(t.MaxResults, vars), t.MaxResults)), "maxResults"),
+		errors.Wrap(ResolveParam(&maxRange, From(VarExpr(t.MaxBlockRange, vars), t.MaxBlockRange)), "maxBlockRange"),
+		errors.Wrap(ResolveParam(&chainID, From(VarExpr(t.getEvmChainID(), vars), NonemptyString(t.getEvmChainID()), "")), "evmChainID"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	topicFilter, err := parseTopicFilter(topics)
+	if err != nil {
+		return Result{Error: errors.Wrapf(ErrBadInput, "topics: %v", err)}, runInfo
+	}
+	var event *abi.Event
+	if theABI != "" {
+		name, args, _, err2 := parseETHABIString([]byte(theABI), true)
+		if err2 != nil {
+			return Result{Error: errors.Wrap(ErrBadInput, err2.Error())}, runInfo
+		}
+		ev := abi.NewEvent(name, name, false, args)
+		event = &ev
+		// The first topic of a non-anonymous event is its ID
+		if len(topicFilter) == 0 {
+			topicFilter = [][]common.Hash{nil}
+		}
+		if len(topicFilter[0]) > 0 && (len(topicFilter[0]) != 1 || topicFilter[0][0] != event.ID) {
+			return Result{Error: errors.Wrapf(ErrBadInput, "first topic must be the event ID %s", event.ID)}, runInfo
+		}
+		topicFilter[0] = []common.Hash{event.ID}
+	}
+	limit, isSet := maxResults.Uint64()
+	if !isSet {
+		limit = defaultETHGetLogsMaxResults
+	}
+	rangeLimit, isSet := maxRange.Uint64()
+	if !isSet {
+		rangeLimit = defaultETHGetLogsMaxBlockRange
+	} else if rangeLimit == 0 {
+		return Result{Error: errors.Wrap(ErrBadInput, "maxBlockRange must be positive")}, runInfo
+	}
+
+	chain, err := t.legacyChains.Get(string(chainID))
+	if err != nil {
+		err = fmt.Errorf("%w: %s: %w", ErrInvalidEVMChainID, chainID, err)
+		return Result{Error: err}, runInfo
+	}
+	legacyChain, ok := chain.(legacyevm.Chain)
+	if !ok {
+		return Result{Error: ErrUnsupportedInLOOPPMode}, runInfo
+	}
+	client := legacyChain.Client()
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{common.Address(address)},
+		Topics:    topicFilter,
+	}
+	if n, isLast := lastBlocks.Uint64(); isLast {
+		if fromBlock != "" || toBlock != "" {
+			return Result{Error: errors.Wrap(ErrBadInput, "lastBlocks cannot be combined with fromBlock or toBlock")}, runInfo
+		} else if n == 0 {
+			return Result{Error: errors.Wrap(ErrBadInput, "lastBlocks must be positive")}, runInfo
+		} else if n > rangeLimit {
+			return Result{Error: errors.Wrapf(ErrBadInput, "lastBlocks %d exceeds maxBlockRange %d", n, rangeLimit)}, runInfo
+		}
+		latest, err2 := client.LatestBlockHeight(ctx)
+		if err2 != nil {
+			return Result{Error: errors.Wrap(err2, "failed to get latest block")}, retryableRunInfo()
+		}
+		query.ToBlock = latest
+		query.FromBlock = new(big.Int).Sub(latest, new(big.Int).SetUint64(n-1))
+		if query.FromBlock.Sign() < 0 {
+			query.FromBlock = big.NewInt(0)
+		}
+	} else {
+		if query.FromBlock, err = parseLogsBlock(string(fromBlock)); err != nil {
+			return Result{Error: errors.Wrapf(ErrBadInput, "fromBlock: %v", err)}, runInfo
+		}
+		if query.ToBlock, err = parseLogsBlock(string(toBlock)); err != nil {
+			return Result{Error: errors.Wrapf(ErrBadInput, "toBlock: %v", err)}, runInfo
+		}
+		if query.FromBlock == nil {
+			return Result{Error: errors.Wrap(ErrBadInput, "one of fromBlock or lastBlocks must be set")}, runInfo
+		}
+		if query.ToBlock == nil {
+			// Pin "latest", so that the range checked is the range queried
+			if query.ToBlock, err = client.LatestBlockHeight(ctx); err != nil {
+				return Result{Error: errors.Wrap(err, "failed to get latest block")}, retryableRunInfo()
+			}
+		}
+		if query.ToBlock.Cmp(query.FromBlock) < 0 {
+			return Result{Error: errors.Wrapf(ErrBadInput, "toBlock %s is before fromBlock %s", query.ToBlock, query.FromBlock)}, runInfo
+		}
+		blocks := new(big.Int).Sub(query.ToBlock, query.FromBlock)
+		if blocks.Cmp(new(big.Int).SetUint64(rangeLimit)) >= 0 {
+			return Result{Error: errors.Wrapf(ErrBadInput, "block range %s to %s exceeds maxBlockRange %d", query.FromBlock, query.ToBlock, rangeLimit)}, runInfo
+		}
+	}
+
+	logs, err := client.FilterLogs(ctx, query)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "failed to get logs")}, retryableRunInfo()
+	}
+	if uint64(len(logs)) > limit {
+		return Result{Error: errors.Wrapf(ErrBadInput, "got %d logs, more than maxResults %d", len(logs), limit)}, runInfo
+	}
+	lggr.Debugw("Got logs", "count", len(logs), "fromBlock", query.FromBlock, "toBlock", query.ToBlock)
+
+	out := make([]interface{}, 0, len(logs))
+	for _, log := range logs {
+		topics := make([]interface{}, len(log.Topics))
+		for i, topic := range log.Topics {
+			topics[i] = topic.Hex()
+		}
+		l := map[string]interface{}{
+			"address":         log.Address.Hex(),
+			"topics":          topics,
+			"data":            log.Data,
+			"blockNumber":     log.BlockNumber,
+			"blockHash":       log.BlockHash.Hex(),
+			"transactionHash": log.TxHash.Hex(),
+			"logIndex":        log.Index,
+		}
+		if event != nil {
+			decoded := make(map[string]interface{})
+			if err = decodeETHLog(decoded, event.Inputs, log.Data, log.Topics); err != nil {
+				return Result{Error: errors.Wrapf(ErrBadInput, "failed to decode log %d of tx %s: %v", log.Index, log.TxHash, err)}, runInfo
+			}
+			l["decoded"] = decoded
+		}
+		out = append(out, l)
+	}
+	return Result{Value: out}, runInfo
+}
+
+// parseTopicFilter parses positional topics, each of which is a hash, a list of hashes or null.
+func parseTopicFilter(topics SliceParam) ([][]common.Hash, error) {
+	filter := make([][]common.Hash, len(topics))
+	for i, topic := range topics {
+		switch v := topic.(type) {
+		case nil:
+		case string:
+			if v == "" {
+				continue
+			}
+			var h common.Hash
+			if err := h.UnmarshalText([]byte(v)); err != nil {
+				return nil, fmt.Errorf("topic %d: %w", i, err)
+			}
+			filter[i] = []common.Hash{h}
+		case common.Hash:
+			filter[i] = []common.Hash{v}
+		default:
+			var hashes HashSliceParam
+			if err := hashes.UnmarshalPipelineParam(v); err != nil {
+				return nil, fmt.Errorf("topic %d: %w", i, err)
+			}
+			filter[i] = hashes
+		}
+	}
+	return filter, nil
+}
+
+// parseLogsBlock parses a block number, or "latest" and empty as nil.
+func parseLogsBlock(s string) (*big.Int, error) {
+	if s == "" || strings.EqualFold(s, "latest") {
+		return nil, nil
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, errors.Errorf("invalid block number %q", s)
+	}
+	return n, nil
+}
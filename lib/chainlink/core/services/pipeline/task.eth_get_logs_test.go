@@ -0,0 +1,172 @@
+package pipeline_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/client/clienttest"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestETHGetLogsTask(t *testing.T) {
+	t.Parallel()
+
+	contract := common.HexToAddress("0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF")
+	bidder := common.HexToAddress("0x882969652440ccf14a5dbb9bd53eb21cb1e11e5c")
+	eventID := crypto.Keccak256Hash([]byte("Committed(address,uint256)"))
+	bidderTopic := common.BytesToHash(bidder.Bytes())
+	log := types.Log{
+		Address:     contract,
+		Topics:      []common.Hash{eventID, bidderTopic},
+		Data:        common.LeftPadBytes(big.NewInt(42).Bytes(), 32),
+		BlockNumber: 95,
+		BlockHash:   common.HexToHash("0x1"),
+		TxHash:      common.HexToHash("0x2"),
+		Index:       3,
+	}
+	const abi = "Committed(address indexed bidder, uint256 amount)"
+
+	tests := []struct {
+		name             string
+		task             pipeline.ETHGetLogsTask
+		setupClientMocks func(ethClient *clienttest.Client)
+		expectedErrCause error
+		expectedDecoded  map[string]interface{}
+	}{
+		{
+			"block range with abi",
+			pipeline.ETHGetLogsTask{Address: contract.Hex(), ABI: abi, FromBlock: "90", ToBlock: "latest", Topics: `[null, "` + bidderTopic.Hex() + `"]`},
+			func(ethClient *clienttest.Client) {
+				ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil)
+				ethClient.On("FilterLogs", mock.Anything, ethereum.FilterQuery{
+					Addresses: []common.Address{contract},
+					FromBlock: big.NewInt(90),
+					ToBlock:   big.NewInt(100),
+					Topics:    [][]common.Hash{{eventID}, {bidderTopic}},
+				}).Return([]types.Log{log}, nil)
+			},
+			nil,
+			map[string]interface{}{"bidder": bidder, "amount": big.NewInt(42)},
+		},
+		{
+			"last blocks",
+			pipeline.ETHGetLogsTask{Address: contract.Hex(), LastBlocks: "10"},
+			func(ethClient *clienttest.Client) {
+				ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil)
+				ethClient.On("FilterLogs", mock.Anything, ethereum.FilterQuery{
+					Addresses: []common.Address{contract},
+					FromBlock: big.NewInt(91),
+					ToBlock:   big.NewInt(100),
+					Topics:    [][]common.Hash{},
+				}).Return([]types.Log{log}, nil)
+			},
+			nil,
+			nil,
+		},
+		{
+			"too many logs",
+			pipeline.ETHGetLogsTask{Address: contract.Hex(), FromBlock: "0", MaxResults: "1"},
+			func(ethClient *clienttest.Client) {
+				ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil)
+				ethClient.On("FilterLogs", mock.Anything, mock.Anything).Return([]types.Log{log, log}, nil)
+			},
+			pipeline.ErrBadInput,
+			nil,
+		},
+		{
+			"topic does not match abi",
+			pipeline.ETHGetLogsTask{Address: contract.Hex(), ABI: abi, FromBlock: "0", Topics: `["` + bidderTopic.Hex() + `"]`},
+			func(ethClient *clienttest.Client) {},
+			pipeline.ErrBadInput,
+			nil,
+		},
+		{
+			"last blocks and block range",
+			pipeline.ETHGetLogsTask{Address: contract.Hex(), FromBlock: "0", LastBlocks: "10"},
+			func(ethClient *clienttest.Client) {},
+			pipeline.ErrBadInput,
+			nil,
+		},
+		{
+			"block range too large",
+			pipeline.ETHGetLogsTask{Address: contract.Hex(), FromBlock: "0", MaxBlockRange: "100"},
+			func(ethClient *clienttest.Client) {
+				ethClient.On("LatestBlockHeight", mock.Anything).Return(big.NewInt(100), nil)
+			},
+			pipeline.ErrBadInput,
+			nil,
+		},
+		{
+			"last blocks too many",
+			pipeline.ETHGetLogsTask{Address: contract.Hex(), LastBlocks: "10001"},
+			func(ethClient *clienttest.Client) {},
+			pipeline.ErrBadInput,
+			nil,
+		},
+		{
+			"inverted block range",
+			pipeline.ETHGetLogsTask{Address: contract.Hex(), FromBlock: "100", ToBlock: "90"},
+			func(ethClient *clienttest.Client) {},
+			pipeline.ErrBadInput,
+			nil,
+		},
+		{
+			"no block range",
+			pipeline.ETHGetLogsTask{Address: contract.Hex()},
+			func(ethClient *clienttest.Client) {},
+			pipeline.ErrBadInput,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			ethClient := clienttest.NewClient(t)
+			test.setupClientMocks(ethClient)
+			cfg := configtest.NewGeneralConfig(t, nil)
+
+			task := test.task
+			task.BaseTask = pipeline.NewBaseTask(0, "ethgetlogs", nil, nil, 0)
+			task.EVMChainID = "0"
+			task.HelperSetDependencies(cltest.NewLegacyChainsWithMockChain(t, ethClient, cfg))
+
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+			assert.False(t, runInfo.IsPending)
+			if test.expectedErrCause != nil {
+				require.Error(t, result.Error)
+				assert.Equal(t, test.expectedErrCause, errors.Cause(result.Error))
+				return
+			}
+			require.NoError(t, result.Error)
+			logs := result.Value.([]interface{})
+			require.Len(t, logs, 1)
+			l := logs[0].(map[string]interface{})
+			assert.Equal(t, contract.Hex(), l["address"])
+			assert.Equal(t, uint64(95), l["blockNumber"])
+			assert.Equal(t, uint(3), l["logIndex"])
+			assert.Equal(t, []interface{}{eventID.Hex(), bidderTopic.Hex()}, l["topics"])
+			if test.expectedDecoded != nil {
+				assert.Equal(t, test.expectedDecoded, l["decoded"])
+			} else {
+				assert.NotContains(t, l, "decoded")
+			}
+		})
+	}
+}
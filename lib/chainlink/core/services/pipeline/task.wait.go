@@ -0,0 +1,181 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/chains/legacyevm"
+)
+
+// WaitTask suspends the run until a point in time, given as an RFC3339 timestamp or unix
+// seconds in `until`, or relative to when the task first ran in `duration`, or until the
+// chain given by `evmChainID` reaches block number `block`. Exactly one of `until`,
+// `duration` and `block` must be set.
+//
+// The wait is persisted, and the run is resumed once it is over, so waits survive node
+// restarts without holding on to a goroutine. This requires the run to be persisted before
+// it executes, so wait tasks fail in runs that are only executed in memory.
+//
+// Return types:
+//
+//	int64: the unix timestamp waited until, or the block number reached
+type WaitTask struct {
+	BaseTask   `mapstructure:",squash"`
+	Until      string `json:"until"`
+	Duration   string `json:"duration"`
+	Block      string `json:"block"`
+	EVMChainID string `json:"evmChainID" mapstructure:"evmChainID"`
+
+	orm          ORM
+	legacyChains legacyevm.LegacyChainContainer
+	waitCreated  func()
+	inMemoryRun  bool
+}
+
+var _ Task = (*WaitTask)(nil)
+
+// ErrWaitInMemoryRun is returned by wait tasks of runs that were not persisted before
+// executing, which cannot be suspended and resumed.
+var ErrWaitInMemoryRun = errors.New("wait task requires a persisted run, it cannot be used in runs that are only executed in memory")
+
+func (t *WaitTask) Type() TaskType {
+	return TaskTypeWait
+}
+
+func (t *WaitTask) getEvmChainID() string {
+	if t.EVMChainID == "" {
+		t.EVMChainID = "$(jobSpec.evmChainID)"
+	}
+	return t.EVMChainID
+}
+
+func (t *WaitTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, -1, -1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var (
+		until    StringParam
+		duration StringParam
+		block    MaybeUint64Param
+	)
+	err = multierr.Combine(
+		errors.Wrap(ResolveParam(&until, From(VarExpr(t.Until, vars), t.Until)), "until"),
+		errors.Wrap(ResolveParam(&duration, From(VarExpr(t.Duration, vars), t.Duration)), "duration"),
+		errors.Wrap(ResolveParam(&block, From(VarExpr(t.Block, vars), t.Block)), "block"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	wait := TaskRunWait{PipelineTaskRunID: t.uuid}
+	blockNumber, isBlock := block.Uint64()
+	set := 0
+	for _, isSet := range []bool{until != "", duration != "", isBlock} {
+		if isSet {
+			set++
+		}
+	}
+	switch {
+	case set > 1:
+		return Result{Error: errors.Wrapf(ErrBadInput, "only one of until, duration and block may be set")}, runInfo
+	case until != "":
+		var ts time.Time
+		ts, err = parseWaitUntil(string(until))
+		if err != nil {
+			return Result{Error: errors.Wrapf(ErrBadInput, "until: %v", err)}, runInfo
+		}
+		wait.Until = null.TimeFrom(ts)
+	case duration != "":
+		var d time.Duration
+		d, err = time.ParseDuration(string(duration))
+		if err != nil {
+			return Result{Error: errors.Wrapf(ErrBadInput, "duration: %v", err)}, runInfo
+		} else if d < 0 {
+			return Result{Error: errors.Wrapf(ErrBadInput, "duration must not be negative")}, runInfo
+		}
+		wait.Until = null.TimeFrom(time.Now().Add(d))
+	case isBlock:
+		var chainID StringParam
+		err = errors.Wrap(ResolveParam(&chainID, From(VarExpr(t.getEvmChainID(), vars), NonemptyString(t.getEvmChainID()), "")), "evmChainID")
+		if err != nil {
+			return Result{Error: err}, runInfo
+		}
+		if _, err = latestBlockNumber(t.legacyChains, string(chainID)); err != nil {
+			return Result{Error: err}, runInfo
+		}
+		wait.BlockNumber = null.IntFrom(int64(blockNumber))
+		wait.EVMChainID = null.StringFrom(string(chainID))
+	default:
+		return Result{Error: errors.Wrapf(ErrBadInput, "one of until, duration or block must be set")}, runInfo
+	}
+
+	if t.inMemoryRun {
+		return Result{Error: ErrWaitInMemoryRun}, runInfo
+	}
+	// If the run is resumed before the wait is over, the wait persisted first applies
+	wait, err = t.orm.CreateTaskRunWait(ctx, wait)
+	if err != nil {
+		return Result{Error: err}, retryableRunInfo()
+	}
+	value, over, err := checkTaskRunWait(wait, time.Now(), t.legacyChains)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if !over {
+		if t.waitCreated != nil {
+			t.waitCreated()
+		}
+		return Result{}, pendingRunInfo()
+	}
+	if err = t.orm.DeleteTaskRunWait(ctx, wait.PipelineTaskRunID); err != nil {
+		lggr.Warnw("Failed to delete wait of completed task run", "err", err)
+	}
+	return Result{Value: value}, runInfo
+}
+
+// parseWaitUntil parses an RFC3339 timestamp or unix seconds.
+func parseWaitUntil(s string) (time.Time, error) {
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func latestBlockNumber(legacyChains legacyevm.LegacyChainContainer, chainID string) (int64, error) {
+	chain, err := legacyChains.Get(chainID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", ErrInvalidEVMChainID, chainID, err)
+	}
+	legacyChain, ok := chain.(legacyevm.Chain)
+	if !ok {
+		return 0, ErrUnsupportedInLOOPPMode
+	}
+	head := legacyChain.HeadTracker().LatestChain()
+	if head == nil {
+		return 0, nil
+	}
+	return head.Number, nil
+}
+
+// checkTaskRunWait returns whether the wait is over by now, and if so the value the wait task
+// run resolves to.
+func checkTaskRunWait(wait TaskRunWait, now time.Time, legacyChains legacyevm.LegacyChainContainer) (value int64, over bool, err error) {
+	if wait.Until.Valid {
+		return wait.Until.Time.Unix(), !now.Before(wait.Until.Time), nil
+	}
+	latest, err := latestBlockNumber(legacyChains, wait.EVMChainID.ValueOrZero())
+	if err != nil {
+		return 0, false, err
+	}
+	return latest, latest >= wait.BlockNumber.Int64, nil
+}
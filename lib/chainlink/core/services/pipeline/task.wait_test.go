@@ -0,0 +1,110 @@
+package pipeline_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
+)
+
+func TestWaitTask(t *testing.T) {
+	t.Parallel()
+
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		until         string
+		duration      string
+		block         string
+		vars          pipeline.Vars
+		persisted     *pipeline.TaskRunWait
+		expected      interface{}
+		expectPending bool
+		expectedErr   error
+	}{
+		{"until in the past", past.Format(time.RFC3339), "", "", pipeline.NewVarsFrom(nil), nil, past.Unix(), false, nil},
+		{"until in the past (unix)", "$(foo)", "", "", pipeline.NewVarsFrom(map[string]interface{}{"foo": strconv.FormatInt(past.Unix(), 10)}), nil, past.Unix(), false, nil},
+		{"until in the future", future.Format(time.RFC3339), "", "", pipeline.NewVarsFrom(nil), nil, nil, true, nil},
+		{"zero duration", "", "0s", "", pipeline.NewVarsFrom(nil), nil, nil, false, nil},
+		{"duration", "", "1h", "", pipeline.NewVarsFrom(nil), nil, nil, true, nil},
+		{"duration already persisted", "", "1h", "", pipeline.NewVarsFrom(nil), &pipeline.TaskRunWait{Until: null.TimeFrom(past)}, past.Unix(), false, nil},
+		{"negative duration", "", "-1h", "", pipeline.NewVarsFrom(nil), nil, nil, false, pipeline.ErrBadInput},
+		{"bad until", "tomorrow", "", "", pipeline.NewVarsFrom(nil), nil, nil, false, pipeline.ErrBadInput},
+		{"nothing set", "", "", "", pipeline.NewVarsFrom(nil), nil, nil, false, pipeline.ErrBadInput},
+		{"several set", past.Format(time.RFC3339), "", "123", pipeline.NewVarsFrom(nil), nil, nil, false, pipeline.ErrBadInput},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			id := uuid.New()
+			orm := mocks.NewORM(t)
+			if test.expectedErr == nil {
+				orm.On("CreateTaskRunWait", mock.Anything, mock.MatchedBy(func(w pipeline.TaskRunWait) bool {
+					return w.PipelineTaskRunID == id && w.Until.Valid
+				})).Return(func(_ context.Context, w pipeline.TaskRunWait) (pipeline.TaskRunWait, error) {
+					if test.persisted != nil {
+						w.Until = test.persisted.Until
+					}
+					return w, nil
+				}).Once()
+				if !test.expectPending {
+					orm.On("DeleteTaskRunWait", mock.Anything, id).Return(nil).Once()
+				}
+			}
+
+			task := pipeline.WaitTask{
+				BaseTask: pipeline.NewBaseTask(0, "wait", nil, nil, 0),
+				Until:    test.until,
+				Duration: test.duration,
+				Block:    test.block,
+			}
+			task.HelperSetDependencies(orm, nil, id)
+
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), test.vars, nil)
+			if test.expectedErr != nil {
+				require.Error(t, result.Error)
+				assert.Equal(t, test.expectedErr, errors.Cause(result.Error))
+				return
+			}
+			require.NoError(t, result.Error)
+			assert.Equal(t, test.expectPending, runInfo.IsPending)
+			if test.expected != nil {
+				assert.Equal(t, test.expected, result.Value)
+			}
+		})
+	}
+}
+
+func TestWaitTask_InMemoryRun(t *testing.T) {
+	t.Parallel()
+
+	task := pipeline.WaitTask{
+		BaseTask: pipeline.NewBaseTask(0, "wait", nil, nil, 0),
+		Duration: "1h",
+	}
+	// The orm mock fails the test if the wait is persisted
+	task.HelperSetDependencies(mocks.NewORM(t), nil, uuid.New())
+	task.HelperSetInMemoryRun()
+
+	result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), nil)
+	require.ErrorIs(t, result.Error, pipeline.ErrWaitInMemoryRun)
+	assert.False(t, runInfo.IsRetryable)
+	assert.False(t, runInfo.IsPending)
+}
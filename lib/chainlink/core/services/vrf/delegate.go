@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	stderrors "errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -24,6 +25,7 @@ import (
 	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
 	"github.com/smartcontractkit/chainlink-evm/pkg/chains/legacyevm"
 	"github.com/smartcontractkit/chainlink-evm/pkg/log"
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
@@ -34,13 +36,16 @@ import (
 )
 
 type Delegate struct {
-	ds           sqlutil.DataSource
-	pr           pipeline.Runner
-	porm         pipeline.ORM
-	ks           keystore.Master
-	legacyChains legacyevm.LegacyChainContainer
-	lggr         logger.Logger
-	mailMon      *mailbox.Monitor
+	ds                     sqlutil.DataSource
+	dryRunners             *v2.DryRunners
+	pr                     pipeline.Runner
+	porm                   pipeline.ORM
+	ks                     keystore.Master
+	legacyChains           legacyevm.LegacyChainContainer
+	lggr                   logger.Logger
+	mailMon                *mailbox.Monitor
+	restrictedHTTPClient   *http.Client
+	unrestrictedHTTPClient *http.Client
 }
 
 func NewDelegate(
@@ -50,18 +55,27 @@ func NewDelegate(
 	porm pipeline.ORM,
 	legacyChains legacyevm.LegacyChainContainer,
 	lggr logger.Logger,
-	mailMon *mailbox.Monitor) *Delegate {
+	mailMon *mailbox.Monitor,
+	restrictedHTTPClient, unrestrictedHTTPClient *http.Client) *Delegate {
 	return &Delegate{
-		ds:           ds,
-		ks:           ks,
-		pr:           pr,
-		porm:         porm,
-		legacyChains: legacyChains,
-		lggr:         lggr.Named("VRF"),
-		mailMon:      mailMon,
+		ds:                     ds,
+		dryRunners:             v2.NewDryRunners(),
+		ks:                     ks,
+		pr:                     pr,
+		porm:                   porm,
+		legacyChains:           legacyChains,
+		lggr:                   lggr.Named("VRF"),
+		mailMon:                mailMon,
+		restrictedHTTPClient:   restrictedHTTPClient,
+		unrestrictedHTTPClient: unrestrictedHTTPClient,
 	}
 }
 
+// DryRunners returns the registry of running VRF v2plus listeners that can dry-run fulfillments.
+func (d *Delegate) DryRunners() *v2.DryRunners {
+	return d.dryRunners
+}
+
 func (d *Delegate) JobType() job.Type {
 	return job.VRF
 }
@@ -200,6 +214,11 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, jb job.Job) ([]job.Servi
 					// otherwise we will end up re-delivering logs that were already delivered.
 					vrfcommon.NewInflightCache(int(chain.Config().EVM().FinalityDepth())),
 					vrfcommon.NewLogDeduper(int(chain.Config().EVM().FinalityDepth())),
+					vrfcommon.NewLifecycleRecorder(vrfcommon.NewLifecycleORM(d.ds), lV2Plus, chain.ID(), jb.ID),
+					vrfcommon.NewKeyRotationORM(d.ds),
+					vrfcommon.NewLowBalanceNotifier(bridges.NewORM(d.ds), d.restrictedHTTPClient, d.unrestrictedHTTPClient),
+					vrfcommon.NewLowBalanceAlertORM(d.ds),
+					d.dryRunners,
 				),
 			}, nil
 		}
@@ -254,6 +273,11 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, jb job.Job) ([]job.Servi
 				// otherwise we will end up re-delivering logs that were already delivered.
 				vrfcommon.NewInflightCache(int(chain.Config().EVM().FinalityDepth())),
 				vrfcommon.NewLogDeduper(int(chain.Config().EVM().FinalityDepth())),
+				vrfcommon.NewLifecycleRecorder(vrfcommon.NewLifecycleORM(d.ds), lV2, chain.ID(), jb.ID),
+				vrfcommon.NewKeyRotationORM(d.ds),
+				vrfcommon.NewLowBalanceNotifier(bridges.NewORM(d.ds), d.restrictedHTTPClient, d.unrestrictedHTTPClient),
+				vrfcommon.NewLowBalanceAlertORM(d.ds),
+				d.dryRunners,
 			),
 			}, nil
 		}
@@ -34,6 +34,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
+	clhttptest "github.com/smartcontractkit/chainlink/v2/core/internal/testutils/httptest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
@@ -171,7 +172,9 @@ func setup(t *testing.T) (vrfUniverse, *v1.Listener, job.Job) {
 		vuni.prm,
 		vuni.legacyChains,
 		logger.TestLogger(t),
-		mailMon)
+		mailMon,
+		clhttptest.NewTestLocalOnlyHTTPClient(),
+		clhttptest.NewTestLocalOnlyHTTPClient())
 	vs := testspecs.GenerateVRFSpec(testspecs.VRFSpecParams{PublicKey: vuni.vrfkey.PublicKey.String(), EVMChainID: testutils.FixtureChainID.String()})
 	jb, err := vrfcommon.ValidatedVRFSpec(vs.Toml())
 	require.NoError(t, err)
@@ -700,7 +703,9 @@ func Test_VRFV2PlusServiceFailsWhenVRFOwnerProvided(t *testing.T) {
 		vuni.prm,
 		vuni.legacyChains,
 		logger.TestLogger(t),
-		mailMon)
+		mailMon,
+		clhttptest.NewTestLocalOnlyHTTPClient(),
+		clhttptest.NewTestLocalOnlyHTTPClient())
 	chainService, err := vuni.legacyChains.Get(testutils.FixtureChainID.String())
 	require.NoError(t, err)
 	chain, ok := chainService.(legacyevm.Chain)
@@ -0,0 +1,70 @@
+package proof
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Attestation is a statement by a node about the outcome of verifying a VRF
+// fulfillment.
+type Attestation struct {
+	Verification FulfillmentVerification `json:"verification"`
+	IssuedAt     time.Time               `json:"issuedAt"`
+}
+
+// SignedAttestation is an Attestation signed with an ed25519 key, meant to be
+// archived by auditors. Attestation holds the exact bytes which were signed, so
+// the signature can be checked without re-encoding them.
+type SignedAttestation struct {
+	Attestation json.RawMessage `json:"attestation"`
+	PublicKey   string          `json:"publicKey"`
+	Signature   string          `json:"signature"`
+}
+
+// NewSignedAttestation signs an attestation of v with signer, which must hold an
+// ed25519 key, e.g. the node's CSA key.
+func NewSignedAttestation(v FulfillmentVerification, signer crypto.Signer, issuedAt time.Time) (SignedAttestation, error) {
+	pub, ok := signer.Public().(ed25519.PublicKey)
+	if !ok {
+		return SignedAttestation{}, errors.Errorf("attestations must be signed with an ed25519 key, got %T", signer.Public())
+	}
+	msg, err := json.Marshal(Attestation{Verification: v, IssuedAt: issuedAt.UTC()})
+	if err != nil {
+		return SignedAttestation{}, errors.Wrap(err, "encoding attestation")
+	}
+	sig, err := signer.Sign(rand.Reader, msg, crypto.Hash(0))
+	if err != nil {
+		return SignedAttestation{}, errors.Wrap(err, "signing attestation")
+	}
+	return SignedAttestation{
+		Attestation: msg,
+		PublicKey:   hex.EncodeToString(pub),
+		Signature:   hex.EncodeToString(sig),
+	}, nil
+}
+
+// Verify checks the signature of a, and returns the attestation it signs.
+func (a SignedAttestation) Verify() (Attestation, error) {
+	pub, err := hex.DecodeString(a.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return Attestation{}, errors.Errorf("invalid public key %q", a.PublicKey)
+	}
+	sig, err := hex.DecodeString(a.Signature)
+	if err != nil {
+		return Attestation{}, errors.Wrap(err, "invalid signature")
+	}
+	if !ed25519.Verify(pub, a.Attestation, sig) {
+		return Attestation{}, errors.New("signature does not match attestation")
+	}
+	var att Attestation
+	if err := json.Unmarshal(a.Attestation, &att); err != nil {
+		return Attestation{}, errors.Wrap(err, "decoding attestation")
+	}
+	return att, nil
+}
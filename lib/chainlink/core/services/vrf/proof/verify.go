@@ -0,0 +1,446 @@
+package proof
+
+// Offline verification of on-chain VRF v2 and v2plus fulfillments. The checks
+// mirror those VRFCoordinatorV2, VRFCoordinatorV2_5 and VRF.sol perform on a
+// fulfillment, so a fulfilled request can be audited without trusting the
+// chain or the node which fulfilled it.
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"go.dedis.ch/kyber/v3"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/batch_vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/batch_vrf_coordinator_v2plus"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2plus_interface"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	bm "github.com/smartcontractkit/chainlink/v2/core/utils/big_math"
+)
+
+// Coordinator versions a fulfillment can be verified for.
+const (
+	CoordinatorV2     = "v2"
+	CoordinatorV2Plus = "v2plus"
+)
+
+// Names of the checks performed by VerifyFulfillment.
+const (
+	CheckPublicKey     = "public_key"
+	CheckRequestID     = "request_id"
+	CheckCommitment    = "commitment"
+	CheckWellFormed    = "well_formed"
+	CheckUWitness      = "u_witness"
+	CheckCGammaWitness = "c_gamma_witness"
+	CheckSHashWitness  = "s_hash_witness"
+	CheckZInv          = "z_inv"
+	CheckVRFProof      = "vrf_proof"
+)
+
+const fulfillRandomWords = "fulfillRandomWords"
+
+var fulfillmentMethods = []struct {
+	method  abi.Method
+	version string
+	batch   bool
+}{
+	{evmtypes.MustGetABI(vrf_coordinator_v2.VRFCoordinatorV2ABI).Methods[fulfillRandomWords], CoordinatorV2, false},
+	{evmtypes.MustGetABI(vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalABI).Methods[fulfillRandomWords], CoordinatorV2Plus, false},
+	{evmtypes.MustGetABI(batch_vrf_coordinator_v2.BatchVRFCoordinatorV2ABI).Methods[fulfillRandomWords], CoordinatorV2, true},
+	{evmtypes.MustGetABI(batch_vrf_coordinator_v2plus.BatchVRFCoordinatorV2PlusABI).Methods[fulfillRandomWords], CoordinatorV2Plus, true},
+}
+
+// RequestCommitment is the commitment a coordinator stores for a request, and
+// expects back with its fulfillment. ExtraArgs is only used by v2plus.
+type RequestCommitment struct {
+	BlockNum         uint64         `json:"blockNum"`
+	SubID            *ubig.Big      `json:"subID"`
+	CallbackGasLimit uint32         `json:"callbackGasLimit"`
+	NumWords         uint32         `json:"numWords"`
+	Sender           common.Address `json:"sender"`
+	ExtraArgs        hexutil.Bytes  `json:"extraArgs,omitempty"`
+}
+
+func (rc RequestCommitment) equal(o RequestCommitment) bool {
+	return rc.BlockNum == o.BlockNum &&
+		rc.SubID.ToInt().Cmp(o.SubID.ToInt()) == 0 &&
+		rc.CallbackGasLimit == o.CallbackGasLimit &&
+		rc.NumWords == o.NumWords &&
+		rc.Sender == o.Sender &&
+		bytes.Equal(rc.ExtraArgs, o.ExtraArgs)
+}
+
+// FulfillmentVerificationRequest holds what is needed to verify a fulfillment
+// independently of the chain.
+type FulfillmentVerificationRequest struct {
+	// PublicKey is the VRF key the request was made to.
+	PublicKey secp256k1.PublicKey
+	// RequestID selects the fulfillment to verify. It may be omitted unless the
+	// calldata is a batch fulfillment of several requests.
+	RequestID *big.Int
+	// BlockHash is the hash of the block the request was made in, mixed into the seed.
+	BlockHash common.Hash
+	// Commitment is the commitment of the request, as emitted when it was made.
+	Commitment RequestCommitment
+	// Calldata is the input of the fulfillment transaction, to the coordinator
+	// or the batch coordinator.
+	Calldata []byte
+}
+
+// VerificationCheck is the outcome of a single check on a fulfillment.
+type VerificationCheck struct {
+	Name   string `json:"name"`
+	Passed bool   `json:"passed"`
+	Error  string `json:"error,omitempty"`
+}
+
+// FulfillmentVerification is the outcome of verifying a fulfillment. Valid is
+// true iff all checks passed.
+type FulfillmentVerification struct {
+	CoordinatorVersion string              `json:"coordinatorVersion"`
+	BatchFulfillment   bool                `json:"batchFulfillment"`
+	PublicKey          secp256k1.PublicKey `json:"publicKey"`
+	KeyHash            common.Hash         `json:"keyHash"`
+	RequestID          *ubig.Big           `json:"requestID"`
+	PreSeed            *ubig.Big           `json:"preSeed"`
+	BlockHash          common.Hash         `json:"blockHash"`
+	Seed               *ubig.Big           `json:"seed"`
+	Commitment         RequestCommitment   `json:"commitment"`
+	CommitmentHash     common.Hash         `json:"commitmentHash"`
+	Output             *ubig.Big           `json:"output"`
+	RandomWords        []*ubig.Big         `json:"randomWords"`
+	Checks             []VerificationCheck `json:"checks"`
+	Valid              bool                `json:"valid"`
+}
+
+func (v *FulfillmentVerification) check(name string, err error) {
+	c := VerificationCheck{Name: name, Passed: err == nil}
+	if err != nil {
+		c.Error = err.Error()
+	}
+	v.Checks = append(v.Checks, c)
+}
+
+// onChainProof is the proof of a fulfillment, as passed to the coordinator.
+// The v2 and v2plus coordinators share this layout.
+type onChainProof struct {
+	Pk            [2]*big.Int
+	Gamma         [2]*big.Int
+	C             *big.Int
+	S             *big.Int
+	Seed          *big.Int
+	UWitness      common.Address
+	CGammaWitness [2]*big.Int
+	SHashWitness  [2]*big.Int
+	ZInv          *big.Int
+}
+
+type onChainFulfillment struct {
+	proof      onChainProof
+	commitment RequestCommitment
+}
+
+// decodeFulfillments decodes the proofs and commitments in the calldata of a
+// (batch) fulfillment transaction.
+func decodeFulfillments(calldata []byte) (version string, batch bool, fulfillments []onChainFulfillment, err error) {
+	if len(calldata) < 4 {
+		return "", false, nil, errors.New("calldata too short")
+	}
+	for _, m := range fulfillmentMethods {
+		if !bytes.Equal(calldata[:4], m.method.ID) {
+			continue
+		}
+		args, err := m.method.Inputs.Unpack(calldata[4:])
+		if err != nil {
+			return "", false, nil, errors.Wrap(err, "unpacking fulfillment calldata")
+		}
+		var proofs []onChainProof
+		var commitments []RequestCommitment
+		if m.batch {
+			proofs = *abi.ConvertType(args[0], new([]onChainProof)).(*[]onChainProof)
+		} else {
+			proofs = []onChainProof{*abi.ConvertType(args[0], new(onChainProof)).(*onChainProof)}
+		}
+		switch {
+		case m.version == CoordinatorV2 && m.batch:
+			for _, rc := range *abi.ConvertType(args[1], new([]vrf_coordinator_v2.VRFCoordinatorV2RequestCommitment)).(*[]vrf_coordinator_v2.VRFCoordinatorV2RequestCommitment) {
+				commitments = append(commitments, fromV2Commitment(rc))
+			}
+		case m.version == CoordinatorV2:
+			commitments = append(commitments, fromV2Commitment(*abi.ConvertType(args[1], new(vrf_coordinator_v2.VRFCoordinatorV2RequestCommitment)).(*vrf_coordinator_v2.VRFCoordinatorV2RequestCommitment)))
+		case m.batch:
+			for _, rc := range *abi.ConvertType(args[1], new([]vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalRequestCommitment)).(*[]vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalRequestCommitment) {
+				commitments = append(commitments, fromV2PlusCommitment(rc))
+			}
+		default:
+			commitments = append(commitments, fromV2PlusCommitment(*abi.ConvertType(args[1], new(vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalRequestCommitment)).(*vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalRequestCommitment)))
+		}
+		if len(proofs) != len(commitments) {
+			return "", false, nil, errors.Errorf("calldata has %d proofs but %d commitments", len(proofs), len(commitments))
+		}
+		for i := range proofs {
+			fulfillments = append(fulfillments, onChainFulfillment{proof: proofs[i], commitment: commitments[i]})
+		}
+		return m.version, m.batch, fulfillments, nil
+	}
+	return "", false, nil, errors.Errorf("calldata is not a VRF v2 or v2plus fulfillment: unknown method 0x%x", calldata[:4])
+}
+
+func fromV2Commitment(rc vrf_coordinator_v2.VRFCoordinatorV2RequestCommitment) RequestCommitment {
+	return RequestCommitment{
+		BlockNum:         rc.BlockNum,
+		SubID:            ubig.New(new(big.Int).SetUint64(rc.SubId)),
+		CallbackGasLimit: rc.CallbackGasLimit,
+		NumWords:         rc.NumWords,
+		Sender:           rc.Sender,
+	}
+}
+
+func fromV2PlusCommitment(rc vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalRequestCommitment) RequestCommitment {
+	return RequestCommitment{
+		BlockNum:         rc.BlockNum,
+		SubID:            ubig.New(rc.SubId),
+		CallbackGasLimit: rc.CallbackGasLimit,
+		NumWords:         rc.NumWords,
+		Sender:           rc.Sender,
+		ExtraArgs:        rc.ExtraArgs,
+	}
+}
+
+// RequestID returns the ID the coordinators assign to a request with the given
+// key hash and pre-seed.
+func RequestID(keyHash common.Hash, preSeed *big.Int) *big.Int {
+	return new(big.Int).SetBytes(utils.MustHash(string(append(keyHash.Bytes(), common.BigToHash(preSeed).Bytes()...))).Bytes())
+}
+
+// CommitmentHash returns the hash of the request commitment the coordinator stores
+// for requestID.
+func CommitmentHash(version string, requestID *big.Int, rc RequestCommitment) (common.Hash, error) {
+	var (
+		encoded []byte
+		err     error
+	)
+	switch version {
+	case CoordinatorV2:
+		if !rc.SubID.ToInt().IsUint64() {
+			return common.Hash{}, errors.Errorf("subscription ID %s out of range for VRF v2", rc.SubID)
+		}
+		encoded, err = utils.ABIEncode(`[{"type":"uint256"},{"type":"uint64"},{"type":"uint64"},{"type":"uint32"},{"type":"uint32"},{"type":"address"}]`,
+			requestID, rc.BlockNum, rc.SubID.ToInt().Uint64(), rc.CallbackGasLimit, rc.NumWords, rc.Sender)
+	case CoordinatorV2Plus:
+		encoded, err = utils.ABIEncode(`[{"type":"uint256"},{"type":"uint256"},{"type":"uint256"},{"type":"uint32"},{"type":"uint32"},{"type":"address"},{"type":"bytes"}]`,
+			requestID, new(big.Int).SetUint64(rc.BlockNum), rc.SubID.ToInt(), rc.CallbackGasLimit, rc.NumWords, rc.Sender, []byte(rc.ExtraArgs))
+	default:
+		return common.Hash{}, errors.Errorf("unknown coordinator version %q", version)
+	}
+	if err != nil {
+		return common.Hash{}, errors.Wrap(err, "encoding request commitment")
+	}
+	return utils.MustHash(string(encoded)), nil
+}
+
+// Output returns the VRF output for the given gamma of a proof.
+func Output(gamma kyber.Point) *big.Int {
+	return utils.MustHash(string(vrfkey.RandomOutputHashPrefix) + string(secp256k1.LongMarshal(gamma))).Big()
+}
+
+// RandomWordsFromGamma returns the words the coordinators hand to the consumer
+// for a proof with the given gamma coordinates.
+func RandomWordsFromGamma(gamma [2]*big.Int, numWords uint32) ([]*big.Int, error) {
+	g, err := pointFromCoordinates(gamma)
+	if err != nil {
+		return nil, errors.Wrap(err, "gamma")
+	}
+	return RandomWords(Output(g), numWords), nil
+}
+
+// RandomWords returns the words the coordinators derive from the VRF output.
+func RandomWords(output *big.Int, numWords uint32) []*big.Int {
+	words := make([]*big.Int, numWords)
+	for i := range words {
+		words[i] = utils.MustHash(string(append(common.BigToHash(output).Bytes(), common.BigToHash(big.NewInt(int64(i))).Bytes()...))).Big()
+	}
+	return words
+}
+
+// VerifyFulfillment decodes the proof of a fulfillment from its calldata and
+// checks it against the given public key, block hash and request commitment, the
+// same way the coordinator and VRF.sol do. Failed checks are reported in the
+// returned verification. An error is only returned if the request can't be
+// checked at all, e.g. because the calldata can't be decoded.
+func VerifyFulfillment(req FulfillmentVerificationRequest) (FulfillmentVerification, error) {
+	version, batch, fulfillments, err := decodeFulfillments(req.Calldata)
+	if err != nil {
+		return FulfillmentVerification{}, err
+	}
+	if req.Commitment.SubID == nil {
+		return FulfillmentVerification{}, errors.New("request commitment is missing the subscription ID")
+	}
+	publicKey, err := req.PublicKey.Point()
+	if err != nil {
+		return FulfillmentVerification{}, errors.Wrap(err, "invalid public key")
+	}
+	keyHash, err := req.PublicKey.Hash()
+	if err != nil {
+		return FulfillmentVerification{}, errors.Wrap(err, "invalid public key")
+	}
+
+	f, err := selectFulfillment(fulfillments, req.RequestID)
+	if err != nil {
+		return FulfillmentVerification{}, err
+	}
+	p := f.proof
+
+	requestID := RequestID(keyHash, p.Seed)
+	seed := FinalSeed(PreSeedData{PreSeed: Seed(common.BigToHash(p.Seed)), BlockHash: req.BlockHash})
+	commitmentHash, err := CommitmentHash(version, requestID, req.Commitment)
+	if err != nil {
+		return FulfillmentVerification{}, err
+	}
+
+	v := FulfillmentVerification{
+		CoordinatorVersion: version,
+		BatchFulfillment:   batch,
+		PublicKey:          req.PublicKey,
+		KeyHash:            keyHash,
+		RequestID:          ubig.New(requestID),
+		PreSeed:            ubig.New(p.Seed),
+		BlockHash:          req.BlockHash,
+		Seed:               ubig.New(seed),
+		Commitment:         req.Commitment,
+		CommitmentHash:     commitmentHash,
+		RandomWords:        []*ubig.Big{},
+	}
+
+	proofKey, err := pointFromCoordinates(p.Pk)
+	if err == nil && !proofKey.Equal(publicKey) {
+		err = errors.Errorf("proof is for key %s", secp256k1.LongMarshal(proofKey))
+	}
+	v.check(CheckPublicKey, err)
+
+	if req.RequestID != nil && req.RequestID.Cmp(requestID) != 0 {
+		v.check(CheckRequestID, errors.Errorf("proof is for request %s", requestID))
+	} else {
+		v.check(CheckRequestID, nil)
+	}
+
+	if !f.commitment.equal(req.Commitment) {
+		v.check(CheckCommitment, errors.Errorf("fulfillment commitment %+v differs from the request's", f.commitment))
+	} else {
+		v.check(CheckCommitment, nil)
+	}
+
+	gamma, cGammaWitness, sHashWitness, err := wellFormed(p)
+	v.check(CheckWellFormed, err)
+	if err != nil {
+		return v, nil
+	}
+	v.Output = ubig.New(Output(gamma))
+	for _, w := range RandomWords(v.Output.ToInt(), req.Commitment.NumWords) {
+		v.RandomWords = append(v.RandomWords, ubig.New(w))
+	}
+
+	c, s := secp256k1.IntToScalar(p.C), secp256k1.IntToScalar(p.S)
+	u := point().Add(point().Mul(c, publicKey), point().Mul(s, vrfkey.Generator))
+	if secp256k1.EthereumAddress(u) != p.UWitness {
+		v.check(CheckUWitness, errors.New("uWitness is not the address of c*pk+s*g"))
+	} else {
+		v.check(CheckUWitness, nil)
+	}
+
+	if !point().Mul(c, gamma).Equal(cGammaWitness) {
+		v.check(CheckCGammaWitness, errors.New("cGammaWitness is not c*gamma"))
+	} else {
+		v.check(CheckCGammaWitness, nil)
+	}
+
+	hash, err := vrfkey.HashToCurve(publicKey, seed, func(*big.Int) {})
+	if err == nil && !point().Mul(s, hash).Equal(sHashWitness) {
+		err = errors.New("sHashWitness is not s*hashToCurve(pk, seed)")
+	}
+	v.check(CheckSHashWitness, err)
+
+	_, _, z := vrfkey.ProjectiveECAdd(cGammaWitness, sHashWitness)
+	if bm.Mod(bm.Mul(z, p.ZInv), vrfkey.FieldSize).Cmp(bm.One) != 0 {
+		v.check(CheckZInv, errors.New("zInv is not the inverse of the z coordinate of cGammaWitness+sHashWitness"))
+	} else {
+		v.check(CheckZInv, nil)
+	}
+
+	goProof := vrfkey.Proof{PublicKey: publicKey, Gamma: gamma, C: p.C, S: p.S, Seed: seed, Output: v.Output.ToInt()}
+	ok, err := goProof.VerifyVRFProof()
+	if err == nil && !ok {
+		err = errors.New("proof does not verify")
+	}
+	v.check(CheckVRFProof, err)
+
+	v.Valid = true
+	for _, c := range v.Checks {
+		v.Valid = v.Valid && c.Passed
+	}
+	return v, nil
+}
+
+// selectFulfillment returns the fulfillment of requestID, or the only fulfillment if
+// requestID is nil.
+func selectFulfillment(fulfillments []onChainFulfillment, requestID *big.Int) (onChainFulfillment, error) {
+	if requestID == nil {
+		if len(fulfillments) != 1 {
+			return onChainFulfillment{}, errors.Errorf("calldata fulfills %d requests, a request ID is required", len(fulfillments))
+		}
+		return fulfillments[0], nil
+	}
+	if len(fulfillments) == 1 {
+		// Checked against the proof's request ID later on.
+		return fulfillments[0], nil
+	}
+	for _, f := range fulfillments {
+		pk, err := pointFromCoordinates(f.proof.Pk)
+		if err != nil {
+			continue
+		}
+		keyHash := utils.MustHash(string(secp256k1.LongMarshal(pk)))
+		if RequestID(keyHash, f.proof.Seed).Cmp(requestID) == 0 {
+			return f, nil
+		}
+	}
+	return onChainFulfillment{}, errors.Errorf("calldata does not fulfill request %s", requestID)
+}
+
+// wellFormed checks the proof points are on the curve and its scalars in range,
+// as VRF.sol does.
+func wellFormed(p onChainProof) (gamma, cGammaWitness, sHashWitness kyber.Point, err error) {
+	if gamma, err = pointFromCoordinates(p.Gamma); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "gamma")
+	}
+	if cGammaWitness, err = pointFromCoordinates(p.CGammaWitness); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "cGammaWitness")
+	}
+	if sHashWitness, err = pointFromCoordinates(p.SHashWitness); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "sHashWitness")
+	}
+	if !secp256k1.RepresentsScalar(p.C) || !secp256k1.RepresentsScalar(p.S) {
+		return nil, nil, nil, errors.New("c or s is not a valid scalar")
+	}
+	if p.ZInv == nil || p.ZInv.Cmp(vrfkey.FieldSize) >= 0 {
+		return nil, nil, nil, errors.New("zInv is not a field element")
+	}
+	return gamma, cGammaWitness, sHashWitness, nil
+}
+
+func pointFromCoordinates(c [2]*big.Int) (kyber.Point, error) {
+	if c[0] == nil || c[1] == nil || c[0].BitLen() > 256 || c[1].BitLen() > 256 {
+		return nil, fmt.Errorf("invalid coordinates %v", c)
+	}
+	return secp256k1.LongUnmarshal(append(common.BigToHash(c[0]).Bytes(), common.BigToHash(c[1]).Bytes()...))
+}
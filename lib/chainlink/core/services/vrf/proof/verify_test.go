@@ -0,0 +1,227 @@
+package proof_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/batch_vrf_coordinator_v2plus"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2plus_interface"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/csakey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	proof2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+)
+
+var (
+	coordinatorV2ABI          = evmtypes.MustGetABI(vrf_coordinator_v2.VRFCoordinatorV2ABI)
+	coordinatorV2PlusABI      = evmtypes.MustGetABI(vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalABI)
+	batchCoordinatorV2PlusABI = evmtypes.MustGetABI(batch_vrf_coordinator_v2plus.BatchVRFCoordinatorV2PlusABI)
+)
+
+func v2PlusFulfillment(t *testing.T, key vrfkey.KeyV2, preSeed int64, blockHash common.Hash) (
+	vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalProof,
+	vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalRequestCommitment,
+) {
+	seed, err := proof2.BigToSeed(big.NewInt(preSeed))
+	require.NoError(t, err)
+	s := proof2.PreSeedDataV2Plus{
+		PreSeed:          seed,
+		BlockHash:        blockHash,
+		BlockNum:         10,
+		SubId:            big.NewInt(123),
+		CallbackGasLimit: 100_000,
+		NumWords:         3,
+		Sender:           common.HexToAddress("0xabc"),
+		ExtraArgs:        []byte{1, 2, 3},
+	}
+	p, err := key.GenerateProof(proof2.FinalSeedV2Plus(s))
+	require.NoError(t, err)
+	onChainProof, rc, err := proof2.GenerateProofResponseFromProofV2Plus(p, s)
+	require.NoError(t, err)
+	return onChainProof, rc
+}
+
+func v2PlusCommitment(rc vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalRequestCommitment) proof2.RequestCommitment {
+	return proof2.RequestCommitment{
+		BlockNum:         rc.BlockNum,
+		SubID:            ubig.New(rc.SubId),
+		CallbackGasLimit: rc.CallbackGasLimit,
+		NumWords:         rc.NumWords,
+		Sender:           rc.Sender,
+		ExtraArgs:        rc.ExtraArgs,
+	}
+}
+
+func assertFailedChecks(t *testing.T, v proof2.FulfillmentVerification, failed ...string) {
+	t.Helper()
+	var actual []string
+	for _, c := range v.Checks {
+		if !c.Passed {
+			actual = append(actual, c.Name)
+		}
+	}
+	assert.Equal(t, failed, actual)
+	assert.Equal(t, len(failed) == 0, v.Valid)
+}
+
+func TestVerifyFulfillment_V2Plus(t *testing.T) {
+	key := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1))
+	blockHash := common.HexToHash("0x1234")
+	p, rc := v2PlusFulfillment(t, key, 1, blockHash)
+	calldata, err := coordinatorV2PlusABI.Pack("fulfillRandomWords", p, rc, false)
+	require.NoError(t, err)
+
+	req := proof2.FulfillmentVerificationRequest{
+		PublicKey:  key.PublicKey,
+		BlockHash:  blockHash,
+		Commitment: v2PlusCommitment(rc),
+		Calldata:   calldata,
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		v, err := proof2.VerifyFulfillment(req)
+		require.NoError(t, err)
+		assertFailedChecks(t, v)
+		assert.Equal(t, proof2.CoordinatorV2Plus, v.CoordinatorVersion)
+		assert.False(t, v.BatchFulfillment)
+		assert.Equal(t, proof2.RequestID(key.PublicKey.MustHash(), big.NewInt(1)), v.RequestID.ToInt())
+		require.Len(t, v.RandomWords, 3)
+		assert.Equal(t, proof2.RandomWords(v.Output.ToInt(), 3)[2], v.RandomWords[2].ToInt())
+	})
+
+	t.Run("random words from gamma", func(t *testing.T) {
+		v, err := proof2.VerifyFulfillment(req)
+		require.NoError(t, err)
+		words, err := proof2.RandomWordsFromGamma(p.Gamma, 3)
+		require.NoError(t, err)
+		require.Len(t, words, 3)
+		for i, w := range words {
+			assert.Equal(t, v.RandomWords[i].ToInt(), w)
+		}
+
+		_, err = proof2.RandomWordsFromGamma([2]*big.Int{big.NewInt(1), big.NewInt(2)}, 3)
+		require.ErrorContains(t, err, "gamma")
+	})
+
+	t.Run("wrong block hash", func(t *testing.T) {
+		r := req
+		r.BlockHash = common.HexToHash("0x5678")
+		v, err := proof2.VerifyFulfillment(r)
+		require.NoError(t, err)
+		assertFailedChecks(t, v, proof2.CheckSHashWitness, proof2.CheckVRFProof)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		r := req
+		r.PublicKey = vrfkey.MustNewV2XXXTestingOnly(big.NewInt(2)).PublicKey
+		v, err := proof2.VerifyFulfillment(r)
+		require.NoError(t, err)
+		assert.False(t, v.Valid)
+		assert.False(t, v.Checks[0].Passed)
+	})
+
+	t.Run("wrong commitment", func(t *testing.T) {
+		r := req
+		r.Commitment.NumWords = 4
+		v, err := proof2.VerifyFulfillment(r)
+		require.NoError(t, err)
+		assertFailedChecks(t, v, proof2.CheckCommitment)
+	})
+
+	t.Run("wrong request ID", func(t *testing.T) {
+		r := req
+		r.RequestID = big.NewInt(42)
+		v, err := proof2.VerifyFulfillment(r)
+		require.NoError(t, err)
+		assertFailedChecks(t, v, proof2.CheckRequestID)
+	})
+
+	t.Run("tampered proof", func(t *testing.T) {
+		tampered := p
+		tampered.ZInv = new(big.Int).Add(p.ZInv, big.NewInt(1))
+		calldata, err := coordinatorV2PlusABI.Pack("fulfillRandomWords", tampered, rc, false)
+		require.NoError(t, err)
+		r := req
+		r.Calldata = calldata
+		v, err := proof2.VerifyFulfillment(r)
+		require.NoError(t, err)
+		assertFailedChecks(t, v, proof2.CheckZInv)
+	})
+
+	t.Run("unknown calldata", func(t *testing.T) {
+		r := req
+		r.Calldata, err = coordinatorV2ABI.Pack("getConfig")
+		require.NoError(t, err)
+		_, err := proof2.VerifyFulfillment(r)
+		require.ErrorContains(t, err, "unknown method")
+	})
+}
+
+func TestVerifyFulfillment_Batch(t *testing.T) {
+	key := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1))
+	blockHash := common.HexToHash("0x1234")
+	p1, rc1 := v2PlusFulfillment(t, key, 1, blockHash)
+	p2, rc2 := v2PlusFulfillment(t, key, 2, blockHash)
+	calldata, err := batchCoordinatorV2PlusABI.Pack("fulfillRandomWords",
+		[]vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalProof{p1, p2},
+		[]vrf_coordinator_v2plus_interface.IVRFCoordinatorV2PlusInternalRequestCommitment{rc1, rc2})
+	require.NoError(t, err)
+
+	req := proof2.FulfillmentVerificationRequest{
+		PublicKey:  key.PublicKey,
+		BlockHash:  blockHash,
+		Commitment: v2PlusCommitment(rc2),
+		Calldata:   calldata,
+	}
+	_, err = proof2.VerifyFulfillment(req)
+	require.ErrorContains(t, err, "a request ID is required")
+
+	req.RequestID = proof2.RequestID(key.PublicKey.MustHash(), big.NewInt(2))
+	v, err := proof2.VerifyFulfillment(req)
+	require.NoError(t, err)
+	assertFailedChecks(t, v)
+	assert.True(t, v.BatchFulfillment)
+	assert.Equal(t, req.RequestID, v.RequestID.ToInt())
+
+	req.RequestID = big.NewInt(42)
+	_, err = proof2.VerifyFulfillment(req)
+	require.ErrorContains(t, err, "does not fulfill request 42")
+}
+
+func TestSignedAttestation(t *testing.T) {
+	key := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1))
+	blockHash := common.HexToHash("0x1234")
+	p, rc := v2PlusFulfillment(t, key, 1, blockHash)
+	calldata, err := coordinatorV2PlusABI.Pack("fulfillRandomWords", p, rc, false)
+	require.NoError(t, err)
+	v, err := proof2.VerifyFulfillment(proof2.FulfillmentVerificationRequest{
+		PublicKey:  key.PublicKey,
+		BlockHash:  blockHash,
+		Commitment: v2PlusCommitment(rc),
+		Calldata:   calldata,
+	})
+	require.NoError(t, err)
+
+	signer := csakey.MustNewV2XXXTestingOnly(big.NewInt(1))
+	issuedAt := time.Unix(1700000000, 0)
+	signed, err := proof2.NewSignedAttestation(v, signer, issuedAt)
+	require.NoError(t, err)
+	assert.Equal(t, signer.PublicKeyString(), signed.PublicKey)
+
+	att, err := signed.Verify()
+	require.NoError(t, err)
+	assert.True(t, att.IssuedAt.Equal(issuedAt))
+	assert.Equal(t, v.RequestID.String(), att.Verification.RequestID.String())
+	assert.True(t, att.Verification.Valid)
+
+	signed.Attestation = []byte(string(signed.Attestation[:len(signed.Attestation)-1]) + ` `)
+	_, err = signed.Verify()
+	require.ErrorContains(t, err, "signature does not match")
+}
@@ -0,0 +1,288 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	evmclient "github.com/smartcontractkit/chainlink-evm/pkg/client"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+
+	prooflib "github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+var (
+	// ErrDryRunnerNotFound is returned when no running VRF v2plus job can fulfill the given request.
+	ErrDryRunnerNotFound = errors.New("no running VRF v2plus job found for coordinator")
+	// ErrDryRunLogNotFound is returned when the given transaction has no matching request log.
+	ErrDryRunLogNotFound = errors.New("request log not found")
+
+	// vrfConsumerABI holds the callback every VRF v2plus consumer implements.
+	vrfConsumerABI = evmtypes.MustGetABI(`[{"inputs":[{"internalType":"uint256","name":"requestId","type":"uint256"},{"internalType":"uint256[]","name":"randomWords","type":"uint256[]"}],"name":"rawFulfillRandomWords","outputs":[],"stateMutability":"nonpayable","type":"function"}]`)
+)
+
+// DryRunRequest identifies the request log to dry-run the fulfillment of.
+type DryRunRequest struct {
+	// EVMChainID is optional if the coordinator is only served on a single chain.
+	EVMChainID  *big.Int
+	Coordinator common.Address
+	TxHash      common.Hash
+	LogIndex    uint
+	// BlockNumber is the block the fulfillment and callback are simulated at. Latest if nil.
+	BlockNumber *big.Int
+}
+
+// DryRunResult is the outcome of a dry-run fulfillment.
+type DryRunResult struct {
+	JobID     int32
+	RequestID *big.Int
+	SubID     *big.Int
+	Sender    common.Address
+	// Payload is the encoded fulfillRandomWords call, empty if no proof could be generated.
+	Payload string
+	Proof   *VRFProof
+	// RandomWords are the words the consumer callback would receive.
+	RandomWords []*big.Int
+	// EstimatedFee is the fee estimated by estimateFee, in juels or wei.
+	EstimatedFee *big.Int
+	// MaxFee is the fee charged at the gas lane max gas price, as computed by the pipeline.
+	MaxFee   *big.Int
+	GasLimit uint64
+	// SimulationError is set if the fulfillment pipeline failed.
+	SimulationError string
+	// FulfillmentRevertReason is set if the fulfillRandomWords call itself reverts.
+	FulfillmentRevertReason string
+	// CallbackRevertReason is set if the consumer's rawFulfillRandomWords callback reverts.
+	CallbackRevertReason string
+}
+
+// FulfillmentDryRunner simulates VRF v2plus fulfillments without broadcasting anything.
+type FulfillmentDryRunner interface {
+	DryRunFulfillment(ctx context.Context, req DryRunRequest) (DryRunResult, error)
+}
+
+type dryRunKey struct {
+	chainID     string
+	coordinator common.Address
+}
+
+// DryRunners tracks the running VRF v2plus listeners, so that fulfillments can be
+// dry-run through the same pipeline the listener of the request's job uses.
+type DryRunners struct {
+	mu        sync.RWMutex
+	listeners map[dryRunKey][]*listenerV2
+}
+
+var _ FulfillmentDryRunner = (*DryRunners)(nil)
+
+func NewDryRunners() *DryRunners {
+	return &DryRunners{listeners: make(map[dryRunKey][]*listenerV2)}
+}
+
+func (d *DryRunners) register(lsn *listenerV2) {
+	if d == nil || lsn.coordinator.Version() != vrfcommon.V2Plus {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := dryRunKey{chainID: lsn.chainID.String(), coordinator: lsn.coordinator.Address()}
+	d.listeners[key] = append(d.listeners[key], lsn)
+}
+
+func (d *DryRunners) unregister(lsn *listenerV2) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	key := dryRunKey{chainID: lsn.chainID.String(), coordinator: lsn.coordinator.Address()}
+	ls := d.listeners[key]
+	for i, l := range ls {
+		if l == lsn {
+			ls = append(ls[:i], ls[i+1:]...)
+			break
+		}
+	}
+	if len(ls) == 0 {
+		delete(d.listeners, key)
+		return
+	}
+	d.listeners[key] = ls
+}
+
+func (d *DryRunners) find(chainID *big.Int, coordinator common.Address) ([]*listenerV2, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if chainID != nil {
+		ls := d.listeners[dryRunKey{chainID: chainID.String(), coordinator: coordinator}]
+		if len(ls) == 0 {
+			return nil, errors.Wrapf(ErrDryRunnerNotFound, "%s on chain %s", coordinator, chainID)
+		}
+		return append([]*listenerV2(nil), ls...), nil
+	}
+	var found []*listenerV2
+	chains := make(map[string]struct{})
+	for key, ls := range d.listeners {
+		if key.coordinator == coordinator {
+			found = append(found, ls...)
+			chains[key.chainID] = struct{}{}
+		}
+	}
+	if len(found) == 0 {
+		return nil, errors.Wrapf(ErrDryRunnerNotFound, "%s", coordinator)
+	}
+	if len(chains) > 1 {
+		return nil, errors.Errorf("coordinator %s is served on %d chains, an EVM chain ID must be given", coordinator, len(chains))
+	}
+	return found, nil
+}
+
+// DryRunFulfillment finds the request log, then simulates its fulfillment using the
+// job that serves the request's key hash.
+func (d *DryRunners) DryRunFulfillment(ctx context.Context, req DryRunRequest) (DryRunResult, error) {
+	listeners, err := d.find(req.EVMChainID, req.Coordinator)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+
+	// All listeners share the chain and coordinator, so any of them can parse the log.
+	rwr, err := listeners[0].findRequestLog(ctx, req.TxHash, req.LogIndex)
+	if err != nil {
+		return DryRunResult{}, err
+	}
+	for _, lsn := range listeners {
+		if _, ok := lsn.publicKeyFor(rwr.KeyHash()); ok {
+			return lsn.dryRunFulfillment(ctx, rwr, req.BlockNumber)
+		}
+	}
+	return DryRunResult{}, errors.Wrapf(ErrDryRunnerNotFound, "%s with key hash %s", req.Coordinator, common.Hash(rwr.KeyHash()))
+}
+
+// findRequestLog returns the RandomWordsRequested log emitted by the coordinator at the
+// given index of the given transaction.
+func (lsn *listenerV2) findRequestLog(ctx context.Context, txHash common.Hash, logIndex uint) (RandomWordsRequested, error) {
+	receipt, err := lsn.chain.Client().TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("fetching receipt of %s: %w", txHash, err)
+	}
+	for _, lg := range receipt.Logs {
+		if lg.Index != logIndex {
+			continue
+		}
+		if lg.Address != lsn.coordinator.Address() || len(lg.Topics) == 0 || lg.Topics[0] != lsn.coordinator.RandomWordsRequestedTopic() {
+			return nil, errors.Wrapf(ErrDryRunLogNotFound, "log %d of %s is not a RandomWordsRequested log of %s", logIndex, txHash, lsn.coordinator.Address())
+		}
+		return lsn.coordinator.ParseRandomWordsRequested(*lg)
+	}
+	return nil, errors.Wrapf(ErrDryRunLogNotFound, "no log %d in %s", logIndex, txHash)
+}
+
+// dryRunFulfillment runs the fulfillment pipeline for the given request exactly like
+// runPipelines does, then simulates the fulfillment and the consumer callback.
+func (lsn *listenerV2) dryRunFulfillment(ctx context.Context, rwr RandomWordsRequested, blockNumber *big.Int) (DryRunResult, error) {
+	fromAddresses := lsn.fromAddresses()
+	if len(fromAddresses) == 0 {
+		return DryRunResult{}, errors.New("job has no from addresses")
+	}
+	maxGasPriceWei := lsn.feeCfg.PriceMaxKey(fromAddresses[0])
+	lg := logger.With(lsn.l, "reqID", rwr.RequestID().String(), "dryRun", true)
+
+	res := lsn.simulateFulfillment(ctx, maxGasPriceWei, pendingRequest{
+		confirmedAtBlock: rwr.Raw().BlockNumber,
+		req:              rwr,
+		utcTimestamp:     time.Now().UTC(),
+	}, lg)
+
+	result := DryRunResult{
+		JobID:        lsn.job.ID,
+		RequestID:    rwr.RequestID(),
+		SubID:        rwr.SubID(),
+		Sender:       rwr.Sender(),
+		Payload:      res.payload,
+		EstimatedFee: res.fundsNeeded,
+		MaxFee:       res.maxFee,
+		GasLimit:     res.gasLimit,
+	}
+	if res.err != nil {
+		result.SimulationError = res.err.Error()
+	}
+	if res.payload == "" {
+		// Proof generation failed, there is nothing left to simulate.
+		return result, nil
+	}
+	result.Proof = &res.proof
+
+	coordinator := lsn.coordinator.Address()
+	_, err := lsn.chain.Client().CallContract(ctx, ethereum.CallMsg{
+		From: fromAddresses[0],
+		To:   &coordinator,
+		Data: hexutil.MustDecode(res.payload),
+	}, blockNumber)
+	if err != nil {
+		result.FulfillmentRevertReason = decodeRevertReason(err, coordinatorV2PlusABI.Errors)
+	}
+
+	result.RandomWords, err = prooflib.RandomWordsFromGamma(res.proof.V2Plus.Gamma, rwr.NumWords())
+	if err != nil {
+		return DryRunResult{}, fmt.Errorf("deriving random words: %w", err)
+	}
+	callback, err := vrfConsumerABI.Pack("rawFulfillRandomWords", rwr.RequestID(), result.RandomWords)
+	if err != nil {
+		return DryRunResult{}, fmt.Errorf("packing rawFulfillRandomWords: %w", err)
+	}
+	consumer := rwr.Sender()
+	// The coordinator calls the consumer with exactly the callback gas limit.
+	_, err = lsn.chain.Client().CallContract(ctx, ethereum.CallMsg{
+		From: coordinator,
+		To:   &consumer,
+		Gas:  uint64(rwr.CallbackGasLimit()),
+		Data: callback,
+	}, blockNumber)
+	if err != nil {
+		result.CallbackRevertReason = decodeRevertReason(err, nil)
+	}
+	return result, nil
+}
+
+// decodeRevertReason returns a human readable revert reason for a failed eth_call.
+// Error(string) and Panic(uint256) reverts are decoded, as are custom errors of the
+// given contract errors. Unknown revert data is returned hex encoded.
+func decodeRevertReason(callErr error, contractErrors map[string]abi.Error) string {
+	rpcErr, err := evmclient.ExtractRPCError(callErr)
+	if err != nil || rpcErr.Data == nil {
+		return callErr.Error()
+	}
+	dataStr, ok := rpcErr.Data.(string)
+	if !ok {
+		return rpcErr.Error()
+	}
+	data, err := hexutil.Decode(dataStr)
+	if err != nil || len(data) < 4 {
+		return rpcErr.Error()
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	for name, e := range contractErrors {
+		if !bytes.HasPrefix(data, e.ID.Bytes()[:4]) {
+			continue
+		}
+		args, err := e.Inputs.Unpack(data[4:])
+		if err != nil {
+			return name
+		}
+		return fmt.Sprintf("%s%v", name, args)
+	}
+	return dataStr
+}
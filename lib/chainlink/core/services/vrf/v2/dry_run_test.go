@@ -0,0 +1,86 @@
+package v2
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	evmclient "github.com/smartcontractkit/chainlink-evm/pkg/client"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+func TestDryRunners_Find(t *testing.T) {
+	coordinator := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+	l1, l2, l3 := &listenerV2{}, &listenerV2{}, &listenerV2{}
+	d := NewDryRunners()
+	d.listeners[dryRunKey{chainID: "1", coordinator: coordinator}] = []*listenerV2{l1, l2}
+	d.listeners[dryRunKey{chainID: "2", coordinator: coordinator}] = []*listenerV2{l3}
+
+	found, err := d.find(big.NewInt(1), coordinator)
+	require.NoError(t, err)
+	assert.Equal(t, []*listenerV2{l1, l2}, found)
+
+	_, err = d.find(big.NewInt(3), coordinator)
+	require.ErrorIs(t, err, ErrDryRunnerNotFound)
+
+	_, err = d.find(nil, other)
+	require.ErrorIs(t, err, ErrDryRunnerNotFound)
+
+	// Without a chain ID the coordinator is ambiguous.
+	_, err = d.find(nil, coordinator)
+	require.ErrorContains(t, err, "served on 2 chains")
+
+	delete(d.listeners, dryRunKey{chainID: "2", coordinator: coordinator})
+	found, err = d.find(nil, coordinator)
+	require.NoError(t, err)
+	assert.Len(t, found, 2)
+}
+
+func TestDecodeRevertReason(t *testing.T) {
+	contractABI := evmtypes.MustGetABI(`[{"inputs":[{"internalType":"uint256","name":"have","type":"uint256"}],"name":"InsufficientBalance","type":"error"}]`)
+	rpcError := func(data []byte) error {
+		return evmclient.JsonError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(data)}
+	}
+
+	t.Run("error string", func(t *testing.T) {
+		stringType, err := abi.NewType("string", "", nil)
+		require.NoError(t, err)
+		reason, err := abi.Arguments{{Type: stringType}}.Pack("not enough gas")
+		require.NoError(t, err)
+		data := append(crypto.Keccak256([]byte("Error(string)"))[:4], reason...)
+		assert.Equal(t, "not enough gas", decodeRevertReason(rpcError(data), contractABI.Errors))
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		e := contractABI.Errors["InsufficientBalance"]
+		args, err := e.Inputs.Pack(big.NewInt(42))
+		require.NoError(t, err)
+		data := append(e.ID.Bytes()[:4], args...)
+		assert.Equal(t, "InsufficientBalance[42]", decodeRevertReason(rpcError(data), contractABI.Errors))
+		// Without the contract errors the raw revert data is returned.
+		assert.Equal(t, hexutil.Encode(data), decodeRevertReason(rpcError(data), nil))
+	})
+
+	t.Run("malformed custom error", func(t *testing.T) {
+		data := contractABI.Errors["InsufficientBalance"].ID.Bytes()[:4]
+		assert.Equal(t, "InsufficientBalance", decodeRevertReason(rpcError(data), contractABI.Errors))
+	})
+
+	t.Run("no revert data", func(t *testing.T) {
+		err := evmclient.JsonError{Code: 3, Message: "execution reverted"}
+		assert.Equal(t, err.Error(), decodeRevertReason(err, contractABI.Errors))
+		assert.Equal(t, err.Error(), decodeRevertReason(rpcError([]byte{1, 2}), contractABI.Errors))
+	})
+
+	t.Run("not an rpc error", func(t *testing.T) {
+		assert.Equal(t, "connection refused", decodeRevertReason(errors.New("connection refused"), contractABI.Errors))
+	})
+}
@@ -62,6 +62,14 @@ const (
 	// backoffFactor is the factor by which to increase the delay each time a request fails.
 	backoffFactor = 1.3
 
+	// lifecyclePruneInterval is how often request lifecycle events are pruned.
+	lifecyclePruneInterval = time.Hour
+
+	// minLifecycleRetention is the minimum amount of time request lifecycle events are kept
+	// for after a request was last seen. Jobs with a longer request timeout keep them for
+	// the request timeout instead.
+	minLifecycleRetention = 7 * 24 * time.Hour
+
 	txMetaFieldSubId  = "SubId"
 	txMetaGlobalSubId = "GlobalSubId"
 )
@@ -83,6 +91,11 @@ func New(
 	reqAdded func(),
 	inflightCache vrfcommon.InflightCache,
 	fulfillmentDeduper *vrfcommon.LogDeduper,
+	lifecycle *vrfcommon.LifecycleRecorder,
+	keyRotations vrfcommon.KeyRotationORM,
+	lowBalanceNotifier vrfcommon.LowBalanceNotifier,
+	lowBalanceAlerts vrfcommon.LowBalanceAlertORM,
+	dryRunners *DryRunners,
 ) job.ServiceCtx {
 	return &listenerV2{
 		cfg:                   cfg,
@@ -105,6 +118,12 @@ func New(
 		aggregator:            aggregator,
 		inflightCache:         inflightCache,
 		fulfillmentLogDeduper: fulfillmentDeduper,
+		scheduler:             newFulfillmentScheduler(job.VRFSpec),
+		lifecycle:             lifecycle,
+		keyRotations:          keyRotations,
+		lowBalanceNotifier:    lowBalanceNotifier,
+		lowBalanceAlerts:      lowBalanceAlerts,
+		dryRunners:            dryRunners,
 	}
 }
 
@@ -154,6 +173,35 @@ type listenerV2 struct {
 	// inflightCache is a cache of in-flight requests, used to prevent
 	// re-processing of requests that are in-flight or already fulfilled.
 	inflightCache vrfcommon.InflightCache
+
+	// scheduler orders confirmed requests across subscriptions, as configured
+	// by the job spec's fulfillmentScheduler.
+	scheduler fulfillmentScheduler
+
+	// lifecycle persists the lifecycle of the requests handled by this listener,
+	// so that they can be traced end to end.
+	lifecycle *vrfcommon.LifecycleRecorder
+
+	// dryRunners is where the listener registers itself while running, so that
+	// fulfillments can be dry-run through its pipeline.
+	dryRunners *DryRunners
+
+	// batchSizer adapts the size of batch fulfillments to chain conditions and
+	// the outcome of recent batches.
+	batchSizer batchSizer
+
+	// keyRotations holds the rotations of the job's VRF key. While a rotation is
+	// in progress, keys tracks both the old and the new key.
+	keyRotations vrfcommon.KeyRotationORM
+	keys         vrfKeys
+
+	// lowBalanceNotifier is called when a subscription is forecast to run out of
+	// funds. lowBalanceAlerted caches the targets already alerted per subscription
+	// balance, as persisted by lowBalanceAlerts. It is loaded by the first forecast and only
+	// accessed by the balance forecaster.
+	lowBalanceNotifier vrfcommon.LowBalanceNotifier
+	lowBalanceAlerts   vrfcommon.LowBalanceAlertORM
+	lowBalanceAlerted  map[string]struct{}
 }
 
 func (lsn *listenerV2) HealthReport() map[string]error {
@@ -202,6 +250,18 @@ func (lsn *listenerV2) Start(ctx context.Context) error {
 			}()
 		}
 
+		lsn.wg.Add(1)
+		go func() {
+			defer lsn.wg.Done()
+			lsn.runLifecyclePruner()
+		}()
+
+		lsn.wg.Add(1)
+		go func() {
+			defer lsn.wg.Done()
+			lsn.runBalanceForecaster()
+		}()
+
 		// Log listener gathers request logs and processes them
 		lsn.wg.Add(1)
 		go func() {
@@ -209,6 +269,8 @@ func (lsn *listenerV2) Start(ctx context.Context) error {
 			lsn.runLogListener(spec.PollPeriod, spec.MinIncomingConfirmations)
 		}()
 
+		lsn.dryRunners.register(lsn)
+
 		return nil
 	})
 }
@@ -253,6 +315,24 @@ func (lsn *listenerV2) GetStartingResponseCountsV2(ctx context.Context) (respCou
 	return respCounts, nil
 }
 
+// runLifecyclePruner periodically deletes the lifecycle events of requests that
+// have not been seen for longer than the retention period.
+func (lsn *listenerV2) runLifecyclePruner() {
+	ctx, cancel := lsn.chStop.NewCtx()
+	defer cancel()
+	retention := max(lsn.job.VRFSpec.RequestTimeout, minLifecycleRetention)
+	ticker := time.NewTicker(lifecyclePruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lsn.chStop:
+			return
+		case <-ticker.C:
+			lsn.lifecycle.Prune(ctx, retention)
+		}
+	}
+}
+
 func (lsn *listenerV2) setLatestHead(head logpoller.Block) {
 	lsn.latestHeadMu.Lock()
 	defer lsn.latestHeadMu.Unlock()
@@ -271,6 +351,7 @@ func (lsn *listenerV2) getLatestHead() uint64 {
 // Close complies with job.Service
 func (lsn *listenerV2) Close() error {
 	return lsn.StopOnce("VRFListenerV2", func() error {
+		lsn.dryRunners.unregister(lsn)
 		close(lsn.chStop)
 		// wait on the request handler, log listener
 		lsn.wg.Wait()
@@ -0,0 +1,314 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+const (
+	// balanceForecastInterval is how often subscription balances are forecast.
+	balanceForecastInterval = 5 * time.Minute
+	// balanceForecastWindow is how far back confirmed fulfillments are looked at to
+	// determine the spend rate of a subscription.
+	balanceForecastWindow = 6 * time.Hour
+	// maxForecastDuration is returned for subscriptions that are not spending.
+	maxForecastDuration = time.Duration(math.MaxInt64)
+)
+
+// subscriptionSpend is what confirmed fulfillments spent from a subscription.
+type subscriptionSpend struct {
+	link   *big.Int
+	native *big.Int
+}
+
+// fulfillmentPayment is what the coordinator charged for fulfilling a request, as
+// emitted in its RandomWordsFulfilled log.
+type fulfillmentPayment struct {
+	amount *big.Int
+	native bool
+}
+
+// aggregateSubscriptionSpend sums up the payments of the requests fulfilled by the
+// given confirmed txes per subscription, ignoring txes not sent to one of the given
+// addresses. As in vrfcommon.GetRespCounts, txes are matched to requests by the
+// request IDs in their meta, and each request is only counted once, however many
+// txes were sent for it. Requests without a payment did not land a fulfillment.
+func aggregateSubscriptionSpend(txes []*txmgr.Tx, to []common.Address, payments map[common.Hash]fulfillmentPayment) (map[string]*subscriptionSpend, error) {
+	spends := make(map[string]*subscriptionSpend)
+	counted := make(map[common.Hash]struct{})
+	for _, tx := range vrfcommon.DedupeTxList(txes) {
+		if !containsAddress(to, tx.ToAddress) {
+			continue
+		}
+		meta, err := tx.GetMeta()
+		if err != nil {
+			return nil, fmt.Errorf("GetMeta for Tx failed: %w", err)
+		}
+		if meta == nil {
+			continue
+		}
+		var subID string
+		switch {
+		case meta.GlobalSubID != nil:
+			subID = *meta.GlobalSubID
+		case meta.SubID != nil:
+			subID = new(big.Int).SetUint64(*meta.SubID).String()
+		default:
+			continue
+		}
+		reqIDs := meta.RequestIDs
+		if meta.RequestID != nil {
+			reqIDs = append([]common.Hash{*meta.RequestID}, reqIDs...)
+		}
+		for _, reqID := range reqIDs {
+			payment, ok := payments[reqID]
+			if !ok {
+				continue
+			}
+			if _, ok := counted[reqID]; ok {
+				continue
+			}
+			counted[reqID] = struct{}{}
+			spend, ok := spends[subID]
+			if !ok {
+				spend = &subscriptionSpend{link: big.NewInt(0), native: big.NewInt(0)}
+				spends[subID] = spend
+			}
+			if payment.native {
+				spend.native.Add(spend.native, payment.amount)
+			} else {
+				spend.link.Add(spend.link, payment.amount)
+			}
+		}
+	}
+	return spends, nil
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
+
+// spendPerHour returns the hourly rate of spending spent over window.
+func spendPerHour(spent *big.Int, window time.Duration) *big.Int {
+	rate := new(big.Int).Mul(spent, big.NewInt(int64(time.Hour)))
+	return rate.Quo(rate, big.NewInt(int64(window)))
+}
+
+// forecastTimeUntilEmpty returns how long balance lasts when spending spent every
+// window, or maxForecastDuration if nothing was spent.
+func forecastTimeUntilEmpty(balance, spent *big.Int, window time.Duration) time.Duration {
+	if balance.Sign() <= 0 {
+		return 0
+	}
+	if spent.Sign() <= 0 {
+		return maxForecastDuration
+	}
+	d := new(big.Int).Mul(balance, big.NewInt(int64(window)))
+	d.Quo(d, spent)
+	if !d.IsInt64() {
+		return maxForecastDuration
+	}
+	return time.Duration(d.Int64())
+}
+
+// runBalanceForecaster periodically forecasts when the subscriptions fulfilled by
+// this job run out of funds.
+func (lsn *listenerV2) runBalanceForecaster() {
+	ctx, cancel := lsn.chStop.NewCtx()
+	defer cancel()
+	ticker := time.NewTicker(balanceForecastInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lsn.chStop:
+			return
+		case <-ticker.C:
+			if err := lsn.forecastBalances(ctx); err != nil {
+				lsn.l.Warnw("Couldn't forecast VRF subscription balances", "err", err)
+			}
+		}
+	}
+}
+
+// forecastBalances derives the spend rate of every subscription with fulfillments
+// confirmed within balanceForecastWindow, and forecasts when its balance not reserved
+// by in-flight fulfillments runs out. Subscriptions forecast to run dry within the
+// spec's lowBalanceAlertThreshold are alerted on once, until they are topped up.
+func (lsn *listenerV2) forecastBalances(ctx context.Context) error {
+	latest := lsn.getLatestHead()
+	if latest == 0 {
+		return nil
+	}
+	fromBlock := max(int64(latest)-numReplayBlocks(balanceForecastWindow, lsn.chainID), 0)
+
+	version := lsn.coordinator.Version()
+	metaField := txMetaFieldSubId
+	if version == vrfcommon.V2Plus {
+		metaField = txMetaGlobalSubId
+	}
+	txes, err := lsn.chain.TxManager().FindTxesWithMetaFieldByReceiptBlockNum(ctx, metaField, fromBlock, lsn.chainID)
+	if err != nil {
+		return fmt.Errorf("TXM FindTxesWithMetaFieldByReceiptBlockNum failed: %w", err)
+	}
+	spec := lsn.job.VRFSpec
+	to := []common.Address{spec.CoordinatorAddress.Address()}
+	if spec.BatchCoordinatorAddress != nil {
+		to = append(to, spec.BatchCoordinatorAddress.Address())
+	}
+	if spec.VRFOwnerAddress != nil {
+		to = append(to, spec.VRFOwnerAddress.Address())
+	}
+	logs, err := lsn.chain.LogPoller().LogsWithSigs(ctx, fromBlock, int64(latest),
+		[]common.Hash{lsn.coordinator.RandomWordsFulfilledTopic()}, lsn.coordinator.Address())
+	if err != nil {
+		return fmt.Errorf("LogPoller.LogsWithSigs RandomWordsFulfilled logs: %w", err)
+	}
+	spends, err := aggregateSubscriptionSpend(txes, to, lsn.fulfillmentPayments(logs))
+	if err != nil {
+		return err
+	}
+	if lsn.lowBalanceAlerted == nil {
+		alerted, err := lsn.lowBalanceAlerts.FindLowBalanceAlerts(ctx, lsn.job.ID)
+		if err != nil {
+			return err
+		}
+		lsn.lowBalanceAlerted = alerted
+	}
+
+	var failed int
+	for subID, spend := range spends {
+		state, err := lsn.getSubscriptionState(ctx, lsn.l, subID)
+		if err != nil {
+			lsn.l.Warnw("Couldn't read subscription for balance forecast", "subID", subID, "err", err)
+			failed++
+			continue
+		}
+		if !state.isActive {
+			continue
+		}
+		linkBalance, err := lsn.MaybeSubtractReservedLink(ctx, state.startLinkBalance, lsn.chainID, state.subID, version)
+		if err != nil {
+			lsn.l.Warnw("Couldn't subtract reserved LINK for balance forecast", "subID", subID, "err", err)
+			continue
+		}
+		lsn.forecastBalance(ctx, subID, vrfcommon.CurrencyLink, linkBalance, spend.link)
+		if version == vrfcommon.V2Plus {
+			nativeBalance, err := lsn.MaybeSubtractReservedEth(ctx, state.startEthBalance, lsn.chainID, state.subID, version)
+			if err != nil {
+				lsn.l.Warnw("Couldn't subtract reserved ETH for balance forecast", "subID", subID, "err", err)
+				continue
+			}
+			lsn.forecastBalance(ctx, subID, vrfcommon.CurrencyNative, nativeBalance, spend.native)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("couldn't read %d of %d subscriptions", failed, len(spends))
+	}
+	return nil
+}
+
+// fulfillmentPayments returns the payments of the successfully parsed
+// RandomWordsFulfilled logs, by request ID.
+func (lsn *listenerV2) fulfillmentPayments(logs []logpoller.Log) map[common.Hash]fulfillmentPayment {
+	payments := make(map[common.Hash]fulfillmentPayment, len(logs))
+	for _, l := range logs {
+		fulfilled, err := lsn.coordinator.ParseRandomWordsFulfilled(l.ToGethLog())
+		if err != nil {
+			lsn.l.Warnw("Couldn't parse RandomWordsFulfilled log for balance forecast", "txHash", l.TxHash, "err", err)
+			continue
+		}
+		payments[common.BigToHash(fulfilled.RequestID())] = fulfillmentPayment{
+			amount: fulfilled.Payment(),
+			native: fulfilled.NativePayment(),
+		}
+	}
+	return payments
+}
+
+// forecastBalance publishes the forecast for a single balance of a subscription, and
+// raises a low balance alert if needed. The alert is delivered to each target once,
+// and only the targets that failed are retried by the next forecast.
+func (lsn *listenerV2) forecastBalance(ctx context.Context, subID, currency string, balance, spent *big.Int) {
+	untilEmpty := forecastTimeUntilEmpty(balance, spent, balanceForecastWindow)
+	rate := spendPerHour(spent, balanceForecastWindow)
+	balanceF, _ := new(big.Float).SetInt(balance).Float64()
+	rateF, _ := new(big.Float).SetInt(rate).Float64()
+	vrfcommon.SetSubscriptionForecast(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(),
+		subID, currency, balanceF, rateF, untilEmpty)
+
+	threshold := lsn.job.VRFSpec.LowBalanceAlertThreshold
+	allTargets := []vrfcommon.LowBalanceAlertTarget{vrfcommon.LowBalanceAlertTargetWebhook, vrfcommon.LowBalanceAlertTargetExternalInitiator}
+	if threshold == 0 || untilEmpty > threshold {
+		// Alert again once the subscription runs low after being topped up.
+		if slices.ContainsFunc(allTargets, func(target vrfcommon.LowBalanceAlertTarget) bool {
+			_, ok := lsn.lowBalanceAlerted[vrfcommon.LowBalanceAlertKey(subID, currency, target)]
+			return ok
+		}) {
+			if err := lsn.lowBalanceAlerts.DeleteLowBalanceAlert(ctx, lsn.job.ID, subID, currency); err != nil {
+				lsn.l.Warnw("Couldn't clear low balance alert", "subID", subID, "currency", currency, "err", err)
+				return
+			}
+			for _, target := range allTargets {
+				delete(lsn.lowBalanceAlerted, vrfcommon.LowBalanceAlertKey(subID, currency, target))
+			}
+		}
+		return
+	}
+	if lsn.lowBalanceNotifier == nil {
+		return
+	}
+	var pending []vrfcommon.LowBalanceAlertTarget
+	for _, target := range vrfcommon.LowBalanceAlertTargets(lsn.job.VRFSpec) {
+		if _, ok := lsn.lowBalanceAlerted[vrfcommon.LowBalanceAlertKey(subID, currency, target)]; !ok {
+			pending = append(pending, target)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	alert := vrfcommon.LowBalanceAlert{
+		JobID:         lsn.job.ID,
+		ExternalJobID: lsn.job.ExternalJobID,
+		JobName:       lsn.job.Name.ValueOrZero(),
+		VRFVersion:    lsn.coordinator.Version(),
+		SubID:         subID,
+		Currency:      currency,
+		Balance:       balance.String(),
+		SpendPerHour:  rate.String(),
+		EmptyAt:       now.Add(untilEmpty),
+		RaisedAt:      now,
+	}
+	lsn.l.Warnw("VRF subscription is forecast to run out of funds",
+		"subID", subID, "currency", currency, "balance", balance, "spendPerHour", rate, "emptyAt", alert.EmptyAt)
+	for _, target := range pending {
+		err := lsn.lowBalanceNotifier.Notify(ctx, lsn.job.VRFSpec, target, alert)
+		vrfcommon.IncLowBalanceAlerts(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), subID, err == nil)
+		if err != nil {
+			// Retried on the next forecast, without alerting the targets already notified.
+			lsn.l.Errorw("Couldn't notify low balance alert target", "subID", subID, "currency", currency, "target", target, "err", err)
+			continue
+		}
+		if err := lsn.lowBalanceAlerts.CreateLowBalanceAlert(ctx, lsn.job.ID, subID, currency, target); err != nil {
+			lsn.l.Warnw("Couldn't record low balance alert, it may be raised again after a restart", "subID", subID, "currency", currency, "target", target, "err", err)
+		}
+		lsn.lowBalanceAlerted[vrfcommon.LowBalanceAlertKey(subID, currency, target)] = struct{}{}
+	}
+}
@@ -0,0 +1,168 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+func TestAggregateSubscriptionSpend(t *testing.T) {
+	coordinator := common.HexToAddress("0x1")
+	other := common.HexToAddress("0x2")
+	newTx := func(id int64, to common.Address, meta *txmgr.TxMeta) *txmgr.Tx {
+		tx := &txmgr.Tx{ID: id, ToAddress: to}
+		if meta != nil {
+			b, err := json.Marshal(meta)
+			require.NoError(t, err)
+			m := sqlutil.JSON(b)
+			tx.Meta = &m
+		}
+		return tx
+	}
+	subID := uint64(7)
+	globalSubID := "123456789012345678901234567890"
+	req := func(i int64) common.Hash { return common.BigToHash(big.NewInt(i)) }
+	txes := []*txmgr.Tx{
+		newTx(1, coordinator, &txmgr.TxMeta{RequestID: ptr(req(1)), SubID: &subID, MaxLink: ptr("1000")}),
+		// Resent for the same request, which is only paid for once.
+		newTx(2, coordinator, &txmgr.TxMeta{RequestID: ptr(req(1)), SubID: &subID, MaxLink: ptr("1000")}),
+		newTx(3, coordinator, &txmgr.TxMeta{RequestIDs: []common.Hash{req(2), req(3)}, SubID: &subID}),
+		// Duplicates are only counted once.
+		newTx(3, coordinator, &txmgr.TxMeta{RequestIDs: []common.Hash{req(2), req(3)}, SubID: &subID}),
+		newTx(4, coordinator, &txmgr.TxMeta{RequestID: ptr(req(4)), GlobalSubID: &globalSubID}),
+		newTx(5, coordinator, &txmgr.TxMeta{RequestID: ptr(req(5)), GlobalSubID: &globalSubID}),
+		// Not fulfilled on-chain.
+		newTx(6, coordinator, &txmgr.TxMeta{RequestID: ptr(req(6)), SubID: &subID, MaxLink: ptr("1000")}),
+		// Sent to another contract.
+		newTx(7, other, &txmgr.TxMeta{RequestID: ptr(req(7)), SubID: &subID}),
+		// Not a fulfillment of a subscription.
+		newTx(8, coordinator, nil),
+	}
+	payments := map[common.Hash]fulfillmentPayment{
+		req(1): {amount: big.NewInt(100)},
+		req(2): {amount: big.NewInt(30)},
+		req(3): {amount: big.NewInt(20)},
+		req(4): {amount: big.NewInt(5)},
+		req(5): {amount: big.NewInt(30), native: true},
+		req(7): {amount: big.NewInt(1000)},
+	}
+
+	spends, err := aggregateSubscriptionSpend(txes, []common.Address{coordinator}, payments)
+	require.NoError(t, err)
+	require.Len(t, spends, 2)
+	assert.Equal(t, "150", spends["7"].link.String())
+	assert.Equal(t, "0", spends["7"].native.String())
+	assert.Equal(t, "5", spends[globalSubID].link.String())
+	assert.Equal(t, "30", spends[globalSubID].native.String())
+}
+
+func TestForecastTimeUntilEmpty(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		balance int64
+		spent   int64
+		want    time.Duration
+	}{
+		{"empty", 0, 10, 0},
+		{"overdrawn", -5, 10, 0},
+		{"not spending", 100, 0, maxForecastDuration},
+		{"spending", 100, 50, 12 * time.Hour},
+		{"spending fast", 100, 600, time.Hour},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := forecastTimeUntilEmpty(big.NewInt(tc.balance), big.NewInt(tc.spent), 6*time.Hour)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	huge, ok := new(big.Int).SetString("1000000000000000000000000000000", 10)
+	require.True(t, ok)
+	assert.Equal(t, maxForecastDuration, forecastTimeUntilEmpty(huge, big.NewInt(1), 6*time.Hour))
+	assert.Equal(t, "100", spendPerHour(big.NewInt(600), 6*time.Hour).String())
+}
+
+type fakeLowBalanceNotifier struct {
+	failing  map[vrfcommon.LowBalanceAlertTarget]bool
+	notified []vrfcommon.LowBalanceAlertTarget
+}
+
+func (n *fakeLowBalanceNotifier) Notify(_ context.Context, _ *job.VRFSpec, target vrfcommon.LowBalanceAlertTarget, _ vrfcommon.LowBalanceAlert) error {
+	if n.failing[target] {
+		return errors.New("unreachable")
+	}
+	n.notified = append(n.notified, target)
+	return nil
+}
+
+type fakeLowBalanceAlertORM struct {
+	created []vrfcommon.LowBalanceAlertTarget
+	deleted int
+}
+
+func (o *fakeLowBalanceAlertORM) FindLowBalanceAlerts(context.Context, int32) (map[string]struct{}, error) {
+	return map[string]struct{}{}, nil
+}
+
+func (o *fakeLowBalanceAlertORM) CreateLowBalanceAlert(_ context.Context, _ int32, _, _ string, target vrfcommon.LowBalanceAlertTarget) error {
+	o.created = append(o.created, target)
+	return nil
+}
+
+func (o *fakeLowBalanceAlertORM) DeleteLowBalanceAlert(context.Context, int32, string, string) error {
+	o.deleted++
+	return nil
+}
+
+func TestForecastBalance_LowBalanceAlertTargets(t *testing.T) {
+	webhook, ei := vrfcommon.LowBalanceAlertTargetWebhook, vrfcommon.LowBalanceAlertTargetExternalInitiator
+	notifier := &fakeLowBalanceNotifier{failing: map[vrfcommon.LowBalanceAlertTarget]bool{ei: true}}
+	orm := &fakeLowBalanceAlertORM{}
+	lsn := &listenerV2{
+		job: job.Job{VRFSpec: &job.VRFSpec{
+			LowBalanceAlertThreshold:    24 * time.Hour,
+			LowBalanceWebhookURL:        "http://webhook",
+			LowBalanceExternalInitiator: "alerter",
+		}},
+		l:                  logger.Sugared(logger.Test(t)),
+		coordinator:        &coordinatorV2{vrfVersion: vrfcommon.V2},
+		lowBalanceNotifier: notifier,
+		lowBalanceAlerts:   orm,
+		lowBalanceAlerted:  map[string]struct{}{},
+	}
+	ctx := testutils.Context(t)
+	// spends the whole balance within the forecast window
+	low, spent := big.NewInt(100), big.NewInt(100)
+
+	lsn.forecastBalance(ctx, "1", vrfcommon.CurrencyLink, low, spent)
+	assert.Equal(t, []vrfcommon.LowBalanceAlertTarget{webhook}, notifier.notified)
+	assert.Equal(t, []vrfcommon.LowBalanceAlertTarget{webhook}, orm.created)
+
+	// only the failed target is retried
+	notifier.failing = nil
+	lsn.forecastBalance(ctx, "1", vrfcommon.CurrencyLink, low, spent)
+	assert.Equal(t, []vrfcommon.LowBalanceAlertTarget{webhook, ei}, notifier.notified)
+	assert.Equal(t, []vrfcommon.LowBalanceAlertTarget{webhook, ei}, orm.created)
+
+	lsn.forecastBalance(ctx, "1", vrfcommon.CurrencyLink, low, spent)
+	assert.Len(t, notifier.notified, 2)
+
+	// topping up clears the alerts of every target
+	lsn.forecastBalance(ctx, "1", vrfcommon.CurrencyLink, big.NewInt(1_000_000), spent)
+	assert.Equal(t, 1, orm.deleted)
+	assert.Empty(t, lsn.lowBalanceAlerted)
+}
@@ -0,0 +1,305 @@
+package v2
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+const (
+	// batchMaxBlockGasFraction is the largest share of the current block gas limit
+	// a single batch fulfillment may use.
+	batchMaxBlockGasFraction = 0.3
+
+	// batchGasPriceSpikeStart is the ratio of the current gas price to the key's max
+	// gas price above which batches start to shrink. At a ratio of 1 and above, batches
+	// are reduced to batchMinGasPriceFactor of their size.
+	batchGasPriceSpikeStart = 0.5
+	batchMinGasPriceFactor  = 0.25
+
+	// batchRevertRecoveryFactor is the factor by which the batch gas limit grows
+	// after each fully successful batch, until it is back at the static limit.
+	batchRevertRecoveryFactor = 1.25
+
+	// batchSizerRefreshInterval is the minimum time between two refreshes of the chain
+	// conditions and batch outcomes, so that not every pass over the pending requests
+	// costs RPC calls and a query.
+	batchSizerRefreshInterval = 30 * time.Second
+
+	// batchMaxPendingAge is how long the outcome of a sent batch is waited for.
+	batchMaxPendingAge = time.Hour
+)
+
+// Sources of the batch gas limit, as reported by the vrf_batch_gas_limit metric.
+const (
+	batchLimitStatic    = "static"
+	batchLimitBlock     = "block_gas_limit"
+	batchLimitGasPrice  = "gas_price"
+	batchLimitReverts   = "reverts"
+	batchLimitEffective = "effective"
+)
+
+// Outcomes of mined batch fulfillments, as reported by the vrf_batch_fulfillment_outcome_count metric.
+const (
+	batchOutcomeSuccess       = "success"
+	batchOutcomePartialRevert = "partial_revert"
+	batchOutcomeRevert        = "revert"
+)
+
+// batchSizer adapts the gas limit used to group fulfillments into batches to the
+// chain's current block gas limit and gas price, and to the outcome of recently mined
+// batches. After a batch (partially) reverts, the limit is set to half the batch gas
+// of that batch, the sum of the gas of its fulfillments, splitting the next batches.
+// Every fully successful batch then raises it again, until it is back at the static
+// limit.
+//
+// Only batches sent since the node started are tracked, as their batch gas is not
+// persisted with the transaction.
+type batchSizer struct {
+	mu sync.Mutex
+	// sent holds the batch transactions whose outcome has not been observed yet.
+	sent map[int64]sentBatch
+	// lastRefresh is when the chain conditions and outcomes were last refreshed.
+	lastRefresh time.Time
+	// revertLimit bounds the batch gas after reverts. Zero means unbounded.
+	revertLimit uint64
+	// blockGasLimit and gasPrice are the latest observed chain conditions. Zero or
+	// nil if unknown, in which case they do not bound the batch gas.
+	blockGasLimit uint64
+	gasPrice      *big.Int
+}
+
+type sentBatch struct {
+	batchGas uint64
+	reqs     int
+	sentAt   time.Time
+}
+
+// batchOutcome is the result of a mined batch fulfillment.
+type batchOutcome struct {
+	txID int64
+	// batchGas is the sum of the gas of the batch's fulfillments, the amount bounded
+	// by the batch gas limit. It excludes the overhead and multiplier of the tx gas limit.
+	batchGas uint64
+	reqs     int
+	// failed is the number of fulfillments in the batch that reverted.
+	failed int
+	// reverted is true if the whole batch transaction reverted.
+	reverted bool
+}
+
+func (o batchOutcome) String() string {
+	switch {
+	case o.reverted:
+		return batchOutcomeRevert
+	case o.failed > 0:
+		return batchOutcomePartialRevert
+	default:
+		return batchOutcomeSuccess
+	}
+}
+
+// batchLimits holds the gas limit imposed by each source. Sources that do not
+// currently bound the batch gas are zero.
+type batchLimits struct {
+	static, block, gasPrice, reverts uint64
+}
+
+// effective returns the batch gas limit to use, the minimum of all bounding sources.
+func (b batchLimits) effective() uint64 {
+	limit := b.static
+	for _, l := range []uint64{b.block, b.gasPrice, b.reverts} {
+		if l > 0 && l < limit {
+			limit = l
+		}
+	}
+	return limit
+}
+
+// setChainConditions records the current block gas limit and gas price.
+func (s *batchSizer) setChainConditions(blockGasLimit uint64, gasPrice *big.Int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blockGasLimit = blockGasLimit
+	s.gasPrice = gasPrice
+}
+
+// dueForRefresh reports whether the last refresh is older than batchSizerRefreshInterval,
+// and if so, marks the sizer as refreshed now.
+func (s *batchSizer) dueForRefresh(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastRefresh) < batchSizerRefreshInterval {
+		return false
+	}
+	s.lastRefresh = now
+	return true
+}
+
+// recordSent records a batch transaction, so that its outcome is observed once mined.
+func (s *batchSizer) recordSent(txID int64, batchGas uint64, reqs int, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sent == nil {
+		s.sent = make(map[int64]sentBatch)
+	}
+	s.sent[txID] = sentBatch{batchGas: batchGas, reqs: reqs, sentAt: now}
+}
+
+// pendingBatches returns the IDs of the batch transactions whose outcome has not been
+// observed yet. Batches sent before the given time are forgotten, as their receipts
+// are no longer looked for.
+func (s *batchSizer) pendingBatches(sentAfter time.Time) []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]int64, 0, len(s.sent))
+	for id, b := range s.sent {
+		if b.sentAt.Before(sentAfter) {
+			delete(s.sent, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// minedBatch returns the outcome of the given mined batch transaction, if it was sent
+// by this sizer and not observed yet.
+func (s *batchSizer) minedBatch(txID int64, failed int, reverted bool) (batchOutcome, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.sent[txID]
+	if !ok {
+		return batchOutcome{}, false
+	}
+	delete(s.sent, txID)
+	return batchOutcome{txID: txID, batchGas: b.batchGas, reqs: b.reqs, failed: failed, reverted: reverted}, true
+}
+
+// observe updates the revert limit with the outcome of a mined batch.
+func (s *batchSizer) observe(o batchOutcome) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if o.reverted || o.failed > 0 {
+		halved := max(o.batchGas/2, 1)
+		if s.revertLimit == 0 || halved < s.revertLimit {
+			s.revertLimit = halved
+		}
+		return
+	}
+	if s.revertLimit == 0 {
+		return
+	}
+	s.revertLimit = max(uint64(float64(s.revertLimit)*batchRevertRecoveryFactor), s.revertLimit+1)
+}
+
+// limits returns the batch gas limit imposed by each source, given the static limit
+// derived from the coordinator config and the max gas price of the sending keys.
+func (s *batchSizer) limits(staticLimit uint64, maxGasPrice *big.Int) batchLimits {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.revertLimit >= staticLimit {
+		// Fully recovered from past reverts.
+		s.revertLimit = 0
+	}
+	l := batchLimits{static: staticLimit, reverts: s.revertLimit}
+	if s.blockGasLimit > 0 {
+		l.block = uint64(float64(s.blockGasLimit) * batchMaxBlockGasFraction)
+	}
+	if s.gasPrice != nil && maxGasPrice != nil && maxGasPrice.Sign() > 0 {
+		ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(s.gasPrice), new(big.Float).SetInt(maxGasPrice)).Float64()
+		if ratio > batchGasPriceSpikeStart {
+			// Shrink linearly from the full static limit at the start of the spike down
+			// to the minimum factor at the max gas price.
+			factor := 1 - (ratio-batchGasPriceSpikeStart)/(1-batchGasPriceSpikeStart)*(1-batchMinGasPriceFactor)
+			factor = max(factor, batchMinGasPriceFactor)
+			l.gasPrice = max(uint64(float64(staticLimit)*factor), 1)
+		}
+	}
+	return l
+}
+
+type minedBatchReceipt struct {
+	ID      int64            `db:"id"`
+	Receipt evmtypes.Receipt `db:"receipt"`
+}
+
+// refreshBatchSizer updates the batch sizer with the current chain conditions and the
+// outcomes of the batches it sent that were mined since. It does nothing if the last
+// refresh is more recent than batchSizerRefreshInterval. Failures are logged, the batch
+// sizer then keeps using the last known values.
+func (lsn *listenerV2) refreshBatchSizer(ctx context.Context) {
+	now := time.Now()
+	if !lsn.batchSizer.dueForRefresh(now) {
+		return
+	}
+
+	header, err := lsn.chain.Client().HeaderByNumber(ctx, nil)
+	if err != nil {
+		lsn.l.Warnw("Couldn't get latest header for batch sizing", "err", err)
+	}
+	gasPrice, err2 := lsn.chain.Client().SuggestGasPrice(ctx)
+	if err2 != nil {
+		lsn.l.Warnw("Couldn't get gas price for batch sizing", "err", err2)
+	}
+	if err == nil && err2 == nil {
+		lsn.batchSizer.setChainConditions(header.GasLimit, gasPrice)
+	}
+
+	pending := lsn.batchSizer.pendingBatches(now.Add(-batchMaxPendingAge))
+	if len(pending) == 0 {
+		return
+	}
+
+	var receipts []minedBatchReceipt
+	err = lsn.ds.SelectContext(ctx, &receipts, `
+		SELECT DISTINCT ON (t.id) t.id, r.receipt
+		FROM evm.txes t
+		INNER JOIN evm.tx_attempts a ON a.eth_tx_id = t.id
+		INNER JOIN evm.receipts r ON r.tx_hash = a.hash
+		WHERE t.id = ANY($1)
+		ORDER BY t.id ASC`, pq.Array(pending))
+	if err != nil {
+		lsn.l.Warnw("Couldn't fetch batch fulfillment receipts for batch sizing", "err", err)
+		return
+	}
+
+	batchEvents := batchCoordinatorV2ABI.Events
+	if lsn.coordinator.Version() == vrfcommon.V2Plus {
+		batchEvents = batchCoordinatorV2PlusABI.Events
+	}
+	for _, r := range receipts {
+		o, ok := lsn.batchSizer.minedBatch(r.ID, len(batchErrorLogs(r.Receipt, batchEvents)), r.Receipt.Status == 0)
+		if !ok {
+			continue
+		}
+		if o.failed > 0 || o.reverted {
+			lsn.l.Infow("Batch fulfillment reverted, splitting subsequent batches",
+				"ethTxID", o.txID, "batchGas", o.batchGas, "numReqs", o.reqs, "numFailed", o.failed, "outcome", o.String())
+		}
+		lsn.batchSizer.observe(o)
+		vrfcommon.IncBatchOutcome(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), o.String())
+	}
+}
+
+// batchGasLimit returns the gas limit to group fulfillments into batches with, and
+// reports the limit of each source.
+func (lsn *listenerV2) batchGasLimit(staticLimit uint64, maxGasPrice *big.Int) uint64 {
+	limits := lsn.batchSizer.limits(staticLimit, maxGasPrice)
+	effective := limits.effective()
+
+	jobName, extJobID, version := lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version()
+	vrfcommon.SetBatchGasLimit(jobName, extJobID, version, batchLimitStatic, limits.static)
+	vrfcommon.SetBatchGasLimit(jobName, extJobID, version, batchLimitBlock, limits.block)
+	vrfcommon.SetBatchGasLimit(jobName, extJobID, version, batchLimitGasPrice, limits.gasPrice)
+	vrfcommon.SetBatchGasLimit(jobName, extJobID, version, batchLimitReverts, limits.reverts)
+	vrfcommon.SetBatchGasLimit(jobName, extJobID, version, batchLimitEffective, effective)
+	return effective
+}
@@ -0,0 +1,120 @@
+package v2
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+)
+
+func TestBatchSizer_Limits(t *testing.T) {
+	const static = 2_900_000
+	maxGasPrice := big.NewInt(1000)
+
+	t.Run("static limit without chain conditions or reverts", func(t *testing.T) {
+		s := &batchSizer{}
+		l := s.limits(static, maxGasPrice)
+		assert.Equal(t, batchLimits{static: static}, l)
+		assert.Equal(t, uint64(static), l.effective())
+	})
+
+	t.Run("bounded by block gas limit", func(t *testing.T) {
+		s := &batchSizer{}
+		s.setChainConditions(5_000_000, big.NewInt(100))
+		l := s.limits(static, maxGasPrice)
+		assert.Equal(t, uint64(1_500_000), l.block)
+		assert.Zero(t, l.gasPrice)
+		assert.Equal(t, uint64(1_500_000), l.effective())
+	})
+
+	t.Run("bounded by gas price", func(t *testing.T) {
+		s := &batchSizer{}
+		s.setChainConditions(30_000_000, big.NewInt(500))
+		assert.Zero(t, s.limits(static, maxGasPrice).gasPrice)
+
+		s.setChainConditions(30_000_000, big.NewInt(750))
+		l := s.limits(static, maxGasPrice)
+		assert.Equal(t, uint64(static*0.625), l.gasPrice)
+		assert.Equal(t, l.gasPrice, l.effective())
+
+		s.setChainConditions(30_000_000, big.NewInt(5000))
+		assert.Equal(t, uint64(static*batchMinGasPriceFactor), s.limits(static, maxGasPrice).gasPrice)
+
+		// Without a max gas price, the gas price doesn't bound the batch.
+		assert.Zero(t, s.limits(static, nil).gasPrice)
+	})
+
+	t.Run("split after reverts and recover after successes", func(t *testing.T) {
+		s := &batchSizer{}
+		s.observe(batchOutcome{txID: 1, batchGas: 2_000_000, reqs: 4, failed: 1})
+		assert.Equal(t, uint64(1_000_000), s.limits(static, maxGasPrice).effective())
+
+		// A larger reverted batch doesn't raise the limit.
+		s.observe(batchOutcome{txID: 2, batchGas: 2_800_000, reqs: 4, reverted: true})
+		assert.Equal(t, uint64(1_000_000), s.limits(static, maxGasPrice).effective())
+
+		s.observe(batchOutcome{txID: 3, batchGas: 1_000_000, reqs: 2})
+		assert.Equal(t, uint64(1_250_000), s.limits(static, maxGasPrice).effective())
+
+		for i := int64(4); i < 10; i++ {
+			s.observe(batchOutcome{txID: i, batchGas: 1_000_000, reqs: 2})
+		}
+		l := s.limits(static, maxGasPrice)
+		assert.Zero(t, l.reverts)
+		assert.Equal(t, uint64(static), l.effective())
+	})
+}
+
+func TestBatchSizer_SentBatches(t *testing.T) {
+	now := time.Now()
+	s := &batchSizer{}
+	s.recordSent(1, 1_200_000, 3, now.Add(-2*batchMaxPendingAge))
+	s.recordSent(2, 800_000, 2, now)
+	s.recordSent(3, 600_000, 1, now)
+
+	assert.ElementsMatch(t, []int64{2, 3}, s.pendingBatches(now.Add(-batchMaxPendingAge)))
+
+	o, ok := s.minedBatch(2, 1, false)
+	require.True(t, ok)
+	assert.Equal(t, batchOutcome{txID: 2, batchGas: 800_000, reqs: 2, failed: 1}, o)
+	_, ok = s.minedBatch(2, 1, false)
+	assert.False(t, ok, "outcomes are observed once")
+	_, ok = s.minedBatch(1, 0, true)
+	assert.False(t, ok, "expired batches are forgotten")
+	assert.Equal(t, []int64{3}, s.pendingBatches(now.Add(-batchMaxPendingAge)))
+}
+
+func TestBatchSizer_DueForRefresh(t *testing.T) {
+	now := time.Now()
+	s := &batchSizer{}
+	assert.True(t, s.dueForRefresh(now))
+	assert.False(t, s.dueForRefresh(now.Add(batchSizerRefreshInterval/2)))
+	assert.True(t, s.dueForRefresh(now.Add(batchSizerRefreshInterval)))
+}
+
+func TestBatchOutcome_String(t *testing.T) {
+	assert.Equal(t, batchOutcomeSuccess, batchOutcome{reqs: 3}.String())
+	assert.Equal(t, batchOutcomePartialRevert, batchOutcome{reqs: 3, failed: 1}.String())
+	assert.Equal(t, batchOutcomeRevert, batchOutcome{reqs: 3, reverted: true}.String())
+}
+
+func TestBatchErrorLogs(t *testing.T) {
+	events := batchCoordinatorV2PlusABI.Events
+	receipt := evmtypes.Receipt{
+		Status: 1,
+		Logs: []*evmtypes.Log{
+			{Topics: []common.Hash{events["RawErrorReturned"].ID}},
+			{Topics: []common.Hash{common.HexToHash("0x1")}},
+			{Topics: []common.Hash{events["ErrorReturned"].ID}},
+			{},
+			nil,
+		},
+	}
+	assert.Equal(t, []*evmtypes.Log{receipt.Logs[0], receipt.Logs[2]}, batchErrorLogs(receipt, events))
+	assert.Empty(t, batchErrorLogs(evmtypes.Receipt{Status: 1}, events))
+}
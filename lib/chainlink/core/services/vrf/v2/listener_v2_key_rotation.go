@@ -0,0 +1,141 @@
+package v2
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/logpoller"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// vrfKeys tracks the VRF keys a listener answers requests for. Outside of key
+// rotations, this is only the key of the job's spec. While a rotation is in
+// progress, requests for both its old and new key are answered. The zero value
+// answers requests for the spec key only.
+type vrfKeys struct {
+	mu sync.RWMutex
+	// keys holds the keys to answer requests for, the active key first. Empty
+	// until the job's key rotations were first loaded.
+	keys []secp256k1.PublicKey
+	// rotation is the key rotation in progress, if any.
+	rotation *vrfcommon.KeyRotation
+}
+
+// update sets the keys to answer requests for, based on the job's key rotations in
+// the order they were started. Each rotation starts from the key the previous one
+// rotated to, so the last rotation determines the keys. It returns true if the keys
+// changed.
+func (k *vrfKeys) update(specKey secp256k1.PublicKey, rotations []vrfcommon.KeyRotation) bool {
+	keys := []secp256k1.PublicKey{specKey}
+	var rotation *vrfcommon.KeyRotation
+	if len(rotations) > 0 {
+		last := rotations[len(rotations)-1]
+		keys = []secp256k1.PublicKey{last.NewPublicKey}
+		if last.RetiredAt == nil {
+			keys = append(keys, last.OldPublicKey)
+			rotation = &last
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	changed := !slices.Equal(k.keys, keys)
+	k.keys = keys
+	k.rotation = rotation
+	return changed
+}
+
+// get returns the keys to answer requests for, the active key first.
+func (k *vrfKeys) get(specKey secp256k1.PublicKey) []secp256k1.PublicKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if len(k.keys) == 0 {
+		return []secp256k1.PublicKey{specKey}
+	}
+	return slices.Clone(k.keys)
+}
+
+// inProgress returns the key rotation in progress, if any.
+func (k *vrfKeys) inProgress() (vrfcommon.KeyRotation, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.rotation == nil {
+		return vrfcommon.KeyRotation{}, false
+	}
+	return *k.rotation, true
+}
+
+// keyHashes returns the hashes of the keys the listener answers requests for.
+func (lsn *listenerV2) keyHashes() []common.Hash {
+	keys := lsn.keys.get(lsn.job.VRFSpec.PublicKey)
+	hashes := make([]common.Hash, len(keys))
+	for i := range keys {
+		hashes[i] = keys[i].MustHash()
+	}
+	return hashes
+}
+
+// publicKeyFor returns the key with the given hash, if the listener answers requests for it.
+func (lsn *listenerV2) publicKeyFor(keyHash common.Hash) (secp256k1.PublicKey, bool) {
+	for _, k := range lsn.keys.get(lsn.job.VRFSpec.PublicKey) {
+		if k.MustHash() == keyHash {
+			return k, true
+		}
+	}
+	return secp256k1.PublicKey{}, false
+}
+
+// refreshKeys reloads the job's key rotations. On failure, the listener keeps
+// answering requests for the keys it last knew of.
+func (lsn *listenerV2) refreshKeys(ctx context.Context) {
+	if lsn.keyRotations == nil {
+		return
+	}
+	rotations, err := lsn.keyRotations.FindKeyRotationsForJob(ctx, lsn.job.ID)
+	if err != nil {
+		lsn.l.Warnw("Couldn't load VRF key rotations, keeping current keys", "err", err)
+		return
+	}
+	if lsn.keys.update(lsn.job.VRFSpec.PublicKey, rotations) {
+		lsn.l.Infow("Answering requests for VRF keys", "keyHashes", lsn.keyHashes())
+	}
+}
+
+// retireRotatedKey retires the old key of the rotation in progress once its overlap
+// window has passed and no pending request references it. Requests that timed out
+// are not pending anymore.
+func (lsn *listenerV2) retireRotatedKey(ctx context.Context, unfulfilled []RandomWordsRequested, unfulfilledLP []logpoller.Log) {
+	rotation, ok := lsn.keys.inProgress()
+	if !ok {
+		return
+	}
+	oldKeyHash := rotation.OldPublicKey.MustHash()
+	timedOut := time.Now().UTC().Add(-lsn.job.VRFSpec.RequestTimeout)
+	var pending int64
+	for i, req := range unfulfilled {
+		if req.KeyHash() == oldKeyHash && unfulfilledLP[i].CreatedAt.After(timedOut) {
+			pending++
+		}
+	}
+	if err := lsn.keyRotations.UpdateKeyRotationPendingRequests(ctx, rotation.ID, pending); err != nil {
+		lsn.l.Warnw("Couldn't update pending requests of VRF key rotation", "err", err)
+	}
+	if pending > 0 || time.Now().Before(rotation.OverlapUntil) {
+		return
+	}
+
+	if err := lsn.keyRotations.RetireKeyRotation(ctx, rotation.ID); err != nil {
+		lsn.l.Errorw("Couldn't retire rotated VRF key", "oldKeyHash", oldKeyHash, "err", err)
+		return
+	}
+	lsn.l.Infow("Retired rotated VRF key, no pending requests reference it anymore",
+		"oldPublicKey", rotation.OldPublicKey, "oldKeyHash", oldKeyHash,
+		"newPublicKey", rotation.NewPublicKey, "overlapUntil", rotation.OverlapUntil)
+	lsn.refreshKeys(ctx)
+}
@@ -0,0 +1,56 @@
+package v2
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+func TestVRFKeys(t *testing.T) {
+	k1 := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PublicKey
+	k2 := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(2)).PublicKey
+	k3 := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(3)).PublicKey
+	lsn := &listenerV2{job: job.Job{VRFSpec: &job.VRFSpec{PublicKey: k1}}}
+
+	// Before the rotations are loaded, only the spec key is used.
+	assert.Equal(t, []secp256k1.PublicKey{k1}, lsn.keys.get(k1))
+	_, ok := lsn.keys.inProgress()
+	assert.False(t, ok)
+	assert.True(t, lsn.keys.update(k1, nil))
+	assert.False(t, lsn.keys.update(k1, nil))
+
+	retired := time.Now()
+	rotations := []vrfcommon.KeyRotation{
+		{ID: 1, OldPublicKey: k1, NewPublicKey: k2, RetiredAt: &retired},
+		{ID: 2, OldPublicKey: k2, NewPublicKey: k3},
+	}
+	assert.True(t, lsn.keys.update(k1, rotations))
+	assert.Equal(t, []secp256k1.PublicKey{k3, k2}, lsn.keys.get(k1))
+	assert.Equal(t, []common.Hash{k3.MustHash(), k2.MustHash()}, lsn.keyHashes())
+	rotation, ok := lsn.keys.inProgress()
+	require.True(t, ok)
+	assert.Equal(t, int64(2), rotation.ID)
+
+	pk, ok := lsn.publicKeyFor(k2.MustHash())
+	require.True(t, ok)
+	assert.Equal(t, k2, pk)
+	_, ok = lsn.publicKeyFor(k1.MustHash())
+	assert.False(t, ok)
+
+	// Once retired, only the new key is used.
+	rotations[1].RetiredAt = &retired
+	assert.True(t, lsn.keys.update(k1, rotations))
+	assert.Equal(t, []secp256k1.PublicKey{k3}, lsn.keys.get(k1))
+	_, ok = lsn.keys.inProgress()
+	assert.False(t, ok)
+	assert.False(t, lsn.keys.update(k1, rotations))
+}
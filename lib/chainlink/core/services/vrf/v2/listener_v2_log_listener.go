@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"math/big"
@@ -75,6 +74,8 @@ func (lsn *listenerV2) runLogListener(
 				lsn.l.Debugw("initialized last processed block", "lastProcessedBlock", lastProcessedBlock)
 			}
 
+			lsn.refreshKeys(ctx)
+
 			pending, err := lsn.pollLogs(ctx, minConfs, lastProcessedBlock)
 			if err != nil {
 				lsn.l.Errorw("error polling vrf logs, retrying",
@@ -147,9 +148,9 @@ func (lsn *listenerV2) initializeLastProcessedBlock(ctx context.Context) (lastPr
 		lsn.coordinator.RandomWordsRequestedTopic(), // event sig
 		lsn.coordinator.Address(),                   // address
 		1,                                           // topic index
-		[]common.Hash{lsn.job.VRFSpec.PublicKey.MustHash()}, // topic values
-		fromTimestamp,      // from time
-		evmtypes.Finalized, // confs
+		lsn.keyHashes(),                             // topic values
+		fromTimestamp,                               // from time
+		evmtypes.Finalized,                          // confs
 	)
 	if err != nil {
 		return 0, fmt.Errorf("LogPoller.LogsCreatedAfter RandomWordsRequested logs: %w", err)
@@ -277,17 +278,17 @@ func (lsn *listenerV2) pollLogs(ctx context.Context, minConfs uint32, lastProces
 		ll.Debugw("no unfulfilled logs found")
 	}
 
-	lsn.handleFulfilled(fulfilled)
+	lsn.handleFulfilled(ctx, fulfilled)
+	lsn.retireRotatedKey(ctx, unfulfilled, unfulfilledLP)
 
-	return lsn.handleRequested(unfulfilled, unfulfilledLP, minConfs), nil
+	return lsn.handleRequested(ctx, unfulfilled, unfulfilledLP, minConfs), nil
 }
 
 func (lsn *listenerV2) getUnfulfilled(logs []logpoller.Log, ll logger.Logger) (unfulfilled []RandomWordsRequested, unfulfilledLP []logpoller.Log, fulfilled map[string]RandomWordsFulfilled) {
 	var (
-		requested       = make(map[string]RandomWordsRequested)
-		requestedLP     = make(map[string]logpoller.Log)
-		errs            error
-		expectedKeyHash = lsn.job.VRFSpec.PublicKey.MustHash()
+		requested   = make(map[string]RandomWordsRequested)
+		requestedLP = make(map[string]logpoller.Log)
+		errs        error
 	)
 	fulfilled = make(map[string]RandomWordsFulfilled)
 	for _, l := range logs {
@@ -306,8 +307,7 @@ func (lsn *listenerV2) getUnfulfilled(logs []logpoller.Log, ll logger.Logger) (u
 				errs = multierr.Append(errs, err2)
 				continue
 			}
-			keyHash := parsed.KeyHash()
-			if !bytes.Equal(keyHash[:], expectedKeyHash[:]) {
+			if _, ok := lsn.publicKeyFor(parsed.KeyHash()); !ok {
 				// wrong keyhash, can ignore
 				continue
 			}
@@ -366,7 +366,7 @@ func (lsn *listenerV2) getConfirmedAt(req RandomWordsRequested, nodeMinConfs uin
 	return req.Raw().BlockNumber + newConfs
 }
 
-func (lsn *listenerV2) handleFulfilled(fulfilled map[string]RandomWordsFulfilled) {
+func (lsn *listenerV2) handleFulfilled(ctx context.Context, fulfilled map[string]RandomWordsFulfilled) {
 	for _, v := range fulfilled {
 		// don't process same log over again
 		// log key includes block number and blockhash, so on re-orgs it would return true
@@ -375,6 +375,12 @@ func (lsn *listenerV2) handleFulfilled(fulfilled map[string]RandomWordsFulfilled
 			continue
 		}
 		lsn.l.Debugw("Received fulfilled log", "reqID", v.RequestID(), "success", v.Success())
+		lsn.lifecycle.Record(ctx, v.RequestID(), vrfcommon.StageFulfilled, vrfcommon.LifecycleDetails{
+			"success":     v.Success(),
+			"blockNumber": v.Raw().BlockNumber,
+			"blockHash":   v.Raw().BlockHash,
+			"txHash":      v.Raw().TxHash,
+		})
 		lsn.respCount[v.RequestID().String()]++
 		lsn.blockNumberToReqID.Insert(fulfilledReqV2{
 			blockNumber: v.Raw().BlockNumber,
@@ -383,12 +389,16 @@ func (lsn *listenerV2) handleFulfilled(fulfilled map[string]RandomWordsFulfilled
 	}
 }
 
-func (lsn *listenerV2) handleRequested(requested []RandomWordsRequested, requestedLP []logpoller.Log, minConfs uint32) (pendingRequests []pendingRequest) {
+func (lsn *listenerV2) handleRequested(ctx context.Context, requested []RandomWordsRequested, requestedLP []logpoller.Log, minConfs uint32) (pendingRequests []pendingRequest) {
 	for i, req := range requested {
 		// don't process same log over again
 		// log key includes block number and blockhash, so on re-orgs it would return true
 		// and we would re-process the re-orged request.
 		if lsn.inflightCache.Contains(req.Raw()) {
+			lsn.lifecycle.Record(ctx, req.RequestID(), vrfcommon.StageSkippedInflight, vrfcommon.LifecycleDetails{
+				"blockNumber": req.Raw().BlockNumber,
+				"blockHash":   req.Raw().BlockHash,
+			})
 			continue
 		}
 
@@ -401,6 +411,15 @@ func (lsn *listenerV2) handleRequested(requested []RandomWordsRequested, request
 			"confirmedAt", confirmedAt,
 			"subID", req.SubID(),
 			"sender", req.Sender())
+		lsn.lifecycle.Record(ctx, req.RequestID(), vrfcommon.StageLogReceived, vrfcommon.LifecycleDetails{
+			"blockNumber":      req.Raw().BlockNumber,
+			"blockHash":        req.Raw().BlockHash,
+			"txHash":           req.Raw().TxHash,
+			"subID":            req.SubID().String(),
+			"sender":           req.Sender(),
+			"confirmedAtBlock": confirmedAt,
+			"responseCount":    lsn.respCount[req.RequestID().String()],
+		})
 		pendingRequests = append(pendingRequests, pendingRequest{
 			confirmedAtBlock: confirmedAt,
 			req:              req,
@@ -36,13 +36,18 @@ import (
 )
 
 // Returns all the confirmed logs from the provided pending queue by subscription
-func (lsn *listenerV2) getConfirmedLogsBySub(latestHead uint64, pendingRequests []pendingRequest) map[string][]pendingRequest {
+func (lsn *listenerV2) getConfirmedLogsBySub(ctx context.Context, latestHead uint64, pendingRequests []pendingRequest) map[string][]pendingRequest {
 	vrfcommon.UpdateQueueSize(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), uniqueReqs(pendingRequests))
 	var toProcess = make(map[string][]pendingRequest)
 	for _, request := range pendingRequests {
 		if lsn.ready(request, latestHead) {
 			toProcess[request.req.SubID().String()] = append(toProcess[request.req.SubID().String()], request)
+			continue
 		}
+		lsn.lifecycle.Record(ctx, request.req.RequestID(), vrfcommon.StageAwaitingConfirmations, vrfcommon.LifecycleDetails{
+			"confirmedAtBlock": request.confirmedAtBlock,
+			"attempts":         request.attempts,
+		})
 	}
 	return toProcess
 }
@@ -109,7 +114,7 @@ func (lsn *listenerV2) pruneConfirmedRequestCounts() {
 // Its easier to optimistically assume it will go though and in the rare case of a reversion
 // we simply retry TODO: follow up where if we see a fulfillment revert, return log to the queue.
 func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context, pendingRequests []pendingRequest) {
-	confirmed := lsn.getConfirmedLogsBySub(lsn.getLatestHead(), pendingRequests)
+	confirmed := lsn.getConfirmedLogsBySub(ctx, lsn.getLatestHead(), pendingRequests)
 	var processedMu sync.Mutex
 	processed := make(map[string]struct{})
 	start := time.Now()
@@ -135,60 +140,45 @@ func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context, pendingReq
 		lsn.l.Infow("No pending requests ready for processing")
 		return
 	}
-	for subID, reqs := range confirmed {
+
+	if lsn.job.VRFSpec.BatchFulfillmentEnabled && lsn.batchCoordinator != nil {
+		lsn.refreshBatchSizer(ctx)
+	}
+
+	// Sort requests in ascending order by CallbackGasLimit
+	// so that we process the "cheapest" requests for each subscription
+	// first. This allows us to break out of the processing loop as early as possible
+	// in the event that a subscription is too underfunded to have it's
+	// requests processed.
+	for _, reqs := range confirmed {
+		slices.SortFunc(reqs, func(a, b pendingRequest) int {
+			return cmp.Compare(a.req.CallbackGasLimit(), b.req.CallbackGasLimit())
+		})
+	}
+	lsn.applyMaxInFlight(ctx, confirmed)
+
+	// The scheduler may dispatch a subscription more than once per pass, so the
+	// on-chain subscription state is only read the first time it is seen.
+	subs := make(map[string]subscriptionState)
+	for _, slot := range lsn.scheduler.schedule(confirmed) {
+		subID, reqs := slot.subID, slot.reqs
 		l := lsn.l.With("subID", subID, "startTime", time.Now(), "numReqsForSub", len(reqs))
-		// Get the balance of the subscription and also it's active status.
-		// The reason we need both is that we cannot determine if a subscription
-		// is active solely by it's balance, since an active subscription could legitimately
-		// have a zero balance.
-		var (
-			startLinkBalance *big.Int
-			startEthBalance  *big.Int
-			subIsActive      bool
-		)
-		sID, ok := new(big.Int).SetString(subID, 10)
+		sub, ok := subs[subID]
 		if !ok {
-			l.Criticalf("Unable to convert %s to Int", subID)
-			return
-		}
-		sub, err := lsn.coordinator.GetSubscription(&bind.CallOpts{
-			Context: ctx}, sID)
-
-		if err != nil {
-			if !strings.Contains(err.Error(), "execution reverted") {
-				// Most likely this is an RPC error, so we re-try later.
-				l.Errorw("Unable to read subscription balance", "err", err)
+			var err error
+			sub, err = lsn.getSubscriptionState(ctx, l, subID)
+			if err != nil {
 				return
 			}
-			// "execution reverted" indicates that the subscription no longer exists.
-			// We can no longer just mark these as processed and continue,
-			// since it could be that the subscription was canceled while there
-			// were still unfulfilled requests.
-			// The simplest approach to handle this is to enter the processRequestsPerSub
-			// loop rather than create a bunch of largely duplicated code
-			// to handle this specific situation, since we need to run the pipeline to get
-			// the VRF proof, abi-encode it, etc.
-			l.Warnw("Subscription not found - setting start balance to zero", "subID", subID, "err", err)
-			startLinkBalance = big.NewInt(0)
-		} else {
-			// Happy path - sub is active.
-			startLinkBalance = sub.Balance()
-			if sub.Version() == vrfcommon.V2Plus {
-				startEthBalance = sub.NativeBalance()
-			}
-			subIsActive = true
+			subs[subID] = sub
 		}
 
-		// Sort requests in ascending order by CallbackGasLimit
-		// so that we process the "cheapest" requests for each subscription
-		// first. This allows us to break out of the processing loop as early as possible
-		// in the event that a subscription is too underfunded to have it's
-		// requests processed.
-		slices.SortFunc(reqs, func(a, b pendingRequest) int {
-			return cmp.Compare(a.req.CallbackGasLimit(), b.req.CallbackGasLimit())
-		})
+		now := time.Now().UTC()
+		for _, req := range reqs {
+			vrfcommon.ObserveQueueWaitTime(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), subID, now.Sub(req.utcTimestamp))
+		}
 
-		p := lsn.processRequestsPerSub(ctx, sID, startLinkBalance, startEthBalance, reqs, subIsActive)
+		p := lsn.processRequestsPerSub(ctx, sub.subID, sub.startLinkBalance, sub.startEthBalance, reqs, sub.isActive)
 		processedMu.Lock()
 		for reqID := range p {
 			processed[reqID] = struct{}{}
@@ -198,6 +188,132 @@ func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context, pendingReq
 	lsn.pruneConfirmedRequestCounts()
 }
 
+// subscriptionState is the on-chain state of a subscription read at the start of a
+// processing pass.
+type subscriptionState struct {
+	subID            *big.Int
+	startLinkBalance *big.Int
+	startEthBalance  *big.Int
+	isActive         bool
+}
+
+// getSubscriptionState reads the balance of the subscription and also it's active status.
+// The reason we need both is that we cannot determine if a subscription
+// is active solely by it's balance, since an active subscription could legitimately
+// have a zero balance.
+// An error is returned if processing should stop for this pass.
+func (lsn *listenerV2) getSubscriptionState(ctx context.Context, l logger.SugaredLogger, subID string) (subscriptionState, error) {
+	sID, ok := new(big.Int).SetString(subID, 10)
+	if !ok {
+		l.Criticalf("Unable to convert %s to Int", subID)
+		return subscriptionState{}, errors.Errorf("invalid subID %s", subID)
+	}
+	state := subscriptionState{subID: sID}
+	sub, err := lsn.coordinator.GetSubscription(&bind.CallOpts{
+		Context: ctx}, sID)
+
+	if err != nil {
+		if !strings.Contains(err.Error(), "execution reverted") {
+			// Most likely this is an RPC error, so we re-try later.
+			l.Errorw("Unable to read subscription balance", "err", err)
+			return subscriptionState{}, err
+		}
+		// "execution reverted" indicates that the subscription no longer exists.
+		// We can no longer just mark these as processed and continue,
+		// since it could be that the subscription was canceled while there
+		// were still unfulfilled requests.
+		// The simplest approach to handle this is to enter the processRequestsPerSub
+		// loop rather than create a bunch of largely duplicated code
+		// to handle this specific situation, since we need to run the pipeline to get
+		// the VRF proof, abi-encode it, etc.
+		l.Warnw("Subscription not found - setting start balance to zero", "subID", subID, "err", err)
+		state.startLinkBalance = big.NewInt(0)
+	} else {
+		// Happy path - sub is active.
+		state.startLinkBalance = sub.Balance()
+		if sub.Version() == vrfcommon.V2Plus {
+			state.startEthBalance = sub.NativeBalance()
+		}
+		state.isActive = true
+	}
+	return state, nil
+}
+
+// applyMaxInFlight trims the confirmed requests of every subscription that has a
+// maxInFlight limit configured, so that the number of its fulfillments pending in the
+// txm does not exceed that limit. Trimmed requests stay in the queue for a later pass.
+func (lsn *listenerV2) applyMaxInFlight(ctx context.Context, confirmed map[string][]pendingRequest) {
+	for subID, reqs := range confirmed {
+		sched, ok := lsn.job.VRFSpec.SubscriptionSchedules.ForSub(subID)
+		if !ok || sched.MaxInFlight == 0 {
+			continue
+		}
+		inFlight, err := lsn.countInFlightFulfillments(ctx, subID)
+		if err != nil {
+			lsn.l.Errorw("Couldn't count in-flight fulfillments for subscription, deferring its requests",
+				"subID", subID, "err", err)
+			inFlight = int(sched.MaxInFlight)
+		}
+		capacity := max(int(sched.MaxInFlight)-inFlight, 0)
+
+		// Requests in the inflight cache already have a fulfillment in the txm, so they
+		// are counted by inFlight and do not take up any of the remaining capacity.
+		var kept []pendingRequest
+		for _, req := range reqs {
+			if lsn.inflightCache.Contains(req.req.Raw()) {
+				kept = append(kept, req)
+			} else if capacity > 0 {
+				kept = append(kept, req)
+				capacity--
+			}
+		}
+		if deferred := len(reqs) - len(kept); deferred > 0 {
+			lsn.l.Debugw("Subscription reached max in-flight fulfillments, deferring requests",
+				"subID", subID,
+				"maxInFlight", sched.MaxInFlight,
+				"inFlight", inFlight,
+				"deferred", deferred)
+			vrfcommon.AddSchedulerDeferredReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), subID, deferred)
+			confirmed[subID] = kept
+		}
+	}
+}
+
+// countInFlightFulfillments returns the number of requests of the given subscription
+// that are covered by fulfillment transactions not yet confirmed on-chain.
+func (lsn *listenerV2) countInFlightFulfillments(ctx context.Context, subID string) (int, error) {
+	var metaField string
+	if lsn.coordinator.Version() == vrfcommon.V2Plus {
+		metaField = txMetaGlobalSubId
+	} else if lsn.coordinator.Version() == vrfcommon.V2 {
+		metaField = txMetaFieldSubId
+	} else {
+		return 0, errors.Errorf("unsupported vrf version %s", lsn.coordinator.Version())
+	}
+
+	txes, err := lsn.chain.TxManager().FindTxesByMetaFieldAndStates(ctx, metaField, subID, reserveEthLinkQueryStates, lsn.chainID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("TXM FindTxesByMetaFieldAndStates failed: %w", err)
+	}
+
+	var count int
+	for _, tx := range txes {
+		meta, err := tx.GetMeta()
+		if err != nil {
+			return 0, fmt.Errorf("GetMeta for Tx failed: %w", err)
+		}
+		switch {
+		case meta == nil:
+			count++
+		case len(meta.RequestIDs) > 0:
+			count += len(meta.RequestIDs)
+		default:
+			count++
+		}
+	}
+	return count, nil
+}
+
 // MaybeSubtractReservedLink figures out how much LINK is reserved for other VRF requests that
 // have not been fully confirmed yet on-chain, and subtracts that from the given startBalance,
 // and returns that value if there are no errors.
@@ -317,7 +433,15 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 	}
 
 	// Add very conservative upper bound estimate on verification costs.
-	batchMaxGas := config.MaxGasLimit() + 400_000
+	staticBatchMaxGas := config.MaxGasLimit() + 400_000
+
+	// All fromAddresses passed to the VRFv2 job have the same KeySpecific-MaxPrice value.
+	// Without one, the gas price does not bound the batch gas.
+	var maxGasPrice *big.Int
+	if fromAddresses := lsn.fromAddresses(); len(fromAddresses) > 0 {
+		maxGasPrice = lsn.feeCfg.PriceMaxKey(fromAddresses[0]).ToInt()
+	}
+	batchMaxGas := uint32(lsn.batchGasLimit(uint64(staticBatchMaxGas), maxGasPrice))
 
 	l := lsn.l.With(
 		"subID", subID,
@@ -325,6 +449,7 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 		"startBalance", startBalance.String(),
 		"startBalanceNoReserved", startBalanceNoReserved.String(),
 		"batchMaxGas", batchMaxGas,
+		"staticBatchMaxGas", staticBatchMaxGas,
 		"subIsActive", subIsActive,
 		"nativePayment", nativePayment,
 	)
@@ -339,7 +464,7 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 
 	l.Infow("Processing requests for subscription with batching")
 
-	ready, expired := lsn.getReadyAndExpired(l, reqs)
+	ready, expired := lsn.getReadyAndExpired(ctx, l, reqs)
 	for _, reqID := range expired {
 		processed[reqID] = struct{}{}
 	}
@@ -430,6 +555,7 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 							continue
 						}
 						ll.Infow("Successfully enqueued force-fulfillment", "ethTxID", etx.ID)
+						lsn.recordEnqueued(ctx, p.req, etx.ID, fromAddress, "force")
 						processed[p.req.req.RequestID().String()] = struct{}{}
 
 						// Need to put a continue here, otherwise the next if statement will be hit
@@ -456,6 +582,10 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 							"blockNumber", p.req.req.Raw().BlockNumber,
 							"blockHash", p.req.req.Raw().BlockHash,
 						)
+						vrfcommon.IncDroppedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), vrfcommon.ReasonInvalidConsumer)
+						lsn.lifecycle.RecordDropped(ctx, p.req.req.RequestID(), vrfcommon.ReasonInvalidConsumer, vrfcommon.LifecycleDetails{
+							"consumerAddress": p.req.req.Sender(),
+						})
 						processed[p.req.req.RequestID().String()] = struct{}{}
 						continue
 					}
@@ -479,7 +609,10 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 		var processedRequestIDs []string
 		for _, batch := range batches.fulfillments {
 			l.Debugw("Processing batch", "batchSize", len(batch.proofs))
-			p := lsn.processBatch(l, subID, startBalanceNoReserved, batchMaxGas, batch, batch.fromAddress)
+			// A single request may exceed the adaptive limit on its own, make sure
+			// its batch still gets enough gas.
+			maxGas := max(batchMaxGas, uint32(min(batch.totalGasLimit, uint64(staticBatchMaxGas))))
+			p := lsn.processBatch(l, subID, startBalanceNoReserved, maxGas, batch, batch.fromAddress)
 			processedRequestIDs = append(processedRequestIDs, p...)
 		}
 
@@ -683,7 +816,7 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 
 	l.Infow("Processing requests for subscription")
 
-	ready, expired := lsn.getReadyAndExpired(l, reqs)
+	ready, expired := lsn.getReadyAndExpired(ctx, l, reqs)
 	for _, reqID := range expired {
 		processed[reqID] = struct{}{}
 	}
@@ -761,6 +894,7 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 							continue
 						}
 						ll.Infow("Enqueued force-fulfillment", "ethTxID", etx.ID)
+						lsn.recordEnqueued(ctx, p.req, etx.ID, fromAddress, "force")
 						processed[p.req.req.RequestID().String()] = struct{}{}
 
 						// Need to put a continue here, otherwise the next if statement will be hit
@@ -784,6 +918,10 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 							"blockNumber", p.req.req.Raw().BlockNumber,
 							"blockHash", p.req.req.Raw().BlockHash,
 						)
+						vrfcommon.IncDroppedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), vrfcommon.ReasonInvalidConsumer)
+						lsn.lifecycle.RecordDropped(ctx, p.req.req.RequestID(), vrfcommon.ReasonInvalidConsumer, vrfcommon.LifecycleDetails{
+							"consumerAddress": p.req.req.Sender(),
+						})
 						processed[p.req.req.RequestID().String()] = struct{}{}
 						continue
 					}
@@ -850,6 +988,7 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 				continue
 			}
 			ll.Infow("Enqueued fulfillment", "ethTxID", transaction.GetID())
+			lsn.recordEnqueued(ctx, p.req, transaction.ID, fromAddress, "single")
 
 			// If we successfully enqueued for the txm, subtract that balance
 			// And loop to attempt to enqueue another fulfillment
@@ -1039,6 +1178,9 @@ func (lsn *listenerV2) checkReqsFulfilled(ctx context.Context, l logger.Logger,
 				"reqID", reqs[i].req.RequestID().String(),
 				"attempts", reqs[i].attempts,
 				"txHash", reqs[i].req.Raw().TxHash)
+			lsn.lifecycle.Record(ctx, reqs[i].req.RequestID(), vrfcommon.StageAlreadyFulfilled, vrfcommon.LifecycleDetails{
+				"attempts": reqs[i].attempts,
+			})
 			fulfilled[i] = true
 		}
 	}
@@ -1067,6 +1209,7 @@ func (lsn *listenerV2) runPipelines(
 			defer wg.Done()
 			ll := logger.With(l, "reqID", req.req.RequestID().String())
 			results[i] = lsn.simulateFulfillment(ctx, maxGasPriceWei, req, ll)
+			lsn.recordSimulated(ctx, results[i])
 		}(i, req)
 	}
 	wg.Wait()
@@ -1076,6 +1219,33 @@ func (lsn *listenerV2) runPipelines(
 	return results
 }
 
+// recordSimulated records the outcome of the simulation of a request's fulfillment.
+func (lsn *listenerV2) recordSimulated(ctx context.Context, res vrfPipelineResult) {
+	details := vrfcommon.LifecycleDetails{
+		"success":  res.err == nil,
+		"attempts": res.req.attempts,
+	}
+	if res.err != nil {
+		details["error"] = res.err.Error()
+	}
+	if res.maxFee != nil {
+		details["maxFee"] = res.maxFee.String()
+	}
+	if res.gasLimit > 0 {
+		details["gasLimit"] = res.gasLimit
+	}
+	lsn.lifecycle.Record(ctx, res.req.req.RequestID(), vrfcommon.StageSimulated, details)
+}
+
+// recordEnqueued records that a fulfillment transaction for the request was handed to the txm.
+func (lsn *listenerV2) recordEnqueued(ctx context.Context, req pendingRequest, txID int64, fromAddress common.Address, kind string) {
+	lsn.lifecycle.Record(ctx, req.req.RequestID(), vrfcommon.StageEnqueued, vrfcommon.LifecycleDetails{
+		"txID":        txID,
+		"fromAddress": fromAddress,
+		"kind":        kind,
+	})
+}
+
 func (lsn *listenerV2) estimateFee(
 	ctx context.Context,
 	req RandomWordsRequested,
@@ -1124,12 +1294,19 @@ func (lsn *listenerV2) simulateFulfillment(
 		res.fundsNeeded = big.NewInt(0)
 	}
 
+	// During a key rotation, requests may be for either the old or the new key.
+	publicKey, ok := lsn.publicKeyFor(req.req.KeyHash())
+	if !ok {
+		res.err = fmt.Errorf("no VRF key with key hash %s", common.Hash(req.req.KeyHash()))
+		return res
+	}
+
 	vars := pipeline.NewVarsFrom(map[string]interface{}{
 		"jobSpec": map[string]interface{}{
 			"databaseID":    lsn.job.ID,
 			"externalJobID": lsn.job.ExternalJobID,
 			"name":          lsn.job.Name.ValueOrZero(),
-			"publicKey":     lsn.job.VRFSpec.PublicKey[:],
+			"publicKey":     publicKey[:],
 			"maxGasPrice":   maxGasPriceWei.ToInt().String(),
 			"evmChainID":    lsn.job.VRFSpec.EVMChainID.String(),
 		},
@@ -0,0 +1,130 @@
+package v2
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+// subRequests is a slice of a single subscription's confirmed requests that the
+// fulfillment scheduler dispatches for processing as a unit.
+type subRequests struct {
+	subID string
+	reqs  []pendingRequest
+}
+
+// fulfillmentScheduler decides the order in which confirmed requests are processed
+// across subscriptions during a single processPendingVRFRequests pass.
+type fulfillmentScheduler interface {
+	// schedule returns the given confirmed requests, keyed by subscription ID, as an
+	// ordered list of dispatch slots. A subscription may appear in more than one slot.
+	// The requests of each subscription keep their relative order.
+	schedule(confirmed map[string][]pendingRequest) []subRequests
+}
+
+// newFulfillmentScheduler returns the scheduler configured in the given VRF spec.
+func newFulfillmentScheduler(spec *job.VRFSpec) fulfillmentScheduler {
+	if spec == nil {
+		return fifoScheduler{}
+	}
+	switch spec.FulfillmentScheduler {
+	case job.VRFSchedulerWeightedFair:
+		return weightedFairScheduler{
+			quantum:   int(spec.ChunkSize),
+			schedules: spec.SubscriptionSchedules,
+		}
+	default:
+		return fifoScheduler{}
+	}
+}
+
+// fifoScheduler dispatches every subscription's requests in a single slot, starting
+// with the subscription whose oldest request has waited the longest.
+type fifoScheduler struct{}
+
+func (fifoScheduler) schedule(confirmed map[string][]pendingRequest) []subRequests {
+	slots := make([]subRequests, 0, len(confirmed))
+	for subID, reqs := range confirmed {
+		if len(reqs) == 0 {
+			continue
+		}
+		slots = append(slots, subRequests{subID: subID, reqs: reqs})
+	}
+	slices.SortFunc(slots, func(a, b subRequests) int {
+		if c := oldestRequest(a.reqs).Compare(oldestRequest(b.reqs)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.subID, b.subID)
+	})
+	return slots
+}
+
+// weightedFairScheduler interleaves subscriptions using weighted fair queuing. Every
+// request has unit cost, so this reduces to deficit round robin without carry-over:
+// in each round a subscription may dispatch up to quantum*priority requests. Within a
+// round, subscriptions with a higher priority are served first, followed by those
+// whose oldest request has waited the longest.
+type weightedFairScheduler struct {
+	quantum   int
+	schedules job.VRFSubscriptionSchedules
+}
+
+func (s weightedFairScheduler) schedule(confirmed map[string][]pendingRequest) []subRequests {
+	type subQueue struct {
+		subID    string
+		reqs     []pendingRequest
+		priority uint32
+		oldest   time.Time
+	}
+
+	quantum := max(s.quantum, 1)
+	queues := make([]*subQueue, 0, len(confirmed))
+	for subID, reqs := range confirmed {
+		if len(reqs) == 0 {
+			continue
+		}
+		q := &subQueue{subID: subID, reqs: reqs, priority: 1, oldest: oldestRequest(reqs)}
+		if sched, ok := s.schedules.ForSub(subID); ok && sched.Priority > 0 {
+			q.priority = sched.Priority
+		}
+		queues = append(queues, q)
+	}
+	slices.SortFunc(queues, func(a, b *subQueue) int {
+		if c := cmp.Compare(b.priority, a.priority); c != 0 {
+			return c
+		}
+		if c := a.oldest.Compare(b.oldest); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.subID, b.subID)
+	})
+
+	var slots []subRequests
+	for remaining := len(queues); remaining > 0; {
+		for _, q := range queues {
+			if len(q.reqs) == 0 {
+				continue
+			}
+			n := min(len(q.reqs), quantum*int(q.priority))
+			slots = append(slots, subRequests{subID: q.subID, reqs: q.reqs[:n]})
+			q.reqs = q.reqs[n:]
+			if len(q.reqs) == 0 {
+				remaining--
+			}
+		}
+	}
+	return slots
+}
+
+// oldestRequest returns the earliest utcTimestamp among the given requests.
+func oldestRequest(reqs []pendingRequest) time.Time {
+	var oldest time.Time
+	for i, r := range reqs {
+		if i == 0 || r.utcTimestamp.Before(oldest) {
+			oldest = r.utcTimestamp
+		}
+	}
+	return oldest
+}
@@ -0,0 +1,108 @@
+package v2
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+func newScheduledRequest(reqID int64, subID uint64, age time.Duration) pendingRequest {
+	return pendingRequest{
+		req: NewV2RandomWordsRequested(&vrf_coordinator_v2.VRFCoordinatorV2RandomWordsRequested{
+			RequestId: big.NewInt(reqID),
+			SubId:     subID,
+		}),
+		utcTimestamp: time.Now().UTC().Add(-age),
+	}
+}
+
+func slotSummary(slots []subRequests) (summary [][2]any) {
+	for _, s := range slots {
+		summary = append(summary, [2]any{s.subID, len(s.reqs)})
+	}
+	return
+}
+
+func Test_NewFulfillmentScheduler(t *testing.T) {
+	require.IsType(t, fifoScheduler{}, newFulfillmentScheduler(nil))
+	require.IsType(t, fifoScheduler{}, newFulfillmentScheduler(&job.VRFSpec{}))
+	require.IsType(t, fifoScheduler{}, newFulfillmentScheduler(&job.VRFSpec{FulfillmentScheduler: job.VRFSchedulerFIFO}))
+	require.IsType(t, weightedFairScheduler{}, newFulfillmentScheduler(&job.VRFSpec{FulfillmentScheduler: job.VRFSchedulerWeightedFair}))
+}
+
+func Test_FIFOScheduler_Schedule(t *testing.T) {
+	confirmed := map[string][]pendingRequest{
+		"1": {newScheduledRequest(1, 1, time.Minute), newScheduledRequest(2, 1, time.Minute)},
+		"2": {newScheduledRequest(3, 2, time.Hour)},
+		"3": {},
+	}
+
+	slots := fifoScheduler{}.schedule(confirmed)
+	require.Equal(t, [][2]any{{"2", 1}, {"1", 2}}, slotSummary(slots))
+}
+
+func Test_WeightedFairScheduler_Schedule(t *testing.T) {
+	t.Run("interleaves subscriptions in quanta", func(t *testing.T) {
+		var busy []pendingRequest
+		for i := int64(0); i < 5; i++ {
+			busy = append(busy, newScheduledRequest(i, 1, time.Hour))
+		}
+		confirmed := map[string][]pendingRequest{
+			"1": busy,
+			"2": {newScheduledRequest(10, 2, time.Minute)},
+		}
+
+		slots := weightedFairScheduler{quantum: 2}.schedule(confirmed)
+		require.Equal(t, [][2]any{{"1", 2}, {"2", 1}, {"1", 2}, {"1", 1}}, slotSummary(slots))
+
+		// The busy subscription's requests keep their relative order across slots.
+		var reqIDs []int64
+		for _, s := range slots {
+			if s.subID != "1" {
+				continue
+			}
+			for _, r := range s.reqs {
+				reqIDs = append(reqIDs, r.req.RequestID().Int64())
+			}
+		}
+		require.Equal(t, []int64{0, 1, 2, 3, 4}, reqIDs)
+	})
+
+	t.Run("higher priority gets a larger share and goes first", func(t *testing.T) {
+		var noisy, auction []pendingRequest
+		for i := int64(0); i < 4; i++ {
+			noisy = append(noisy, newScheduledRequest(i, 1, time.Hour))
+			auction = append(auction, newScheduledRequest(10+i, 2, time.Minute))
+		}
+		confirmed := map[string][]pendingRequest{
+			"1": noisy,
+			"2": auction,
+		}
+
+		slots := weightedFairScheduler{
+			quantum: 1,
+			schedules: job.VRFSubscriptionSchedules{
+				{SubID: "2", Priority: 3},
+			},
+		}.schedule(confirmed)
+		require.Equal(t, [][2]any{{"2", 3}, {"1", 1}, {"2", 1}, {"1", 1}, {"1", 1}, {"1", 1}}, slotSummary(slots))
+	})
+
+	t.Run("zero quantum falls back to one", func(t *testing.T) {
+		confirmed := map[string][]pendingRequest{
+			"1": {newScheduledRequest(1, 1, time.Minute), newScheduledRequest(2, 1, time.Minute)},
+		}
+
+		slots := weightedFairScheduler{}.schedule(confirmed)
+		require.Equal(t, [][2]any{{"1", 1}, {"1", 1}}, slotSummary(slots))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		require.Empty(t, weightedFairScheduler{quantum: 5}.schedule(map[string][]pendingRequest{}))
+	})
+}
@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"time"
@@ -262,6 +263,17 @@ func (lsn *listenerV2) processBatch(
 		return
 	}
 	ll.Infow("Enqueued fulfillment", "ethTxID", ethTX.GetID())
+	lsn.batchSizer.recordSent(ethTX.ID, batch.totalGasLimit, len(batch.reqIDs), time.Now())
+	vrfcommon.ObserveBatchSize(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, batch.version, len(batch.reqIDs))
+
+	for _, reqID := range batch.reqIDs {
+		lsn.lifecycle.Record(ctx, reqID, vrfcommon.StageEnqueued, vrfcommon.LifecycleDetails{
+			"txID":        ethTX.ID,
+			"fromAddress": fromAddress,
+			"kind":        "batch",
+			"batchSize":   len(batch.reqIDs),
+		})
+	}
 
 	// mark requests as processed since the fulfillment has been successfully enqueued
 	// to the txm.
@@ -277,7 +289,7 @@ func (lsn *listenerV2) processBatch(
 
 // getReadyAndExpired filters out requests that are expired from the given pendingRequest slice
 // and returns requests that are ready for processing.
-func (lsn *listenerV2) getReadyAndExpired(l logger.Logger, reqs []pendingRequest) (ready []pendingRequest, expired []string) {
+func (lsn *listenerV2) getReadyAndExpired(ctx context.Context, l logger.Logger, reqs []pendingRequest) (ready []pendingRequest, expired []string) {
 	for _, req := range reqs {
 		// Check if we can ignore the request due to its age.
 		if time.Now().UTC().Sub(req.utcTimestamp) >= lsn.job.VRFSpec.RequestTimeout {
@@ -286,6 +298,9 @@ func (lsn *listenerV2) getReadyAndExpired(l logger.Logger, reqs []pendingRequest
 				"txHash", req.req.Raw().TxHash)
 			expired = append(expired, req.req.RequestID().String())
 			vrfcommon.IncDroppedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, vrfcommon.V2, vrfcommon.ReasonAge)
+			lsn.lifecycle.RecordDropped(ctx, req.req.RequestID(), vrfcommon.ReasonAge, vrfcommon.LifecycleDetails{
+				"requestTimeout": lsn.job.VRFSpec.RequestTimeout.String(),
+			})
 			continue
 		}
 		// we always check if the requests are already fulfilled prior to trying to fulfill them again
@@ -560,8 +560,9 @@ func (lsn *listenerV2) filterBatchRevertedTxn(ctx context.Context,
 	reqCommitments := abi.ConvertType(unpackedInputs[1], new([]vrf_coordinator_v2.VRFCoordinatorV2RequestCommitment)).(*[]vrf_coordinator_v2.VRFCoordinatorV2RequestCommitment)
 
 	proofReqIDs := make([]common.Hash, 0)
-	keyHash := lsn.job.VRFSpec.PublicKey.MustHash()
 	for _, proof := range *proofs {
+		// The proofs of a batch may be for different keys during a key rotation.
+		keyHash := crypto.Keccak256Hash(common.LeftPadBytes(proof.Pk[0].Bytes(), 32), common.LeftPadBytes(proof.Pk[1].Bytes(), 32))
 		payload, err := evmutils.ABIEncode(`[{"type":"bytes32"},{"type":"uint256"}]`, keyHash, proof.Seed)
 		if err != nil {
 			return nil, fmt.Errorf("ABI Encode Error: (err %w), (keyHash %v), (prood: %v)", err, keyHash, proof.Seed)
@@ -572,7 +573,7 @@ func (lsn *listenerV2) filterBatchRevertedTxn(ctx context.Context,
 
 	// BatchVRFCoordinatorV2
 	revertedTxns := make([]RevertedVRFTxn, 0)
-	for _, log := range txnReceiptDB.EVMReceipt.Logs {
+	for _, log := range batchErrorLogs(txnReceiptDB.EVMReceipt, batchCoordinatorV2ABI.Events) {
 		if log.Topics[0] != batchCoordinatorV2ABI.Events["RawErrorReturned"].ID {
 			continue
 		}
@@ -637,6 +638,21 @@ func (lsn *listenerV2) filterBatchRevertedTxn(ctx context.Context,
 	return revertedTxns, nil
 }
 
+// batchErrorLogs returns the ErrorReturned and RawErrorReturned logs the batch
+// coordinator emitted in a batch transaction, one for each fulfillment that reverted.
+func batchErrorLogs(receipt evmtypes.Receipt, batchEvents map[string]abi.Event) []*evmtypes.Log {
+	var logs []*evmtypes.Log
+	for _, lg := range receipt.Logs {
+		if lg == nil || len(lg.Topics) == 0 {
+			continue
+		}
+		if lg.Topics[0] == batchEvents["ErrorReturned"].ID || lg.Topics[0] == batchEvents["RawErrorReturned"].ID {
+			logs = append(logs, lg)
+		}
+	}
+	return logs
+}
+
 // enqueueForceFulfillment enqueues a forced fulfillment through the
 // VRFOwner contract. It estimates gas again on the transaction due
 // to the extra steps taken within VRFOwner.fulfillRandomWords.
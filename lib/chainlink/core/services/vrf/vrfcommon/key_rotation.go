@@ -0,0 +1,175 @@
+package vrfcommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+)
+
+// KeyRotationStatus describes how far a KeyRotation has progressed.
+type KeyRotationStatus string
+
+const (
+	// KeyRotationOverlap means requests for both the old and the new key are answered.
+	KeyRotationOverlap KeyRotationStatus = "overlap"
+	// KeyRotationDraining means the overlap window has passed, but the old key is not
+	// retired yet because pending requests still reference it.
+	KeyRotationDraining KeyRotationStatus = "draining"
+	// KeyRotationRetired means the old key no longer answers requests, and can be
+	// deleted once no other job uses it.
+	KeyRotationRetired KeyRotationStatus = "retired"
+)
+
+var (
+	ErrNoJobsForKey             = errors.New("no VRF jobs use this key")
+	ErrKeyRotationInProgress    = errors.New("a key rotation is already in progress")
+	ErrKeyRotationKeysNotUnique = errors.New("the new key must differ from the old key")
+)
+
+// KeyRotation is the rotation of the VRF key of a single job from OldPublicKey to
+// NewPublicKey. The job's spec uses the new key as soon as the rotation starts, while
+// the old key keeps answering requests until it is retired.
+type KeyRotation struct {
+	ID           int64
+	JobID        int32
+	OldPublicKey secp256k1.PublicKey
+	NewPublicKey secp256k1.PublicKey
+	OverlapUntil time.Time
+	// PendingRequests is the number of unfulfilled requests for the old key, as last
+	// observed by the job.
+	PendingRequests int64
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RetiredAt       *time.Time
+}
+
+// Status returns the status of the rotation at the given time.
+func (r KeyRotation) Status(now time.Time) KeyRotationStatus {
+	switch {
+	case r.RetiredAt != nil:
+		return KeyRotationRetired
+	case now.Before(r.OverlapUntil):
+		return KeyRotationOverlap
+	default:
+		return KeyRotationDraining
+	}
+}
+
+type KeyRotationORM interface {
+	StartKeyRotations(ctx context.Context, oldKey, newKey secp256k1.PublicKey, overlap time.Duration) ([]KeyRotation, error)
+	FindKeyRotations(ctx context.Context) ([]KeyRotation, error)
+	FindKeyRotationsForJob(ctx context.Context, jobID int32) ([]KeyRotation, error)
+	UpdateKeyRotationPendingRequests(ctx context.Context, id int64, pending int64) error
+	RetireKeyRotation(ctx context.Context, id int64) error
+}
+
+type keyRotationORM struct {
+	ds sqlutil.DataSource
+}
+
+var _ KeyRotationORM = (*keyRotationORM)(nil)
+
+func NewKeyRotationORM(ds sqlutil.DataSource) KeyRotationORM {
+	return &keyRotationORM{ds: ds}
+}
+
+// StartKeyRotations starts rotating every VRF job using oldKey to newKey, and points
+// their specs at newKey. The old key keeps answering requests for the given overlap,
+// or if zero, for the keyRotationOverlap of each job's spec.
+func (o *keyRotationORM) StartKeyRotations(ctx context.Context, oldKey, newKey secp256k1.PublicKey, overlap time.Duration) (rotations []KeyRotation, err error) {
+	if oldKey == newKey {
+		return nil, ErrKeyRotationKeysNotUnique
+	}
+	err = sqlutil.TransactDataSource(ctx, o.ds, nil, func(tx sqlutil.DataSource) error {
+		var inProgress bool
+		if err := tx.GetContext(ctx, &inProgress, `SELECT EXISTS (
+				SELECT 1 FROM vrf_key_rotations r
+				INNER JOIN jobs j ON j.id = r.job_id
+				INNER JOIN vrf_specs s ON s.id = j.vrf_spec_id
+				WHERE s.public_key = $1 AND r.retired_at IS NULL
+			)`, oldKey); err != nil {
+			return fmt.Errorf("failed to check for vrf key rotations in progress: %w", err)
+		}
+		if inProgress {
+			return ErrKeyRotationInProgress
+		}
+
+		if err := tx.SelectContext(ctx, &rotations, `INSERT INTO vrf_key_rotations (job_id, old_public_key, new_public_key, overlap_until, created_at, updated_at)
+			SELECT j.id, s.public_key, $2,
+				NOW() + (CASE WHEN $3::bigint > 0 THEN $3::bigint
+					WHEN s.key_rotation_overlap > 0 THEN s.key_rotation_overlap
+					ELSE COALESCE(s.request_timeout, 0) END / 1000) * interval '1 microsecond',
+				NOW(), NOW()
+			FROM jobs j
+			INNER JOIN vrf_specs s ON s.id = j.vrf_spec_id
+			WHERE s.public_key = $1
+			ORDER BY j.id
+			RETURNING *`, oldKey, newKey, overlap.Nanoseconds()); err != nil {
+			return fmt.Errorf("failed to insert vrf key rotations: %w", err)
+		}
+		if len(rotations) == 0 {
+			return ErrNoJobsForKey
+		}
+
+		_, err := tx.ExecContext(ctx, `UPDATE vrf_specs SET public_key = $2, updated_at = NOW() WHERE public_key = $1`, oldKey, newKey)
+		if err != nil {
+			return fmt.Errorf("failed to update vrf spec public keys: %w", err)
+		}
+		return nil
+	})
+	return
+}
+
+// FindKeyRotations returns all key rotations, most recent first.
+func (o *keyRotationORM) FindKeyRotations(ctx context.Context) (rotations []KeyRotation, err error) {
+	err = o.ds.SelectContext(ctx, &rotations, `SELECT * FROM vrf_key_rotations ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find vrf key rotations: %w", err)
+	}
+	return rotations, nil
+}
+
+// FindKeyRotationsForJob returns the key rotations of the given job, in the order they
+// were started.
+func (o *keyRotationORM) FindKeyRotationsForJob(ctx context.Context, jobID int32) (rotations []KeyRotation, err error) {
+	err = o.ds.SelectContext(ctx, &rotations, `SELECT * FROM vrf_key_rotations WHERE job_id = $1 ORDER BY id ASC`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find vrf key rotations for job: %w", err)
+	}
+	return rotations, nil
+}
+
+// UpdateKeyRotationPendingRequests records the number of unfulfilled requests for the
+// old key of a rotation that is in progress.
+func (o *keyRotationORM) UpdateKeyRotationPendingRequests(ctx context.Context, id int64, pending int64) error {
+	_, err := o.ds.ExecContext(ctx, `UPDATE vrf_key_rotations SET pending_requests = $2, updated_at = NOW()
+		WHERE id = $1 AND retired_at IS NULL AND pending_requests <> $2`, id, pending)
+	if err != nil {
+		return fmt.Errorf("failed to update vrf key rotation pending requests: %w", err)
+	}
+	return nil
+}
+
+// RetireKeyRotation marks the old key of a rotation as retired.
+func (o *keyRotationORM) RetireKeyRotation(ctx context.Context, id int64) error {
+	res, err := o.ds.ExecContext(ctx, `UPDATE vrf_key_rotations SET retired_at = NOW(), pending_requests = 0, updated_at = NOW()
+		WHERE id = $1 AND retired_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to retire vrf key rotation: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
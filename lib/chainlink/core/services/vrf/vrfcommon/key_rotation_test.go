@@ -0,0 +1,138 @@
+package vrfcommon_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/testdata/testspecs"
+)
+
+func TestKeyRotationORM(t *testing.T) {
+	ctx := testutils.Context(t)
+	config := configtest.NewTestGeneralConfig(t)
+	db := pgtest.NewSqlxDB(t)
+	lggr := logger.TestLogger(t)
+	jobORM := job.NewORM(db, pipeline.NewORM(db, lggr, config.JobPipeline().MaxSuccessfulRuns()), bridges.NewORM(db), cltest.NewKeyStore(t, db), lggr)
+	orm := vrfcommon.NewKeyRotationORM(db)
+
+	oldKey := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PublicKey
+	newKey := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(2)).PublicKey
+	otherKey := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(3)).PublicKey
+
+	createJob := func(publicKey string, overlap time.Duration) job.Job {
+		toml := testspecs.GenerateVRFSpec(testspecs.VRFSpecParams{
+			JobID:     uuid.New().String(),
+			Name:      uuid.New().String(),
+			PublicKey: publicKey,
+		}).Toml()
+		if overlap > 0 {
+			toml += "\nkeyRotationOverlap = \"" + overlap.String() + "\"\n"
+		}
+		jb, err := vrfcommon.ValidatedVRFSpec(toml)
+		require.NoError(t, err)
+		require.NoError(t, jobORM.CreateJob(ctx, &jb))
+		return jb
+	}
+	jb1 := createJob(oldKey.String(), time.Hour)
+	jb2 := createJob(oldKey.String(), 0)
+	jb3 := createJob(otherKey.String(), 0)
+
+	_, err := orm.StartKeyRotations(ctx, oldKey, oldKey, 0)
+	require.ErrorIs(t, err, vrfcommon.ErrKeyRotationKeysNotUnique)
+	_, err = orm.StartKeyRotations(ctx, newKey, oldKey, 0)
+	require.ErrorIs(t, err, vrfcommon.ErrNoJobsForKey)
+
+	start := time.Now()
+	rotations, err := orm.StartKeyRotations(ctx, oldKey, newKey, 0)
+	require.NoError(t, err)
+	require.Len(t, rotations, 2)
+	assert.Equal(t, jb1.ID, rotations[0].JobID)
+	assert.Equal(t, jb2.ID, rotations[1].JobID)
+	for _, r := range rotations {
+		assert.Equal(t, oldKey, r.OldPublicKey)
+		assert.Equal(t, newKey, r.NewPublicKey)
+		assert.Nil(t, r.RetiredAt)
+		assert.Equal(t, vrfcommon.KeyRotationOverlap, r.Status(start))
+	}
+	// The spec's keyRotationOverlap applies, defaulting to the request timeout.
+	assert.WithinDuration(t, start.Add(time.Hour), rotations[0].OverlapUntil, time.Minute)
+	assert.WithinDuration(t, start.Add(24*time.Hour), rotations[1].OverlapUntil, time.Minute)
+	assert.Equal(t, vrfcommon.KeyRotationDraining, rotations[0].Status(start.Add(2*time.Hour)))
+
+	// The specs now use the new key.
+	var keys []string
+	require.NoError(t, db.Select(&keys, `SELECT encode(public_key, 'hex') FROM vrf_specs ORDER BY id`))
+	assert.Equal(t, []string{newKey.String()[2:], newKey.String()[2:], otherKey.String()[2:]}, keys)
+
+	t.Run("only one rotation in progress per job", func(t *testing.T) {
+		_, err := orm.StartKeyRotations(ctx, newKey, otherKey, 0)
+		require.ErrorIs(t, err, vrfcommon.ErrKeyRotationInProgress)
+	})
+
+	t.Run("explicit overlap", func(t *testing.T) {
+		rotations, err := orm.StartKeyRotations(ctx, otherKey, oldKey, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, rotations, 1)
+		assert.Equal(t, jb3.ID, rotations[0].JobID)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), rotations[0].OverlapUntil, 30*time.Second)
+	})
+
+	t.Run("pending requests and retirement", func(t *testing.T) {
+		id := rotations[0].ID
+		require.NoError(t, orm.UpdateKeyRotationPendingRequests(ctx, id, 3))
+		found, err := orm.FindKeyRotationsForJob(ctx, jb1.ID)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, int64(3), found[0].PendingRequests)
+
+		require.NoError(t, orm.RetireKeyRotation(ctx, id))
+		require.Error(t, orm.RetireKeyRotation(ctx, id))
+		found, err = orm.FindKeyRotationsForJob(ctx, jb1.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found[0].RetiredAt)
+		assert.Zero(t, found[0].PendingRequests)
+		assert.Equal(t, vrfcommon.KeyRotationRetired, found[0].Status(time.Now()))
+
+		all, err := orm.FindKeyRotations(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
+
+	t.Run("rotate back to a previous key", func(t *testing.T) {
+		require.NoError(t, orm.RetireKeyRotation(ctx, rotations[1].ID))
+		back, err := orm.StartKeyRotations(ctx, newKey, oldKey, 0)
+		require.NoError(t, err)
+		require.Len(t, back, 2)
+		for _, r := range back {
+			require.NoError(t, orm.RetireKeyRotation(ctx, r.ID))
+		}
+		found, err := orm.FindKeyRotationsForJob(ctx, jb3.ID)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		require.NoError(t, orm.RetireKeyRotation(ctx, found[0].ID))
+
+		// jb1 and jb2 rotate away from oldKey a second time.
+		again, err := orm.StartKeyRotations(ctx, oldKey, newKey, 0)
+		require.NoError(t, err)
+		require.Len(t, again, 3)
+		found, err = orm.FindKeyRotationsForJob(ctx, jb1.ID)
+		require.NoError(t, err)
+		require.Len(t, found, 3)
+		assert.Equal(t, found[0].OldPublicKey, found[2].OldPublicKey)
+	})
+}
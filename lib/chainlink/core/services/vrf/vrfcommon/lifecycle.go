@@ -0,0 +1,278 @@
+package vrfcommon
+
+import (
+	"container/list"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+// LifecycleStage describes a step a VRF request goes through on this node, from the
+// moment its log is seen until its fulfillment lands on-chain.
+type LifecycleStage string
+
+const (
+	// StageLogReceived is recorded when the log listener picks up the request log.
+	StageLogReceived LifecycleStage = "log_received"
+	// StageSkippedInflight is recorded when the request log is skipped because the
+	// InflightCache already holds it.
+	StageSkippedInflight LifecycleStage = "skipped_inflight"
+	// StageAwaitingConfirmations is recorded while the request waits for its required
+	// number of confirmations or for its retry backoff to elapse.
+	StageAwaitingConfirmations LifecycleStage = "awaiting_confirmations"
+	// StageAlreadyFulfilled is recorded when the coordinator reports the request as
+	// already fulfilled.
+	StageAlreadyFulfilled LifecycleStage = "already_fulfilled"
+	// StageSimulated is recorded after the fulfillment pipeline has been simulated.
+	StageSimulated LifecycleStage = "simulated"
+	// StageDropped is recorded when the request is removed from the queue without
+	// being fulfilled.
+	StageDropped LifecycleStage = "dropped"
+	// StageEnqueued is recorded when a fulfillment transaction is handed to the txm.
+	StageEnqueued LifecycleStage = "enqueued"
+	// StageFulfilled is recorded when the LogDeduper delivers the fulfillment log.
+	StageFulfilled LifecycleStage = "fulfilled"
+)
+
+// LifecycleDetails holds the stage specific data of a LifecycleEvent.
+type LifecycleDetails map[string]any
+
+// Value returns this instance serialized for database storage.
+func (d LifecycleDetails) Value() (driver.Value, error) {
+	if d == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(d)
+}
+
+// Scan reads the database value and returns an instance.
+func (d *LifecycleDetails) Scan(value any) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", value)
+	}
+	return json.Unmarshal(b, d)
+}
+
+// LifecycleEvent is the persisted record of a request reaching a LifecycleStage.
+// Each request has at most one event per job and stage. Repeated observations
+// update the details and bump Occurrences.
+type LifecycleEvent struct {
+	ID          int64
+	EVMChainID  *ubig.Big `db:"evm_chain_id"`
+	JobID       int32
+	RequestID   string
+	Stage       LifecycleStage
+	Details     LifecycleDetails
+	Occurrences int64
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
+
+// TxID returns the ID of the txm transaction referenced by the event, if any.
+func (e LifecycleEvent) TxID() (int64, bool) {
+	switch v := e.Details["txID"].(type) {
+	case float64:
+		return int64(v), true
+	case int64:
+		return v, true
+	case json.Number:
+		id, err := v.Int64()
+		return id, err == nil
+	default:
+		return 0, false
+	}
+}
+
+type LifecycleORM interface {
+	UpsertLifecycleEvent(ctx context.Context, e LifecycleEvent) error
+	FindLifecycleEvents(ctx context.Context, requestID string) ([]LifecycleEvent, error)
+	DeleteLifecycleEventsBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type lifecycleORM struct {
+	ds sqlutil.DataSource
+}
+
+var _ LifecycleORM = (*lifecycleORM)(nil)
+
+func NewLifecycleORM(ds sqlutil.DataSource) LifecycleORM {
+	return &lifecycleORM{ds: ds}
+}
+
+// UpsertLifecycleEvent inserts the event, or updates the details of the existing event
+// for the same request and stage.
+func (o *lifecycleORM) UpsertLifecycleEvent(ctx context.Context, e LifecycleEvent) error {
+	_, err := o.ds.ExecContext(ctx, `INSERT INTO vrf_request_lifecycle_events (evm_chain_id, job_id, request_id, stage, details, occurrences, first_seen_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, 1, NOW(), NOW())
+		ON CONFLICT (evm_chain_id, job_id, request_id, stage) DO UPDATE SET
+			details = EXCLUDED.details,
+			occurrences = vrf_request_lifecycle_events.occurrences + 1,
+			last_seen_at = EXCLUDED.last_seen_at`,
+		e.EVMChainID, e.JobID, e.RequestID, e.Stage, e.Details)
+	if err != nil {
+		return fmt.Errorf("failed to upsert vrf request lifecycle event: %w", err)
+	}
+	return nil
+}
+
+// FindLifecycleEvents returns all events recorded for the given request ID, across
+// chains and jobs, in the order they were first seen.
+func (o *lifecycleORM) FindLifecycleEvents(ctx context.Context, requestID string) (events []LifecycleEvent, err error) {
+	err = o.ds.SelectContext(ctx, &events, `SELECT * FROM vrf_request_lifecycle_events
+		WHERE request_id = $1
+		ORDER BY first_seen_at ASC, id ASC`, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find vrf request lifecycle events: %w", err)
+	}
+	return events, nil
+}
+
+// DeleteLifecycleEventsBefore deletes the events of all requests that have not been
+// seen since before the given time.
+func (o *lifecycleORM) DeleteLifecycleEventsBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := o.ds.ExecContext(ctx, `DELETE FROM vrf_request_lifecycle_events
+		WHERE request_id IN (
+			SELECT request_id FROM vrf_request_lifecycle_events
+			GROUP BY request_id
+			HAVING MAX(last_seen_at) < $1
+		)`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete vrf request lifecycle events: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// maxRecordedLifecycleKeys bounds the number of request stages for which the
+// LifecycleRecorder remembers the last persisted details. The least recently
+// recorded stages are forgotten first.
+const maxRecordedLifecycleKeys = 10_000
+
+type lifecycleKey struct {
+	requestID string
+	stage     LifecycleStage
+}
+
+type recordedDetails struct {
+	key     lifecycleKey
+	details string
+}
+
+// LifecycleRecorder persists the lifecycle of the VRF requests handled by a single job.
+// Failing to record an event never affects request processing, errors are only logged.
+// A nil *LifecycleRecorder discards all events.
+type LifecycleRecorder struct {
+	orm     LifecycleORM
+	lggr    logger.Logger
+	chainID *ubig.Big
+	jobID   int32
+
+	mu sync.Mutex
+	// recorded holds the details last persisted for each request stage, so that the
+	// same observation made on every poll is only written once. lru orders its
+	// elements from the most to the least recently recorded.
+	recorded map[lifecycleKey]*list.Element
+	lru      *list.List
+}
+
+func NewLifecycleRecorder(orm LifecycleORM, lggr logger.Logger, chainID *big.Int, jobID int32) *LifecycleRecorder {
+	return &LifecycleRecorder{
+		orm:      orm,
+		lggr:     logger.Named(lggr, "LifecycleRecorder"),
+		chainID:  ubig.New(chainID),
+		jobID:    jobID,
+		recorded: make(map[lifecycleKey]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// Record persists that the given request reached the given stage. The write happens
+// outside of the recorder lock, so that parallel fulfillments are not serialized on it.
+func (r *LifecycleRecorder) Record(ctx context.Context, requestID *big.Int, stage LifecycleStage, details LifecycleDetails) {
+	if r == nil {
+		return
+	}
+	b, err := json.Marshal(details)
+	if err != nil {
+		r.lggr.Warnw("Failed to marshal vrf request lifecycle details", "reqID", requestID, "stage", stage, "err", err)
+		return
+	}
+	key := lifecycleKey{requestID: requestID.String(), stage: stage}
+	if r.alreadyRecorded(key, string(b)) {
+		return
+	}
+	err = r.orm.UpsertLifecycleEvent(ctx, LifecycleEvent{
+		EVMChainID: r.chainID,
+		JobID:      r.jobID,
+		RequestID:  key.requestID,
+		Stage:      stage,
+		Details:    details,
+	})
+	if err != nil {
+		r.lggr.Warnw("Failed to record vrf request lifecycle event", "reqID", requestID, "stage", stage, "err", err)
+		return
+	}
+	r.markRecorded(key, string(b))
+}
+
+func (r *LifecycleRecorder) alreadyRecorded(key lifecycleKey, details string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.recorded[key]
+	if !ok || e.Value.(recordedDetails).details != details {
+		return false
+	}
+	r.lru.MoveToFront(e)
+	return true
+}
+
+func (r *LifecycleRecorder) markRecorded(key lifecycleKey, details string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e, ok := r.recorded[key]; ok {
+		e.Value = recordedDetails{key: key, details: details}
+		r.lru.MoveToFront(e)
+		return
+	}
+	r.recorded[key] = r.lru.PushFront(recordedDetails{key: key, details: details})
+	for r.lru.Len() > maxRecordedLifecycleKeys {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.recorded, oldest.Value.(recordedDetails).key)
+	}
+}
+
+// RecordDropped persists that the given request was dropped for the given reason.
+func (r *LifecycleRecorder) RecordDropped(ctx context.Context, requestID *big.Int, reason dropReason, details LifecycleDetails) {
+	if details == nil {
+		details = LifecycleDetails{}
+	}
+	details["reason"] = string(reason)
+	r.Record(ctx, requestID, StageDropped, details)
+}
+
+// Prune deletes the events of requests that have not been seen for longer than the
+// given retention period.
+func (r *LifecycleRecorder) Prune(ctx context.Context, retention time.Duration) {
+	if r == nil {
+		return
+	}
+	deleted, err := r.orm.DeleteLifecycleEventsBefore(ctx, time.Now().Add(-retention))
+	if err != nil {
+		r.lggr.Warnw("Failed to prune vrf request lifecycle events", "err", err)
+		return
+	}
+	if deleted > 0 {
+		r.lggr.Debugw("Pruned vrf request lifecycle events", "deleted", deleted)
+	}
+}
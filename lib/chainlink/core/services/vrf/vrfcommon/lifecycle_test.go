@@ -0,0 +1,113 @@
+package vrfcommon
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+)
+
+func TestLifecycleRecorder(t *testing.T) {
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	orm := NewLifecycleORM(db)
+	chainID := testutils.NewRandomEVMChainID()
+	reqID := big.NewInt(1234)
+
+	r := NewLifecycleRecorder(orm, logger.Test(t), chainID, 1)
+	r.Record(ctx, reqID, StageLogReceived, LifecycleDetails{"blockNumber": 10})
+	// Repeating the same observation does not write again.
+	r.Record(ctx, reqID, StageLogReceived, LifecycleDetails{"blockNumber": 10})
+	r.Record(ctx, reqID, StageSimulated, LifecycleDetails{"success": false, "error": "reverted"})
+	r.Record(ctx, reqID, StageSimulated, LifecycleDetails{"success": true})
+	r.Record(ctx, reqID, StageEnqueued, LifecycleDetails{"txID": int64(42)})
+	r.RecordDropped(ctx, big.NewInt(5678), ReasonAge, nil)
+
+	events, err := orm.FindLifecycleEvents(ctx, reqID.String())
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	assert.Equal(t, StageLogReceived, events[0].Stage)
+	assert.Equal(t, int64(1), events[0].Occurrences)
+	assert.Equal(t, int32(1), events[0].JobID)
+	assert.Equal(t, chainID.String(), events[0].EVMChainID.String())
+
+	assert.Equal(t, StageSimulated, events[1].Stage)
+	assert.Equal(t, int64(2), events[1].Occurrences)
+	assert.Equal(t, true, events[1].Details["success"])
+
+	assert.Equal(t, StageEnqueued, events[2].Stage)
+	txID, ok := events[2].TxID()
+	require.True(t, ok)
+	assert.Equal(t, int64(42), txID)
+	_, ok = events[0].TxID()
+	assert.False(t, ok)
+
+	dropped, err := orm.FindLifecycleEvents(ctx, "5678")
+	require.NoError(t, err)
+	require.Len(t, dropped, 1)
+	assert.Equal(t, StageDropped, dropped[0].Stage)
+	assert.Equal(t, string(ReasonAge), dropped[0].Details["reason"])
+
+	t.Run("prune", func(t *testing.T) {
+		r.Prune(ctx, time.Hour)
+		events, err := orm.FindLifecycleEvents(ctx, reqID.String())
+		require.NoError(t, err)
+		require.Len(t, events, 3)
+
+		_, err = db.ExecContext(ctx, `UPDATE vrf_request_lifecycle_events SET last_seen_at = NOW() - interval '2 hours' WHERE request_id = $1`, reqID.String())
+		require.NoError(t, err)
+		r.Prune(ctx, time.Hour)
+
+		events, err = orm.FindLifecycleEvents(ctx, reqID.String())
+		require.NoError(t, err)
+		require.Empty(t, events)
+		dropped, err = orm.FindLifecycleEvents(ctx, "5678")
+		require.NoError(t, err)
+		require.Len(t, dropped, 1)
+	})
+}
+
+func TestLifecycleRecorder_Nil(t *testing.T) {
+	var r *LifecycleRecorder
+	ctx := testutils.Context(t)
+	r.Record(ctx, big.NewInt(1), StageLogReceived, nil)
+	r.RecordDropped(ctx, big.NewInt(1), ReasonAge, nil)
+	r.Prune(ctx, time.Hour)
+}
+
+type countingLifecycleORM struct {
+	LifecycleORM
+	upserts int
+}
+
+func (o *countingLifecycleORM) UpsertLifecycleEvent(context.Context, LifecycleEvent) error {
+	o.upserts++
+	return nil
+}
+
+func TestLifecycleRecorder_EvictsLeastRecentlyRecorded(t *testing.T) {
+	ctx := testutils.Context(t)
+	orm := &countingLifecycleORM{}
+	r := NewLifecycleRecorder(orm, logger.Test(t), big.NewInt(1), 1)
+
+	for i := range maxRecordedLifecycleKeys {
+		r.Record(ctx, big.NewInt(int64(i)), StageLogReceived, nil)
+	}
+	// Touch the oldest request so that the second oldest is evicted instead.
+	r.Record(ctx, big.NewInt(0), StageLogReceived, nil)
+	r.Record(ctx, big.NewInt(maxRecordedLifecycleKeys), StageLogReceived, nil)
+	require.Equal(t, maxRecordedLifecycleKeys+1, orm.upserts)
+
+	r.Record(ctx, big.NewInt(0), StageLogReceived, nil)
+	assert.Equal(t, maxRecordedLifecycleKeys+1, orm.upserts)
+	r.Record(ctx, big.NewInt(1), StageLogReceived, nil)
+	assert.Equal(t, maxRecordedLifecycleKeys+2, orm.upserts)
+}
@@ -0,0 +1,210 @@
+package vrfcommon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/static"
+)
+
+const (
+	// CurrencyLink is the currency of the LINK balance of a subscription.
+	CurrencyLink = "link"
+	// CurrencyNative is the currency of the native balance of a V2Plus subscription.
+	CurrencyNative = "native"
+)
+
+// LowBalanceAlert is sent to the alert targets of a VRF job when one of its
+// subscriptions is forecast to run out of funds within the job's
+// lowBalanceAlertThreshold, so that it can be topped up before requests are
+// dropped for insufficient balance.
+type LowBalanceAlert struct {
+	JobID         int32     `json:"jobID"`
+	ExternalJobID uuid.UUID `json:"externalJobID"`
+	JobName       string    `json:"jobName"`
+	VRFVersion    Version   `json:"vrfVersion"`
+	SubID         string    `json:"subID"`
+	Currency      string    `json:"currency"`
+	// Balance is the balance not reserved by in-flight fulfillments, in juels or wei.
+	Balance string `json:"balance"`
+	// SpendPerHour is the rate at which confirmed fulfillments spent the balance
+	// recently, in juels or wei.
+	SpendPerHour string `json:"spendPerHour"`
+	// EmptyAt is when the subscription is forecast to run out of funds.
+	EmptyAt  time.Time `json:"emptyAt"`
+	RaisedAt time.Time `json:"raisedAt"`
+}
+
+// LowBalanceAlertTarget is a destination of low balance alerts. Delivery is
+// recorded per target, so that a failed target is retried without alerting the
+// others again.
+type LowBalanceAlertTarget string
+
+const (
+	// LowBalanceAlertTargetWebhook is the lowBalanceWebhookURL of a VRF spec.
+	LowBalanceAlertTargetWebhook LowBalanceAlertTarget = "webhook"
+	// LowBalanceAlertTargetExternalInitiator is the lowBalanceExternalInitiator of a VRF spec.
+	LowBalanceAlertTargetExternalInitiator LowBalanceAlertTarget = "external_initiator"
+)
+
+// LowBalanceAlertTargets returns the alert targets configured in spec.
+func LowBalanceAlertTargets(spec *job.VRFSpec) (targets []LowBalanceAlertTarget) {
+	if spec.LowBalanceWebhookURL != "" {
+		targets = append(targets, LowBalanceAlertTargetWebhook)
+	}
+	if spec.LowBalanceExternalInitiator != "" {
+		targets = append(targets, LowBalanceAlertTargetExternalInitiator)
+	}
+	return
+}
+
+// LowBalanceNotifier notifies an alert target configured in a VRF spec.
+type LowBalanceNotifier interface {
+	Notify(ctx context.Context, spec *job.VRFSpec, target LowBalanceAlertTarget, alert LowBalanceAlert) error
+}
+
+type lowBalanceNotifier struct {
+	bridgeORM bridges.ORM
+	// restrictedHTTPClient calls the webhook URL of the job spec, while
+	// unrestrictedHTTPClient calls external initiators, which are configured by the
+	// node operator and commonly run on the local network.
+	restrictedHTTPClient   *http.Client
+	unrestrictedHTTPClient *http.Client
+}
+
+var _ LowBalanceNotifier = (*lowBalanceNotifier)(nil)
+
+func NewLowBalanceNotifier(bridgeORM bridges.ORM, restrictedHTTPClient, unrestrictedHTTPClient *http.Client) LowBalanceNotifier {
+	return &lowBalanceNotifier{
+		bridgeORM:              bridgeORM,
+		restrictedHTTPClient:   restrictedHTTPClient,
+		unrestrictedHTTPClient: unrestrictedHTTPClient,
+	}
+}
+
+// Notify POSTs the alert to the spec's lowBalanceWebhookURL, or to the URL of its
+// lowBalanceExternalInitiator. The external initiator is authenticated with its
+// outgoing token and secret, as for job notifications.
+func (n *lowBalanceNotifier) Notify(ctx context.Context, spec *job.VRFSpec, target LowBalanceAlertTarget, alert LowBalanceAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal low balance alert: %w", err)
+	}
+	switch target {
+	case LowBalanceAlertTargetWebhook:
+		if spec.LowBalanceWebhookURL == "" {
+			return errors.New("no low balance webhook URL")
+		}
+		return n.post(ctx, n.restrictedHTTPClient, spec.LowBalanceWebhookURL, body, nil)
+	case LowBalanceAlertTargetExternalInitiator:
+		name := spec.LowBalanceExternalInitiator
+		if name == "" {
+			return errors.New("no low balance external initiator")
+		}
+		ei, err := n.bridgeORM.FindExternalInitiatorByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to find external initiator %q: %w", name, err)
+		}
+		if ei.URL == nil {
+			return fmt.Errorf("external initiator %q has no URL", name)
+		}
+		return n.post(ctx, n.unrestrictedHTTPClient, ei.URL.String(), body, func(h http.Header) {
+			h.Set(static.ExternalInitiatorAccessKeyHeader, ei.OutgoingToken)
+			h.Set(static.ExternalInitiatorSecretHeader, ei.OutgoingSecret)
+		})
+	default:
+		return fmt.Errorf("unknown low balance alert target %q", target)
+	}
+}
+
+func (n *lowBalanceNotifier) post(ctx context.Context, client *http.Client, url string, body []byte, setHeaders func(http.Header)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create low balance alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if setHeaders != nil {
+		setHeaders(req.Header)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send low balance alert: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("low balance alert received bad response %q", resp.Status)
+	}
+	return nil
+}
+
+// LowBalanceAlertORM records the targets a job has delivered a low balance alert to,
+// per subscription balance, so that they are not alerted again after a restart.
+type LowBalanceAlertORM interface {
+	// FindLowBalanceAlerts returns the alerts delivered by the given job, keyed by
+	// LowBalanceAlertKey.
+	FindLowBalanceAlerts(ctx context.Context, jobID int32) (map[string]struct{}, error)
+	CreateLowBalanceAlert(ctx context.Context, jobID int32, subID, currency string, target LowBalanceAlertTarget) error
+	// DeleteLowBalanceAlert deletes the alerts of a balance, for every target.
+	DeleteLowBalanceAlert(ctx context.Context, jobID int32, subID, currency string) error
+}
+
+// LowBalanceAlertKey identifies the alert of a balance of a subscription delivered to target.
+func LowBalanceAlertKey(subID, currency string, target LowBalanceAlertTarget) string {
+	return subID + "/" + currency + "/" + string(target)
+}
+
+type lowBalanceAlertORM struct {
+	ds sqlutil.DataSource
+}
+
+var _ LowBalanceAlertORM = (*lowBalanceAlertORM)(nil)
+
+func NewLowBalanceAlertORM(ds sqlutil.DataSource) LowBalanceAlertORM {
+	return &lowBalanceAlertORM{ds: ds}
+}
+
+func (o *lowBalanceAlertORM) FindLowBalanceAlerts(ctx context.Context, jobID int32) (map[string]struct{}, error) {
+	var rows []struct {
+		SubID    string
+		Currency string
+		Target   LowBalanceAlertTarget
+	}
+	if err := o.ds.SelectContext(ctx, &rows, `SELECT sub_id, currency, target FROM vrf_low_balance_alerts WHERE job_id = $1`, jobID); err != nil {
+		return nil, fmt.Errorf("failed to find vrf low balance alerts: %w", err)
+	}
+	alerted := make(map[string]struct{}, len(rows))
+	for _, r := range rows {
+		alerted[LowBalanceAlertKey(r.SubID, r.Currency, r.Target)] = struct{}{}
+	}
+	return alerted, nil
+}
+
+func (o *lowBalanceAlertORM) CreateLowBalanceAlert(ctx context.Context, jobID int32, subID, currency string, target LowBalanceAlertTarget) error {
+	_, err := o.ds.ExecContext(ctx, `INSERT INTO vrf_low_balance_alerts (job_id, sub_id, currency, target, alerted_at)
+		VALUES ($1, $2, $3, $4, NOW()) ON CONFLICT DO NOTHING`, jobID, subID, currency, target)
+	if err != nil {
+		return fmt.Errorf("failed to create vrf low balance alert: %w", err)
+	}
+	return nil
+}
+
+func (o *lowBalanceAlertORM) DeleteLowBalanceAlert(ctx context.Context, jobID int32, subID, currency string) error {
+	_, err := o.ds.ExecContext(ctx, `DELETE FROM vrf_low_balance_alerts WHERE job_id = $1 AND sub_id = $2 AND currency = $3`, jobID, subID, currency)
+	if err != nil {
+		return fmt.Errorf("failed to delete vrf low balance alert: %w", err)
+	}
+	return nil
+}
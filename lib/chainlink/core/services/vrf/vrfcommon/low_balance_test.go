@@ -0,0 +1,142 @@
+package vrfcommon_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	bridgesMocks "github.com/smartcontractkit/chainlink/v2/core/bridges/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/static"
+	"github.com/smartcontractkit/chainlink/v2/core/testdata/testspecs"
+)
+
+func TestLowBalanceNotifier(t *testing.T) {
+	ctx := testutils.Context(t)
+	alert := vrfcommon.LowBalanceAlert{
+		JobID:         1,
+		ExternalJobID: uuid.New(),
+		JobName:       "vrf",
+		VRFVersion:    vrfcommon.V2Plus,
+		SubID:         "42",
+		Currency:      vrfcommon.CurrencyNative,
+		Balance:       "1000",
+		SpendPerHour:  "100",
+		EmptyAt:       time.Now().Add(10 * time.Hour).UTC().Truncate(time.Second),
+		RaisedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+
+	type received struct {
+		alert vrfcommon.LowBalanceAlert
+		token string
+	}
+	newServer := func(status int) (*httptest.Server, chan received) {
+		ch := make(chan received, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var a vrfcommon.LowBalanceAlert
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&a))
+			ch <- received{alert: a, token: r.Header.Get(static.ExternalInitiatorAccessKeyHeader)}
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(srv.Close)
+		return srv, ch
+	}
+
+	t.Run("webhook and external initiator", func(t *testing.T) {
+		webhook, webhookCh := newServer(http.StatusOK)
+		ei, eiCh := newServer(http.StatusOK)
+		bridgeORM := bridgesMocks.NewORM(t)
+		bridgeORM.On("FindExternalInitiatorByName", ctx, "alerter").Return(bridges.ExternalInitiator{
+			Name:          "alerter",
+			URL:           cltest.MustWebURL(t, ei.URL),
+			OutgoingToken: "token",
+		}, nil)
+
+		n := vrfcommon.NewLowBalanceNotifier(bridgeORM, http.DefaultClient, http.DefaultClient)
+		spec := &job.VRFSpec{
+			LowBalanceWebhookURL:        webhook.URL,
+			LowBalanceExternalInitiator: "alerter",
+		}
+		assert.Equal(t, []vrfcommon.LowBalanceAlertTarget{vrfcommon.LowBalanceAlertTargetWebhook, vrfcommon.LowBalanceAlertTargetExternalInitiator},
+			vrfcommon.LowBalanceAlertTargets(spec))
+		require.NoError(t, n.Notify(ctx, spec, vrfcommon.LowBalanceAlertTargetWebhook, alert))
+		require.NoError(t, n.Notify(ctx, spec, vrfcommon.LowBalanceAlertTargetExternalInitiator, alert))
+
+		got := <-webhookCh
+		assert.Equal(t, alert, got.alert)
+		assert.Empty(t, got.token)
+		got = <-eiCh
+		assert.Equal(t, alert, got.alert)
+		assert.Equal(t, "token", got.token)
+	})
+
+	t.Run("bad response", func(t *testing.T) {
+		webhook, _ := newServer(http.StatusInternalServerError)
+		n := vrfcommon.NewLowBalanceNotifier(bridgesMocks.NewORM(t), http.DefaultClient, http.DefaultClient)
+		err := n.Notify(ctx, &job.VRFSpec{LowBalanceWebhookURL: webhook.URL}, vrfcommon.LowBalanceAlertTargetWebhook, alert)
+		require.ErrorContains(t, err, "bad response")
+	})
+
+	t.Run("target not configured", func(t *testing.T) {
+		webhook, _ := newServer(http.StatusOK)
+		n := vrfcommon.NewLowBalanceNotifier(bridgesMocks.NewORM(t), http.DefaultClient, http.DefaultClient)
+		err := n.Notify(ctx, &job.VRFSpec{LowBalanceWebhookURL: webhook.URL}, vrfcommon.LowBalanceAlertTargetExternalInitiator, alert)
+		require.ErrorContains(t, err, "no low balance external initiator")
+	})
+}
+
+func TestLowBalanceAlertORM(t *testing.T) {
+	ctx := testutils.Context(t)
+	config := configtest.NewTestGeneralConfig(t)
+	db := pgtest.NewSqlxDB(t)
+	lggr := logger.TestLogger(t)
+	jobORM := job.NewORM(db, pipeline.NewORM(db, lggr, config.JobPipeline().MaxSuccessfulRuns()), bridges.NewORM(db), cltest.NewKeyStore(t, db), lggr)
+	orm := vrfcommon.NewLowBalanceAlertORM(db)
+
+	jb, err := vrfcommon.ValidatedVRFSpec(testspecs.GenerateVRFSpec(testspecs.VRFSpecParams{
+		PublicKey: vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PublicKey.String(),
+	}).Toml())
+	require.NoError(t, err)
+	require.NoError(t, jobORM.CreateJob(ctx, &jb))
+
+	webhook, ei := vrfcommon.LowBalanceAlertTargetWebhook, vrfcommon.LowBalanceAlertTargetExternalInitiator
+	require.NoError(t, orm.CreateLowBalanceAlert(ctx, jb.ID, "42", vrfcommon.CurrencyLink, webhook))
+	require.NoError(t, orm.CreateLowBalanceAlert(ctx, jb.ID, "42", vrfcommon.CurrencyLink, webhook))
+	require.NoError(t, orm.CreateLowBalanceAlert(ctx, jb.ID, "42", vrfcommon.CurrencyLink, ei))
+	require.NoError(t, orm.CreateLowBalanceAlert(ctx, jb.ID, "42", vrfcommon.CurrencyNative, webhook))
+	alerted, err := orm.FindLowBalanceAlerts(ctx, jb.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{
+		vrfcommon.LowBalanceAlertKey("42", vrfcommon.CurrencyLink, webhook):   {},
+		vrfcommon.LowBalanceAlertKey("42", vrfcommon.CurrencyLink, ei):        {},
+		vrfcommon.LowBalanceAlertKey("42", vrfcommon.CurrencyNative, webhook): {},
+	}, alerted)
+
+	// Deleting clears every target of the balance.
+	require.NoError(t, orm.DeleteLowBalanceAlert(ctx, jb.ID, "42", vrfcommon.CurrencyLink))
+	alerted, err = orm.FindLowBalanceAlerts(ctx, jb.ID)
+	require.NoError(t, err)
+	assert.Len(t, alerted, 1)
+
+	// Alerts are removed with their job.
+	require.NoError(t, jobORM.DeleteJob(ctx, jb.ID, jb.Type))
+	alerted, err = orm.FindLowBalanceAlerts(ctx, jb.ID)
+	require.NoError(t, err)
+	assert.Empty(t, alerted)
+}
@@ -1,6 +1,8 @@
 package vrfcommon
 
 import (
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -27,6 +29,10 @@ const (
 
 	// ReasonAge describes when a VRF request is dropped due to its age.
 	ReasonAge dropReason = "age"
+
+	// ReasonInvalidConsumer describes when a VRF request is dropped because it was made
+	// by a consumer that is not valid for its subscription.
+	ReasonInvalidConsumer dropReason = "invalid_consumer"
 )
 
 var (
@@ -73,6 +79,59 @@ var (
 			float64(5 * time.Minute),
 		},
 	}, []string{"job_name", "external_job_id", "vrf_version"})
+
+	MetricQueueWaitTime = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "vrf_request_queue_wait_time",
+		Help: "How long a confirmed VRF request waits in the queue before the fulfillment scheduler dispatches it, per subscription.",
+		Buckets: []float64{
+			float64(time.Second),
+			float64(30 * time.Second),
+			float64(time.Minute),
+			float64(2 * time.Minute),
+			float64(5 * time.Minute),
+		},
+	}, []string{"job_name", "external_job_id", "vrf_version", "sub_id"})
+
+	MetricSchedulerDeferredReqs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vrf_scheduler_deferred_request_count",
+		Help: "The number of confirmed VRF requests deferred by the fulfillment scheduler because their subscription reached its max in-flight limit.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "sub_id"})
+
+	MetricBatchGasLimit = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vrf_batch_gas_limit",
+		Help: "The gas limit used to group VRF fulfillments into batches, by the source that bounds it. The effective limit is the minimum of all sources.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "source"})
+
+	MetricBatchOutcomes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vrf_batch_fulfillment_outcome_count",
+		Help: "The number of mined VRF batch fulfillments, by outcome (success, partial_revert or revert).",
+	}, []string{"job_name", "external_job_id", "vrf_version", "outcome"})
+
+	MetricBatchSize = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vrf_batch_size",
+		Help:    "The number of requests in each enqueued VRF batch fulfillment.",
+		Buckets: []float64{1, 2, 4, 8, 16, 32, 64, 128},
+	}, []string{"job_name", "external_job_id", "vrf_version"})
+
+	MetricSubscriptionBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vrf_subscription_balance",
+		Help: "The balance of a VRF subscription not reserved by in-flight fulfillments, in juels or wei by currency.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "sub_id", "currency"})
+
+	MetricSubscriptionSpendRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vrf_subscription_spend_rate",
+		Help: "The rate at which confirmed fulfillments spend the balance of a VRF subscription, in juels or wei per hour by currency.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "sub_id", "currency"})
+
+	MetricSubscriptionTimeUntilEmpty = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vrf_subscription_seconds_until_empty",
+		Help: "The forecast number of seconds until a VRF subscription runs out of funds at its current spend rate, +Inf if it is not spending.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "sub_id", "currency"})
+
+	MetricLowBalanceAlerts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vrf_subscription_low_balance_alert_count",
+		Help: "The number of low balance alerts raised for VRF subscriptions, by whether notifying the alert targets succeeded.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "sub_id", "success"})
 )
 
 func UpdateQueueSize(jobName string, extJobID uuid.UUID, vrfVersion Version, size int) {
@@ -92,3 +151,44 @@ func IncDroppedReqs(jobName string, extJobID uuid.UUID, vrfVersion Version, reas
 func IncDupeReqs(jobName string, extJobID uuid.UUID, vrfVersion Version) {
 	MetricDupeRequests.WithLabelValues(jobName, extJobID.String(), string(vrfVersion)).Inc()
 }
+
+func ObserveQueueWaitTime(jobName string, extJobID uuid.UUID, vrfVersion Version, subID string, wait time.Duration) {
+	MetricQueueWaitTime.WithLabelValues(jobName, extJobID.String(), string(vrfVersion), subID).
+		Observe(float64(wait))
+}
+
+func AddSchedulerDeferredReqs(jobName string, extJobID uuid.UUID, vrfVersion Version, subID string, count int) {
+	MetricSchedulerDeferredReqs.WithLabelValues(jobName, extJobID.String(), string(vrfVersion), subID).
+		Add(float64(count))
+}
+
+func SetBatchGasLimit(jobName string, extJobID uuid.UUID, vrfVersion Version, source string, gasLimit uint64) {
+	MetricBatchGasLimit.WithLabelValues(jobName, extJobID.String(), string(vrfVersion), source).
+		Set(float64(gasLimit))
+}
+
+func IncBatchOutcome(jobName string, extJobID uuid.UUID, vrfVersion Version, outcome string) {
+	MetricBatchOutcomes.WithLabelValues(jobName, extJobID.String(), string(vrfVersion), outcome).Inc()
+}
+
+func ObserveBatchSize(jobName string, extJobID uuid.UUID, vrfVersion Version, size int) {
+	MetricBatchSize.WithLabelValues(jobName, extJobID.String(), string(vrfVersion)).
+		Observe(float64(size))
+}
+
+func SetSubscriptionForecast(jobName string, extJobID uuid.UUID, vrfVersion Version, subID, currency string,
+	balance, spendPerHour float64, untilEmpty time.Duration) {
+	labels := []string{jobName, extJobID.String(), string(vrfVersion), subID, currency}
+	MetricSubscriptionBalance.WithLabelValues(labels...).Set(balance)
+	MetricSubscriptionSpendRate.WithLabelValues(labels...).Set(spendPerHour)
+	seconds := math.Inf(1)
+	if untilEmpty < math.MaxInt64 {
+		seconds = untilEmpty.Seconds()
+	}
+	MetricSubscriptionTimeUntilEmpty.WithLabelValues(labels...).Set(seconds)
+}
+
+func IncLowBalanceAlerts(jobName string, extJobID uuid.UUID, vrfVersion Version, subID string, success bool) {
+	MetricLowBalanceAlerts.WithLabelValues(jobName, extJobID.String(), string(vrfVersion), subID,
+		strconv.FormatBool(success)).Inc()
+}
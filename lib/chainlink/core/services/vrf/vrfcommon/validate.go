@@ -3,6 +3,8 @@ package vrfcommon
 import (
 	"bytes"
 	"fmt"
+	"math/big"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
@@ -57,6 +59,27 @@ func ValidatedVRFSpec(tomlString string) (job.Job, error) {
 	if spec.RequestTimeout == 0 {
 		spec.RequestTimeout = 24 * time.Hour
 	}
+	if spec.KeyRotationOverlap < 0 {
+		return jb, fmt.Errorf("keyRotationOverlap must be >= 0, given: %s", spec.KeyRotationOverlap)
+	}
+	// Requests for the old key can be answered until they time out by default.
+	if spec.KeyRotationOverlap == 0 {
+		spec.KeyRotationOverlap = spec.RequestTimeout
+	}
+
+	if spec.LowBalanceAlertThreshold < 0 {
+		return jb, fmt.Errorf("lowBalanceAlertThreshold must be >= 0, given: %s", spec.LowBalanceAlertThreshold)
+	}
+	if spec.LowBalanceWebhookURL != "" {
+		u, err := url.Parse(spec.LowBalanceWebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return jb, fmt.Errorf("lowBalanceWebhookURL must be an http or https URL, given: %q", spec.LowBalanceWebhookURL)
+		}
+	}
+	// Alert a day before a subscription runs dry, if there is anyone to alert.
+	if spec.LowBalanceAlertThreshold == 0 && (spec.LowBalanceWebhookURL != "" || spec.LowBalanceExternalInitiator != "") {
+		spec.LowBalanceAlertThreshold = 24 * time.Hour
+	}
 
 	if spec.BatchFulfillmentEnabled && spec.BatchCoordinatorAddress == nil {
 		return jb, errors.Wrap(ErrKeyNotSet, "batch coordinator address must be provided if batchFulfillmentEnabled = true")
@@ -79,6 +102,33 @@ func ValidatedVRFSpec(tomlString string) (job.Job, error) {
 		return jb, fmt.Errorf("gasLanePrice must be positive, given: %s", spec.GasLanePrice.String())
 	}
 
+	switch spec.FulfillmentScheduler {
+	case "":
+		spec.FulfillmentScheduler = job.VRFSchedulerFIFO
+	case job.VRFSchedulerFIFO, job.VRFSchedulerWeightedFair:
+	default:
+		return jb, fmt.Errorf("unsupported fulfillmentScheduler %q, must be one of %q or %q",
+			spec.FulfillmentScheduler, job.VRFSchedulerFIFO, job.VRFSchedulerWeightedFair)
+	}
+
+	seenSubs := make(map[string]struct{}, len(spec.SubscriptionSchedules))
+	for i, sched := range spec.SubscriptionSchedules {
+		subID, ok := new(big.Int).SetString(sched.SubID, 10)
+		if !ok || subID.Sign() < 0 {
+			return jb, fmt.Errorf("subscriptionSchedules[%d]: invalid subID %q", i, sched.SubID)
+		}
+		// Normalize so that lookups by the listener's subID strings match.
+		sched.SubID = subID.String()
+		if _, dup := seenSubs[sched.SubID]; dup {
+			return jb, fmt.Errorf("subscriptionSchedules[%d]: duplicate subID %s", i, sched.SubID)
+		}
+		seenSubs[sched.SubID] = struct{}{}
+		if sched.Priority == 0 {
+			sched.Priority = 1
+		}
+		spec.SubscriptionSchedules[i] = sched
+	}
+
 	var foundVRFTask bool
 	for _, t := range jb.Pipeline.Tasks {
 		if t.Type() == pipeline.TaskTypeVRF || t.Type() == pipeline.TaskTypeVRFV2 || t.Type() == pipeline.TaskTypeVRFV2Plus {
@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"context"
+	"net/http"
 	"sync"
 
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
 
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
@@ -26,16 +28,21 @@ type (
 
 	JobRunner interface {
 		RunJob(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta jsonserializable.JSONSerializable) (int64, error)
+		// RunPublicJob runs a job from a request to its public trigger, with the verified
+		// claims of the request in jobRun.meta.
+		RunPublicJob(ctx context.Context, jobUUID uuid.UUID, header http.Header, requestBody []byte) (int64, error)
 	}
 )
 
 var _ job.Delegate = (*Delegate)(nil)
 
-func NewDelegate(runner pipeline.Runner, externalInitiatorManager ExternalInitiatorManager, lggr logger.Logger) *Delegate {
+// NewDelegate returns the delegate of webhook jobs. Public trigger secrets are read from orm,
+// and decrypted with cipher.
+func NewDelegate(runner pipeline.Runner, externalInitiatorManager ExternalInitiatorManager, orm ORM, cipher *bridges.SecretCipher, lggr logger.Logger) *Delegate {
 	lggr = lggr.Named("Webhook")
 	return &Delegate{
 		externalInitiatorManager: externalInitiatorManager,
-		webhookJobRunner:         newWebhookJobRunner(runner, lggr),
+		webhookJobRunner:         newWebhookJobRunner(runner, orm, cipher, lggr),
 		lggr:                     lggr,
 		stopCh:                   make(services.StopChan),
 	}
@@ -105,13 +112,17 @@ type webhookJobRunner struct {
 	specsByUUID   map[uuid.UUID]registeredJob
 	muSpecsByUUID sync.RWMutex
 	runner        pipeline.Runner
+	orm           ORM
+	cipher        *bridges.SecretCipher
 	lggr          logger.Logger
 }
 
-func newWebhookJobRunner(runner pipeline.Runner, lggr logger.Logger) *webhookJobRunner {
+func newWebhookJobRunner(runner pipeline.Runner, orm ORM, cipher *bridges.SecretCipher, lggr logger.Logger) *webhookJobRunner {
 	return &webhookJobRunner{
 		specsByUUID: make(map[uuid.UUID]registeredJob),
 		runner:      runner,
+		orm:         orm,
+		cipher:      cipher,
 		lggr:        lggr.Named("JobRunner"),
 	}
 }
@@ -119,6 +130,8 @@ func newWebhookJobRunner(runner pipeline.Runner, lggr logger.Logger) *webhookJob
 type registeredJob struct {
 	job.Job
 	chRemove services.StopChan
+	// trigger is the public trigger of the job, if enabled.
+	trigger *publicTrigger
 }
 
 func (r *webhookJobRunner) addSpec(spec job.Job) error {
@@ -128,7 +141,15 @@ func (r *webhookJobRunner) addSpec(spec job.Job) error {
 	if exists {
 		return errors.Errorf("a webhook job with that UUID already exists (uuid: %v)", spec.ExternalJobID)
 	}
-	r.specsByUUID[spec.ExternalJobID] = registeredJob{spec, make(chan struct{})}
+	var trigger *publicTrigger
+	if spec.WebhookSpec != nil && spec.WebhookSpec.PublicTrigger != nil {
+		var err error
+		trigger, err = newPublicTrigger(*spec.WebhookSpec, r.orm, r.cipher)
+		if err != nil {
+			return err
+		}
+	}
+	r.specsByUUID[spec.ExternalJobID] = registeredJob{spec, make(chan struct{}), trigger}
 	return nil
 }
 
@@ -151,6 +172,20 @@ func (r *webhookJobRunner) spec(externalJobID uuid.UUID) (registeredJob, bool) {
 
 var ErrJobNotExists = errors.New("job does not exist")
 
+func (r *webhookJobRunner) RunPublicJob(ctx context.Context, jobUUID uuid.UUID, header http.Header, requestBody []byte) (int64, error) {
+	spec, exists := r.spec(jobUUID)
+	// Jobs without a public trigger are not revealed to unauthenticated callers.
+	if !exists || spec.trigger == nil {
+		return 0, ErrJobNotExists
+	}
+
+	meta, err := spec.trigger.verify(ctx, header, requestBody)
+	if err != nil {
+		return 0, err
+	}
+	return r.RunJob(ctx, jobUUID, string(requestBody), jsonserializable.JSONSerializable{Val: meta, Valid: true})
+}
+
 func (r *webhookJobRunner) RunJob(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta jsonserializable.JSONSerializable) (int64, error) {
 	spec, exists := r.spec(jobUUID)
 	if !exists {
@@ -1,7 +1,10 @@
 package webhook_test
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"gopkg.in/guregu/null.v4"
@@ -12,13 +15,16 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	pipelinemocks "github.com/smartcontractkit/chainlink/v2/core/services/pipeline/mocks"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	webhookmocks "github.com/smartcontractkit/chainlink/v2/core/services/webhook/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 )
 
 func TestWebhookDelegate(t *testing.T) {
@@ -49,7 +55,7 @@ func TestWebhookDelegate(t *testing.T) {
 		}
 		runner    = pipelinemocks.NewRunner(t)
 		eiManager = new(webhookmocks.ExternalInitiatorManager)
-		delegate  = webhook.NewDelegate(runner, eiManager, logger.TestLogger(t))
+		delegate  = webhook.NewDelegate(runner, eiManager, webhook.NewORM(pgtest.NewSqlxDB(t), nil), nil, logger.TestLogger(t))
 	)
 
 	services, err := delegate.ServicesForSpec(ctx, *spec)
@@ -95,3 +101,90 @@ func TestWebhookDelegate(t *testing.T) {
 	_, err = delegate.WebhookJobRunner().RunJob(ctx, spec.ExternalJobID, requestBody, meta)
 	require.Equal(t, webhook.ErrJobNotExists, errors.Cause(err))
 }
+
+func TestWebhookDelegate_PublicTrigger(t *testing.T) {
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+
+	trigger := &job.WebhookPublicTrigger{
+		Auth:            job.WebhookPublicTriggerAuthHMAC,
+		RequestSchema:   `{"type": "object", "required": ["lotID"]}`,
+		RateLimit:       3,
+		RateLimitPeriod: models.Interval(time.Hour),
+	}
+	var webhookSpecID int32
+	require.NoError(t, db.GetContext(ctx, &webhookSpecID, `INSERT INTO webhook_specs (public_trigger, created_at, updated_at) VALUES ($1, NOW(), NOW()) RETURNING id`, trigger))
+	cipher := bridges.NewSecretCipher(func(context.Context) ([]byte, error) { return make([]byte, 32), nil })
+	orm := webhook.NewORM(db, cipher)
+	require.NoError(t, orm.UpdatePublicTriggerSecret(ctx, webhookSpecID, "secret"))
+	require.ErrorIs(t, webhook.NewORM(db, nil).UpdatePublicTriggerSecret(ctx, webhookSpecID, "secret"), bridges.ErrNoSecretCipher)
+
+	// The secret is not stored in plaintext
+	encrypted, err := orm.FindEncryptedPublicTriggerSecret(ctx, webhookSpecID)
+	require.NoError(t, err)
+	require.NotContains(t, string(encrypted), "secret")
+
+	spec := job.Job{
+		ID:            123,
+		Type:          job.Webhook,
+		SchemaVersion: 1,
+		ExternalJobID: uuid.New(),
+		WebhookSpec:   &job.WebhookSpec{ID: webhookSpecID, PublicTrigger: trigger},
+		PipelineSpec:  &pipeline.Spec{},
+	}
+	private := job.Job{
+		ID:            124,
+		Type:          job.Webhook,
+		SchemaVersion: 1,
+		ExternalJobID: uuid.New(),
+		WebhookSpec:   &job.WebhookSpec{},
+		PipelineSpec:  &pipeline.Spec{},
+	}
+	runner := pipelinemocks.NewRunner(t)
+	delegate := webhook.NewDelegate(runner, new(webhookmocks.ExternalInitiatorManager), orm, cipher, logger.TestLogger(t))
+	for _, jb := range []job.Job{spec, private} {
+		services, err := delegate.ServicesForSpec(ctx, jb)
+		require.NoError(t, err)
+		require.NoError(t, services[0].Start(ctx))
+		t.Cleanup(func() { require.NoError(t, services[0].Close()) })
+	}
+
+	sign := func(secret string, body []byte) http.Header {
+		header, err := bridges.SignRequest(secret, body, time.Now())
+		require.NoError(t, err)
+		return header
+	}
+	body := []byte(`{"lotID": 42}`)
+
+	_, err = delegate.WebhookJobRunner().RunPublicJob(ctx, private.ExternalJobID, sign("secret", body), body)
+	require.ErrorIs(t, err, webhook.ErrJobNotExists)
+
+	// Unauthenticated requests do not count towards the rate limit.
+	for i := 0; i < 5; i++ {
+		_, err = delegate.WebhookJobRunner().RunPublicJob(ctx, spec.ExternalJobID, sign("wrong", body), body)
+		require.ErrorIs(t, err, webhook.ErrPublicTriggerUnauthorized)
+	}
+
+	_, err = delegate.WebhookJobRunner().RunPublicJob(ctx, spec.ExternalJobID, sign("secret", []byte(`{}`)), []byte(`{}`))
+	require.ErrorIs(t, err, webhook.ErrPublicTriggerInvalidRequest)
+
+	runner.On("Run", mock.Anything, mock.AnythingOfType("*pipeline.Run"), mock.Anything, mock.Anything, mock.Anything).
+		Return(false, nil).
+		Run(func(args mock.Arguments) {
+			run := args.Get(1).(*pipeline.Run)
+			run.ID = int64(1)
+
+			jobRun := run.Inputs.Val.(map[string]interface{})["jobRun"].(map[string]interface{})
+			require.Equal(t, string(body), jobRun["requestBody"])
+			require.Equal(t, "hmac", jobRun["meta"].(map[string]interface{})["auth"])
+		}).Twice()
+
+	for i := 0; i < 2; i++ {
+		runID, err := delegate.WebhookJobRunner().RunPublicJob(ctx, spec.ExternalJobID, sign("secret", body), body)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), runID)
+	}
+
+	_, err = delegate.WebhookJobRunner().RunPublicJob(ctx, spec.ExternalJobID, sign("secret", body), body)
+	require.ErrorIs(t, err, webhook.ErrPublicTriggerRateLimited)
+}
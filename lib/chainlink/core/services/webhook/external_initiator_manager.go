@@ -3,7 +3,6 @@ package webhook
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -11,8 +10,10 @@ import (
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/static"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
@@ -20,9 +21,12 @@ import (
 
 // ExternalInitiatorManager manages HTTP requests to remote external initiators
 type ExternalInitiatorManager interface {
+	services.Service
 	Notify(ctx context.Context, webhookSpecID int32) error
 	DeleteJob(ctx context.Context, webhookSpecID int32) error
 	FindExternalInitiatorByName(ctx context.Context, name string) (bridges.ExternalInitiator, error)
+	Resync(ctx context.Context, name string) (int64, error)
+	NotificationStats(ctx context.Context, eiIDs []int64) (map[int64]NotificationStats, error)
 }
 
 type HTTPClient interface {
@@ -30,62 +34,75 @@ type HTTPClient interface {
 }
 
 type externalInitiatorManager struct {
+	services.Service
+	eng *services.Engine
+
 	ds         sqlutil.DataSource
 	httpclient HTTPClient
+	chTrigger  chan struct{}
 }
 
 var _ ExternalInitiatorManager = (*externalInitiatorManager)(nil)
 
 // NewExternalInitiatorManager returns the concrete externalInitiatorManager
-func NewExternalInitiatorManager(ds sqlutil.DataSource, httpclient HTTPClient) *externalInitiatorManager {
-	return &externalInitiatorManager{
+func NewExternalInitiatorManager(ds sqlutil.DataSource, httpclient HTTPClient, lggr logger.Logger) *externalInitiatorManager {
+	m := &externalInitiatorManager{
 		ds:         ds,
 		httpclient: httpclient,
+		chTrigger:  make(chan struct{}, 1),
 	}
+	m.Service, m.eng = services.Config{
+		Name:  "ExternalInitiatorManager",
+		Start: m.start,
+	}.NewServiceEngine(lggr)
+	return m
 }
 
-// Notify sends a POST notification to the External Initiator
-// responsible for initiating the Job Spec.
+// Notify queues a POST notification to the External Initiators
+// responsible for initiating the Job Spec, and tries to deliver it right away.
+// Notifications that cannot be delivered are retried in the background, and the
+// first delivery error is returned.
 func (m *externalInitiatorManager) Notify(ctx context.Context, webhookSpecID int32) error {
+	return m.notifyAll(ctx, webhookSpecID, NotificationKindNotify)
+}
+
+func (m *externalInitiatorManager) notifyAll(ctx context.Context, webhookSpecID int32, kind NotificationKind) error {
 	eiWebhookSpecs, jobID, err := m.Load(ctx, webhookSpecID)
 	if err != nil {
 		return err
 	}
+	var notifications []ExternalInitiatorNotification
 	for _, eiWebhookSpec := range eiWebhookSpecs {
-		ei := eiWebhookSpec.ExternalInitiator
-		if ei.URL == nil {
+		if eiWebhookSpec.ExternalInitiator.URL == nil {
 			continue
 		}
-		notice := JobSpecNotice{
-			JobID:  jobID,
-			Type:   ei.Name,
-			Params: eiWebhookSpec.Spec,
-		}
-		buf, err := json.Marshal(notice)
-		if err != nil {
-			return errors.Wrap(err, "new Job Spec notification")
+		n := ExternalInitiatorNotification{
+			ExternalInitiatorID: eiWebhookSpec.ExternalInitiatorID,
+			Kind:                kind,
+			JobID:               jobID,
 		}
-		req, err := newNotifyHTTPRequest(ctx, buf, ei)
-		if err != nil {
-			return errors.Wrap(err, "creating notify HTTP request")
-		}
-		resp, err := m.httpclient.Do(req)
-		if err != nil {
-			return errors.Wrap(err, "could not notify '%s' (%s)")
-		}
-		if err := resp.Body.Close(); err != nil {
-			return err
-		}
-		if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
-			return fmt.Errorf(" notify '%s' (%s) received bad response '%d: %s'", ei.Name, ei.URL, resp.StatusCode, resp.Status)
+		if kind == NotificationKindNotify {
+			n.Spec = eiWebhookSpec.Spec
 		}
+		notifications = append(notifications, n)
 	}
-	return nil
+	if len(notifications) == 0 {
+		return nil
+	}
+	ids, err := m.enqueue(ctx, notifications)
+	if err != nil {
+		return err
+	}
+	sendErr, err := m.deliver(ctx, ids)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(sendErr, "notification will be retried")
 }
 
 func (m *externalInitiatorManager) Load(ctx context.Context, webhookSpecID int32) (eiWebhookSpecs []job.ExternalInitiatorWebhookSpec, jobID uuid.UUID, err error) {
 	err = sqlutil.Transact(ctx, func(ds sqlutil.DataSource) *externalInitiatorManager {
-		return NewExternalInitiatorManager(ds, m.httpclient)
+		return &externalInitiatorManager{ds: ds, httpclient: m.httpclient}
 	}, m.ds, nil, func(tx *externalInitiatorManager) error {
 		if err = tx.ds.GetContext(ctx, &jobID, "SELECT external_job_id FROM jobs WHERE webhook_spec_id = $1", webhookSpecID); err != nil {
 			if err = errors.Wrapf(err, "failed to load job ID from job for webhook spec with ID %d", webhookSpecID); err != nil {
@@ -132,33 +149,12 @@ func (m *externalInitiatorManager) eagerLoadExternalInitiator(ctx context.Contex
 	return nil
 }
 
+// DeleteJob queues a DELETE notification to the External Initiators
+// responsible for initiating the Job Spec, and tries to deliver it right away.
+// Notifications that cannot be delivered are retried in the background, and the
+// first delivery error is returned.
 func (m *externalInitiatorManager) DeleteJob(ctx context.Context, webhookSpecID int32) error {
-	eiWebhookSpecs, jobID, err := m.Load(ctx, webhookSpecID)
-	if err != nil {
-		return err
-	}
-	for _, eiWebhookSpec := range eiWebhookSpecs {
-		ei := eiWebhookSpec.ExternalInitiator
-		if ei.URL == nil {
-			continue
-		}
-
-		req, err := newDeleteJobFromExternalInitiatorHTTPRequest(ctx, ei, jobID)
-		if err != nil {
-			return errors.Wrap(err, "creating delete HTTP request")
-		}
-		resp, err := m.httpclient.Do(req)
-		if err != nil {
-			return errors.Wrapf(err, "could not delete job from remote external initiator at %s", req.URL)
-		}
-		if err := resp.Body.Close(); err != nil {
-			return err
-		}
-		if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
-			return fmt.Errorf(" delete '%s' (%s) received bad response '%d: %s'", ei.Name, ei.URL, resp.StatusCode, resp.Status)
-		}
-	}
-	return nil
+	return m.notifyAll(ctx, webhookSpecID, NotificationKindDelete)
 }
 
 func (m *externalInitiatorManager) FindExternalInitiatorByName(ctx context.Context, name string) (bridges.ExternalInitiator, error) {
@@ -209,3 +205,14 @@ func (NullExternalInitiatorManager) DeleteJob(context.Context, int32) error { re
 func (NullExternalInitiatorManager) FindExternalInitiatorByName(ctx context.Context, name string) (bridges.ExternalInitiator, error) {
 	return bridges.ExternalInitiator{}, nil
 }
+func (NullExternalInitiatorManager) Resync(context.Context, string) (int64, error) { return 0, nil }
+func (NullExternalInitiatorManager) NotificationStats(context.Context, []int64) (map[int64]NotificationStats, error) {
+	return nil, nil
+}
+func (NullExternalInitiatorManager) Start(context.Context) error { return nil }
+func (NullExternalInitiatorManager) Close() error                { return nil }
+func (NullExternalInitiatorManager) Ready() error                { return nil }
+func (NullExternalInitiatorManager) HealthReport() map[string]error {
+	return map[string]error{}
+}
+func (NullExternalInitiatorManager) Name() string { return "NullExternalInitiatorManager" }
@@ -16,6 +16,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	_ "github.com/smartcontractkit/chainlink/v2/core/services/pg"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	webhookmocks "github.com/smartcontractkit/chainlink/v2/core/services/webhook/mocks"
@@ -37,7 +38,7 @@ func Test_ExternalInitiatorManager_Load(t *testing.T) {
 	pgtest.MustExec(t, db, `INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiBar.ID, webhookSpecTwoEIs.ID, `{"ei": "bar", "name": "webhookSpecTwoEIs"}`)
 	pgtest.MustExec(t, db, `INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiFoo.ID, webhookSpecOneEI.ID, `{"ei": "foo", "name": "webhookSpecOneEI"}`)
 
-	eim := webhook.NewExternalInitiatorManager(db, nil)
+	eim := webhook.NewExternalInitiatorManager(db, nil, logger.TestLogger(t))
 
 	eiWebhookSpecs, jobID, err := eim.Load(ctx, webhookSpecNoEIs.ID)
 	require.NoError(t, err)
@@ -76,7 +77,7 @@ func Test_ExternalInitiatorManager_Notify(t *testing.T) {
 	pgtest.MustExec(t, db, `INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiNoURL.ID, webhookSpecTwoEIs.ID, `{"ei": "bar", "name": "webhookSpecTwoEIs"}`)
 
 	client := webhookmocks.NewHTTPClient(t)
-	eim := webhook.NewExternalInitiatorManager(db, client)
+	eim := webhook.NewExternalInitiatorManager(db, client, logger.TestLogger(t))
 
 	// Does nothing with no EI
 	require.NoError(t, eim.Notify(ctx, webhookSpecNoEIs.ID))
@@ -115,7 +116,7 @@ func Test_ExternalInitiatorManager_DeleteJob(t *testing.T) {
 	pgtest.MustExec(t, db, `INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, eiNoURL.ID, webhookSpecTwoEIs.ID, `{"ei": "bar", "name": "webhookSpecTwoEIs"}`)
 
 	client := webhookmocks.NewHTTPClient(t)
-	eim := webhook.NewExternalInitiatorManager(db, client)
+	eim := webhook.NewExternalInitiatorManager(db, client, logger.TestLogger(t))
 
 	// Does nothing with no EI
 	require.NoError(t, eim.DeleteJob(ctx, webhookSpecNoEIs.ID))
@@ -126,3 +127,74 @@ func Test_ExternalInitiatorManager_DeleteJob(t *testing.T) {
 	})).Once().Return(&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(""))}, nil)
 	require.NoError(t, eim.DeleteJob(ctx, webhookSpecTwoEIs.ID))
 }
+
+func Test_ExternalInitiatorManager_Outbox(t *testing.T) {
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	borm := bridges.NewORM(db)
+
+	ei := cltest.MustInsertExternalInitiatorWithOpts(t, borm, cltest.ExternalInitiatorOpts{
+		URL:            cltest.MustWebURL(t, "http://example.com/foo"),
+		OutgoingSecret: "secret",
+		OutgoingToken:  "token",
+	})
+	jb, webhookSpec := cltest.MustInsertWebhookSpec(t, db)
+	pgtest.MustExec(t, db, `INSERT INTO external_initiator_webhook_specs (external_initiator_id, webhook_spec_id, spec) VALUES ($1,$2,$3)`, ei.ID, webhookSpec.ID, `{"ei": "foo"}`)
+
+	client := webhookmocks.NewHTTPClient(t)
+	eim := webhook.NewExternalInitiatorManager(db, client, logger.TestLogger(t))
+
+	isNotify := mock.MatchedBy(func(r *http.Request) bool { return r.Method == "POST" })
+	isDelete := mock.MatchedBy(func(r *http.Request) bool { return r.Method == "DELETE" })
+
+	t.Run("keeps failed notifications for retry", func(t *testing.T) {
+		client.On("Do", isNotify).Once().Return(&http.Response{StatusCode: 503, Status: "503 Service Unavailable", Body: io.NopCloser(strings.NewReader(""))}, nil)
+		err := eim.Notify(ctx, webhookSpec.ID)
+		require.ErrorContains(t, err, "503 Service Unavailable")
+		require.ErrorContains(t, err, "notification will be retried")
+
+		var n webhook.ExternalInitiatorNotification
+		require.NoError(t, db.GetContext(ctx, &n, `SELECT * FROM external_initiator_notifications`))
+		assert.Equal(t, webhook.NotificationKindNotify, n.Kind)
+		assert.Equal(t, webhook.NotificationStatePending, n.State)
+		assert.Equal(t, jb.ExternalJobID, n.JobID)
+		assert.Equal(t, int32(1), n.Attempts)
+		assert.Contains(t, n.LastError.String, "503")
+		assert.True(t, n.NextAttemptAt.After(n.CreatedAt))
+
+		stats, err := eim.NotificationStats(ctx, []int64{ei.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), stats[ei.ID].Pending)
+		assert.Equal(t, int64(0), stats[ei.ID].Dead)
+	})
+
+	t.Run("drops pending notifications of deleted jobs", func(t *testing.T) {
+		client.On("Do", isDelete).Once().Return(&http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(""))}, nil)
+		require.NoError(t, eim.DeleteJob(ctx, webhookSpec.ID))
+
+		cltest.AssertCount(t, db, "external_initiator_notifications", 0)
+	})
+
+	t.Run("resync announces all jobs again", func(t *testing.T) {
+		pgtest.MustExec(t, db, `INSERT INTO external_initiator_notifications (external_initiator_id, kind, job_id, state, attempts, next_attempt_at, last_error, created_at, updated_at)
+			VALUES ($1, 'notify', $2, 'dead', 12, NOW(), 'connection refused', NOW(), NOW())`, ei.ID, jb.ExternalJobID)
+
+		stats, err := eim.NotificationStats(ctx, []int64{ei.ID})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), stats[ei.ID].Dead)
+		assert.Equal(t, "connection refused", stats[ei.ID].LastError.String)
+
+		_, err = eim.Resync(ctx, "missing")
+		require.Error(t, err)
+
+		count, err := eim.Resync(ctx, ei.Name)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		var n webhook.ExternalInitiatorNotification
+		require.NoError(t, db.GetContext(ctx, &n, `SELECT * FROM external_initiator_notifications`))
+		assert.Equal(t, webhook.NotificationStatePending, n.State)
+		assert.Equal(t, int32(0), n.Attempts)
+		assert.JSONEq(t, `{"ei": "foo"}`, n.Spec.Raw)
+	})
+}
@@ -0,0 +1,300 @@
+package webhook
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jpillora/backoff"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+// Notifications of job creations and deletions to External Initiators are saved to an outbox,
+// and retried with exponential backoff until they are delivered. Notifications that still fail
+// after outboxMaxAttempts are dead, until the External Initiator is resynced.
+const (
+	outboxPollInterval = 30 * time.Second
+	outboxBatchSize    = 100
+	outboxMinBackoff   = 10 * time.Second
+	outboxMaxBackoff   = time.Hour
+	outboxMaxAttempts  = 12
+	// outboxClaimTimeout is how long a notification being delivered is hidden from other deliveries.
+	outboxClaimTimeout = time.Minute
+)
+
+// NotificationKind is what a notification tells the External Initiator about a job.
+type NotificationKind string
+
+const (
+	NotificationKindNotify NotificationKind = "notify"
+	NotificationKindDelete NotificationKind = "delete"
+)
+
+// NotificationState is the delivery state of a notification.
+type NotificationState string
+
+const (
+	NotificationStatePending NotificationState = "pending"
+	NotificationStateDead    NotificationState = "dead"
+)
+
+// ExternalInitiatorNotification is a notification of a job creation or deletion to an External
+// Initiator, kept in the outbox until it is delivered.
+type ExternalInitiatorNotification struct {
+	ID                  int64
+	ExternalInitiatorID int64
+	Kind                NotificationKind
+	JobID               uuid.UUID
+	Spec                models.JSON
+	State               NotificationState
+	Attempts            int32
+	NextAttemptAt       time.Time
+	LastError           null.String
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// NotificationStats are the undelivered notifications of an External Initiator.
+type NotificationStats struct {
+	ExternalInitiatorID int64
+	Pending             int64
+	Dead                int64
+	// LastError is the error of the latest dead notification.
+	LastError null.String
+}
+
+func (m *externalInitiatorManager) start(context.Context) error {
+	m.eng.Go(m.run)
+	return nil
+}
+
+func (m *externalInitiatorManager) run(ctx context.Context) {
+	ticker := services.TickerConfig{
+		JitterPct: services.DefaultJitter,
+	}.NewTicker(outboxPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.chTrigger:
+		}
+		if _, err := m.deliver(ctx, nil); err != nil {
+			m.eng.Errorw("Failed to deliver External Initiator notifications", "err", err)
+		}
+	}
+}
+
+// trigger wakes up the worker, so that notifications are delivered without waiting for the next poll.
+func (m *externalInitiatorManager) trigger() {
+	select {
+	case m.chTrigger <- struct{}{}:
+	default:
+	}
+}
+
+// enqueue saves notifications to the outbox, and returns their IDs. Pending notifications of a
+// job's creation are dropped when its deletion is enqueued.
+func (m *externalInitiatorManager) enqueue(ctx context.Context, notifications []ExternalInitiatorNotification) (ids []int64, err error) {
+	err = sqlutil.TransactDataSource(ctx, m.ds, nil, func(tx sqlutil.DataSource) error {
+		for _, n := range notifications {
+			if n.Kind == NotificationKindDelete {
+				if _, err := tx.ExecContext(ctx, `DELETE FROM external_initiator_notifications
+					WHERE external_initiator_id = $1 AND job_id = $2 AND kind = $3`, n.ExternalInitiatorID, n.JobID, NotificationKindNotify); err != nil {
+					return errors.Wrap(err, "failed to drop pending notifications")
+				}
+			}
+			spec, err := n.Spec.Value()
+			if err != nil {
+				return err
+			}
+			var id int64
+			if err := tx.GetContext(ctx, &id, `INSERT INTO external_initiator_notifications
+				(external_initiator_id, kind, job_id, spec, state, next_attempt_at, created_at, updated_at)
+				VALUES ($1, $2, $3, COALESCE($4::jsonb, 'null'), $5, NOW(), NOW(), NOW()) RETURNING id`,
+				n.ExternalInitiatorID, n.Kind, n.JobID, spec, NotificationStatePending); err != nil {
+				return errors.Wrap(err, "failed to enqueue notification")
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	return
+}
+
+// deliver sends the due notifications, or only those with ids if given. Notifications that
+// can't be sent are kept for retry, and the first of their errors is returned as sendErr.
+func (m *externalInitiatorManager) deliver(ctx context.Context, ids []int64) (sendErr error, err error) {
+	var notifications []ExternalInitiatorNotification
+	err = m.ds.SelectContext(ctx, &notifications, `UPDATE external_initiator_notifications SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT id FROM external_initiator_notifications
+			WHERE state = $2 AND next_attempt_at <= NOW() AND ($3::bigint[] IS NULL OR id = ANY($3))
+			ORDER BY id LIMIT $4 FOR UPDATE SKIP LOCKED
+		) RETURNING *`, time.Now().Add(outboxClaimTimeout), NotificationStatePending, pq.Array(ids), outboxBatchSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to claim notifications")
+	}
+	if len(notifications) == 0 {
+		return nil, nil
+	}
+	slices.SortFunc(notifications, func(a, b ExternalInitiatorNotification) int { return cmp.Compare(a.ID, b.ID) })
+
+	eiIDs := make([]int64, 0, len(notifications))
+	for _, n := range notifications {
+		eiIDs = append(eiIDs, n.ExternalInitiatorID)
+	}
+	var eis []bridges.ExternalInitiator
+	if err = m.ds.SelectContext(ctx, &eis, `SELECT * FROM external_initiators WHERE id = ANY($1)`, pq.Array(eiIDs)); err != nil {
+		return nil, errors.Wrap(err, "failed to load external initiators")
+	}
+	eiMap := make(map[int64]bridges.ExternalInitiator, len(eis))
+	for _, ei := range eis {
+		eiMap[ei.ID] = ei
+	}
+
+	for _, n := range notifications {
+		ei := eiMap[n.ExternalInitiatorID]
+		var nErr error
+		if ei.URL != nil {
+			nErr = m.send(ctx, ei, n)
+		}
+		if nErr == nil {
+			if _, err = m.ds.ExecContext(ctx, `DELETE FROM external_initiator_notifications WHERE id = $1`, n.ID); err != nil {
+				return nil, errors.Wrap(err, "failed to delete delivered notification")
+			}
+			continue
+		}
+		if sendErr == nil {
+			sendErr = nErr
+		}
+
+		attempts := n.Attempts + 1
+		state := NotificationStatePending
+		if attempts >= outboxMaxAttempts {
+			state = NotificationStateDead
+		}
+		b := backoff.Backoff{Min: outboxMinBackoff, Max: outboxMaxBackoff, Factor: 2}
+		next := time.Now().Add(b.ForAttempt(float64(attempts - 1)))
+		m.eng.Warnw("Failed to deliver External Initiator notification", "externalInitiator", ei.Name, "kind", n.Kind,
+			"jobID", n.JobID, "attempts", attempts, "state", state, "nextAttemptAt", next, "err", nErr)
+		if _, err = m.ds.ExecContext(ctx, `UPDATE external_initiator_notifications
+			SET attempts = $2, state = $3, next_attempt_at = $4, last_error = $5, updated_at = NOW() WHERE id = $1`,
+			n.ID, attempts, state, next, nErr.Error()); err != nil {
+			return nil, errors.Wrap(err, "failed to update notification")
+		}
+	}
+	return sendErr, nil
+}
+
+func (m *externalInitiatorManager) send(ctx context.Context, ei bridges.ExternalInitiator, n ExternalInitiatorNotification) error {
+	var req *http.Request
+	var err error
+	switch n.Kind {
+	case NotificationKindNotify:
+		notice := JobSpecNotice{
+			JobID:  n.JobID,
+			Type:   ei.Name,
+			Params: n.Spec,
+		}
+		var buf []byte
+		buf, err = json.Marshal(notice)
+		if err != nil {
+			return errors.Wrap(err, "new Job Spec notification")
+		}
+		req, err = newNotifyHTTPRequest(ctx, buf, ei)
+	case NotificationKindDelete:
+		req, err = newDeleteJobFromExternalInitiatorHTTPRequest(ctx, ei, n.JobID)
+	default:
+		return errors.Errorf("unknown notification kind %q", n.Kind)
+	}
+	if err != nil {
+		return errors.Wrap(err, "creating HTTP request")
+	}
+
+	resp, err := m.httpclient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "could not %s '%s' (%s)", n.Kind, ei.Name, ei.URL)
+	}
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		return fmt.Errorf("%s '%s' (%s) received bad response '%d: %s'", n.Kind, ei.Name, ei.URL, resp.StatusCode, resp.Status)
+	}
+	return nil
+}
+
+// Resync announces all the jobs of an External Initiator to it again, replacing its pending and
+// dead notifications of job creations, and retries its dead notifications of job deletions.
+// It returns the number of jobs announced.
+func (m *externalInitiatorManager) Resync(ctx context.Context, name string) (count int64, err error) {
+	ei, err := m.FindExternalInitiatorByName(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+
+	err = sqlutil.TransactDataSource(ctx, m.ds, nil, func(tx sqlutil.DataSource) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM external_initiator_notifications WHERE external_initiator_id = $1 AND kind = $2`,
+			ei.ID, NotificationKindNotify); err != nil {
+			return errors.Wrap(err, "failed to drop notifications")
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE external_initiator_notifications
+			SET state = $2, attempts = 0, next_attempt_at = NOW(), last_error = NULL, updated_at = NOW()
+			WHERE external_initiator_id = $1 AND state = $3`, ei.ID, NotificationStatePending, NotificationStateDead); err != nil {
+			return errors.Wrap(err, "failed to retry dead notifications")
+		}
+		res, err := tx.ExecContext(ctx, `INSERT INTO external_initiator_notifications
+			(external_initiator_id, kind, job_id, spec, state, next_attempt_at, created_at, updated_at)
+			SELECT eiws.external_initiator_id, $2, jobs.external_job_id, eiws.spec, $3, NOW(), NOW(), NOW()
+			FROM external_initiator_webhook_specs eiws
+			JOIN jobs ON jobs.webhook_spec_id = eiws.webhook_spec_id
+			WHERE eiws.external_initiator_id = $1`, ei.ID, NotificationKindNotify, NotificationStatePending)
+		if err != nil {
+			return errors.Wrap(err, "failed to enqueue notifications")
+		}
+		count, err = res.RowsAffected()
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	m.trigger()
+	return count, nil
+}
+
+// NotificationStats returns the undelivered notifications of the External Initiators, by ID.
+func (m *externalInitiatorManager) NotificationStats(ctx context.Context, eiIDs []int64) (map[int64]NotificationStats, error) {
+	var stats []NotificationStats
+	err := m.ds.SelectContext(ctx, &stats, `SELECT external_initiator_id,
+			COUNT(*) FILTER (WHERE state = $2) AS pending,
+			COUNT(*) FILTER (WHERE state = $3) AS dead,
+			(ARRAY_AGG(last_error ORDER BY updated_at DESC) FILTER (WHERE state = $3))[1] AS last_error
+		FROM external_initiator_notifications
+		WHERE external_initiator_id = ANY($1)
+		GROUP BY external_initiator_id`, pq.Array(eiIDs), NotificationStatePending, NotificationStateDead)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load notification stats")
+	}
+	byID := make(map[int64]NotificationStats, len(stats))
+	for _, s := range stats {
+		byID[s.ExternalInitiatorID] = s
+	}
+	return byID, nil
+}
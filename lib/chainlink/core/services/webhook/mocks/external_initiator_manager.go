@@ -8,6 +8,8 @@ import (
 	bridges "github.com/smartcontractkit/chainlink/v2/core/bridges"
 
 	mock "github.com/stretchr/testify/mock"
+
+	webhook "github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 )
 
 // ExternalInitiatorManager is an autogenerated mock type for the ExternalInitiatorManager type
@@ -23,6 +25,51 @@ func (_m *ExternalInitiatorManager) EXPECT() *ExternalInitiatorManager_Expecter
 	return &ExternalInitiatorManager_Expecter{mock: &_m.Mock}
 }
 
+// Close provides a mock function with no fields
+func (_m *ExternalInitiatorManager) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExternalInitiatorManager_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type ExternalInitiatorManager_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *ExternalInitiatorManager_Expecter) Close() *ExternalInitiatorManager_Close_Call {
+	return &ExternalInitiatorManager_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *ExternalInitiatorManager_Close_Call) Run(run func()) *ExternalInitiatorManager_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Close_Call) Return(_a0 error) *ExternalInitiatorManager_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Close_Call) RunAndReturn(run func() error) *ExternalInitiatorManager_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteJob provides a mock function with given fields: ctx, webhookSpecID
 func (_m *ExternalInitiatorManager) DeleteJob(ctx context.Context, webhookSpecID int32) error {
 	ret := _m.Called(ctx, webhookSpecID)
@@ -127,6 +174,157 @@ func (_c *ExternalInitiatorManager_FindExternalInitiatorByName_Call) RunAndRetur
 	return _c
 }
 
+// HealthReport provides a mock function with no fields
+func (_m *ExternalInitiatorManager) HealthReport() map[string]error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HealthReport")
+	}
+
+	var r0 map[string]error
+	if rf, ok := ret.Get(0).(func() map[string]error); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]error)
+		}
+	}
+
+	return r0
+}
+
+// ExternalInitiatorManager_HealthReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HealthReport'
+type ExternalInitiatorManager_HealthReport_Call struct {
+	*mock.Call
+}
+
+// HealthReport is a helper method to define mock.On call
+func (_e *ExternalInitiatorManager_Expecter) HealthReport() *ExternalInitiatorManager_HealthReport_Call {
+	return &ExternalInitiatorManager_HealthReport_Call{Call: _e.mock.On("HealthReport")}
+}
+
+func (_c *ExternalInitiatorManager_HealthReport_Call) Run(run func()) *ExternalInitiatorManager_HealthReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_HealthReport_Call) Return(_a0 map[string]error) *ExternalInitiatorManager_HealthReport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_HealthReport_Call) RunAndReturn(run func() map[string]error) *ExternalInitiatorManager_HealthReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Name provides a mock function with no fields
+func (_m *ExternalInitiatorManager) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ExternalInitiatorManager_Name_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Name'
+type ExternalInitiatorManager_Name_Call struct {
+	*mock.Call
+}
+
+// Name is a helper method to define mock.On call
+func (_e *ExternalInitiatorManager_Expecter) Name() *ExternalInitiatorManager_Name_Call {
+	return &ExternalInitiatorManager_Name_Call{Call: _e.mock.On("Name")}
+}
+
+func (_c *ExternalInitiatorManager_Name_Call) Run(run func()) *ExternalInitiatorManager_Name_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Name_Call) Return(_a0 string) *ExternalInitiatorManager_Name_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Name_Call) RunAndReturn(run func() string) *ExternalInitiatorManager_Name_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NotificationStats provides a mock function with given fields: ctx, eiIDs
+func (_m *ExternalInitiatorManager) NotificationStats(ctx context.Context, eiIDs []int64) (map[int64]webhook.NotificationStats, error) {
+	ret := _m.Called(ctx, eiIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationStats")
+	}
+
+	var r0 map[int64]webhook.NotificationStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64]webhook.NotificationStats, error)); ok {
+		return rf(ctx, eiIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64]webhook.NotificationStats); ok {
+		r0 = rf(ctx, eiIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]webhook.NotificationStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, eiIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExternalInitiatorManager_NotificationStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotificationStats'
+type ExternalInitiatorManager_NotificationStats_Call struct {
+	*mock.Call
+}
+
+// NotificationStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - eiIDs []int64
+func (_e *ExternalInitiatorManager_Expecter) NotificationStats(ctx interface{}, eiIDs interface{}) *ExternalInitiatorManager_NotificationStats_Call {
+	return &ExternalInitiatorManager_NotificationStats_Call{Call: _e.mock.On("NotificationStats", ctx, eiIDs)}
+}
+
+func (_c *ExternalInitiatorManager_NotificationStats_Call) Run(run func(ctx context.Context, eiIDs []int64)) *ExternalInitiatorManager_NotificationStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_NotificationStats_Call) Return(_a0 map[int64]webhook.NotificationStats, _a1 error) *ExternalInitiatorManager_NotificationStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_NotificationStats_Call) RunAndReturn(run func(context.Context, []int64) (map[int64]webhook.NotificationStats, error)) *ExternalInitiatorManager_NotificationStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Notify provides a mock function with given fields: ctx, webhookSpecID
 func (_m *ExternalInitiatorManager) Notify(ctx context.Context, webhookSpecID int32) error {
 	ret := _m.Called(ctx, webhookSpecID)
@@ -174,6 +372,154 @@ func (_c *ExternalInitiatorManager_Notify_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// Ready provides a mock function with no fields
+func (_m *ExternalInitiatorManager) Ready() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExternalInitiatorManager_Ready_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ready'
+type ExternalInitiatorManager_Ready_Call struct {
+	*mock.Call
+}
+
+// Ready is a helper method to define mock.On call
+func (_e *ExternalInitiatorManager_Expecter) Ready() *ExternalInitiatorManager_Ready_Call {
+	return &ExternalInitiatorManager_Ready_Call{Call: _e.mock.On("Ready")}
+}
+
+func (_c *ExternalInitiatorManager_Ready_Call) Run(run func()) *ExternalInitiatorManager_Ready_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Ready_Call) Return(_a0 error) *ExternalInitiatorManager_Ready_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Ready_Call) RunAndReturn(run func() error) *ExternalInitiatorManager_Ready_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resync provides a mock function with given fields: ctx, name
+func (_m *ExternalInitiatorManager) Resync(ctx context.Context, name string) (int64, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resync")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExternalInitiatorManager_Resync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resync'
+type ExternalInitiatorManager_Resync_Call struct {
+	*mock.Call
+}
+
+// Resync is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *ExternalInitiatorManager_Expecter) Resync(ctx interface{}, name interface{}) *ExternalInitiatorManager_Resync_Call {
+	return &ExternalInitiatorManager_Resync_Call{Call: _e.mock.On("Resync", ctx, name)}
+}
+
+func (_c *ExternalInitiatorManager_Resync_Call) Run(run func(ctx context.Context, name string)) *ExternalInitiatorManager_Resync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Resync_Call) Return(_a0 int64, _a1 error) *ExternalInitiatorManager_Resync_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Resync_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *ExternalInitiatorManager_Resync_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Start provides a mock function with given fields: _a0
+func (_m *ExternalInitiatorManager) Start(_a0 context.Context) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExternalInitiatorManager_Start_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Start'
+type ExternalInitiatorManager_Start_Call struct {
+	*mock.Call
+}
+
+// Start is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *ExternalInitiatorManager_Expecter) Start(_a0 interface{}) *ExternalInitiatorManager_Start_Call {
+	return &ExternalInitiatorManager_Start_Call{Call: _e.mock.On("Start", _a0)}
+}
+
+func (_c *ExternalInitiatorManager_Start_Call) Run(run func(_a0 context.Context)) *ExternalInitiatorManager_Start_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Start_Call) Return(_a0 error) *ExternalInitiatorManager_Start_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ExternalInitiatorManager_Start_Call) RunAndReturn(run func(context.Context) error) *ExternalInitiatorManager_Start_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewExternalInitiatorManager creates a new instance of ExternalInitiatorManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewExternalInitiatorManager(t interface {
@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+)
+
+type ORM interface {
+	// FindEncryptedPublicTriggerSecret returns the encrypted secret requests to the public
+	// trigger of a webhook job are signed with, if any.
+	FindEncryptedPublicTriggerSecret(ctx context.Context, webhookSpecID int32) ([]byte, error)
+	// UpdatePublicTriggerSecret encrypts and sets the secret requests to the public trigger of
+	// a webhook job are signed with.
+	UpdatePublicTriggerSecret(ctx context.Context, webhookSpecID int32, secret string) error
+}
+
+type orm struct {
+	ds     sqlutil.DataSource
+	cipher *bridges.SecretCipher
+}
+
+var _ ORM = (*orm)(nil)
+
+// NewORM returns an ORM which encrypts public trigger secrets with cipher. Without a cipher,
+// secrets can't be set.
+func NewORM(ds sqlutil.DataSource, cipher *bridges.SecretCipher) ORM {
+	return &orm{ds: ds, cipher: cipher}
+}
+
+func (o *orm) FindEncryptedPublicTriggerSecret(ctx context.Context, webhookSpecID int32) (encrypted []byte, err error) {
+	err = o.ds.GetContext(ctx, &encrypted, `SELECT encrypted_public_trigger_secret FROM webhook_specs WHERE id = $1`, webhookSpecID)
+	return encrypted, errors.Wrap(err, "failed to find public trigger secret")
+}
+
+func (o *orm) UpdatePublicTriggerSecret(ctx context.Context, webhookSpecID int32, secret string) error {
+	if o.cipher == nil {
+		return bridges.ErrNoSecretCipher
+	}
+	encrypted, err := o.cipher.Encrypt(ctx, secret)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt public trigger secret")
+	}
+	_, err = o.ds.ExecContext(ctx, `UPDATE webhook_specs SET encrypted_public_trigger_secret = $2, updated_at = NOW() WHERE id = $1`, webhookSpecID, encrypted)
+	return errors.Wrap(err, "failed to update public trigger secret")
+}
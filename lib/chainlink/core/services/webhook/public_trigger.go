@@ -0,0 +1,191 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"go.uber.org/multierr"
+	"golang.org/x/time/rate"
+
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+const (
+	// DefaultPublicTriggerRateLimit and DefaultPublicTriggerRateLimitPeriod are the rate limit
+	// of public triggers that do not configure one.
+	DefaultPublicTriggerRateLimit       = 60
+	DefaultPublicTriggerRateLimitPeriod = time.Minute
+
+	jwksRequestTimeout = 10 * time.Second
+)
+
+var (
+	// ErrPublicTriggerUnauthorized is returned when a request to a public trigger is not signed,
+	// or its signature or token is invalid.
+	ErrPublicTriggerUnauthorized = errors.New("unauthorized")
+	// ErrPublicTriggerRateLimited is returned when a public trigger gets more requests than its rate limit.
+	ErrPublicTriggerRateLimited = errors.New("rate limit exceeded")
+	// ErrPublicTriggerInvalidRequest is returned when a request body does not match the request schema.
+	ErrPublicTriggerInvalidRequest = errors.New("invalid request body")
+)
+
+// ValidatePublicTrigger checks the public trigger config of a webhook job, and sets the defaults.
+func ValidatePublicTrigger(cfg *job.WebhookPublicTrigger) (err error) {
+	switch cfg.Auth {
+	case job.WebhookPublicTriggerAuthHMAC:
+		if cfg.JWKSURL != "" || cfg.Issuer != "" || cfg.Audience != "" {
+			err = multierr.Append(err, errors.New("jwksURL, issuer and audience can only be used with jwt auth"))
+		}
+	case job.WebhookPublicTriggerAuthJWT:
+		if u, parseErr := url.Parse(cfg.JWKSURL); parseErr != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			err = multierr.Append(err, errors.Errorf("jwksURL must be an absolute http(s) URL, got %q", cfg.JWKSURL))
+		}
+		// Without them, any token signed by the keys of the JWKS URL would be accepted,
+		// including the ones issued to other applications.
+		if cfg.Issuer == "" {
+			err = multierr.Append(err, errors.New("issuer is required with jwt auth"))
+		}
+		if cfg.Audience == "" {
+			err = multierr.Append(err, errors.New("audience is required with jwt auth"))
+		}
+	default:
+		err = multierr.Append(err, errors.Errorf("auth must be one of %s or %s, got %q", job.WebhookPublicTriggerAuthHMAC, job.WebhookPublicTriggerAuthJWT, cfg.Auth))
+	}
+	if cfg.RequestSchema != "" {
+		if _, schemaErr := jsonschema.CompileString("requestSchema.json", cfg.RequestSchema); schemaErr != nil {
+			err = multierr.Append(err, errors.Wrap(schemaErr, "invalid requestSchema"))
+		}
+	}
+	if cfg.RateLimitPeriod.Duration() < 0 {
+		err = multierr.Append(err, errors.New("rateLimitPeriod cannot be negative"))
+	}
+	if cfg.RateLimit == 0 {
+		cfg.RateLimit = DefaultPublicTriggerRateLimit
+	}
+	if cfg.RateLimitPeriod.IsZero() {
+		cfg.RateLimitPeriod = models.Interval(DefaultPublicTriggerRateLimitPeriod)
+	}
+	return errors.Wrap(err, "invalid publicTrigger")
+}
+
+// publicTrigger authenticates, rate limits and validates the requests to the public trigger
+// endpoint of a webhook job.
+type publicTrigger struct {
+	webhookSpecID int32
+	auth          job.WebhookPublicTriggerAuth
+	orm           ORM
+	cipher        *bridges.SecretCipher
+	limiter       *rate.Limiter
+	schema        *jsonschema.Schema
+	guard         *bridges.ReplayGuard
+	verifier      *oidc.IDTokenVerifier
+}
+
+func newPublicTrigger(spec job.WebhookSpec, orm ORM, cipher *bridges.SecretCipher) (*publicTrigger, error) {
+	cfg := *spec.PublicTrigger
+	if err := ValidatePublicTrigger(&cfg); err != nil {
+		return nil, err
+	}
+
+	t := &publicTrigger{
+		webhookSpecID: spec.ID,
+		auth:          cfg.Auth,
+		orm:           orm,
+		cipher:        cipher,
+		limiter:       rate.NewLimiter(rate.Every(cfg.RateLimitPeriod.Duration()/time.Duration(cfg.RateLimit)), int(cfg.RateLimit)),
+	}
+	if cfg.RequestSchema != "" {
+		t.schema = jsonschema.MustCompileString("requestSchema.json", cfg.RequestSchema)
+	}
+	switch cfg.Auth {
+	case job.WebhookPublicTriggerAuthHMAC:
+		t.guard = bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)
+	case job.WebhookPublicTriggerAuthJWT:
+		// The key set fetches keys with this context, not with the one of the request.
+		ctx := oidc.ClientContext(context.Background(), &http.Client{Timeout: jwksRequestTimeout})
+		t.verifier = oidc.NewVerifier(cfg.Issuer, oidc.NewRemoteKeySet(ctx, cfg.JWKSURL), &oidc.Config{
+			ClientID:             cfg.Audience,
+			SupportedSigningAlgs: []string{oidc.RS256, oidc.RS384, oidc.RS512, oidc.ES256, oidc.ES384, oidc.ES512, oidc.PS256, oidc.PS384, oidc.PS512, oidc.EdDSA},
+		})
+	}
+	return t, nil
+}
+
+// verify checks a request, and returns the meta of the run it triggers. Requests are
+// authenticated before they are rate limited, so that unauthenticated requests can't use up
+// the rate limit of the trigger.
+func (t *publicTrigger) verify(ctx context.Context, header http.Header, body []byte) (map[string]interface{}, error) {
+	meta, err := t.authenticate(ctx, header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.limiter.Allow() {
+		return nil, ErrPublicTriggerRateLimited
+	}
+
+	if t.schema != nil {
+		var v interface{}
+		if err = json.Unmarshal(body, &v); err != nil {
+			return nil, errors.Wrap(ErrPublicTriggerInvalidRequest, err.Error())
+		}
+		if err = t.schema.Validate(v); err != nil {
+			return nil, errors.Wrap(ErrPublicTriggerInvalidRequest, err.Error())
+		}
+	}
+	return meta, nil
+}
+
+func (t *publicTrigger) authenticate(ctx context.Context, header http.Header, body []byte) (map[string]interface{}, error) {
+	switch t.auth {
+	case job.WebhookPublicTriggerAuthHMAC:
+		encrypted, err := t.orm.FindEncryptedPublicTriggerSecret(ctx, t.webhookSpecID)
+		if err != nil {
+			return nil, err
+		}
+		if len(encrypted) == 0 {
+			return nil, errors.Wrap(ErrPublicTriggerUnauthorized, "the job has no secret")
+		}
+		if t.cipher == nil {
+			return nil, bridges.ErrNoSecretCipher
+		}
+		secret, err := t.cipher.Decrypt(ctx, encrypted)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt public trigger secret")
+		}
+		if err = bridges.VerifyRequestSignature([]string{secret}, header, body, t.guard); err != nil {
+			return nil, errors.Wrap(ErrPublicTriggerUnauthorized, err.Error())
+		}
+		return map[string]interface{}{
+			"auth":      string(t.auth),
+			"timestamp": header.Get(bridges.TimestampHeader),
+		}, nil
+	case job.WebhookPublicTriggerAuthJWT:
+		token, found := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+		if !found || token == "" {
+			return nil, errors.Wrap(ErrPublicTriggerUnauthorized, "missing bearer token")
+		}
+		idToken, err := t.verifier.Verify(ctx, token)
+		if err != nil {
+			return nil, errors.Wrap(ErrPublicTriggerUnauthorized, err.Error())
+		}
+		var claims map[string]interface{}
+		if err = idToken.Claims(&claims); err != nil {
+			return nil, errors.Wrap(ErrPublicTriggerUnauthorized, err.Error())
+		}
+		return map[string]interface{}{
+			"auth":   string(t.auth),
+			"claims": claims,
+		}, nil
+	}
+	return nil, ErrPublicTriggerUnauthorized
+}
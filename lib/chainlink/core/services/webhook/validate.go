@@ -18,6 +18,7 @@ type TOMLWebhookSpecExternalInitiator struct {
 
 type TOMLWebhookSpec struct {
 	ExternalInitiators []TOMLWebhookSpecExternalInitiator `toml:"externalInitiators"`
+	PublicTrigger      *job.WebhookPublicTrigger          `toml:"publicTrigger"`
 }
 
 func ValidatedWebhookSpec(ctx context.Context, tomlString string, externalInitiatorManager ExternalInitiatorManager) (jb job.Job, err error) {
@@ -55,12 +56,17 @@ func ValidatedWebhookSpec(ctx context.Context, tomlString string, externalInitia
 		externalInitiatorWebhookSpecs = append(externalInitiatorWebhookSpecs, eiWS)
 	}
 
+	if tomlSpec.PublicTrigger != nil {
+		err = multierr.Combine(err, ValidatePublicTrigger(tomlSpec.PublicTrigger))
+	}
+
 	if err != nil {
 		return jb, err
 	}
 
 	jb.WebhookSpec = &job.WebhookSpec{
 		ExternalInitiatorWebhookSpecs: externalInitiatorWebhookSpecs,
+		PublicTrigger:                 tomlSpec.PublicTrigger,
 	}
 
 	return jb, nil
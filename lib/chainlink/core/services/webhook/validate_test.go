@@ -144,6 +144,73 @@ func TestValidatedWebJobSpec(t *testing.T) {
 				require.EqualError(t, err, "unable to find external initiator named bar: something exploded; unable to find external initiator named baz: something exploded")
 			},
 		},
+		{
+			name: "with public trigger",
+			toml: `
+            type            = "webhook"
+            schemaVersion   = 1
+            observationSource   = """
+                ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+            """
+
+            [publicTrigger]
+            auth          = "jwt"
+            jwksURL       = "https://auth.example.com/.well-known/jwks.json"
+            issuer        = "https://auth.example.com/"
+            audience      = "lottery"
+            requestSchema = '{"type": "object", "required": ["lotID"]}'
+            `,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				trigger := s.WebhookSpec.PublicTrigger
+				require.NotNil(t, trigger)
+				assert.Equal(t, job.WebhookPublicTriggerAuthJWT, trigger.Auth)
+				assert.Equal(t, "https://auth.example.com/.well-known/jwks.json", trigger.JWKSURL)
+				assert.Equal(t, "https://auth.example.com/", trigger.Issuer)
+				assert.Equal(t, "lottery", trigger.Audience)
+				assert.Equal(t, uint32(webhook.DefaultPublicTriggerRateLimit), trigger.RateLimit)
+				assert.Equal(t, webhook.DefaultPublicTriggerRateLimitPeriod, trigger.RateLimitPeriod.Duration())
+			},
+		},
+		{
+			name: "with invalid public trigger",
+			toml: `
+            type            = "webhook"
+            schemaVersion   = 1
+            observationSource   = """
+                ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+            """
+
+            [publicTrigger]
+            auth          = "hmac"
+            jwksURL       = "https://auth.example.com/.well-known/jwks.json"
+            requestSchema = '{"type": 42}'
+            `,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "jwksURL, issuer and audience can only be used with jwt auth")
+				assert.Contains(t, err.Error(), "invalid requestSchema")
+			},
+		},
+		{
+			name: "with jwt public trigger without issuer and audience",
+			toml: `
+            type            = "webhook"
+            schemaVersion   = 1
+            observationSource   = """
+                ds          [type=http method=GET url="https://chain.link/ETH-USD"];
+            """
+
+            [publicTrigger]
+            auth          = "jwt"
+            jwksURL       = "https://auth.example.com/.well-known/jwks.json"
+            `,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "issuer is required with jwt auth")
+				assert.Contains(t, err.Error(), "audience is required with jwt auth")
+			},
+		},
 	}
 	for _, tc := range tt {
 		tc := tc
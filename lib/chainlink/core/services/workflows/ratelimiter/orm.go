@@ -0,0 +1,65 @@
+package ratelimiter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+)
+
+const (
+	periodDay   = "day"
+	periodMonth = "month"
+)
+
+type ORM interface {
+	// AddUsage adds executions of a sender to its counts for a day and month, and returns
+	// the resulting counts, which include those added by other nodes sharing the database.
+	AddUsage(ctx context.Context, sender string, day, month time.Time, count int64) (daily, monthly int64, err error)
+	// DeleteUsageBefore deletes the counts of the days before day and of the months before month.
+	DeleteUsageBefore(ctx context.Context, day, month time.Time) error
+}
+
+type orm struct {
+	ds sqlutil.DataSource
+}
+
+var _ ORM = (*orm)(nil)
+
+func NewORM(ds sqlutil.DataSource) ORM {
+	return &orm{ds: ds}
+}
+
+func (o *orm) AddUsage(ctx context.Context, sender string, day, month time.Time, count int64) (daily, monthly int64, err error) {
+	var rows []struct {
+		Period     string
+		Executions int64
+	}
+	err = o.ds.SelectContext(ctx, &rows, `INSERT INTO workflow_execution_usage (sender, period, period_start, executions, updated_at)
+		VALUES ($1, $2, $3, $6, NOW()), ($1, $4, $5, $6, NOW())
+		ON CONFLICT (sender, period, period_start) DO UPDATE
+		SET executions = workflow_execution_usage.executions + EXCLUDED.executions, updated_at = NOW()
+		RETURNING period, executions`, sender, periodDay, day, periodMonth, month, count)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to add workflow execution usage: %w", err)
+	}
+	for _, r := range rows {
+		switch r.Period {
+		case periodDay:
+			daily = r.Executions
+		case periodMonth:
+			monthly = r.Executions
+		}
+	}
+	return daily, monthly, nil
+}
+
+func (o *orm) DeleteUsageBefore(ctx context.Context, day, month time.Time) error {
+	_, err := o.ds.ExecContext(ctx, `DELETE FROM workflow_execution_usage
+		WHERE (period = $1 AND period_start < $2) OR (period = $3 AND period_start < $4)`, periodDay, day, periodMonth, month)
+	if err != nil {
+		return fmt.Errorf("failed to delete workflow execution usage: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package ratelimiter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/ratelimiter"
+)
+
+func TestORM_AddUsage(t *testing.T) {
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	orm := ratelimiter.NewORM(db)
+
+	day := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	month := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	daily, monthly, err := orm.AddUsage(ctx, "owner", day, month, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), daily)
+	require.Equal(t, int64(2), monthly)
+
+	daily, monthly, err = orm.AddUsage(ctx, "owner", day.AddDate(0, 0, 1), month, 3)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), daily)
+	require.Equal(t, int64(5), monthly)
+
+	require.NoError(t, orm.DeleteUsageBefore(ctx, day.AddDate(0, 0, 1), month))
+	daily, monthly, err = orm.AddUsage(ctx, "owner", day, month, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), daily)
+	require.Equal(t, int64(5), monthly)
+}
@@ -0,0 +1,84 @@
+package ratelimiter
+
+import (
+	"context"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+)
+
+const (
+	defaultQuotaSyncInterval = 10 * time.Second
+	usagePruneInterval       = time.Hour
+)
+
+// QuotaSyncer periodically persists the executions counted by a RateLimiter, and updates its
+// counts with those persisted, so that quotas survive restarts and are shared by the nodes
+// using the same database. Between syncs, a sender can exceed its quota by the executions
+// started on other nodes.
+type QuotaSyncer struct {
+	services.Service
+	eng *services.Engine
+
+	rl       *RateLimiter
+	orm      ORM
+	interval time.Duration
+
+	lastSync  time.Time
+	lastPrune time.Time
+}
+
+func NewQuotaSyncer(lggr logger.Logger, rl *RateLimiter, orm ORM) *QuotaSyncer {
+	s := &QuotaSyncer{
+		rl:       rl,
+		orm:      orm,
+		interval: defaultQuotaSyncInterval,
+	}
+	s.Service, s.eng = services.Config{
+		Name:  "WorkflowQuotaSyncer",
+		Start: s.start,
+		Close: s.close,
+	}.NewServiceEngine(lggr)
+	return s
+}
+
+func (s *QuotaSyncer) start(context.Context) error {
+	ticker := services.TickerConfig{
+		JitterPct: services.DefaultJitter,
+	}.NewTicker(s.interval)
+	s.eng.GoTick(ticker, s.sync)
+	return nil
+}
+
+// close persists the counts one last time, so that they are not lost on shutdown.
+func (s *QuotaSyncer) close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+	s.sync(ctx)
+	return nil
+}
+
+func (s *QuotaSyncer) sync(ctx context.Context) {
+	now := s.rl.clock.Now()
+	if now.Sub(s.lastPrune) > usagePruneInterval {
+		// Keep the previous day and month, which counts can still be added to.
+		if err := s.orm.DeleteUsageBefore(ctx, startOfDay(now).AddDate(0, 0, -1), startOfMonth(now).AddDate(0, -1, 0)); err != nil {
+			s.eng.Errorw("Failed to prune workflow execution usage", "err", err)
+		} else {
+			s.lastPrune = now
+		}
+	}
+
+	deltas := s.rl.takeUnsynced(s.lastSync)
+	s.lastSync = now
+	for i, d := range deltas {
+		daily, monthly, err := s.orm.AddUsage(ctx, d.Sender, d.Day, d.Month, d.Count)
+		if err != nil {
+			s.eng.Errorw("Failed to sync workflow execution quotas", "err", err)
+			s.rl.restoreUnsynced(deltas[i:])
+			return
+		}
+		s.rl.synced(d, daily, monthly)
+	}
+}
@@ -1,18 +1,45 @@
 package ratelimiter
 
 import (
+	"container/list"
 	"errors"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/jonboulle/clockwork"
 	"golang.org/x/time/rate"
 )
 
+const (
+	defaultSenderTTL  = time.Hour
+	defaultMaxSenders = 10_000
+)
+
+var (
+	// ErrDailyQuotaExceeded is returned when a sender has used up its daily quota of executions.
+	ErrDailyQuotaExceeded = errors.New("daily quota exceeded")
+	// ErrMonthlyQuotaExceeded is returned when a sender has used up its monthly quota of executions.
+	ErrMonthlyQuotaExceeded = errors.New("monthly quota exceeded")
+)
+
 // Wrapper around Go's rate.Limiter that supports both global and a per-sender rate limiting.
+// Senders that have been idle for longer than SenderTTL, or the least recently seen ones beyond
+// MaxSenders, are evicted.
+//
+// It also counts the executions of each sender towards its daily and monthly quotas. The counts
+// are persisted, and shared with other nodes using the same database, by a QuotaSyncer.
 type RateLimiter struct {
-	global    *rate.Limiter
-	perSender map[string]*rate.Limiter
-	config    Config
-	mu        sync.Mutex
+	global  *rate.Limiter
+	config  Config
+	clock   clockwork.Clock
+	mu      sync.Mutex
+	senders map[string]*list.Element
+	// lru orders the senders from the most to the least recently seen.
+	lru *list.List
+	// unsynced are the counts of evicted senders, or of past periods, not persisted yet.
+	unsynced []UsageDelta
 }
 
 type Config struct {
@@ -20,6 +47,68 @@ type Config struct {
 	GlobalBurst    int     `json:"globalBurst"`
 	PerSenderRPS   float64 `json:"perSenderRPS"`
 	PerSenderBurst int     `json:"perSenderBurst"`
+
+	// SenderTTL is how long a sender can be idle before it is evicted. Defaults to 1h.
+	SenderTTL time.Duration `json:"senderTTL"`
+	// MaxSenders is how many senders are kept at most. Defaults to 10000.
+	MaxSenders int `json:"maxSenders"`
+	// PerSenderDailyQuota and PerSenderMonthlyQuota are how many executions a sender can start
+	// per UTC day and month. Zero means unlimited.
+	PerSenderDailyQuota   int64 `json:"perSenderDailyQuota"`
+	PerSenderMonthlyQuota int64 `json:"perSenderMonthlyQuota"`
+
+	// PerSenderOverrides maps a sender to the limits that replace the per sender defaults.
+	PerSenderOverrides map[string]SenderLimits `json:"perSenderOverrides"`
+}
+
+// SenderLimits are the limits of a sender. Unset fields fall back to the per sender defaults.
+type SenderLimits struct {
+	RPS          *float64 `json:"rps"`
+	Burst        *int     `json:"burst"`
+	DailyQuota   *int64   `json:"dailyQuota"`
+	MonthlyQuota *int64   `json:"monthlyQuota"`
+}
+
+type sender struct {
+	name         string
+	limiter      *rate.Limiter
+	dailyQuota   int64
+	monthlyQuota int64
+	lastSeen     time.Time
+	usage        usage
+}
+
+// usage is the count of executions of a sender in the current day and month.
+type usage struct {
+	day     time.Time
+	month   time.Time
+	daily   int64
+	monthly int64
+	// unsynced is how many of the executions counted are not persisted yet.
+	unsynced int64
+	// synced is whether the counts include those persisted.
+	synced bool
+}
+
+// UsageDelta is a count of executions of a sender to add to the persisted counts.
+type UsageDelta struct {
+	Sender string
+	Day    time.Time
+	Month  time.Time
+	Count  int64
+}
+
+// SenderUsage is the current usage of the limits of a sender.
+type SenderUsage struct {
+	Sender            string
+	RPS               float64
+	Burst             int
+	Tokens            float64
+	LastSeen          time.Time
+	DailyExecutions   int64
+	DailyQuota        int64
+	MonthlyExecutions int64
+	MonthlyQuota      int64
 }
 
 func NewRateLimiter(config Config) (*RateLimiter, error) {
@@ -29,23 +118,233 @@ func NewRateLimiter(config Config) (*RateLimiter, error) {
 	if config.GlobalBurst <= 0 || config.PerSenderBurst <= 0 {
 		return nil, errors.New("burst values must be positive")
 	}
+	if config.SenderTTL < 0 || config.MaxSenders < 0 || config.PerSenderDailyQuota < 0 || config.PerSenderMonthlyQuota < 0 {
+		return nil, errors.New("sender TTL, max senders and quotas cannot be negative")
+	}
+	if config.SenderTTL == 0 {
+		config.SenderTTL = defaultSenderTTL
+	}
+	if config.MaxSenders == 0 {
+		config.MaxSenders = defaultMaxSenders
+	}
+	overrides := make(map[string]SenderLimits, len(config.PerSenderOverrides))
+	for k, v := range config.PerSenderOverrides {
+		if (v.RPS != nil && *v.RPS <= 0) || (v.Burst != nil && *v.Burst <= 0) {
+			return nil, errors.New("RPS and burst overrides must be positive")
+		}
+		if (v.DailyQuota != nil && *v.DailyQuota < 0) || (v.MonthlyQuota != nil && *v.MonthlyQuota < 0) {
+			return nil, errors.New("quota overrides cannot be negative")
+		}
+		overrides[normalizeSender(k)] = v
+	}
+	config.PerSenderOverrides = overrides
+
 	return &RateLimiter{
-		global:    rate.NewLimiter(rate.Limit(config.GlobalRPS), config.GlobalBurst),
-		perSender: make(map[string]*rate.Limiter),
-		config:    config,
+		global:  rate.NewLimiter(rate.Limit(config.GlobalRPS), config.GlobalBurst),
+		config:  config,
+		clock:   clockwork.NewRealClock(),
+		senders: make(map[string]*list.Element),
+		lru:     list.New(),
 	}, nil
 }
 
 func (rl *RateLimiter) Allow(sender string) (senderAllow bool, globalAllow bool) {
 	rl.mu.Lock()
-	senderLimiter, ok := rl.perSender[sender]
-	if !ok {
-		senderLimiter = rate.NewLimiter(rate.Limit(rl.config.PerSenderRPS), rl.config.PerSenderBurst)
-		rl.perSender[sender] = senderLimiter
-	}
+	s := rl.getSender(normalizeSender(sender))
 	rl.mu.Unlock()
 
-	senderAllow = senderLimiter.Allow()
+	senderAllow = s.limiter.Allow()
 	globalAllow = rl.global.Allow()
 	return senderAllow, globalAllow
 }
+
+// ConsumeQuota counts an execution of the sender, unless it has used up its daily or monthly quota.
+func (rl *RateLimiter) ConsumeQuota(sender string) error {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	s := rl.getSender(normalizeSender(sender))
+	rl.rollOver(s)
+	if s.dailyQuota > 0 && s.usage.daily >= s.dailyQuota {
+		return ErrDailyQuotaExceeded
+	}
+	if s.monthlyQuota > 0 && s.usage.monthly >= s.monthlyQuota {
+		return ErrMonthlyQuotaExceeded
+	}
+	s.usage.daily++
+	s.usage.monthly++
+	s.usage.unsynced++
+	return nil
+}
+
+// Usage returns the current usage of all the senders, sorted by sender.
+func (rl *RateLimiter) Usage() []SenderUsage {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.evict()
+	now := rl.clock.Now()
+	result := make([]SenderUsage, 0, len(rl.senders))
+	for e := rl.lru.Front(); e != nil; e = e.Next() {
+		s := e.Value.(*sender)
+		rl.rollOver(s)
+		result = append(result, SenderUsage{
+			Sender:            s.name,
+			RPS:               float64(s.limiter.Limit()),
+			Burst:             s.limiter.Burst(),
+			Tokens:            s.limiter.TokensAt(now),
+			LastSeen:          s.lastSeen,
+			DailyExecutions:   s.usage.daily,
+			DailyQuota:        s.dailyQuota,
+			MonthlyExecutions: s.usage.monthly,
+			MonthlyQuota:      s.monthlyQuota,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Sender < result[j].Sender })
+	return result
+}
+
+// getSender returns the sender, adding it if it is not known, and marks it as the most
+// recently seen. rl.mu must be held.
+func (rl *RateLimiter) getSender(name string) *sender {
+	now := rl.clock.Now()
+	if e, ok := rl.senders[name]; ok {
+		s := e.Value.(*sender)
+		s.lastSeen = now
+		rl.lru.MoveToFront(e)
+		return s
+	}
+
+	limits := rl.config.PerSenderOverrides[name]
+	rps, burst := rl.config.PerSenderRPS, rl.config.PerSenderBurst
+	dailyQuota, monthlyQuota := rl.config.PerSenderDailyQuota, rl.config.PerSenderMonthlyQuota
+	if limits.RPS != nil {
+		rps = *limits.RPS
+	}
+	if limits.Burst != nil {
+		burst = *limits.Burst
+	}
+	if limits.DailyQuota != nil {
+		dailyQuota = *limits.DailyQuota
+	}
+	if limits.MonthlyQuota != nil {
+		monthlyQuota = *limits.MonthlyQuota
+	}
+	s := &sender{
+		name:         name,
+		limiter:      rate.NewLimiter(rate.Limit(rps), burst),
+		dailyQuota:   dailyQuota,
+		monthlyQuota: monthlyQuota,
+		lastSeen:     now,
+		usage:        usage{day: startOfDay(now), month: startOfMonth(now)},
+	}
+	rl.senders[name] = rl.lru.PushFront(s)
+	rl.evict()
+	return s
+}
+
+// evict removes the senders that have been idle for longer than the TTL, and the least
+// recently seen ones beyond the max number of senders. rl.mu must be held.
+func (rl *RateLimiter) evict() {
+	now := rl.clock.Now()
+	for e := rl.lru.Back(); e != nil; e = rl.lru.Back() {
+		s := e.Value.(*sender)
+		if rl.lru.Len() <= rl.config.MaxSenders && now.Sub(s.lastSeen) <= rl.config.SenderTTL {
+			return
+		}
+		rl.lru.Remove(e)
+		delete(rl.senders, s.name)
+		rl.keepUnsynced(s)
+	}
+}
+
+// rollOver resets the counts of the sender when a new day or month starts. rl.mu must be held.
+func (rl *RateLimiter) rollOver(s *sender) {
+	now := rl.clock.Now()
+	day, month := startOfDay(now), startOfMonth(now)
+	if s.usage.day.Equal(day) {
+		return
+	}
+	rl.keepUnsynced(s)
+	s.usage.day, s.usage.daily, s.usage.synced = day, 0, false
+	if !s.usage.month.Equal(month) {
+		s.usage.month, s.usage.monthly = month, 0
+	}
+}
+
+// keepUnsynced keeps the counts of the sender that are not persisted yet, so that they are
+// persisted later. rl.mu must be held.
+func (rl *RateLimiter) keepUnsynced(s *sender) {
+	if s.usage.unsynced == 0 {
+		return
+	}
+	rl.unsynced = append(rl.unsynced, UsageDelta{Sender: s.name, Day: s.usage.day, Month: s.usage.month, Count: s.usage.unsynced})
+	s.usage.unsynced = 0
+}
+
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// normalizeSender removes any 0x prefix, so that addresses match regardless of their format.
+func normalizeSender(k string) string {
+	return strings.ToLower(strings.TrimPrefix(k, "0x"))
+}
+
+// takeUnsynced returns the counts not persisted yet, and those of the senders that are not
+// synced or were seen since, which are then expected to be passed to synced.
+func (rl *RateLimiter) takeUnsynced(since time.Time) []UsageDelta {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.evict()
+	for _, e := range rl.senders {
+		rl.rollOver(e.Value.(*sender))
+	}
+	deltas := rl.unsynced
+	rl.unsynced = nil
+	for _, e := range rl.senders {
+		s := e.Value.(*sender)
+		if s.usage.unsynced == 0 && s.usage.synced && s.lastSeen.Before(since) {
+			continue
+		}
+		deltas = append(deltas, UsageDelta{Sender: s.name, Day: s.usage.day, Month: s.usage.month, Count: s.usage.unsynced})
+		s.usage.unsynced = 0
+	}
+	return deltas
+}
+
+// restoreUnsynced gives back counts that could not be persisted, to be retried.
+func (rl *RateLimiter) restoreUnsynced(deltas []UsageDelta) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	for _, d := range deltas {
+		if d.Count > 0 {
+			rl.unsynced = append(rl.unsynced, d)
+		}
+	}
+}
+
+// synced sets the counts of a sender to the persisted ones, plus those counted since they were taken.
+func (rl *RateLimiter) synced(d UsageDelta, daily, monthly int64) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	e, ok := rl.senders[d.Sender]
+	if !ok {
+		return
+	}
+	s := e.Value.(*sender)
+	if !s.usage.day.Equal(d.Day) {
+		return
+	}
+	s.usage.daily = daily + s.usage.unsynced
+	s.usage.monthly = monthly + s.usage.unsynced
+	s.usage.synced = true
+}
@@ -1,9 +1,14 @@
 package ratelimiter
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
 )
 
 func TestRateLimiter(t *testing.T) {
@@ -28,3 +33,130 @@ func TestRateLimiter(t *testing.T) {
 	allowUserSender, allowUserGlobal = rl.Allow("user3")
 	require.False(t, allowUserSender && allowUserGlobal)
 }
+
+func TestRateLimiter_Eviction(t *testing.T) {
+	t.Parallel()
+
+	rl, err := NewRateLimiter(Config{
+		GlobalRPS:      100.0,
+		GlobalBurst:    100,
+		PerSenderRPS:   1.0,
+		PerSenderBurst: 1,
+		SenderTTL:      time.Minute,
+		MaxSenders:     2,
+	})
+	require.NoError(t, err)
+	clock := clockwork.NewFakeClock()
+	rl.clock = clock
+
+	rl.Allow("user1")
+	rl.Allow("user2")
+	rl.Allow("user3")
+	require.Equal(t, []string{"user2", "user3"}, senders(rl.Usage()))
+
+	clock.Advance(30 * time.Second)
+	rl.Allow("user3")
+	clock.Advance(45 * time.Second)
+	require.Equal(t, []string{"user3"}, senders(rl.Usage()))
+}
+
+func TestRateLimiter_Quotas(t *testing.T) {
+	t.Parallel()
+
+	rl, err := NewRateLimiter(Config{
+		GlobalRPS:             100.0,
+		GlobalBurst:           100,
+		PerSenderRPS:          100.0,
+		PerSenderBurst:        100,
+		PerSenderDailyQuota:   2,
+		PerSenderMonthlyQuota: 3,
+		PerSenderOverrides: map[string]SenderLimits{
+			"0xABCD": {DailyQuota: ptr[int64](0), Burst: ptr(5)},
+		},
+	})
+	require.NoError(t, err)
+	clock := clockwork.NewFakeClockAt(time.Date(2025, 1, 30, 12, 0, 0, 0, time.UTC))
+	rl.clock = clock
+
+	require.NoError(t, rl.ConsumeQuota("user1"))
+	require.NoError(t, rl.ConsumeQuota("user1"))
+	require.ErrorIs(t, rl.ConsumeQuota("user1"), ErrDailyQuotaExceeded)
+
+	clock.Advance(24 * time.Hour)
+	require.NoError(t, rl.ConsumeQuota("user1"))
+	require.ErrorIs(t, rl.ConsumeQuota("user1"), ErrMonthlyQuotaExceeded)
+
+	clock.Advance(24 * time.Hour)
+	require.NoError(t, rl.ConsumeQuota("user1"))
+
+	// The override lifts the daily quota, and applies to any format of the address.
+	for range 3 {
+		require.NoError(t, rl.ConsumeQuota("0xabcd"))
+	}
+	require.ErrorIs(t, rl.ConsumeQuota("abcd"), ErrMonthlyQuotaExceeded)
+
+	usage := rl.Usage()
+	require.Len(t, usage, 2)
+	require.Equal(t, "abcd", usage[0].Sender)
+	require.Equal(t, 5, usage[0].Burst)
+	require.Equal(t, int64(3), usage[0].MonthlyExecutions)
+	require.Equal(t, int64(0), usage[0].DailyQuota)
+	require.Equal(t, "user1", usage[1].Sender)
+	require.Equal(t, int64(1), usage[1].DailyExecutions)
+	require.Equal(t, int64(1), usage[1].MonthlyExecutions)
+}
+
+func TestQuotaSyncer(t *testing.T) {
+	t.Parallel()
+
+	rl, err := NewRateLimiter(Config{
+		GlobalRPS:           100.0,
+		GlobalBurst:         100,
+		PerSenderRPS:        100.0,
+		PerSenderBurst:      100,
+		PerSenderDailyQuota: 3,
+	})
+	require.NoError(t, err)
+	rl.clock = clockwork.NewFakeClockAt(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	orm := &fakeORM{usage: map[string]int64{}}
+	syncer := NewQuotaSyncer(logger.TestLogger(t), rl, orm)
+
+	require.NoError(t, rl.ConsumeQuota("user1"))
+	syncer.sync(t.Context())
+	require.Equal(t, int64(1), orm.usage["user1"])
+
+	// Another node sharing the database started executions too.
+	orm.usage["user1"] += 2
+	syncer.sync(t.Context())
+	require.ErrorIs(t, rl.ConsumeQuota("user1"), ErrDailyQuotaExceeded)
+
+	// Counts of evicted senders are still persisted.
+	require.NoError(t, rl.ConsumeQuota("user2"))
+	rl.mu.Lock()
+	rl.config.MaxSenders = 0
+	rl.evict()
+	rl.mu.Unlock()
+	syncer.sync(t.Context())
+	require.Equal(t, int64(1), orm.usage["user2"])
+}
+
+type fakeORM struct {
+	usage map[string]int64
+}
+
+func (o *fakeORM) AddUsage(_ context.Context, sender string, _, _ time.Time, count int64) (int64, int64, error) {
+	o.usage[sender] += count
+	return o.usage[sender], o.usage[sender], nil
+}
+
+func (o *fakeORM) DeleteUsageBefore(context.Context, time.Time, time.Time) error { return nil }
+
+func senders(usage []SenderUsage) (names []string) {
+	for _, u := range usage {
+		names = append(names, u.Sender)
+	}
+	return names
+}
+
+func ptr[T any](v T) *T { return &v }
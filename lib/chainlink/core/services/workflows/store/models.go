@@ -15,6 +15,7 @@ const (
 	StatusTimeout            = "timeout"
 	StatusCompleted          = "completed"
 	StatusCompletedEarlyExit = "completed_early_exit"
+	StatusCanceled           = "canceled"
 )
 
 var ValidStatuses = map[string]bool{
@@ -23,6 +24,7 @@ var ValidStatuses = map[string]bool{
 	StatusTimeout:            true,
 	StatusCompleted:          true,
 	StatusCompletedEarlyExit: true,
+	StatusCanceled:           true,
 }
 
 type StepOutput struct {
@@ -38,6 +40,7 @@ type WorkflowExecutionStep struct {
 	Inputs  *values.Map
 	Outputs StepOutput
 
+	CreatedAt *time.Time
 	UpdatedAt *time.Time
 }
 
@@ -86,6 +89,7 @@ func (w WorkflowExecution) DeepCopy() WorkflowExecution {
 			},
 
 			Inputs:    mval,
+			CreatedAt: step.CreatedAt,
 			UpdatedAt: step.UpdatedAt,
 		}
 
@@ -12,4 +12,11 @@ type Store interface {
 	Get(ctx context.Context, executionID string) (WorkflowExecution, error)
 }
 
+// DurableStore is a Store that persists executions across restarts.
+type DurableStore interface {
+	Store
+	// GetUnfinished returns the executions of the workflow that have not finished, oldest first.
+	GetUnfinished(ctx context.Context, workflowID string) ([]WorkflowExecution, error)
+}
+
 var _ Store = (*InMemoryStore)(nil)
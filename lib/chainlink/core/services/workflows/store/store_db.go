@@ -0,0 +1,338 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	valuespb "github.com/smartcontractkit/chainlink-common/pkg/values/pb"
+)
+
+const (
+	// defaultFinishedExecutionRetention is how long finished executions are kept in the database.
+	defaultFinishedExecutionRetention = 24 * time.Hour
+	dbPruneInterval                   = time.Hour
+)
+
+var _ DurableStore = (*DBStore)(nil)
+
+// DBStore is a Postgres implementation of the Store interface. Executions survive restarts, so that
+// the unfinished ones can be resumed or failed, and finished ones are pruned after a retention period.
+type DBStore struct {
+	services.Service
+	eng *services.Engine
+
+	ds        sqlutil.DataSource
+	clock     clockwork.Clock
+	retention time.Duration
+}
+
+func NewDBStore(ds sqlutil.DataSource, lggr logger.Logger, clock clockwork.Clock) *DBStore {
+	return NewDBStoreWithRetention(ds, lggr, clock, defaultFinishedExecutionRetention)
+}
+
+func NewDBStoreWithRetention(ds sqlutil.DataSource, lggr logger.Logger, clock clockwork.Clock, retention time.Duration) *DBStore {
+	s := &DBStore{ds: ds, clock: clock, retention: retention}
+	s.Service, s.eng = services.Config{
+		Name:  "WorkflowDBStore",
+		Start: s.start,
+	}.NewServiceEngine(lggr)
+	return s
+}
+
+func (s *DBStore) start(context.Context) error {
+	ticker := services.TickerConfig{
+		JitterPct: services.DefaultJitter,
+	}.NewTicker(dbPruneInterval)
+	s.eng.GoTick(ticker, s.prune)
+	return nil
+}
+
+func (s *DBStore) prune(ctx context.Context) {
+	n, err := s.DeleteFinishedBefore(ctx, s.clock.Now().Add(-s.retention))
+	if err != nil {
+		s.eng.Errorw("Failed to prune finished workflow executions", "err", err)
+		return
+	}
+	if n > 0 {
+		s.eng.Debugw("Pruned finished workflow executions", "count", n)
+	}
+}
+
+type executionRow struct {
+	ID         string
+	WorkflowID sql.NullString
+	Status     string
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+type stepRow struct {
+	ID                  int64
+	WorkflowExecutionID string
+	Ref                 string
+	Status              string
+	Inputs              []byte
+	OutputErr           sql.NullString
+	OutputValue         []byte
+	CreatedAt           *time.Time
+	UpdatedAt           *time.Time
+}
+
+// ExecutionsFilter selects the executions returned by List. Empty fields match all executions.
+type ExecutionsFilter struct {
+	WorkflowID string
+	Status     string
+}
+
+// Add adds a new execution, and its steps, under the given executionID
+func (s *DBStore) Add(ctx context.Context, steps map[string]*WorkflowExecutionStep,
+	executionID string, workflowID string, status string) (WorkflowExecution, error) {
+	now := s.clock.Now()
+	err := sqlutil.TransactDataSource(ctx, s.ds, nil, func(tx sqlutil.DataSource) error {
+		res, err := tx.ExecContext(ctx, `INSERT INTO workflow_executions (id, workflow_id, status, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $4) ON CONFLICT (id) DO NOTHING`, executionID, workflowID, status, now)
+		if err != nil {
+			return fmt.Errorf("failed to insert execution %s: %w", executionID, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return fmt.Errorf("execution ID %s already exists in store", executionID)
+		}
+		for _, step := range steps {
+			step.ExecutionID = executionID
+			if err := upsertStep(ctx, tx, step, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return WorkflowExecution{}, err
+	}
+	return s.Get(ctx, executionID)
+}
+
+// UpsertStep updates a step for the given executionID
+func (s *DBStore) UpsertStep(ctx context.Context, step *WorkflowExecutionStep) (WorkflowExecution, error) {
+	now := s.clock.Now()
+	err := sqlutil.TransactDataSource(ctx, s.ds, nil, func(tx sqlutil.DataSource) error {
+		res, err := tx.ExecContext(ctx, `UPDATE workflow_executions SET updated_at = $2 WHERE id = $1`, step.ExecutionID, now)
+		if err != nil {
+			return fmt.Errorf("failed to update execution %s: %w", step.ExecutionID, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return fmt.Errorf("could not find execution %s", step.ExecutionID)
+		}
+		return upsertStep(ctx, tx, step, now)
+	})
+	if err != nil {
+		return WorkflowExecution{}, err
+	}
+	return s.Get(ctx, step.ExecutionID)
+}
+
+func upsertStep(ctx context.Context, ds sqlutil.DataSource, step *WorkflowExecutionStep, now time.Time) error {
+	var inputs, outputValue []byte
+	var err error
+	if step.Inputs != nil {
+		if inputs, err = proto.Marshal(values.ProtoMap(step.Inputs)); err != nil {
+			return fmt.Errorf("failed to marshal inputs of step %s: %w", step.Ref, err)
+		}
+	}
+	if step.Outputs.Value != nil {
+		if outputValue, err = proto.Marshal(values.Proto(step.Outputs.Value)); err != nil {
+			return fmt.Errorf("failed to marshal outputs of step %s: %w", step.Ref, err)
+		}
+	}
+	var outputErr sql.NullString
+	if step.Outputs.Err != nil {
+		outputErr = sql.NullString{String: step.Outputs.Err.Error(), Valid: true}
+	}
+	_, err = ds.ExecContext(ctx, `INSERT INTO workflow_steps (workflow_execution_id, ref, status, inputs, output_err, output_value, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		ON CONFLICT (workflow_execution_id, ref) DO UPDATE SET
+			status = EXCLUDED.status, inputs = EXCLUDED.inputs, output_err = EXCLUDED.output_err,
+			output_value = EXCLUDED.output_value, updated_at = EXCLUDED.updated_at`,
+		step.ExecutionID, step.Ref, step.Status, inputs, outputErr, outputValue, now)
+	if err != nil {
+		return fmt.Errorf("failed to upsert step %s of execution %s: %w", step.Ref, step.ExecutionID, err)
+	}
+	return nil
+}
+
+// FinishExecution marks the execution as finished with the given status
+func (s *DBStore) FinishExecution(ctx context.Context, executionID string, status string) (WorkflowExecution, error) {
+	if !isCompletedStatus(status) {
+		return WorkflowExecution{}, fmt.Errorf("invalid status for a finished execution %s", status)
+	}
+
+	now := s.clock.Now()
+	res, err := s.ds.ExecContext(ctx, `UPDATE workflow_executions SET status = $2, updated_at = $3, finished_at = $3 WHERE id = $1`,
+		executionID, status, now)
+	if err != nil {
+		return WorkflowExecution{}, fmt.Errorf("failed to finish execution %s: %w", executionID, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return WorkflowExecution{}, err
+	}
+	if n == 0 {
+		return WorkflowExecution{}, fmt.Errorf("could not find execution %s", executionID)
+	}
+	return s.Get(ctx, executionID)
+}
+
+// Get gets the state for the given executionID
+func (s *DBStore) Get(ctx context.Context, executionID string) (WorkflowExecution, error) {
+	var row executionRow
+	err := s.ds.GetContext(ctx, &row, `SELECT * FROM workflow_executions WHERE id = $1`, executionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return WorkflowExecution{}, fmt.Errorf("could not find execution %s: %w", executionID, err)
+	} else if err != nil {
+		return WorkflowExecution{}, fmt.Errorf("failed to get execution %s: %w", executionID, err)
+	}
+	executions, err := s.withSteps(ctx, []executionRow{row})
+	if err != nil {
+		return WorkflowExecution{}, err
+	}
+	return executions[0], nil
+}
+
+// GetUnfinished returns the executions of the workflow that have not finished, oldest first.
+func (s *DBStore) GetUnfinished(ctx context.Context, workflowID string) ([]WorkflowExecution, error) {
+	var rows []executionRow
+	err := s.ds.SelectContext(ctx, &rows, `SELECT * FROM workflow_executions
+		WHERE workflow_id = $1 AND status = $2 ORDER BY created_at, id`, workflowID, StatusStarted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unfinished executions of workflow %s: %w", workflowID, err)
+	}
+	return s.withSteps(ctx, rows)
+}
+
+// List returns a page of the executions matching the filter, most recent first, without their
+// steps, and the number of executions matching it.
+func (s *DBStore) List(ctx context.Context, filter ExecutionsFilter, offset, limit int) ([]WorkflowExecution, int, error) {
+	var count int
+	err := s.ds.GetContext(ctx, &count, `SELECT COUNT(*) FROM workflow_executions
+		WHERE ($1 = '' OR workflow_id = $1) AND ($2 = '' OR status::text = $2)`, filter.WorkflowID, filter.Status)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count executions: %w", err)
+	}
+	var rows []executionRow
+	err = s.ds.SelectContext(ctx, &rows, `SELECT * FROM workflow_executions
+		WHERE ($1 = '' OR workflow_id = $1) AND ($2 = '' OR status::text = $2)
+		ORDER BY created_at DESC, id LIMIT $3 OFFSET $4`, filter.WorkflowID, filter.Status, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list executions: %w", err)
+	}
+	executions := make([]WorkflowExecution, len(rows))
+	for i, r := range rows {
+		executions[i] = r.toExecution()
+	}
+	return executions, count, nil
+}
+
+// DeleteFinishedBefore deletes the executions that finished before the given time, and returns how many were deleted.
+func (s *DBStore) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.ds.ExecContext(ctx, `DELETE FROM workflow_executions WHERE finished_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished executions: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func (s *DBStore) withSteps(ctx context.Context, rows []executionRow) ([]WorkflowExecution, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+	}
+	var stepRows []stepRow
+	if err := s.ds.SelectContext(ctx, &stepRows, `SELECT * FROM workflow_steps WHERE workflow_execution_id = ANY($1)`, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to get steps: %w", err)
+	}
+
+	executions := make([]WorkflowExecution, len(rows))
+	byID := make(map[string]*WorkflowExecution, len(rows))
+	for i, r := range rows {
+		executions[i] = r.toExecution()
+		byID[r.ID] = &executions[i]
+	}
+	for _, r := range stepRows {
+		step, err := r.toStep()
+		if err != nil {
+			return nil, err
+		}
+		byID[r.WorkflowExecutionID].Steps[r.Ref] = step
+	}
+	return executions, nil
+}
+
+func (r executionRow) toExecution() WorkflowExecution {
+	return WorkflowExecution{
+		Steps:       map[string]*WorkflowExecutionStep{},
+		ExecutionID: r.ID,
+		WorkflowID:  r.WorkflowID.String,
+		Status:      r.Status,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+		FinishedAt:  r.FinishedAt,
+	}
+}
+
+func (r stepRow) toStep() (*WorkflowExecutionStep, error) {
+	step := &WorkflowExecutionStep{
+		ExecutionID: r.WorkflowExecutionID,
+		Ref:         r.Ref,
+		Status:      r.Status,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if len(r.Inputs) > 0 {
+		m := &valuespb.Map{}
+		if err := proto.Unmarshal(r.Inputs, m); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal inputs of step %s: %w", r.Ref, err)
+		}
+		inputs, err := values.FromMapValueProto(m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode inputs of step %s: %w", r.Ref, err)
+		}
+		step.Inputs = inputs
+	}
+	if len(r.OutputValue) > 0 {
+		v := &valuespb.Value{}
+		if err := proto.Unmarshal(r.OutputValue, v); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outputs of step %s: %w", r.Ref, err)
+		}
+		value, err := values.FromProto(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode outputs of step %s: %w", r.Ref, err)
+		}
+		step.Outputs.Value = value
+	}
+	if r.OutputErr.Valid {
+		step.Outputs.Err = errors.New(r.OutputErr.String)
+	}
+	return step, nil
+}
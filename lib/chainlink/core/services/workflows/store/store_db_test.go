@@ -0,0 +1,149 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/artifacts"
+)
+
+func newTestDBStore(t *testing.T, clock clockwork.Clock, workflowIDs ...string) *DBStore {
+	db := pgtest.NewSqlxDB(t)
+	lggr := logger.TestLogger(t)
+	specs := artifacts.NewWorkflowRegistryDS(db, lggr)
+	for _, id := range workflowIDs {
+		_, err := specs.UpsertWorkflowSpec(testutils.Context(t), &job.WorkflowSpec{
+			Workflow:      "workflow",
+			WorkflowID:    id,
+			WorkflowOwner: "owner",
+			WorkflowName:  "name-" + id,
+			Status:        job.WorkflowSpecStatusActive,
+			CreatedAt:     time.Now(),
+			SpecType:      job.WASMFile,
+		})
+		require.NoError(t, err)
+	}
+	return NewDBStore(db, lggr, clock)
+}
+
+func TestDBStore_AddUpsertFinish(t *testing.T) {
+	ctx := testutils.Context(t)
+	clock := clockwork.NewFakeClockAt(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	store := newTestDBStore(t, clock, "w1")
+
+	inputs, err := values.NewMap(map[string]any{"a": int64(1)})
+	require.NoError(t, err)
+	execution, err := store.Add(ctx, map[string]*WorkflowExecutionStep{
+		"step-1": {Ref: "step-1", Status: StatusCompleted, Inputs: inputs, Outputs: StepOutput{Value: values.NewString("out")}},
+	}, "test-id", "w1", StatusStarted)
+	require.NoError(t, err)
+	assert.Equal(t, "test-id", execution.ExecutionID)
+	assert.Equal(t, "w1", execution.WorkflowID)
+	assert.Equal(t, StatusStarted, execution.Status)
+	require.Len(t, execution.Steps, 1)
+	assert.Equal(t, inputs, execution.Steps["step-1"].Inputs)
+	assert.Equal(t, values.NewString("out"), execution.Steps["step-1"].Outputs.Value)
+
+	_, err = store.Add(ctx, nil, "test-id", "w1", StatusStarted)
+	require.Error(t, err)
+
+	clock.Advance(time.Minute)
+	execution, err = store.UpsertStep(ctx, &WorkflowExecutionStep{
+		ExecutionID: "test-id", Ref: "step-2", Status: StatusErrored, Outputs: StepOutput{Err: errors.New("boom")},
+	})
+	require.NoError(t, err)
+	require.Len(t, execution.Steps, 2)
+	assert.EqualError(t, execution.Steps["step-2"].Outputs.Err, "boom")
+	assert.True(t, execution.UpdatedAt.After(*execution.CreatedAt))
+
+	_, err = store.UpsertStep(ctx, &WorkflowExecutionStep{ExecutionID: "missing", Ref: "step-1"})
+	require.Error(t, err)
+
+	unfinished, err := store.GetUnfinished(ctx, "w1")
+	require.NoError(t, err)
+	require.Len(t, unfinished, 1)
+	assert.Equal(t, "test-id", unfinished[0].ExecutionID)
+
+	_, err = store.FinishExecution(ctx, "test-id", StatusStarted)
+	require.Error(t, err)
+	execution, err = store.FinishExecution(ctx, "test-id", StatusCompleted)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCompleted, execution.Status)
+	assert.NotNil(t, execution.FinishedAt)
+
+	unfinished, err = store.GetUnfinished(ctx, "w1")
+	require.NoError(t, err)
+	assert.Empty(t, unfinished)
+}
+
+func TestDBStore_DeleteFinishedBefore(t *testing.T) {
+	ctx := testutils.Context(t)
+	clock := clockwork.NewFakeClockAt(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	store := newTestDBStore(t, clock, "w1")
+
+	for _, id := range []string{"finished", "unfinished"} {
+		_, err := store.Add(ctx, nil, id, "w1", StatusStarted)
+		require.NoError(t, err)
+	}
+	_, err := store.FinishExecution(ctx, "finished", StatusErrored)
+	require.NoError(t, err)
+
+	clock.Advance(2 * time.Hour)
+	deleted, err := store.DeleteFinishedBefore(ctx, clock.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	_, err = store.Get(ctx, "finished")
+	require.Error(t, err)
+	_, err = store.Get(ctx, "unfinished")
+	require.NoError(t, err)
+}
+
+func TestDBStore_List(t *testing.T) {
+	ctx := testutils.Context(t)
+	clock := clockwork.NewFakeClockAt(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	store := newTestDBStore(t, clock, "w1", "w2")
+
+	for _, e := range []struct{ id, workflowID string }{{"e1", "w1"}, {"e2", "w2"}, {"e3", "w1"}} {
+		_, err := store.Add(ctx, map[string]*WorkflowExecutionStep{
+			"step-1": {Ref: "step-1", Status: StatusCompleted},
+		}, e.id, e.workflowID, StatusStarted)
+		require.NoError(t, err)
+		clock.Advance(time.Minute)
+	}
+	_, err := store.FinishExecution(ctx, "e3", StatusCanceled)
+	require.NoError(t, err)
+
+	executions, count, err := store.List(ctx, ExecutionsFilter{}, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	require.Len(t, executions, 2)
+	assert.Equal(t, "e3", executions[0].ExecutionID)
+	assert.Equal(t, "e2", executions[1].ExecutionID)
+	assert.Empty(t, executions[0].Steps)
+
+	executions, count, err = store.List(ctx, ExecutionsFilter{WorkflowID: "w1", Status: StatusStarted}, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, executions, 1)
+	assert.Equal(t, "e1", executions[0].ExecutionID)
+
+	execution, err := store.Get(ctx, "e1")
+	require.NoError(t, err)
+	assert.True(t, execution.Steps["step-1"].CreatedAt.Equal(clock.Now().Add(-3*time.Minute)))
+
+	_, err = store.Get(ctx, "missing")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -91,6 +91,12 @@ func (s *InMemoryStore) UpsertStep(ctx context.Context, step *WorkflowExecutionS
 	now := s.clock.Now()
 	execution.UpdatedAt = &now
 
+	if prev, ok := execution.Steps[step.Ref]; ok && step.CreatedAt == nil {
+		step.CreatedAt = prev.CreatedAt
+	}
+	if step.CreatedAt == nil {
+		step.CreatedAt = &now
+	}
 	execution.Steps[step.Ref] = step
 	return execution.DeepCopy(), nil
 }
@@ -118,7 +124,7 @@ func (s *InMemoryStore) FinishExecution(ctx context.Context, executionID string,
 
 func isCompletedStatus(status string) bool {
 	switch status {
-	case StatusCompleted, StatusErrored, StatusTimeout, StatusCompletedEarlyExit:
+	case StatusCompleted, StatusErrored, StatusTimeout, StatusCompletedEarlyExit, StatusCanceled:
 		return true
 	}
 	return false
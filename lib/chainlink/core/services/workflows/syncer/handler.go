@@ -116,6 +116,7 @@ type eventHandler struct {
 	lggr logger.Logger
 
 	workflowStore          store.Store
+	v2ExecutionsStore      store.Store
 	capRegistry            core.CapabilitiesRegistry
 	engineRegistry         *EngineRegistry
 	emitter                custmsg.MessageEmitter
@@ -151,6 +152,14 @@ func WithStaticEngine(engine services.Service) func(*eventHandler) {
 	}
 }
 
+// WithV2ExecutionsStore sets the store of the executions of V2 workflows, e.g. a durable one so
+// that their executions are resumed after a restart. Defaults to the workflow store.
+func WithV2ExecutionsStore(s store.Store) func(*eventHandler) {
+	return func(e *eventHandler) {
+		e.v2ExecutionsStore = s
+	}
+}
+
 func WithBillingClient(client metering.BillingClient) func(*eventHandler) {
 	return func(e *eventHandler) {
 		e.billingClient = client
@@ -210,6 +219,9 @@ func NewEventHandler(
 	for _, o := range opts {
 		o(eh)
 	}
+	if eh.v2ExecutionsStore == nil {
+		eh.v2ExecutionsStore = workflowStore
+	}
 
 	return eh, nil
 }
@@ -536,7 +548,7 @@ func (h *eventHandler) engineFactoryFn(ctx context.Context, workflowID string, o
 		Module:          module,
 		WorkflowConfig:  config,
 		CapRegistry:     h.capRegistry,
-		ExecutionsStore: h.workflowStore,
+		ExecutionsStore: h.v2ExecutionsStore,
 
 		WorkflowID:    workflowID,
 		WorkflowOwner: owner,
@@ -1,6 +1,7 @@
 package syncerlimiter
 
 import (
+	"sort"
 	"strings"
 	"sync"
 
@@ -112,6 +113,38 @@ func (l *Limits) Decrement(owner string) {
 	*l.global--
 }
 
+// OwnerUsage is the number of running workflows of an owner, and its limit.
+type OwnerUsage struct {
+	Owner     string
+	Workflows int32
+	Limit     int32
+}
+
+// Usage returns the number of running workflows of every owner that has any, sorted by owner,
+// and the global number of running workflows.
+func (l *Limits) Usage() (owners []OwnerUsage, global int32) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for owner, count := range l.perOwner {
+		if *count <= 0 {
+			continue
+		}
+		owners = append(owners, OwnerUsage{
+			Owner:     owner,
+			Workflows: *count,
+			Limit:     l.getPerOwnerLimit(owner),
+		})
+	}
+	sort.Slice(owners, func(i, j int) bool { return owners[i].Owner < owners[j].Owner })
+	return owners, *l.global
+}
+
+// GlobalLimit returns the maximum number of workflows that can run on the node.
+func (l *Limits) GlobalLimit() int32 {
+	return l.config.Global
+}
+
 // getPerOwnerLimit returns the default limit per owner if there are no overrides found
 // for the given owner.
 func (l *Limits) getPerOwnerLimit(owner string) int32 {
@@ -163,3 +163,26 @@ func TestLimits_getPerOwnerLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkflowLimits_Usage(t *testing.T) {
+	t.Parallel()
+
+	wsl, err := NewWorkflowLimits(logger.TestLogger(t), Config{
+		Global:   3,
+		PerOwner: 1,
+		PerOwnerOverrides: map[string]int32{
+			"0x519BFD3D78fbb740c614432975CBE829E26C490e": 2,
+		},
+	})
+	require.NoError(t, err)
+
+	wsl.Allow(user5String)
+	wsl.Allow(user5String)
+	wsl.Allow(user1String)
+	wsl.Decrement(user1String)
+
+	owners, global := wsl.Usage()
+	require.Equal(t, int32(2), global)
+	require.Equal(t, []OwnerUsage{{Owner: normalizeOwner(user5String), Workflows: 2, Limit: 2}}, owners)
+	require.Equal(t, int32(3), wsl.GlobalLimit())
+}
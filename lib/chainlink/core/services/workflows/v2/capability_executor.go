@@ -27,10 +27,17 @@ type ExecutionHelper struct {
 	UserLogChan         chan<- *protoevents.LogLine
 	TimeProvider
 	SecretsFetcher
+
+	// steps completed by a previous run of the execution, before a restart
+	completedSteps map[string]*store.WorkflowExecutionStep
 }
 
 // CallCapability handles requests generated by the wasm guest
 func (c *ExecutionHelper) CallCapability(ctx context.Context, request *sdkpb.CapabilityRequest) (*sdkpb.CapabilityResponse, error) {
+	if resp, ok := c.completedCapabilityCall(request); ok {
+		c.lggr.Debugw("Capability call already completed, reusing its response", "capID", request.Id, "capReqCallbackID", request.CallbackId)
+		return resp, nil
+	}
 	return c.capCallsSemaphore.WhenAcquired(ctx, func() (*sdkpb.CapabilityResponse, error) {
 		return c.callCapability(ctx, request)
 	})
@@ -107,12 +114,15 @@ func (c *ExecutionHelper) callCapability(ctx context.Context, request *sdkpb.Cap
 	c.metrics.With(platform.KeyCapabilityID, request.Id).IncrementCapabilityInvocationCounter(ctx)
 	_ = events.EmitCapabilityStartedEvent(ctx, c.loggerLabels, c.WorkflowExecutionID, request.Id, meteringRef)
 
+	c.recordCapabilityCall(ctx, request, store.StatusStarted, nil, nil)
+
 	execCtx, execCancel := context.WithTimeout(ctx, time.Millisecond*time.Duration(c.cfg.LocalLimits.CapabilityCallTimeoutMs))
 	defer execCancel()
 
 	capResp, err := capability.Execute(execCtx, capReq)
 	if err != nil {
 		c.lggr.Debugw("Capability execution failed", "capID", request.Id, "capReqCallbackID", request.CallbackId, "err", err)
+		c.recordCapabilityCall(ctx, request, store.StatusErrored, nil, err)
 		_ = events.EmitCapabilityFinishedEvent(ctx, c.loggerLabels, c.WorkflowExecutionID, request.Id, meteringRef, store.StatusErrored)
 		c.metrics.With(platform.KeyCapabilityID, request.Id).IncrementCapabilityFailureCounter(ctx)
 		c.metrics.IncrementTotalWorkflowStepErrorsCounter(ctx)
@@ -120,6 +130,7 @@ func (c *ExecutionHelper) callCapability(ctx context.Context, request *sdkpb.Cap
 	}
 
 	c.lggr.Debugw("Capability execution succeeded", "capID", request.Id, "capReqCallbackID", request.CallbackId)
+	c.recordCapabilityCall(ctx, request, store.StatusCompleted, capResp.Payload, nil)
 	_ = events.EmitCapabilityFinishedEvent(ctx, c.loggerLabels, c.WorkflowExecutionID, request.Id, meteringRef, store.StatusCompleted)
 
 	err = meterReport.Settle(meteringRef, capResp.Metadata.Metering)
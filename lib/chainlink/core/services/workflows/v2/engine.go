@@ -49,6 +49,11 @@ type Engine struct {
 	executionsSemaphore     chan struct{}
 	capCallsSemaphore       *semaphore[*sdkpb.CapabilityResponse]
 
+	// executions in progress by ID, to cancel them, and so that a trigger event delivered again
+	// while its execution is being resumed does not run it twice
+	running   map[string]context.CancelCauseFunc
+	runningMu sync.Mutex
+
 	meterReports *metering.Reports
 
 	metrics *monitoring.WorkflowsMetricLabeler
@@ -64,6 +69,8 @@ type enqueuedTriggerEvent struct {
 	triggerIndex int
 	timestamp    time.Time
 	event        capabilities.TriggerResponse
+	// resumed is set for executions resumed after a restart, which were already rate limited.
+	resumed bool
 }
 
 func NewEngine(cfg *EngineConfig) (*Engine, error) {
@@ -137,6 +144,7 @@ func NewEngine(cfg *EngineConfig) (*Engine, error) {
 		allTriggerEventsQueueCh: make(chan enqueuedTriggerEvent, cfg.LocalLimits.TriggerEventQueueSize),
 		executionsSemaphore:     make(chan struct{}, cfg.LocalLimits.MaxConcurrentWorkflowExecutions),
 		capCallsSemaphore:       NewSemaphore[*sdkpb.CapabilityResponse](cfg.LocalLimits.MaxConcurrentCapabilityCallsPerWorkflow),
+		running:                 make(map[string]context.CancelCauseFunc),
 		meterReports:            metering.NewReports(cfg.BillingClient, cfg.WorkflowOwner, cfg.WorkflowID, beholderLogger, labelsMap, metricsLabeler),
 		metrics:                 metricsLabeler,
 	}
@@ -183,6 +191,8 @@ func (e *Engine) init(ctx context.Context) {
 	e.lggr.Info("Workflow Engine initialized")
 	e.metrics.IncrementWorkflowInitializationCounter(ctx)
 	e.cfg.Hooks.OnInitialized(nil)
+
+	e.resumeExecutions(ctx)
 }
 
 func (e *Engine) runTriggerSubscriptionPhase(ctx context.Context) error {
@@ -339,7 +349,27 @@ func (e *Engine) startExecution(ctx context.Context, wrappedTriggerEvent enqueue
 		return
 	}
 
-	// TODO(CAPPL-911): add rate-limiting
+	cancelCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	if !e.markRunning(executionID, cancel) {
+		e.lggr.Debugw("Workflow execution already running, skipping", "executionID", executionID, "triggerID", wrappedTriggerEvent.triggerCapID)
+		return
+	}
+	defer e.unmarkRunning(executionID)
+
+	completedSteps, found, ok := e.findExecution(ctx, executionID)
+	if !ok {
+		e.lggr.Debugw("Workflow execution already finished, skipping", "executionID", executionID, "triggerID", wrappedTriggerEvent.triggerCapID)
+		return
+	}
+	// Only new executions count towards the rate limits and quotas, so that duplicate events of
+	// an execution don't use them up.
+	if !found {
+		if !wrappedTriggerEvent.resumed && !e.allowExecution(ctx, executionID, wrappedTriggerEvent.triggerCapID) {
+			return
+		}
+		e.recordExecutionStart(ctx, executionID, wrappedTriggerEvent)
+	}
 
 	meteringReport, meteringErr := e.meterReports.Start(ctx, executionID)
 	if meteringErr != nil {
@@ -351,25 +381,28 @@ func (e *Engine) startExecution(ctx context.Context, wrappedTriggerEvent enqueue
 		mrErr := meteringReport.Reserve(ctx)
 		if mrErr != nil {
 			e.lggr.Errorw("could not reserve metering", "err", mrErr)
+			e.finishExecution(ctx, executionID, store.StatusErrored)
 			return
 		}
 
 		e.deductStandardBalances(meteringReport)
 	}
 
-	execCtx, execCancel := context.WithTimeout(ctx, time.Millisecond*time.Duration(e.cfg.LocalLimits.WorkflowExecutionTimeoutMs))
+	execCtx, execCancel := context.WithTimeout(cancelCtx, time.Millisecond*time.Duration(e.cfg.LocalLimits.WorkflowExecutionTimeoutMs))
 	defer execCancel()
 	executionLogger := logger.With(e.lggr, "executionID", executionID, "triggerID", wrappedTriggerEvent.triggerCapID, "triggerIndex", wrappedTriggerEvent.triggerIndex)
 
 	userLogChan := make(chan *protoevents.LogLine, e.cfg.LocalLimits.MaxUserLogEventsPerExecution)
 	defer close(userLogChan)
-	e.srvcEng.Go(func(_ context.Context) {
-		e.emitUserLogs(execCtx, userLogChan, executionID)
+	e.srvcEng.Go(func(srvcCtx context.Context) {
+		logLines := e.emitUserLogs(execCtx, userLogChan, executionID)
+		e.recordUserLogs(srvcCtx, executionID, logLines)
 	})
 
 	tid, err := safe.IntToUint64(wrappedTriggerEvent.triggerIndex)
 	if err != nil {
 		executionLogger.Errorw("Failed to convert trigger index to uint64", "err", err)
+		e.finishExecution(ctx, executionID, store.StatusErrored)
 		return
 	}
 
@@ -387,7 +420,7 @@ func (e *Engine) startExecution(ctx context.Context, wrappedTriggerEvent enqueue
 		},
 		MaxResponseSize: uint64(e.cfg.LocalLimits.ModuleExecuteMaxResponseSizeBytes),
 		Config:          e.cfg.WorkflowConfig,
-	}, &ExecutionHelper{Engine: e, WorkflowExecutionID: executionID, UserLogChan: userLogChan, SecretsFetcher: e.cfg.SecretsFetcher})
+	}, &ExecutionHelper{Engine: e, WorkflowExecutionID: executionID, UserLogChan: userLogChan, SecretsFetcher: e.cfg.SecretsFetcher, completedSteps: completedSteps})
 
 	endTime := e.cfg.Clock.Now()
 	executionDuration := endTime.Sub(startTime)
@@ -403,6 +436,7 @@ func (e *Engine) startExecution(ctx context.Context, wrappedTriggerEvent enqueue
 		if mrErr != nil {
 			e.lggr.Errorw("could not set metering for compute", "err", mrErr)
 		}
+		e.recordMeteringReport(ctx, executionID, meteringReport)
 		mrErr = e.meterReports.End(ctx, executionID)
 		if mrErr != nil {
 			e.lggr.Errorw("could not end metering report", "err", mrErr)
@@ -410,11 +444,19 @@ func (e *Engine) startExecution(ctx context.Context, wrappedTriggerEvent enqueue
 	}
 
 	if err != nil {
+		if _, ok := e.durableStore(); ok && ctx.Err() != nil {
+			// the engine is stopping, leave the execution unfinished to resume it on the next start
+			executionLogger.Warnw("Workflow execution interrupted", "err", err, "durationMs", executionDuration.Milliseconds())
+			return
+		}
 		executionStatus = store.StatusErrored
-		if errors.Is(err, context.DeadlineExceeded) {
+		if errors.Is(context.Cause(cancelCtx), ErrExecutionCanceled) {
+			executionStatus = store.StatusCanceled
+		} else if errors.Is(err, context.DeadlineExceeded) {
 			executionStatus = store.StatusTimeout
 		}
 		executionLogger.Errorw("Workflow execution failed", "err", err, "status", executionStatus, "durationMs", executionDuration.Milliseconds())
+		e.recordExecutionFinish(ctx, executionID, executionStatus)
 		_ = events.EmitExecutionFinishedEvent(ctx, e.loggerLabels, executionStatus, executionID)
 		e.cfg.Hooks.OnExecutionFinished(executionID, executionStatus)
 		return
@@ -426,12 +468,39 @@ func (e *Engine) startExecution(ctx context.Context, wrappedTriggerEvent enqueue
 
 	executionStatus = store.StatusCompleted
 	executionLogger.Infow("Workflow execution finished successfully", "durationMs", executionDuration.Milliseconds())
+	e.recordExecutionFinish(ctx, executionID, executionStatus)
 	_ = events.EmitExecutionFinishedEvent(ctx, e.loggerLabels, executionStatus, executionID)
 
 	e.cfg.Hooks.OnResultReceived(result)
 	e.cfg.Hooks.OnExecutionFinished(executionID, executionStatus)
 }
 
+// allowExecution returns false if the execution exceeds the global or per owner execution rate
+// limits, or the daily or monthly execution quota of the owner.
+func (e *Engine) allowExecution(ctx context.Context, executionID string, triggerID string) bool {
+	senderAllowed, globalAllowed := e.cfg.ExecutionRateLimiter.Allow(e.cfg.WorkflowOwner)
+	var err error
+	switch {
+	case !senderAllowed:
+		err = errors.New("per sender rate limit exceeded")
+		e.metrics.With(platform.KeyTriggerID, triggerID).IncrementWorkflowExecutionRateLimitPerUserCounter(ctx)
+	case !globalAllowed:
+		err = errors.New("global rate limit exceeded")
+		e.metrics.With(platform.KeyTriggerID, triggerID).IncrementWorkflowExecutionRateLimitGlobalCounter(ctx)
+	default:
+		err = e.cfg.ExecutionRateLimiter.ConsumeQuota(e.cfg.WorkflowOwner)
+		if err != nil {
+			e.metrics.With(platform.KeyTriggerID, triggerID).IncrementWorkflowExecutionRateLimitPerUserCounter(ctx)
+		}
+	}
+	if err != nil {
+		e.lggr.Errorw("Failed to start workflow execution", "executionID", executionID, "triggerID", triggerID, "err", err)
+		e.cfg.Hooks.OnRateLimited(executionID)
+		return false
+	}
+	return true
+}
+
 func (e *Engine) close() error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(e.cfg.LocalLimits.ShutdownTimeoutMs))
 	defer cancel()
@@ -502,25 +571,26 @@ func (e *Engine) deductStandardBalances(meteringReport *metering.Report) {
 	}
 }
 
-// separate call for each workflow execution
-func (e *Engine) emitUserLogs(ctx context.Context, userLogChan chan *protoevents.LogLine, executionID string) {
+// separate call for each workflow execution, returns the log lines emitted
+func (e *Engine) emitUserLogs(ctx context.Context, userLogChan chan *protoevents.LogLine, executionID string) []*protoevents.LogLine {
 	e.lggr.Debugw("Listening for user logs ...")
+	var logLines []*protoevents.LogLine
 	count := 0
 	defer func() { e.lggr.Debugw("Listening for user logs done.", "processedLogLines", count) }()
 	for {
 		select {
 		case <-ctx.Done():
-			return
+			return logLines
 		case logLine, ok := <-userLogChan:
 			if !ok {
-				return
+				return logLines
 			}
 			if e.cfg.DebugMode {
 				e.lggr.Debugf("User log: <<<%s>>>, local node timestamp: %s", logLine.Message, logLine.NodeTimestamp)
 			}
 			if count >= int(e.cfg.LocalLimits.MaxUserLogEventsPerExecution) {
 				e.lggr.Warnw("Max user log events per execution reached, dropping event", "maxEvents", e.cfg.LocalLimits.MaxUserLogEventsPerExecution)
-				return
+				return logLines
 			}
 			if len(logLine.Message) > int(e.cfg.LocalLimits.MaxUserLogLineLength) {
 				logLine.Message = logLine.Message[:e.cfg.LocalLimits.MaxUserLogLineLength] + " ...(truncated)"
@@ -529,6 +599,7 @@ func (e *Engine) emitUserLogs(ctx context.Context, userLogChan chan *protoevents
 			if err := events.EmitUserLogs(ctx, e.loggerLabels, []*protoevents.LogLine{logLine}, executionID); err != nil {
 				e.lggr.Errorw("Failed to emit user logs", "err", err)
 			}
+			logLines = append(logLines, logLine)
 			count++
 		}
 	}
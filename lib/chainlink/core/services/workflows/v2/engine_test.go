@@ -42,6 +42,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/metering"
 	metmocks "github.com/smartcontractkit/chainlink/v2/core/services/workflows/metering/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/ratelimiter"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/syncerlimiter"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/types"
 	v2 "github.com/smartcontractkit/chainlink/v2/core/services/workflows/v2"
@@ -342,6 +343,86 @@ func TestEngine_Execution(t *testing.T) {
 	})
 }
 
+func TestEngine_ExecutionQuota(t *testing.T) {
+	t.Parallel()
+
+	module := modulemocks.NewModuleV2(t)
+	module.EXPECT().Start()
+	module.EXPECT().Close()
+	capreg := regmocks.NewCapabilitiesRegistry(t)
+	capreg.EXPECT().LocalNode(matches.AnyContext).Return(newNode(t), nil)
+	billingClient := setupMockBillingClient(t)
+
+	initDoneCh := make(chan error)
+	subscribedToTriggersCh := make(chan []string, 1)
+	executionFinishedCh := make(chan string)
+	rateLimitedCh := make(chan string)
+
+	rateLimiter, err := ratelimiter.NewRateLimiter(ratelimiter.Config{
+		GlobalRPS:           10.0,
+		GlobalBurst:         100,
+		PerSenderRPS:        10.0,
+		PerSenderBurst:      100,
+		PerSenderDailyQuota: 1,
+	})
+	require.NoError(t, err)
+
+	cfg := defaultTestConfig(t)
+	cfg.Module = module
+	cfg.CapRegistry = capreg
+	cfg.BillingClient = billingClient
+	cfg.ExecutionRateLimiter = rateLimiter
+	cfg.Hooks = v2.LifecycleHooks{
+		OnInitialized: func(err error) {
+			initDoneCh <- err
+		},
+		OnSubscribedToTriggers: func(triggerIDs []string) {
+			subscribedToTriggersCh <- triggerIDs
+		},
+		OnExecutionFinished: func(executionID string, _ string) {
+			executionFinishedCh <- executionID
+		},
+		OnRateLimited: func(executionID string) {
+			rateLimitedCh <- executionID
+		},
+	}
+
+	engine, err := v2.NewEngine(cfg)
+	require.NoError(t, err)
+	module.EXPECT().Execute(matches.AnyContext, mock.Anything, mock.Anything).Return(newTriggerSubs(1), nil).Once()
+	trigger := capmocks.NewTriggerCapability(t)
+	capreg.EXPECT().GetTrigger(matches.AnyContext, "id_0").Return(trigger, nil).Once()
+	eventCh := make(chan capabilities.TriggerResponse)
+	trigger.EXPECT().RegisterTrigger(matches.AnyContext, mock.Anything).Return(eventCh, nil).Once()
+	trigger.EXPECT().UnregisterTrigger(matches.AnyContext, mock.Anything).Return(nil).Once()
+
+	require.NoError(t, engine.Start(t.Context()))
+	require.NoError(t, <-initDoneCh)
+	require.Equal(t, []string{"id_0"}, <-subscribedToTriggersCh)
+
+	// only the first execution fits in the daily quota
+	module.EXPECT().Execute(matches.AnyContext, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	eventCh <- capabilities.TriggerResponse{
+		Event: capabilities.TriggerEvent{TriggerType: "basic-trigger@1.0.0", ID: "quota_event_1"},
+	}
+	wantExecID, err := types.GenerateExecutionID(cfg.WorkflowID, "quota_event_1")
+	require.NoError(t, err)
+	require.Equal(t, wantExecID, <-executionFinishedCh)
+
+	eventCh <- capabilities.TriggerResponse{
+		Event: capabilities.TriggerEvent{TriggerType: "basic-trigger@1.0.0", ID: "quota_event_2"},
+	}
+	wantExecID, err = types.GenerateExecutionID(cfg.WorkflowID, "quota_event_2")
+	require.NoError(t, err)
+	require.Equal(t, wantExecID, <-rateLimitedCh)
+
+	usage := rateLimiter.Usage()
+	require.Len(t, usage, 1)
+	assert.Equal(t, int64(1), usage[0].DailyExecutions)
+
+	require.NoError(t, engine.Close())
+}
+
 func TestEngine_ExecutionTimeout(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,544 @@
+package v2
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	sdkpb "github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk/v2/pb"
+	protoevents "github.com/smartcontractkit/chainlink-protos/workflows/go/events"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/events"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/metering"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+)
+
+// When the executions store is durable, each execution is saved with its trigger event, as a step
+// with triggerStepRef, and with the result of each capability call, as a step with a ref made by
+// capabilityStepRef. After a restart, unfinished executions are run again with the same trigger
+// event, and the capability calls that already completed return their saved result instead of
+// being executed again. This relies on the module making the same calls when it is given the same
+// trigger event and capability results. Executions with a capability call that was started but
+// never saved as completed or errored are not resumed, but finished as errored: the call may
+// have reached the capability, which is not required to be idempotent.
+//
+// The metering report and the user logs of the execution are saved as well, with meteringStepRef
+// and userLogsStepRef, for operators to inspect the execution.
+const (
+	triggerStepRef  = "trigger"
+	meteringStepRef = "metering"
+	userLogsStepRef = "user_logs"
+
+	capabilityStepRefPrefix = "capability_"
+)
+
+// ErrExecutionCanceled is the cause of the context cancellation of an execution stopped by
+// CancelExecution.
+var ErrExecutionCanceled = errors.New("workflow execution canceled")
+
+type triggerStepInputs struct {
+	TriggerID    string
+	TriggerIndex int64
+	EventID      string
+	TriggerType  string
+}
+
+type capabilityStepInputs struct {
+	CapabilityID string
+	Method       string
+	PayloadHash  string
+	Payload      []byte
+}
+
+type userLogLine struct {
+	Timestamp string
+	Message   string
+}
+
+func capabilityStepRef(callbackID int32) string {
+	return fmt.Sprintf(capabilityStepRefPrefix+"%d", callbackID)
+}
+
+// durableStore returns the executions store if it persists executions across restarts.
+func (e *Engine) durableStore() (store.DurableStore, bool) {
+	s, ok := e.cfg.ExecutionsStore.(store.DurableStore)
+	return s, ok
+}
+
+// markRunning returns false if the execution is already running.
+func (e *Engine) markRunning(executionID string, cancel context.CancelCauseFunc) bool {
+	e.runningMu.Lock()
+	defer e.runningMu.Unlock()
+	if _, ok := e.running[executionID]; ok {
+		return false
+	}
+	e.running[executionID] = cancel
+	return true
+}
+
+func (e *Engine) unmarkRunning(executionID string) {
+	e.runningMu.Lock()
+	defer e.runningMu.Unlock()
+	delete(e.running, executionID)
+}
+
+// CancelExecution stops an execution in progress, which then finishes with the canceled status.
+// It returns false if the execution is not running in this engine.
+func (e *Engine) CancelExecution(executionID string) bool {
+	e.runningMu.Lock()
+	defer e.runningMu.Unlock()
+	cancel, ok := e.running[executionID]
+	if ok {
+		cancel(ErrExecutionCanceled)
+	}
+	return ok
+}
+
+// findExecution returns the steps completed by a previous run of the execution, and whether it
+// was saved to the durable store. It returns false if the execution has already finished, or
+// cannot be resumed, and should not run again.
+func (e *Engine) findExecution(ctx context.Context, executionID string) (steps map[string]*store.WorkflowExecutionStep, found bool, ok bool) {
+	s, ok := e.durableStore()
+	if !ok {
+		return nil, false, true
+	}
+	existing, err := s.Get(ctx, executionID)
+	if err != nil {
+		return nil, false, true
+	}
+	if existing.Status != store.StatusStarted {
+		return nil, true, false
+	}
+	if _, interrupted := interruptedCapabilityCall(existing.Steps); interrupted {
+		// resumeExecutions finishes it as errored
+		return nil, true, false
+	}
+	return existing.Steps, true, true
+}
+
+// recordExecutionStart saves a new execution to the durable store.
+func (e *Engine) recordExecutionStart(ctx context.Context, executionID string, event enqueuedTriggerEvent) {
+	s, ok := e.durableStore()
+	if !ok {
+		return
+	}
+	step, err := newTriggerStep(executionID, event)
+	if err == nil {
+		_, err = s.Add(ctx, map[string]*store.WorkflowExecutionStep{triggerStepRef: step}, executionID, e.cfg.WorkflowID, store.StatusStarted)
+	}
+	if err != nil {
+		e.lggr.Errorw("Failed to save workflow execution, it will not be resumed after a restart", "executionID", executionID, "err", err)
+	}
+}
+
+// recordExecutionFinish marks the execution as finished in the durable store.
+func (e *Engine) recordExecutionFinish(ctx context.Context, executionID string, status string) {
+	s, ok := e.durableStore()
+	if !ok {
+		return
+	}
+	if _, err := s.FinishExecution(ctx, executionID, status); err != nil {
+		e.lggr.Errorw("Failed to save workflow execution status", "executionID", executionID, "status", status, "err", err)
+	}
+}
+
+// finishExecution records an execution that finished without being run by the module.
+func (e *Engine) finishExecution(ctx context.Context, executionID string, status string) {
+	e.recordExecutionFinish(ctx, executionID, status)
+	_ = events.EmitExecutionFinishedEvent(ctx, e.loggerLabels, status, executionID)
+	e.cfg.Hooks.OnExecutionFinished(executionID, status)
+}
+
+// recordMeteringReport saves the metering report of the execution to the durable store.
+func (e *Engine) recordMeteringReport(ctx context.Context, executionID string, report *metering.Report) {
+	s, ok := e.durableStore()
+	if !ok {
+		return
+	}
+	b, err := proto.Marshal(report.FormatReport())
+	if err != nil {
+		e.lggr.Errorw("Failed to marshal metering report", "executionID", executionID, "err", err)
+		return
+	}
+	_, err = s.UpsertStep(ctx, &store.WorkflowExecutionStep{
+		ExecutionID: executionID,
+		Ref:         meteringStepRef,
+		Status:      store.StatusCompleted,
+		Outputs:     store.StepOutput{Value: values.NewBytes(b)},
+	})
+	if err != nil {
+		e.lggr.Errorw("Failed to save metering report", "executionID", executionID, "err", err)
+	}
+}
+
+// recordUserLogs saves the user logs emitted by the execution to the durable store.
+func (e *Engine) recordUserLogs(ctx context.Context, executionID string, logLines []*protoevents.LogLine) {
+	s, ok := e.durableStore()
+	if !ok || len(logLines) == 0 {
+		return
+	}
+	lines := make([]any, 0, len(logLines))
+	for _, line := range logLines {
+		lines = append(lines, map[string]any{
+			"Timestamp": line.NodeTimestamp,
+			"Message":   line.Message,
+		})
+	}
+	wrapped, err := values.Wrap(lines)
+	if err == nil {
+		_, err = s.UpsertStep(ctx, &store.WorkflowExecutionStep{
+			ExecutionID: executionID,
+			Ref:         userLogsStepRef,
+			Status:      store.StatusCompleted,
+			Outputs:     store.StepOutput{Value: wrapped},
+		})
+	}
+	if err != nil {
+		e.lggr.Errorw("Failed to save user logs", "executionID", executionID, "err", err)
+	}
+}
+
+// resumeExecutions runs again the executions left unfinished by a previous run of the engine,
+// e.g. before a restart. Executions that cannot be resumed, or are older than the execution
+// timeout, are finished as errored or timed out.
+func (e *Engine) resumeExecutions(ctx context.Context) {
+	s, ok := e.durableStore()
+	if !ok {
+		return
+	}
+	executions, err := s.GetUnfinished(ctx, e.cfg.WorkflowID)
+	if err != nil {
+		e.lggr.Errorw("Failed to load unfinished workflow executions", "err", err)
+		return
+	}
+
+	timeout := time.Millisecond * time.Duration(e.cfg.LocalLimits.WorkflowExecutionTimeoutMs)
+	for _, execution := range executions {
+		status := ""
+		event, err := triggerEventFromStep(execution.Steps[triggerStepRef])
+		interruptedRef, interrupted := interruptedCapabilityCall(execution.Steps)
+		switch {
+		case err != nil:
+			e.lggr.Errorw("Cannot resume workflow execution", "executionID", execution.ExecutionID, "err", err)
+			status = store.StatusErrored
+		case execution.CreatedAt == nil || e.cfg.Clock.Since(*execution.CreatedAt) > timeout:
+			e.lggr.Warnw("Workflow execution is too old to resume", "executionID", execution.ExecutionID, "createdAt", execution.CreatedAt)
+			status = store.StatusTimeout
+		case interrupted:
+			e.lggr.Errorw("Cannot resume workflow execution, a capability call was interrupted and may have been executed", "executionID", execution.ExecutionID, "step", interruptedRef)
+			status = store.StatusErrored
+		}
+		if status != "" {
+			e.finishExecution(ctx, execution.ExecutionID, status)
+			continue
+		}
+
+		e.lggr.Infow("Resuming workflow execution", "executionID", execution.ExecutionID, "triggerID", event.triggerCapID, "completedSteps", len(execution.Steps)-1)
+		event.resumed = true
+		select {
+		case e.executionsSemaphore <- struct{}{}:
+			e.srvcEng.Go(func(srvcCtx context.Context) {
+				e.startExecution(srvcCtx, event)
+				<-e.executionsSemaphore
+			})
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// interruptedCapabilityCall returns the ref of a capability call saved as started, but not as
+// completed or errored.
+func interruptedCapabilityCall(steps map[string]*store.WorkflowExecutionStep) (string, bool) {
+	for ref, step := range steps {
+		if strings.HasPrefix(ref, capabilityStepRefPrefix) && step.Status == store.StatusStarted {
+			return ref, true
+		}
+	}
+	return "", false
+}
+
+func newTriggerStep(executionID string, event enqueuedTriggerEvent) (*store.WorkflowExecutionStep, error) {
+	inputs, err := values.NewMap(map[string]any{
+		"TriggerID":    event.triggerCapID,
+		"TriggerIndex": int64(event.triggerIndex),
+		"EventID":      event.event.Event.ID,
+		"TriggerType":  event.event.Event.TriggerType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap trigger: %w", err)
+	}
+	payload, err := proto.Marshal(event.event.Event.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal trigger payload: %w", err)
+	}
+	return &store.WorkflowExecutionStep{
+		ExecutionID: executionID,
+		Ref:         triggerStepRef,
+		Status:      store.StatusCompleted,
+		Inputs:      inputs,
+		Outputs:     store.StepOutput{Value: values.NewBytes(payload)},
+	}, nil
+}
+
+func triggerEventFromStep(step *store.WorkflowExecutionStep) (enqueuedTriggerEvent, error) {
+	if step == nil || step.Inputs == nil {
+		return enqueuedTriggerEvent{}, errors.New("trigger event not saved")
+	}
+	var inputs triggerStepInputs
+	if err := step.Inputs.UnwrapTo(&inputs); err != nil {
+		return enqueuedTriggerEvent{}, fmt.Errorf("failed to unwrap trigger: %w", err)
+	}
+	payload, err := unwrapPayload(step.Outputs.Value)
+	if err != nil {
+		return enqueuedTriggerEvent{}, fmt.Errorf("failed to unwrap trigger payload: %w", err)
+	}
+	event := enqueuedTriggerEvent{
+		triggerCapID: inputs.TriggerID,
+		triggerIndex: int(inputs.TriggerIndex),
+		event: capabilities.TriggerResponse{
+			Event: capabilities.TriggerEvent{
+				TriggerType: inputs.TriggerType,
+				ID:          inputs.EventID,
+				Payload:     payload,
+			},
+		},
+	}
+	if step.UpdatedAt != nil {
+		event.timestamp = *step.UpdatedAt
+	}
+	return event, nil
+}
+
+// unwrapPayload returns the payload saved by newTriggerStep or recordCapabilityCall.
+func unwrapPayload(v values.Value) (*anypb.Any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var b []byte
+	if err := v.UnwrapTo(&b); err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, nil
+	}
+	payload := &anypb.Any{}
+	if err := proto.Unmarshal(b, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+func hashCapabilityRequest(request *sdkpb.CapabilityRequest) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(request.Payload)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// completedCapabilityCall returns the saved response of a capability call that completed in a
+// previous run of the execution, if it was the same call.
+func (c *ExecutionHelper) completedCapabilityCall(request *sdkpb.CapabilityRequest) (*sdkpb.CapabilityResponse, bool) {
+	step, ok := c.completedSteps[capabilityStepRef(request.CallbackId)]
+	if !ok || step.Status != store.StatusCompleted || step.Inputs == nil {
+		return nil, false
+	}
+	var inputs capabilityStepInputs
+	if err := step.Inputs.UnwrapTo(&inputs); err != nil {
+		return nil, false
+	}
+	hash, err := hashCapabilityRequest(request)
+	if err != nil || inputs.CapabilityID != request.Id || inputs.Method != request.Method || inputs.PayloadHash != hash {
+		c.lggr.Warnw("Capability call differs from the one saved, executing it again", "capID", request.Id, "capReqCallbackID", request.CallbackId)
+		return nil, false
+	}
+	payload, err := unwrapPayload(step.Outputs.Value)
+	if err != nil {
+		c.lggr.Warnw("Failed to unwrap saved capability response, executing it again", "capID", request.Id, "capReqCallbackID", request.CallbackId, "err", err)
+		return nil, false
+	}
+	return &sdkpb.CapabilityResponse{
+		Response: &sdkpb.CapabilityResponse_Payload{
+			Payload: payload,
+		},
+	}, true
+}
+
+// recordCapabilityCall saves the state of a capability call of the execution to the durable store.
+func (c *ExecutionHelper) recordCapabilityCall(ctx context.Context, request *sdkpb.CapabilityRequest, status string, response *anypb.Any, callErr error) {
+	s, ok := c.durableStore()
+	if !ok {
+		return
+	}
+	hash, err := hashCapabilityRequest(request)
+	if err != nil {
+		c.lggr.Errorw("Failed to hash capability request", "capID", request.Id, "capReqCallbackID", request.CallbackId, "err", err)
+		return
+	}
+	payload, err := proto.Marshal(request.Payload)
+	if err != nil {
+		c.lggr.Errorw("Failed to marshal capability request", "capID", request.Id, "capReqCallbackID", request.CallbackId, "err", err)
+		return
+	}
+	inputs, err := values.NewMap(map[string]any{
+		"CapabilityID": request.Id,
+		"Method":       request.Method,
+		"PayloadHash":  hash,
+		"Payload":      payload,
+	})
+	if err != nil {
+		c.lggr.Errorw("Failed to wrap capability request", "capID", request.Id, "capReqCallbackID", request.CallbackId, "err", err)
+		return
+	}
+	step := &store.WorkflowExecutionStep{
+		ExecutionID: c.WorkflowExecutionID,
+		Ref:         capabilityStepRef(request.CallbackId),
+		Status:      status,
+		Inputs:      inputs,
+		Outputs:     store.StepOutput{Err: callErr},
+	}
+	if response != nil {
+		payload, err := proto.Marshal(response)
+		if err != nil {
+			c.lggr.Errorw("Failed to marshal capability response", "capID", request.Id, "capReqCallbackID", request.CallbackId, "err", err)
+			return
+		}
+		step.Outputs.Value = values.NewBytes(payload)
+	}
+	if _, err = s.UpsertStep(ctx, step); err != nil {
+		c.lggr.Errorw("Failed to save capability call", "capID", request.Id, "capReqCallbackID", request.CallbackId, "err", err)
+	}
+}
+
+// ExecutionDetails is a workflow execution as saved by the engine to the durable store.
+type ExecutionDetails struct {
+	Trigger         *TriggerDetails
+	CapabilityCalls []CapabilityCallDetails
+	MeteringReport  *protoevents.MeteringReport
+	UserLogs        []UserLogLine
+}
+
+// TriggerDetails is the trigger event that started an execution.
+type TriggerDetails struct {
+	TriggerID   string
+	EventID     string
+	TriggerType string
+	Payload     *anypb.Any
+	CreatedAt   *time.Time
+}
+
+// CapabilityCallDetails is a capability call made by an execution. Request is nil for calls
+// saved before the request payload was.
+type CapabilityCallDetails struct {
+	CallbackID   int32
+	CapabilityID string
+	Method       string
+	Status       string
+	Error        error
+	Request      *anypb.Any
+	Response     *anypb.Any
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+}
+
+// UserLogLine is a log line emitted by the workflow during an execution.
+type UserLogLine struct {
+	Timestamp string
+	Message   string
+}
+
+// DecodeExecution decodes the steps saved by the engine for an execution.
+func DecodeExecution(execution store.WorkflowExecution) (ExecutionDetails, error) {
+	var details ExecutionDetails
+	for ref, step := range execution.Steps {
+		switch {
+		case ref == triggerStepRef:
+			event, err := triggerEventFromStep(step)
+			if err != nil {
+				return ExecutionDetails{}, err
+			}
+			details.Trigger = &TriggerDetails{
+				TriggerID:   event.triggerCapID,
+				EventID:     event.event.Event.ID,
+				TriggerType: event.event.Event.TriggerType,
+				Payload:     event.event.Event.Payload,
+				CreatedAt:   step.CreatedAt,
+			}
+		case ref == meteringStepRef:
+			var b []byte
+			if err := step.Outputs.Value.UnwrapTo(&b); err != nil {
+				return ExecutionDetails{}, fmt.Errorf("failed to unwrap metering report: %w", err)
+			}
+			report := &protoevents.MeteringReport{}
+			if err := proto.Unmarshal(b, report); err != nil {
+				return ExecutionDetails{}, fmt.Errorf("failed to unmarshal metering report: %w", err)
+			}
+			details.MeteringReport = report
+		case ref == userLogsStepRef:
+			var lines []userLogLine
+			if err := step.Outputs.Value.UnwrapTo(&lines); err != nil {
+				return ExecutionDetails{}, fmt.Errorf("failed to unwrap user logs: %w", err)
+			}
+			for _, line := range lines {
+				details.UserLogs = append(details.UserLogs, UserLogLine(line))
+			}
+		default:
+			var callbackID int32
+			if _, err := fmt.Sscanf(ref, capabilityStepRefPrefix+"%d", &callbackID); err != nil {
+				return ExecutionDetails{}, fmt.Errorf("unknown step %q", ref)
+			}
+			call, err := decodeCapabilityCall(callbackID, step)
+			if err != nil {
+				return ExecutionDetails{}, err
+			}
+			details.CapabilityCalls = append(details.CapabilityCalls, call)
+		}
+	}
+	slices.SortFunc(details.CapabilityCalls, func(a, b CapabilityCallDetails) int {
+		return cmp.Compare(a.CallbackID, b.CallbackID)
+	})
+	return details, nil
+}
+
+func decodeCapabilityCall(callbackID int32, step *store.WorkflowExecutionStep) (CapabilityCallDetails, error) {
+	call := CapabilityCallDetails{
+		CallbackID: callbackID,
+		Status:     step.Status,
+		Error:      step.Outputs.Err,
+		CreatedAt:  step.CreatedAt,
+		UpdatedAt:  step.UpdatedAt,
+	}
+	if step.Inputs != nil {
+		var inputs capabilityStepInputs
+		if err := step.Inputs.UnwrapTo(&inputs); err != nil {
+			return CapabilityCallDetails{}, fmt.Errorf("failed to unwrap capability call %d: %w", callbackID, err)
+		}
+		call.CapabilityID = inputs.CapabilityID
+		call.Method = inputs.Method
+		if len(inputs.Payload) > 0 {
+			call.Request = &anypb.Any{}
+			if err := proto.Unmarshal(inputs.Payload, call.Request); err != nil {
+				return CapabilityCallDetails{}, fmt.Errorf("failed to unmarshal capability request %d: %w", callbackID, err)
+			}
+		}
+	}
+	response, err := unwrapPayload(step.Outputs.Value)
+	if err != nil {
+		return CapabilityCallDetails{}, fmt.Errorf("failed to unwrap capability response %d: %w", callbackID, err)
+	}
+	call.Response = response
+	return call, nil
+}
@@ -0,0 +1,369 @@
+package v2_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/capabilities"
+	regmocks "github.com/smartcontractkit/chainlink-common/pkg/types/core/mocks"
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+	sdkpb "github.com/smartcontractkit/chainlink-common/pkg/workflows/sdk/v2/pb"
+	"github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/host"
+	modulemocks "github.com/smartcontractkit/chainlink-common/pkg/workflows/wasm/host/mocks"
+
+	capmocks "github.com/smartcontractkit/chainlink/v2/core/capabilities/mocks"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/ratelimiter"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/types"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/workflows/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/utils/matches"
+
+	"github.com/smartcontractkit/cre-sdk-go/internal_testing/capabilities/basictrigger"
+)
+
+// durableStore is an in-memory store that lists its unfinished executions, like a store that
+// survives restarts.
+type durableStore struct {
+	*store.InMemoryStore
+	mu  sync.Mutex
+	ids []string
+}
+
+func (s *durableStore) Add(ctx context.Context, steps map[string]*store.WorkflowExecutionStep, executionID string, workflowID string, status string) (store.WorkflowExecution, error) {
+	s.mu.Lock()
+	s.ids = append(s.ids, executionID)
+	s.mu.Unlock()
+	return s.InMemoryStore.Add(ctx, steps, executionID, workflowID, status)
+}
+
+func (s *durableStore) GetUnfinished(ctx context.Context, workflowID string) ([]store.WorkflowExecution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var unfinished []store.WorkflowExecution
+	for _, id := range s.ids {
+		execution, err := s.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if execution.WorkflowID == workflowID && execution.Status == store.StatusStarted {
+			unfinished = append(unfinished, execution)
+		}
+	}
+	return unfinished, nil
+}
+
+// newDurableTestEngine returns an engine saving its executions to the store, whose trigger
+// sends the event once initialized, unless the hooks override OnInitialized.
+func newDurableTestEngine(t *testing.T, executionsStore store.Store, triggerEvent capabilities.TriggerEvent, module *modulemocks.ModuleV2, capreg *regmocks.CapabilitiesRegistry, hooks v2.LifecycleHooks) *v2.Engine {
+	module.EXPECT().Start()
+	module.EXPECT().Close()
+	capreg.EXPECT().LocalNode(matches.AnyContext).Return(newNode(t), nil)
+	module.EXPECT().Execute(matches.AnyContext, mock.Anything, mock.Anything).Return(newTriggerSubs(1), nil).Once()
+	trigger := capmocks.NewTriggerCapability(t)
+	capreg.EXPECT().GetTrigger(matches.AnyContext, "id_0").Return(trigger, nil).Once()
+	eventCh := make(chan capabilities.TriggerResponse, 1)
+	trigger.EXPECT().RegisterTrigger(matches.AnyContext, mock.Anything).Return(eventCh, nil).Once()
+	trigger.EXPECT().UnregisterTrigger(matches.AnyContext, mock.Anything).Return(nil).Once()
+	if hooks.OnInitialized == nil {
+		hooks.OnInitialized = func(err error) {
+			require.NoError(t, err)
+			eventCh <- capabilities.TriggerResponse{Event: triggerEvent}
+		}
+	}
+
+	cfg := defaultTestConfig(t)
+	cfg.Module = module
+	cfg.CapRegistry = capreg
+	cfg.ExecutionsStore = executionsStore
+	cfg.BillingClient = setupMockBillingClient(t)
+	cfg.Hooks = hooks
+	engine, err := v2.NewEngine(cfg)
+	require.NoError(t, err)
+	return engine
+}
+
+func TestEngine_ResumeExecutions(t *testing.T) {
+	executionsStore := &durableStore{InMemoryStore: store.NewInMemoryStore(logger.TestLogger(t), clockwork.NewRealClock())}
+
+	triggerPayload, err := anypb.New(&basictrigger.Outputs{CoolOutput: "Hello, "})
+	require.NoError(t, err)
+	requestPayload, err := anypb.New(&basictrigger.Config{Name: "request", Number: 1})
+	require.NoError(t, err)
+	responsePayload, err := anypb.New(&basictrigger.Outputs{CoolOutput: "response"})
+	require.NoError(t, err)
+	capRequest := &sdkpb.CapabilityRequest{
+		Id:         "action@1.0.0",
+		Method:     "execute",
+		CallbackId: 0,
+		Payload:    requestPayload,
+	}
+	triggerEvent := capabilities.TriggerEvent{
+		TriggerType: "basic-trigger@1.0.0",
+		ID:          "resumed_event",
+		Payload:     triggerPayload,
+	}
+	resumedExecID, err := types.GenerateExecutionID(testWorkflowID, triggerEvent.ID)
+	require.NoError(t, err)
+
+	t.Run("execution interrupted by a shutdown is left unfinished", func(t *testing.T) {
+		module := modulemocks.NewModuleV2(t)
+		capreg := regmocks.NewCapabilitiesRegistry(t)
+		capability := capmocks.NewExecutableCapability(t)
+		capreg.EXPECT().GetExecutable(matches.AnyContext, capRequest.Id).Return(capability, nil).Once()
+		capreg.EXPECT().ConfigForCapability(mock.Anything, mock.Anything, mock.Anything).Return(capabilities.CapabilityConfiguration{}, nil).Maybe()
+		capability.EXPECT().Info(matches.AnyContext).Return(capabilities.CapabilityInfo{IsLocal: true}, nil).Once()
+		capability.EXPECT().Execute(matches.AnyContext, mock.Anything).Return(capabilities.CapabilityResponse{Payload: responsePayload}, nil).Once()
+
+		calledCh := make(chan struct{})
+		module.EXPECT().Execute(matches.AnyContext, mock.Anything, mock.Anything).
+			Run(func(ctx context.Context, _ *sdkpb.ExecuteRequest, executor host.ExecutionHelper) {
+				resp, err := executor.CallCapability(ctx, capRequest)
+				require.NoError(t, err)
+				require.True(t, proto.Equal(responsePayload, resp.GetPayload()))
+				close(calledCh)
+				<-ctx.Done()
+			}).
+			Return(nil, context.Canceled).Once()
+
+		engine := newDurableTestEngine(t, executionsStore, triggerEvent, module, capreg, v2.LifecycleHooks{
+			OnExecutionFinished: func(executionID string, status string) {
+				t.Errorf("execution %s should not finish, got status %s", executionID, status)
+			},
+		})
+		require.NoError(t, engine.Start(t.Context()))
+		<-calledCh
+		require.NoError(t, engine.Close())
+
+		execution, err := executionsStore.Get(t.Context(), resumedExecID)
+		require.NoError(t, err)
+		require.Equal(t, store.StatusStarted, execution.Status)
+	})
+
+	t.Run("unfinished executions are resumed or failed on start", func(t *testing.T) {
+		staleExecID, err := types.GenerateExecutionID(testWorkflowID, "stale_event")
+		require.NoError(t, err)
+		_, err = executionsStore.Add(t.Context(), map[string]*store.WorkflowExecutionStep{}, staleExecID, testWorkflowID, store.StatusStarted)
+		require.NoError(t, err)
+
+		// the capability call of this execution may have been executed before the restart
+		interruptedExecID, err := types.GenerateExecutionID(testWorkflowID, "interrupted_event")
+		require.NoError(t, err)
+		triggerInputs, err := values.NewMap(map[string]any{
+			"TriggerID":    "id_0",
+			"TriggerIndex": int64(0),
+			"EventID":      "interrupted_event",
+			"TriggerType":  triggerEvent.TriggerType,
+		})
+		require.NoError(t, err)
+		payload, err := proto.Marshal(triggerPayload)
+		require.NoError(t, err)
+		_, err = executionsStore.Add(t.Context(), map[string]*store.WorkflowExecutionStep{
+			"trigger": {
+				ExecutionID: interruptedExecID,
+				Ref:         "trigger",
+				Status:      store.StatusCompleted,
+				Inputs:      triggerInputs,
+				Outputs:     store.StepOutput{Value: values.NewBytes(payload)},
+			},
+			"capability_0": {
+				ExecutionID: interruptedExecID,
+				Ref:         "capability_0",
+				Status:      store.StatusStarted,
+			},
+		}, interruptedExecID, testWorkflowID, store.StatusStarted)
+		require.NoError(t, err)
+
+		// the registry has no expectation of GetExecutable, the capability call is not executed again
+		module := modulemocks.NewModuleV2(t)
+		capreg := regmocks.NewCapabilitiesRegistry(t)
+		module.EXPECT().Execute(matches.AnyContext, mock.Anything, mock.Anything).
+			Run(func(ctx context.Context, request *sdkpb.ExecuteRequest, executor host.ExecutionHelper) {
+				require.True(t, proto.Equal(triggerPayload, request.GetTrigger().GetPayload()))
+				resp, err := executor.CallCapability(ctx, capRequest)
+				require.NoError(t, err)
+				require.True(t, proto.Equal(responsePayload, resp.GetPayload()))
+			}).
+			Return(nil, nil).Once()
+
+		finishedCh := make(chan [2]string, 3)
+		engine := newDurableTestEngine(t, executionsStore, triggerEvent, module, capreg, v2.LifecycleHooks{
+			OnInitialized: func(err error) { require.NoError(t, err) },
+			OnExecutionFinished: func(executionID string, status string) {
+				finishedCh <- [2]string{executionID, status}
+			},
+		})
+		require.NoError(t, engine.Start(t.Context()))
+		finished := map[string]string{}
+		for range 3 {
+			f := <-finishedCh
+			finished[f[0]] = f[1]
+		}
+		require.NoError(t, engine.Close())
+
+		require.Equal(t, map[string]string{
+			resumedExecID:     store.StatusCompleted,
+			staleExecID:       store.StatusErrored,
+			interruptedExecID: store.StatusErrored,
+		}, finished)
+		execution, err := executionsStore.Get(t.Context(), resumedExecID)
+		require.NoError(t, err)
+		require.Equal(t, store.StatusCompleted, execution.Status)
+	})
+}
+
+func TestEngine_DuplicateEventsDoNotUseQuota(t *testing.T) {
+	t.Parallel()
+	executionsStore := &durableStore{InMemoryStore: store.NewInMemoryStore(logger.TestLogger(t), clockwork.NewRealClock())}
+	finishedExecID, err := types.GenerateExecutionID(testWorkflowID, "finished_event")
+	require.NoError(t, err)
+	_, err = executionsStore.Add(t.Context(), map[string]*store.WorkflowExecutionStep{}, finishedExecID, testWorkflowID, store.StatusStarted)
+	require.NoError(t, err)
+	_, err = executionsStore.FinishExecution(t.Context(), finishedExecID, store.StatusCompleted)
+	require.NoError(t, err)
+
+	rateLimiter, err := ratelimiter.NewRateLimiter(ratelimiter.Config{
+		GlobalRPS:           10.0,
+		GlobalBurst:         100,
+		PerSenderRPS:        10.0,
+		PerSenderBurst:      100,
+		PerSenderDailyQuota: 1,
+	})
+	require.NoError(t, err)
+
+	module := modulemocks.NewModuleV2(t)
+	module.EXPECT().Start()
+	module.EXPECT().Close()
+	capreg := regmocks.NewCapabilitiesRegistry(t)
+	capreg.EXPECT().LocalNode(matches.AnyContext).Return(newNode(t), nil)
+	module.EXPECT().Execute(matches.AnyContext, mock.Anything, mock.Anything).Return(newTriggerSubs(1), nil).Once()
+	trigger := capmocks.NewTriggerCapability(t)
+	capreg.EXPECT().GetTrigger(matches.AnyContext, "id_0").Return(trigger, nil).Once()
+	eventCh := make(chan capabilities.TriggerResponse, 2)
+	trigger.EXPECT().RegisterTrigger(matches.AnyContext, mock.Anything).Return(eventCh, nil).Once()
+	trigger.EXPECT().UnregisterTrigger(matches.AnyContext, mock.Anything).Return(nil).Once()
+
+	finishedCh := make(chan string, 2)
+	rateLimitedCh := make(chan string, 2)
+	cfg := defaultTestConfig(t)
+	cfg.Module = module
+	cfg.CapRegistry = capreg
+	cfg.ExecutionsStore = executionsStore
+	cfg.BillingClient = setupMockBillingClient(t)
+	cfg.ExecutionRateLimiter = rateLimiter
+	// one execution at a time, so that the events are handled in order
+	cfg.LocalLimits.MaxConcurrentWorkflowExecutions = 1
+	cfg.Hooks = v2.LifecycleHooks{
+		OnInitialized: func(err error) {
+			require.NoError(t, err)
+			// a duplicate of the finished execution, then a new one
+			eventCh <- capabilities.TriggerResponse{Event: capabilities.TriggerEvent{TriggerType: "basic-trigger@1.0.0", ID: "finished_event"}}
+			eventCh <- capabilities.TriggerResponse{Event: capabilities.TriggerEvent{TriggerType: "basic-trigger@1.0.0", ID: "new_event"}}
+		},
+		OnExecutionFinished: func(executionID string, _ string) {
+			finishedCh <- executionID
+		},
+		OnRateLimited: func(executionID string) {
+			rateLimitedCh <- executionID
+		},
+	}
+	engine, err := v2.NewEngine(cfg)
+	require.NoError(t, err)
+
+	module.EXPECT().Execute(matches.AnyContext, mock.Anything, mock.Anything).Return(nil, nil).Once()
+	require.NoError(t, engine.Start(t.Context()))
+	newExecID, err := types.GenerateExecutionID(testWorkflowID, "new_event")
+	require.NoError(t, err)
+	select {
+	case executionID := <-finishedCh:
+		require.Equal(t, newExecID, executionID)
+	case executionID := <-rateLimitedCh:
+		t.Fatalf("execution %s was rate limited, the duplicate event used up the quota", executionID)
+	}
+	require.NoError(t, engine.Close())
+
+	usage := rateLimiter.Usage()
+	require.Len(t, usage, 1)
+	assert.Equal(t, int64(1), usage[0].DailyExecutions)
+}
+
+func TestEngine_CancelExecution(t *testing.T) {
+	executionsStore := &durableStore{InMemoryStore: store.NewInMemoryStore(logger.TestLogger(t), clockwork.NewRealClock())}
+
+	triggerPayload, err := anypb.New(&basictrigger.Outputs{CoolOutput: "Hello, "})
+	require.NoError(t, err)
+	requestPayload, err := anypb.New(&basictrigger.Config{Name: "request", Number: 1})
+	require.NoError(t, err)
+	responsePayload, err := anypb.New(&basictrigger.Outputs{CoolOutput: "response"})
+	require.NoError(t, err)
+	capRequest := &sdkpb.CapabilityRequest{
+		Id:         "action@1.0.0",
+		Method:     "execute",
+		CallbackId: 0,
+		Payload:    requestPayload,
+	}
+	triggerEvent := capabilities.TriggerEvent{
+		TriggerType: "basic-trigger@1.0.0",
+		ID:          "canceled_event",
+		Payload:     triggerPayload,
+	}
+	executionID, err := types.GenerateExecutionID(testWorkflowID, triggerEvent.ID)
+	require.NoError(t, err)
+
+	module := modulemocks.NewModuleV2(t)
+	capreg := regmocks.NewCapabilitiesRegistry(t)
+	capability := capmocks.NewExecutableCapability(t)
+	capreg.EXPECT().GetExecutable(matches.AnyContext, capRequest.Id).Return(capability, nil).Once()
+	capreg.EXPECT().ConfigForCapability(mock.Anything, mock.Anything, mock.Anything).Return(capabilities.CapabilityConfiguration{}, nil).Maybe()
+	capability.EXPECT().Info(matches.AnyContext).Return(capabilities.CapabilityInfo{IsLocal: true}, nil).Once()
+	capability.EXPECT().Execute(matches.AnyContext, mock.Anything).Return(capabilities.CapabilityResponse{Payload: responsePayload}, nil).Once()
+
+	calledCh := make(chan struct{})
+	module.EXPECT().Execute(matches.AnyContext, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, _ *sdkpb.ExecuteRequest, executor host.ExecutionHelper) {
+			_, err := executor.CallCapability(ctx, capRequest)
+			require.NoError(t, err)
+			close(calledCh)
+			<-ctx.Done()
+		}).
+		Return(nil, context.Canceled).Once()
+
+	finishedCh := make(chan string, 1)
+	engine := newDurableTestEngine(t, executionsStore, triggerEvent, module, capreg, v2.LifecycleHooks{
+		OnExecutionFinished: func(id string, status string) {
+			assert.Equal(t, executionID, id)
+			finishedCh <- status
+		},
+	})
+	require.NoError(t, engine.Start(t.Context()))
+	<-calledCh
+
+	require.False(t, engine.CancelExecution("unknown"))
+	require.True(t, engine.CancelExecution(executionID))
+	require.Equal(t, store.StatusCanceled, <-finishedCh)
+	require.NoError(t, engine.Close())
+
+	execution, err := executionsStore.Get(t.Context(), executionID)
+	require.NoError(t, err)
+	require.Equal(t, store.StatusCanceled, execution.Status)
+
+	details, err := v2.DecodeExecution(execution)
+	require.NoError(t, err)
+	require.NotNil(t, details.Trigger)
+	assert.Equal(t, triggerEvent.ID, details.Trigger.EventID)
+	assert.True(t, proto.Equal(triggerPayload, details.Trigger.Payload))
+	require.Len(t, details.CapabilityCalls, 1)
+	call := details.CapabilityCalls[0]
+	assert.Equal(t, capRequest.Id, call.CapabilityID)
+	assert.Equal(t, store.StatusCompleted, call.Status)
+	assert.True(t, proto.Equal(requestPayload, call.Request))
+	assert.True(t, proto.Equal(responsePayload, call.Response))
+}
@@ -0,0 +1,155 @@
+package sessions
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/auth"
+)
+
+// MaxAPITokenDuration is the longest expiry of a named API token.
+const MaxAPITokenDuration = 365 * 24 * time.Hour
+
+// APIToken is a named API token of a user. Unlike the single token of User, a user can hold many
+// of them, and each one expires, is limited to a role no higher than the user's, and can be
+// limited to a list of client IPs.
+type APIToken struct {
+	ID           int64
+	Email        string
+	Name         string
+	AccessKey    string
+	Salt         string
+	HashedSecret string
+	// Role caps the built-in role of the user for the requests authenticated with the token.
+	Role        UserRole
+	IPAllowlist []string
+	ExpiresAt   time.Time
+	LastUsedAt  null.Time
+	CreatedAt   time.Time
+}
+
+// NewAPITokenRequest is sent to create a named API token. ExpiresIn is a duration, e.g. 720h.
+type NewAPITokenRequest struct {
+	Password    string   `json:"password"`
+	Name        string   `json:"name"`
+	Role        string   `json:"role"`
+	ExpiresIn   string   `json:"expiresIn"`
+	IPAllowlist []string `json:"ipAllowlist"`
+}
+
+var apiTokenNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// ValidateAPIToken validates the name, role, expiry and IP allowlist of a new API token of the
+// user.
+func ValidateAPIToken(user User, name string, role UserRole, expiresIn time.Duration, ipAllowlist []string) error {
+	if !apiTokenNameRegexp.MatchString(name) {
+		return pkgerrors.Errorf("invalid token name %q, must be letters, digits, ., - or _", name)
+	}
+	if _, ok := roleRanks[role]; !ok {
+		return pkgerrors.Errorf("invalid role %q", role)
+	}
+	if roleRanks[role] > roleRanks[user.Role] {
+		return pkgerrors.Errorf("the role of a token can not be higher than the role of the user: %s", user.Role)
+	}
+	if expiresIn <= 0 || expiresIn > MaxAPITokenDuration {
+		return pkgerrors.Errorf("the expiry of a token must be positive and at most %s", MaxAPITokenDuration)
+	}
+	for _, entry := range ipAllowlist {
+		if _, err := parseIPAllowlistEntry(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseIPAllowlistEntry(entry string) (*net.IPNet, error) {
+	if !strings.Contains(entry, "/") {
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return nil, pkgerrors.Errorf("invalid IP %q in allowlist", entry)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(entry)
+	if err != nil {
+		return nil, pkgerrors.Errorf("invalid CIDR %q in allowlist", entry)
+	}
+	return ipNet, nil
+}
+
+// Authenticate returns true if the token matches the hashed secret of the API token.
+func (t APIToken) Authenticate(token *auth.Token) (bool, error) {
+	hashedSecret, err := auth.HashedSecret(token, t.Salt)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare([]byte(hashedSecret), []byte(t.HashedSecret)) == 1, nil
+}
+
+// Expired returns true if the token expired at now.
+func (t APIToken) Expired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// AllowsIP returns true if the token has no IP allowlist, or if the IP is in it.
+func (t APIToken) AllowsIP(ip string) bool {
+	if len(t.IPAllowlist) == 0 {
+		return true
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, entry := range t.IPAllowlist {
+		ipNet, err := parseIPAllowlistEntry(entry)
+		if err == nil && ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeUser returns the user with its role capped by the role of the token.
+func (t APIToken) ScopeUser(user User) User {
+	if roleRanks[t.Role] < roleRanks[user.Role] {
+		user.Role = t.Role
+	}
+	return user
+}
+
+type apiTokenRoleKey struct{}
+
+// WithAPITokenRole returns the context of a request authenticated with an API token whose role is
+// lower than the role of its user. The custom role grants of the user don't apply to such
+// requests, see RolesORM.HasPermission.
+func WithAPITokenRole(ctx context.Context, role UserRole) context.Context {
+	return context.WithValue(ctx, apiTokenRoleKey{}, role)
+}
+
+// APITokenRole returns the role of the API token that capped the role of the user of the request,
+// if any.
+func APITokenRole(ctx context.Context) (UserRole, bool) {
+	role, ok := ctx.Value(apiTokenRoleKey{}).(UserRole)
+	return role, ok
+}
+
+// APITokensORM stores the named API tokens of users, regardless of the authentication provider.
+type APITokensORM interface {
+	// CreateAPIToken creates a token for the user and returns its secret, which is not stored.
+	CreateAPIToken(ctx context.Context, token APIToken) (APIToken, *auth.Token, error)
+	ListAPITokens(ctx context.Context, email string) ([]APIToken, error)
+	FindAPIToken(ctx context.Context, accessKey string) (APIToken, error)
+	// DeleteAPIToken revokes a token of the user.
+	DeleteAPIToken(ctx context.Context, email string, id int64) error
+	MarkAPITokenUsed(ctx context.Context, id int64) error
+}
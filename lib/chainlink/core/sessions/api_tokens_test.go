@@ -0,0 +1,92 @@
+package sessions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+func TestValidateAPIToken(t *testing.T) {
+	t.Parallel()
+
+	user := sessions.User{Email: "user@chainlink.test", Role: sessions.UserRoleEdit}
+	tests := []struct {
+		name        string
+		tokenName   string
+		role        sessions.UserRole
+		expiresIn   time.Duration
+		ipAllowlist []string
+		err         string
+	}{
+		{"valid", "ci-deploys", sessions.UserRoleRun, time.Hour, []string{"10.0.0.0/8", "192.168.1.10", "::1"}, ""},
+		{"same role", "ci", sessions.UserRoleEdit, time.Hour, nil, ""},
+		{"invalid name", "ci deploys", sessions.UserRoleRun, time.Hour, nil, "invalid token name"},
+		{"invalid role", "ci", sessions.UserRole("root"), time.Hour, nil, "invalid role"},
+		{"higher role", "ci", sessions.UserRoleAdmin, time.Hour, nil, "can not be higher"},
+		{"no expiry", "ci", sessions.UserRoleRun, 0, nil, "expiry"},
+		{"too long", "ci", sessions.UserRoleRun, sessions.MaxAPITokenDuration + time.Hour, nil, "expiry"},
+		{"invalid ip", "ci", sessions.UserRoleRun, time.Hour, []string{"10.0.0.300"}, "invalid IP"},
+		{"invalid cidr", "ci", sessions.UserRoleRun, time.Hour, []string{"10.0.0.0/33"}, "invalid CIDR"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := sessions.ValidateAPIToken(user, test.tokenName, test.role, test.expiresIn, test.ipAllowlist)
+			if test.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestAPIToken(t *testing.T) {
+	t.Parallel()
+
+	token := sessions.APIToken{
+		Role:        sessions.UserRoleRun,
+		IPAllowlist: []string{"10.0.0.0/8", "192.168.1.10"},
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	t.Run("AllowsIP", func(t *testing.T) {
+		assert.True(t, token.AllowsIP("10.1.2.3"))
+		assert.True(t, token.AllowsIP("192.168.1.10"))
+		assert.False(t, token.AllowsIP("192.168.1.11"))
+		assert.False(t, token.AllowsIP("not an ip"))
+		assert.True(t, sessions.APIToken{}.AllowsIP("192.168.1.11"))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		assert.False(t, token.Expired(time.Now()))
+		assert.True(t, token.Expired(token.ExpiresAt))
+	})
+
+	t.Run("ScopeUser", func(t *testing.T) {
+		admin := token.ScopeUser(sessions.User{Role: sessions.UserRoleAdmin})
+		assert.Equal(t, sessions.UserRoleRun, admin.Role)
+		view := token.ScopeUser(sessions.User{Role: sessions.UserRoleView})
+		assert.Equal(t, sessions.UserRoleView, view.Role)
+	})
+
+	t.Run("Authenticate", func(t *testing.T) {
+		secret := auth.NewToken()
+		hashedSecret, err := auth.HashedSecret(secret, "salt")
+		require.NoError(t, err)
+		withSecret := sessions.APIToken{AccessKey: secret.AccessKey, Salt: "salt", HashedSecret: hashedSecret}
+
+		ok, err := withSecret.Authenticate(secret)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = withSecret.Authenticate(&auth.Token{AccessKey: secret.AccessKey, Secret: "wrong"})
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,128 @@
+package apitokens
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	pkgerrors "github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink/v2/core/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+type orm struct {
+	ds sqlutil.DataSource
+}
+
+var _ sessions.APITokensORM = (*orm)(nil)
+
+// NewORM returns the store of the named API tokens of users. Tokens apply regardless of the
+// authentication provider of the node, the user is looked up by email on every use.
+func NewORM(ds sqlutil.DataSource) sessions.APITokensORM {
+	return &orm{ds: ds}
+}
+
+type tokenRow struct {
+	ID           int64
+	Email        string
+	Name         string
+	AccessKey    string
+	Salt         string
+	HashedSecret string
+	Role         sessions.UserRole
+	IPAllowlist  pq.StringArray `db:"ip_allowlist"`
+	ExpiresAt    time.Time
+	LastUsedAt   null.Time
+	CreatedAt    time.Time
+}
+
+func (r tokenRow) toToken() sessions.APIToken {
+	return sessions.APIToken{
+		ID:           r.ID,
+		Email:        r.Email,
+		Name:         r.Name,
+		AccessKey:    r.AccessKey,
+		Salt:         r.Salt,
+		HashedSecret: r.HashedSecret,
+		Role:         r.Role,
+		IPAllowlist:  r.IPAllowlist,
+		ExpiresAt:    r.ExpiresAt,
+		LastUsedAt:   r.LastUsedAt,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+// CreateAPIToken generates the access key and secret of the token, and stores the salted hash
+// of the secret.
+func (o *orm) CreateAPIToken(ctx context.Context, token sessions.APIToken) (sessions.APIToken, *auth.Token, error) {
+	newToken := auth.NewToken()
+	salt := utils.NewSecret(utils.DefaultSecretSize)
+	hashedSecret, err := auth.HashedSecret(newToken, salt)
+	if err != nil {
+		return sessions.APIToken{}, nil, pkgerrors.Wrap(err, "failed to hash the token secret")
+	}
+	ipAllowlist := pq.StringArray(token.IPAllowlist)
+	if ipAllowlist == nil {
+		ipAllowlist = pq.StringArray{}
+	}
+
+	var row tokenRow
+	err = o.ds.GetContext(ctx, &row, `INSERT INTO user_api_tokens (email, name, access_key, salt, hashed_secret, role, ip_allowlist, expires_at, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now())
+	RETURNING *`, token.Email, token.Name, newToken.AccessKey, salt, hashedSecret, token.Role, ipAllowlist, token.ExpiresAt)
+	if err != nil {
+		return sessions.APIToken{}, nil, pkgerrors.Wrap(err, "failed to create API token")
+	}
+	return row.toToken(), newToken, nil
+}
+
+// ListAPITokens returns the tokens of the user, most recent first.
+func (o *orm) ListAPITokens(ctx context.Context, email string) ([]sessions.APIToken, error) {
+	var rows []tokenRow
+	err := o.ds.SelectContext(ctx, &rows, "SELECT * FROM user_api_tokens WHERE lower(email) = lower($1) ORDER BY created_at DESC, id DESC", email)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to list API tokens")
+	}
+	tokens := make([]sessions.APIToken, len(rows))
+	for i, r := range rows {
+		tokens[i] = r.toToken()
+	}
+	return tokens, nil
+}
+
+// FindAPIToken returns the token with the access key, including expired ones.
+func (o *orm) FindAPIToken(ctx context.Context, accessKey string) (sessions.APIToken, error) {
+	var row tokenRow
+	if err := o.ds.GetContext(ctx, &row, "SELECT * FROM user_api_tokens WHERE access_key = $1", accessKey); err != nil {
+		return sessions.APIToken{}, err
+	}
+	return row.toToken(), nil
+}
+
+// DeleteAPIToken revokes a token of the user, and returns sql.ErrNoRows if the user has no token
+// with the ID.
+func (o *orm) DeleteAPIToken(ctx context.Context, email string, id int64) error {
+	result, err := o.ds.ExecContext(ctx, "DELETE FROM user_api_tokens WHERE id = $1 AND lower(email) = lower($2)", id, email)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to revoke API token")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// MarkAPITokenUsed sets the last used timestamp of the token.
+func (o *orm) MarkAPITokenUsed(ctx context.Context, id int64) error {
+	_, err := o.ds.ExecContext(ctx, "UPDATE user_api_tokens SET last_used_at = now() WHERE id = $1", id)
+	return err
+}
@@ -0,0 +1,63 @@
+package apitokens_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/apitokens"
+)
+
+func TestORM_APITokens(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	orm := apitokens.NewORM(pgtest.NewSqlxDB(t))
+
+	token, secret, err := orm.CreateAPIToken(ctx, sessions.APIToken{
+		Email:       "user@chainlink.test",
+		Name:        "ci",
+		Role:        sessions.UserRoleRun,
+		IPAllowlist: []string{"10.0.0.0/8"},
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, secret.AccessKey, token.AccessKey)
+	assert.False(t, token.LastUsedAt.Valid)
+
+	_, _, err = orm.CreateAPIToken(ctx, sessions.APIToken{
+		Email:     "User@chainlink.test",
+		Name:      "ci",
+		Role:      sessions.UserRoleRun,
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.Error(t, err, "token names are unique per user")
+
+	found, err := orm.FindAPIToken(ctx, secret.AccessKey)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8"}, found.IPAllowlist)
+	ok, err := found.Authenticate(secret)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = orm.FindAPIToken(ctx, "unknown")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, orm.MarkAPITokenUsed(ctx, token.ID))
+	tokens, err := orm.ListAPITokens(ctx, "USER@chainlink.test")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.True(t, tokens[0].LastUsedAt.Valid)
+
+	// tokens can only be revoked by their user
+	require.ErrorIs(t, orm.DeleteAPIToken(ctx, "other@chainlink.test", token.ID), sql.ErrNoRows)
+	require.NoError(t, orm.DeleteAPIToken(ctx, "user@chainlink.test", token.ID))
+	tokens, err = orm.ListAPITokens(ctx, "user@chainlink.test")
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
@@ -113,6 +113,14 @@ func (o *orm) DeleteUser(ctx context.Context, email string) error {
 		if _, err := tx.ExecContext(ctx, "DELETE FROM users WHERE email = $1", email); err != nil {
 			return err
 		}
+		// grants and tokens are not constrained by the users table, since they are also held by
+		// ldap and oidc users, so they have to be deleted with the user
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_role_grants WHERE lower(email) = lower($1)", email); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_api_tokens WHERE lower(email) = lower($1)", email); err != nil {
+			return err
+		}
 		return nil
 	})
 }
@@ -146,6 +146,13 @@ func TestORM_DeleteUserCascade(t *testing.T) {
 	session := sessions.NewSession()
 	_, err := db.Exec("INSERT INTO sessions (id, email, last_used, created_at) VALUES ($1, $2, now(), now())", session.ID, u.Email)
 	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO custom_roles (name, permissions, created_at) VALUES ('operator', '{jobs:run}', now())")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO user_role_grants (email, role_name, scope_type, created_at) VALUES ($1, 'operator', 'node', now())", u.Email)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO user_api_tokens (email, name, access_key, salt, hashed_secret, role, expires_at, created_at)
+	VALUES ($1, 'ci', 'key', 'salt', 'secret', 'view', now() + interval '1 day', now())`, u.Email)
+	require.NoError(t, err)
 
 	err = orm.DeleteUser(ctx, u.Email)
 	require.NoError(t, err)
@@ -156,6 +163,12 @@ func TestORM_DeleteUserCascade(t *testing.T) {
 	sessions, err := orm.Sessions(ctx, 0, 10)
 	assert.NoError(t, err)
 	require.Empty(t, sessions)
+
+	var count int
+	require.NoError(t, db.Get(&count, "SELECT count(*) FROM user_role_grants"))
+	assert.Zero(t, count)
+	require.NoError(t, db.Get(&count, "SELECT count(*) FROM user_api_tokens"))
+	assert.Zero(t, count)
 }
 
 func TestORM_CreateSession(t *testing.T) {
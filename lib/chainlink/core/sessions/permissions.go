@@ -0,0 +1,246 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// Permission is a named action that can be granted to users through custom roles, in the form
+// <resource>:<action>, e.g. jobs:create.
+type Permission string
+
+const (
+	PermissionJobsCreate    Permission = "jobs:create"
+	PermissionJobsUpdate    Permission = "jobs:update"
+	PermissionJobsDelete    Permission = "jobs:delete"
+	PermissionJobsRun       Permission = "jobs:run"
+	PermissionBridgesCreate Permission = "bridges:create"
+	PermissionBridgesUpdate Permission = "bridges:update"
+	PermissionBridgesDelete Permission = "bridges:delete"
+	PermissionTransfersEVM  Permission = "transfers:evm"
+	PermissionKeysETHExport Permission = "keys:eth:export"
+	PermissionKeysVRFExport Permission = "keys:vrf:export"
+	PermissionKeysCSAExport Permission = "keys:csa:export"
+	PermissionKeysOCRExport Permission = "keys:ocr:export"
+	PermissionKeysP2PExport Permission = "keys:p2p:export"
+)
+
+// builtinPermissions is the lowest built-in role that implies each permission, so that users
+// keep the access of their built-in role when no custom roles are used.
+var builtinPermissions = map[Permission]UserRole{
+	PermissionJobsCreate:    UserRoleEdit,
+	PermissionJobsUpdate:    UserRoleEdit,
+	PermissionJobsDelete:    UserRoleEdit,
+	PermissionJobsRun:       UserRoleRun,
+	PermissionBridgesCreate: UserRoleEdit,
+	PermissionBridgesUpdate: UserRoleEdit,
+	PermissionBridgesDelete: UserRoleEdit,
+	PermissionTransfersEVM:  UserRoleAdmin,
+	PermissionKeysETHExport: UserRoleAdmin,
+	PermissionKeysVRFExport: UserRoleAdmin,
+	PermissionKeysCSAExport: UserRoleAdmin,
+	PermissionKeysOCRExport: UserRoleAdmin,
+	PermissionKeysP2PExport: UserRoleAdmin,
+}
+
+var roleRanks = map[UserRole]int{
+	UserRoleView:  0,
+	UserRoleRun:   1,
+	UserRoleEdit:  2,
+	UserRoleAdmin: 3,
+}
+
+// AllPermissions returns the known permissions, sorted by name.
+func AllPermissions() []Permission {
+	perms := make([]Permission, 0, len(builtinPermissions))
+	for p := range builtinPermissions {
+		perms = append(perms, p)
+	}
+	slices.Sort(perms)
+	return perms
+}
+
+// GetPermission validates the name of a permission.
+func GetPermission(name string) (Permission, error) {
+	p := Permission(strings.TrimSpace(name))
+	if _, ok := builtinPermissions[p]; !ok {
+		return "", pkgerrors.Errorf("unknown permission %q, must be one of: %s", name, strings.Join(permissionNames(AllPermissions()), ", "))
+	}
+	return p, nil
+}
+
+func permissionNames(perms []Permission) []string {
+	names := make([]string, len(perms))
+	for i, p := range perms {
+		names[i] = string(p)
+	}
+	return names
+}
+
+// RequiredRole returns the lowest built-in role which implies the permission.
+func RequiredRole(perm Permission) UserRole {
+	if role, ok := builtinPermissions[perm]; ok {
+		return role
+	}
+	return UserRoleAdmin
+}
+
+// RoleAllows returns true if the built-in role implies the permission on every resource.
+func RoleAllows(role UserRole, perm Permission) bool {
+	minRole, ok := builtinPermissions[perm]
+	if !ok {
+		return false
+	}
+	rank, ok := roleRanks[role]
+	return ok && rank >= roleRanks[minRole]
+}
+
+// ScopeType is the kind of resource a custom role is granted on.
+type ScopeType string
+
+const (
+	// ScopeTypeNode grants the permissions of the role on every resource of the node.
+	ScopeTypeNode ScopeType = "node"
+	// ScopeTypeJob grants the permissions of the role on a single job, by ID.
+	ScopeTypeJob ScopeType = "job"
+	// ScopeTypeJobType grants the permissions of the role on the jobs of a type, e.g. vrf.
+	ScopeTypeJobType ScopeType = "job_type"
+	// ScopeTypeKey grants the permissions of the role on a single key, by ID or address.
+	ScopeTypeKey ScopeType = "key"
+)
+
+// Scope is a resource a permission is checked against.
+type Scope struct {
+	Type  ScopeType
+	Value string
+}
+
+// NodeScope is the scope of the whole node, which only grants on the node scope apply to.
+func NodeScope() Scope {
+	return Scope{Type: ScopeTypeNode}
+}
+
+// JobScope is the scope of a single job.
+func JobScope(id int32) Scope {
+	return Scope{Type: ScopeTypeJob, Value: fmt.Sprint(id)}
+}
+
+// JobTypeScope is the scope of the jobs of a type.
+func JobTypeScope(jobType string) Scope {
+	return Scope{Type: ScopeTypeJobType, Value: jobType}
+}
+
+// KeyScope is the scope of a single key.
+func KeyScope(id string) Scope {
+	return Scope{Type: ScopeTypeKey, Value: id}
+}
+
+// ParseScope validates a scope type and value. The node scope takes no value.
+func ParseScope(scopeType, value string) (Scope, error) {
+	s := Scope{Type: ScopeType(scopeType), Value: strings.TrimSpace(value)}
+	switch s.Type {
+	case ScopeTypeNode, "":
+		if s.Value != "" {
+			return Scope{}, pkgerrors.New("the node scope does not take a value")
+		}
+		s.Type = ScopeTypeNode
+	case ScopeTypeJob, ScopeTypeJobType, ScopeTypeKey:
+		if s.Value == "" {
+			return Scope{}, pkgerrors.Errorf("the %s scope requires a value", s.Type)
+		}
+	default:
+		return Scope{}, pkgerrors.Errorf("unknown scope %q, must be one of: node, job, job_type, key", scopeType)
+	}
+	return s, nil
+}
+
+// Matches returns true if a grant on the scope s applies to the resource.
+func (s Scope) Matches(resource Scope) bool {
+	if s.Type == ScopeTypeNode {
+		return true
+	}
+	if s.Type != resource.Type {
+		return false
+	}
+	if s.Type == ScopeTypeKey {
+		// addresses are compared regardless of their checksum casing
+		return strings.EqualFold(s.Value, resource.Value)
+	}
+	return s.Value == resource.Value
+}
+
+func (s Scope) String() string {
+	if s.Type == ScopeTypeNode {
+		return string(ScopeTypeNode)
+	}
+	return fmt.Sprintf("%s:%s", s.Type, s.Value)
+}
+
+var customRoleNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// CustomRole is a named set of permissions which can be granted to users.
+type CustomRole struct {
+	Name        string
+	Permissions []Permission
+	CreatedAt   time.Time
+}
+
+// NewCustomRole validates the name and permissions of a custom role.
+func NewCustomRole(name string, permissions []string) (CustomRole, error) {
+	if !customRoleNameRegexp.MatchString(name) {
+		return CustomRole{}, pkgerrors.Errorf("invalid role name %q, must be lowercase letters, digits, - or _", name)
+	}
+	if _, err := GetUserRole(name); err == nil {
+		return CustomRole{}, pkgerrors.Errorf("%q is a built-in role", name)
+	}
+	if len(permissions) == 0 {
+		return CustomRole{}, pkgerrors.New("a role must have at least one permission")
+	}
+	role := CustomRole{Name: name}
+	for _, name := range permissions {
+		p, err := GetPermission(name)
+		if err != nil {
+			return CustomRole{}, err
+		}
+		if !slices.Contains(role.Permissions, p) {
+			role.Permissions = append(role.Permissions, p)
+		}
+	}
+	slices.Sort(role.Permissions)
+	return role, nil
+}
+
+// HasPermission returns true if the role includes the permission.
+func (r CustomRole) HasPermission(perm Permission) bool {
+	return slices.Contains(r.Permissions, perm)
+}
+
+// RoleGrant assigns a custom role to a user on a scope.
+type RoleGrant struct {
+	ID        int64
+	Email     string
+	RoleName  string
+	Scope     Scope
+	CreatedAt time.Time
+}
+
+// RolesORM stores the custom roles and their grants, and checks the permissions of users.
+type RolesORM interface {
+	ListRoles(ctx context.Context) ([]CustomRole, error)
+	CreateRole(ctx context.Context, role CustomRole) (CustomRole, error)
+	DeleteRole(ctx context.Context, name string) error
+	ListGrants(ctx context.Context, email string) ([]RoleGrant, error)
+	CreateGrant(ctx context.Context, email, roleName string, scope Scope) (RoleGrant, error)
+	DeleteGrant(ctx context.Context, id int64) error
+	// HasPermission returns true if the built-in role of the user, or one of its custom role
+	// grants, allows the permission on any of the resources. With no resources, it returns
+	// true if the user is granted the permission on any scope. The grants are ignored for
+	// requests capped by the role of an API token, see WithAPITokenRole.
+	HasPermission(ctx context.Context, user *User, perm Permission, resources ...Scope) (bool, error)
+}
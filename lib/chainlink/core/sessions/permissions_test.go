@@ -0,0 +1,84 @@
+package sessions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+func TestRoleAllows(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		role    sessions.UserRole
+		perm    sessions.Permission
+		allowed bool
+	}{
+		{sessions.UserRoleAdmin, sessions.PermissionKeysETHExport, true},
+		{sessions.UserRoleEdit, sessions.PermissionKeysETHExport, false},
+		{sessions.UserRoleEdit, sessions.PermissionJobsCreate, true},
+		{sessions.UserRoleRun, sessions.PermissionJobsCreate, false},
+		{sessions.UserRoleRun, sessions.PermissionJobsRun, true},
+		{sessions.UserRoleView, sessions.PermissionJobsRun, false},
+		{sessions.UserRoleAdmin, sessions.Permission("jobs:unknown"), false},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.role)+"/"+string(test.perm), func(t *testing.T) {
+			assert.Equal(t, test.allowed, sessions.RoleAllows(test.role, test.perm))
+		})
+	}
+}
+
+func TestNewCustomRole(t *testing.T) {
+	t.Parallel()
+
+	role, err := sessions.NewCustomRole("vrf-operators", []string{"keys:vrf:export", "jobs:create", "jobs:create"})
+	require.NoError(t, err)
+	assert.Equal(t, []sessions.Permission{sessions.PermissionJobsCreate, sessions.PermissionKeysVRFExport}, role.Permissions)
+	assert.True(t, role.HasPermission(sessions.PermissionJobsCreate))
+	assert.False(t, role.HasPermission(sessions.PermissionJobsDelete))
+
+	_, err = sessions.NewCustomRole("admin", []string{"jobs:create"})
+	require.ErrorContains(t, err, "built-in role")
+	_, err = sessions.NewCustomRole("Bad Name", []string{"jobs:create"})
+	require.ErrorContains(t, err, "invalid role name")
+	_, err = sessions.NewCustomRole("operators", nil)
+	require.ErrorContains(t, err, "at least one permission")
+	_, err = sessions.NewCustomRole("operators", []string{"jobs:fly"})
+	require.ErrorContains(t, err, "unknown permission")
+}
+
+func TestParseScope(t *testing.T) {
+	t.Parallel()
+
+	s, err := sessions.ParseScope("", "")
+	require.NoError(t, err)
+	assert.Equal(t, sessions.NodeScope(), s)
+
+	s, err = sessions.ParseScope("job_type", "vrf")
+	require.NoError(t, err)
+	assert.Equal(t, sessions.JobTypeScope("vrf"), s)
+	assert.Equal(t, "job_type:vrf", s.String())
+
+	_, err = sessions.ParseScope("node", "1")
+	require.Error(t, err)
+	_, err = sessions.ParseScope("job", "")
+	require.Error(t, err)
+	_, err = sessions.ParseScope("chain", "1")
+	require.Error(t, err)
+}
+
+func TestScope_Matches(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, sessions.NodeScope().Matches(sessions.JobScope(1)))
+	assert.True(t, sessions.JobScope(1).Matches(sessions.JobScope(1)))
+	assert.False(t, sessions.JobScope(1).Matches(sessions.JobScope(2)))
+	assert.False(t, sessions.JobScope(1).Matches(sessions.NodeScope()))
+	assert.False(t, sessions.JobTypeScope("vrf").Matches(sessions.JobTypeScope("cron")))
+	assert.True(t, sessions.KeyScope("0xAbC").Matches(sessions.KeyScope("0xabc")))
+}
@@ -0,0 +1,213 @@
+package rbac
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	pkgerrors "github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+type orm struct {
+	ds   sqlutil.DataSource
+	lggr logger.Logger
+}
+
+var _ sessions.RolesORM = (*orm)(nil)
+
+// NewORM returns the store of the custom roles. Custom roles apply regardless of the
+// authentication provider of the node.
+func NewORM(ds sqlutil.DataSource, lggr logger.Logger) sessions.RolesORM {
+	return &orm{
+		ds:   ds,
+		lggr: lggr.Named("RolesORM"),
+	}
+}
+
+type roleRow struct {
+	Name        string
+	Permissions pq.StringArray
+	CreatedAt   time.Time
+}
+
+func (r roleRow) toRole() sessions.CustomRole {
+	role := sessions.CustomRole{Name: r.Name, CreatedAt: r.CreatedAt}
+	for _, p := range r.Permissions {
+		role.Permissions = append(role.Permissions, sessions.Permission(p))
+	}
+	return role
+}
+
+type grantRow struct {
+	ID         int64
+	Email      string
+	RoleName   string
+	ScopeType  string
+	ScopeValue string
+	CreatedAt  time.Time
+}
+
+func (r grantRow) toGrant() sessions.RoleGrant {
+	return sessions.RoleGrant{
+		ID:        r.ID,
+		Email:     r.Email,
+		RoleName:  r.RoleName,
+		Scope:     sessions.Scope{Type: sessions.ScopeType(r.ScopeType), Value: r.ScopeValue},
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// ListRoles returns all the custom roles, sorted by name.
+func (o *orm) ListRoles(ctx context.Context) ([]sessions.CustomRole, error) {
+	var rows []roleRow
+	if err := o.ds.SelectContext(ctx, &rows, "SELECT * FROM custom_roles ORDER BY name ASC"); err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to list roles")
+	}
+	roles := make([]sessions.CustomRole, len(rows))
+	for i, r := range rows {
+		roles[i] = r.toRole()
+	}
+	return roles, nil
+}
+
+// CreateRole creates a custom role, or replaces the permissions of an existing one.
+func (o *orm) CreateRole(ctx context.Context, role sessions.CustomRole) (sessions.CustomRole, error) {
+	perms := make(pq.StringArray, len(role.Permissions))
+	for i, p := range role.Permissions {
+		perms[i] = string(p)
+	}
+	var row roleRow
+	err := o.ds.GetContext(ctx, &row, `INSERT INTO custom_roles (name, permissions, created_at) VALUES ($1, $2, now())
+	ON CONFLICT (name) DO UPDATE SET permissions = EXCLUDED.permissions
+	RETURNING *`, role.Name, perms)
+	if err != nil {
+		return sessions.CustomRole{}, pkgerrors.Wrap(err, "failed to create role")
+	}
+	return row.toRole(), nil
+}
+
+// DeleteRole deletes a custom role and its grants.
+func (o *orm) DeleteRole(ctx context.Context, name string) error {
+	result, err := o.ds.ExecContext(ctx, "DELETE FROM custom_roles WHERE name = $1", name)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to delete role")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListGrants returns the grants of the user, or of all users if email is empty.
+func (o *orm) ListGrants(ctx context.Context, email string) ([]sessions.RoleGrant, error) {
+	var rows []grantRow
+	err := o.ds.SelectContext(ctx, &rows, `SELECT * FROM user_role_grants
+	WHERE $1 = '' OR lower(email) = lower($1)
+	ORDER BY email ASC, id ASC`, email)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to list grants")
+	}
+	grants := make([]sessions.RoleGrant, len(rows))
+	for i, r := range rows {
+		grants[i] = r.toGrant()
+	}
+	return grants, nil
+}
+
+// CreateGrant assigns a custom role to the user on the scope. Granting the same role on the
+// same scope twice returns the existing grant.
+func (o *orm) CreateGrant(ctx context.Context, email, roleName string, scope sessions.Scope) (sessions.RoleGrant, error) {
+	var row grantRow
+	err := sqlutil.TransactDataSource(ctx, o.ds, nil, func(tx sqlutil.DataSource) error {
+		var exists bool
+		if err := tx.GetContext(ctx, &exists, "SELECT EXISTS (SELECT 1 FROM custom_roles WHERE name = $1)", roleName); err != nil {
+			return err
+		}
+		if !exists {
+			return pkgerrors.Errorf("no role named %q", roleName)
+		}
+		err := tx.GetContext(ctx, &row, `INSERT INTO user_role_grants (email, role_name, scope_type, scope_value, created_at)
+		VALUES ($1, $2, $3, $4, now())
+		ON CONFLICT DO NOTHING
+		RETURNING *`, email, roleName, scope.Type, scope.Value)
+		if pkgerrors.Is(err, sql.ErrNoRows) {
+			return tx.GetContext(ctx, &row, `SELECT * FROM user_role_grants
+			WHERE lower(email) = lower($1) AND role_name = $2 AND scope_type = $3 AND scope_value = $4`, email, roleName, scope.Type, scope.Value)
+		}
+		return err
+	})
+	if err != nil {
+		return sessions.RoleGrant{}, pkgerrors.Wrap(err, "failed to grant role")
+	}
+	return row.toGrant(), nil
+}
+
+// DeleteGrant revokes a grant.
+func (o *orm) DeleteGrant(ctx context.Context, id int64) error {
+	result, err := o.ds.ExecContext(ctx, "DELETE FROM user_role_grants WHERE id = $1", id)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to revoke grant")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// HasPermission implements sessions.RolesORM. The built-in role is checked first, so that the
+// grants are only loaded for users whose built-in role does not imply the permission.
+func (o *orm) HasPermission(ctx context.Context, user *sessions.User, perm sessions.Permission, resources ...sessions.Scope) (bool, error) {
+	if user == nil {
+		return false, nil
+	}
+	if sessions.RoleAllows(user.Role, perm) {
+		return true, nil
+	}
+	if user.Email == "" {
+		// external initiators have no grants
+		return false, nil
+	}
+	if _, ok := sessions.APITokenRole(ctx); ok {
+		// the request is limited to the built-in role of its API token
+		return false, nil
+	}
+
+	var grants []grantRow
+	err := o.ds.SelectContext(ctx, &grants, `SELECT g.* FROM user_role_grants g
+	JOIN custom_roles r ON r.name = g.role_name
+	WHERE lower(g.email) = lower($1) AND $2 = ANY(r.permissions)`, user.Email, string(perm))
+	if err != nil {
+		return false, pkgerrors.Wrap(err, "failed to load grants")
+	}
+	return grantsAllow(grants, resources...), nil
+}
+
+// grantsAllow returns true if one of the grants applies to one of the resources, or if there
+// are grants and no resources.
+func grantsAllow(grants []grantRow, resources ...sessions.Scope) bool {
+	if len(resources) == 0 {
+		return len(grants) > 0
+	}
+	for _, g := range grants {
+		scope := g.toGrant().Scope
+		for _, r := range resources {
+			if scope.Matches(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package rbac_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions/rbac"
+)
+
+func TestORM_Roles(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	orm := rbac.NewORM(pgtest.NewSqlxDB(t), logger.TestLogger(t))
+
+	role, err := sessions.NewCustomRole("vrf-operators", []string{"jobs:create"})
+	require.NoError(t, err)
+	_, err = orm.CreateRole(ctx, role)
+	require.NoError(t, err)
+
+	// creating it again replaces its permissions
+	role.Permissions = []sessions.Permission{sessions.PermissionJobsCreate, sessions.PermissionJobsDelete}
+	_, err = orm.CreateRole(ctx, role)
+	require.NoError(t, err)
+
+	roles, err := orm.ListRoles(ctx)
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, role.Permissions, roles[0].Permissions)
+
+	grant, err := orm.CreateGrant(ctx, "team@chainlink.test", role.Name, sessions.JobTypeScope("vrf"))
+	require.NoError(t, err)
+	again, err := orm.CreateGrant(ctx, "Team@chainlink.test", role.Name, sessions.JobTypeScope("vrf"))
+	require.NoError(t, err)
+	assert.Equal(t, grant.ID, again.ID)
+
+	_, err = orm.CreateGrant(ctx, "team@chainlink.test", "unknown", sessions.NodeScope())
+	require.ErrorContains(t, err, "no role named")
+
+	grants, err := orm.ListGrants(ctx, "TEAM@chainlink.test")
+	require.NoError(t, err)
+	require.Len(t, grants, 1)
+	assert.Equal(t, sessions.JobTypeScope("vrf"), grants[0].Scope)
+
+	// deleting the role revokes its grants
+	require.NoError(t, orm.DeleteRole(ctx, role.Name))
+	grants, err = orm.ListGrants(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, grants)
+	require.Error(t, orm.DeleteRole(ctx, role.Name))
+	require.Error(t, orm.DeleteGrant(ctx, grant.ID))
+}
+
+func TestORM_HasPermission(t *testing.T) {
+	t.Parallel()
+	ctx := testutils.Context(t)
+	orm := rbac.NewORM(pgtest.NewSqlxDB(t), logger.TestLogger(t))
+
+	role, err := sessions.NewCustomRole("vrf-operators", []string{"jobs:create", "jobs:delete", "keys:vrf:export"})
+	require.NoError(t, err)
+	_, err = orm.CreateRole(ctx, role)
+	require.NoError(t, err)
+
+	team := &sessions.User{Email: "team@chainlink.test", Role: sessions.UserRoleView}
+	_, err = orm.CreateGrant(ctx, team.Email, role.Name, sessions.JobTypeScope("vrf"))
+	require.NoError(t, err)
+	_, err = orm.CreateGrant(ctx, team.Email, role.Name, sessions.KeyScope("vrf-key"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		user      *sessions.User
+		perm      sessions.Permission
+		resources []sessions.Scope
+		allowed   bool
+	}{
+		{"built-in role", &sessions.User{Email: "edit@chainlink.test", Role: sessions.UserRoleEdit}, sessions.PermissionJobsCreate, []sessions.Scope{sessions.JobTypeScope("cron")}, true},
+		{"built-in role too low", &sessions.User{Email: "edit@chainlink.test", Role: sessions.UserRoleEdit}, sessions.PermissionKeysVRFExport, nil, false},
+		{"granted job type", team, sessions.PermissionJobsDelete, []sessions.Scope{sessions.JobScope(1), sessions.JobTypeScope("vrf")}, true},
+		{"other job type", team, sessions.PermissionJobsDelete, []sessions.Scope{sessions.JobScope(1), sessions.JobTypeScope("cron")}, false},
+		{"granted key", team, sessions.PermissionKeysVRFExport, []sessions.Scope{sessions.KeyScope("vrf-key")}, true},
+		{"other key", team, sessions.PermissionKeysVRFExport, []sessions.Scope{sessions.KeyScope("other-key")}, false},
+		{"whole node", team, sessions.PermissionJobsCreate, []sessions.Scope{sessions.NodeScope()}, false},
+		{"any resource", team, sessions.PermissionJobsCreate, nil, true},
+		{"permission not in role", team, sessions.PermissionJobsRun, nil, false},
+		{"external initiator", &sessions.User{Role: sessions.UserRoleRun}, sessions.PermissionJobsRun, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowed, err := orm.HasPermission(ctx, test.user, test.perm, test.resources...)
+			require.NoError(t, err)
+			assert.Equal(t, test.allowed, allowed)
+		})
+	}
+
+	t.Run("capped by API token", func(t *testing.T) {
+		allowed, err := orm.HasPermission(sessions.WithAPITokenRole(ctx, sessions.UserRoleView), team, sessions.PermissionKeysVRFExport, sessions.KeyScope("vrf-key"))
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE vrf_specs
+    ADD COLUMN "fulfillment_scheduler" TEXT
+    DEFAULT ''
+    NOT NULL;
+
+ALTER TABLE vrf_specs
+    ADD COLUMN "subscription_schedules" JSONB
+    DEFAULT '[]'::jsonb
+    NOT NULL;
+
+-- +goose Down
+ALTER TABLE vrf_specs DROP COLUMN "fulfillment_scheduler";
+
+ALTER TABLE vrf_specs DROP COLUMN "subscription_schedules";
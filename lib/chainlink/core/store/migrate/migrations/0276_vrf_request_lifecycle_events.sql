@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE vrf_request_lifecycle_events (
+    id BIGSERIAL PRIMARY KEY,
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    job_id INTEGER NOT NULL,
+    request_id TEXT NOT NULL,
+    stage TEXT NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb,
+    occurrences BIGINT NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT vrf_request_lifecycle_events_unique UNIQUE (evm_chain_id, job_id, request_id, stage)
+);
+
+CREATE INDEX idx_vrf_request_lifecycle_events_request_id ON vrf_request_lifecycle_events (request_id);
+CREATE INDEX idx_vrf_request_lifecycle_events_last_seen_at ON vrf_request_lifecycle_events (last_seen_at);
+
+-- +goose Down
+DROP TABLE vrf_request_lifecycle_events;
@@ -0,0 +1,28 @@
+-- +goose Up
+ALTER TABLE vrf_specs
+    ADD COLUMN "key_rotation_overlap" BIGINT
+    CHECK (key_rotation_overlap >= 0)
+    DEFAULT 0
+    NOT NULL;
+
+CREATE TABLE vrf_key_rotations (
+    id BIGSERIAL PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES jobs (id) ON DELETE CASCADE DEFERRABLE INITIALLY IMMEDIATE,
+    old_public_key BYTEA NOT NULL CHECK (octet_length(old_public_key) = 33),
+    new_public_key BYTEA NOT NULL CHECK (octet_length(new_public_key) = 33),
+    overlap_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    pending_requests BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    retired_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT vrf_key_rotations_distinct_keys CHECK (old_public_key <> new_public_key)
+);
+
+-- A job can only rotate away from each key once, and only have one rotation in progress at a time.
+CREATE UNIQUE INDEX idx_vrf_key_rotations_job_id_old_public_key ON vrf_key_rotations (job_id, old_public_key);
+CREATE UNIQUE INDEX idx_vrf_key_rotations_in_progress ON vrf_key_rotations (job_id) WHERE retired_at IS NULL;
+
+-- +goose Down
+DROP TABLE vrf_key_rotations;
+
+ALTER TABLE vrf_specs DROP COLUMN "key_rotation_overlap";
@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE vrf_specs
+    ADD COLUMN "low_balance_alert_threshold" BIGINT
+    CHECK (low_balance_alert_threshold >= 0)
+    DEFAULT 0
+    NOT NULL;
+
+ALTER TABLE vrf_specs
+    ADD COLUMN "low_balance_webhook_url" TEXT
+    DEFAULT ''
+    NOT NULL;
+
+ALTER TABLE vrf_specs
+    ADD COLUMN "low_balance_external_initiator" TEXT
+    DEFAULT ''
+    NOT NULL;
+
+-- +goose Down
+ALTER TABLE vrf_specs DROP COLUMN "low_balance_alert_threshold";
+
+ALTER TABLE vrf_specs DROP COLUMN "low_balance_webhook_url";
+
+ALTER TABLE vrf_specs DROP COLUMN "low_balance_external_initiator";
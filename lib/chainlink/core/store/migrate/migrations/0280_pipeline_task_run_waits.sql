@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE pipeline_task_run_waits (
+    pipeline_task_run_id UUID PRIMARY KEY REFERENCES pipeline_task_runs (id) ON DELETE CASCADE,
+    until TIMESTAMP WITH TIME ZONE,
+    block_number BIGINT,
+    evm_chain_id TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT chk_pipeline_task_run_waits_condition CHECK (
+        (until IS NOT NULL AND block_number IS NULL) OR
+        (until IS NULL AND block_number IS NOT NULL AND evm_chain_id IS NOT NULL)
+    )
+);
+CREATE INDEX idx_pipeline_task_run_waits_until ON pipeline_task_run_waits (until);
+
+-- +goose Down
+DROP TABLE pipeline_task_run_waits;
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE bridge_types
+    ADD COLUMN backend_urls TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN selection_policy TEXT NOT NULL DEFAULT 'failover',
+    ADD COLUMN health_check_path TEXT NOT NULL DEFAULT '',
+    ADD COLUMN health_check_interval BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN circuit_breaker_threshold BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN circuit_breaker_cooldown BIGINT NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_bridge_types_selection_policy CHECK (
+        selection_policy IN ('failover', 'round_robin', 'fastest_healthy')
+    );
+
+-- +goose Down
+ALTER TABLE bridge_types
+    DROP CONSTRAINT chk_bridge_types_selection_policy,
+    DROP COLUMN backend_urls,
+    DROP COLUMN selection_policy,
+    DROP COLUMN health_check_path,
+    DROP COLUMN health_check_interval,
+    DROP COLUMN circuit_breaker_threshold,
+    DROP COLUMN circuit_breaker_cooldown;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE bridge_types
+    ADD COLUMN signing_secret TEXT,
+    ADD COLUMN previous_signing_secret TEXT;
+
+-- +goose Down
+ALTER TABLE bridge_types
+    DROP COLUMN signing_secret,
+    DROP COLUMN previous_signing_secret;
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE cron_specs
+    ADD COLUMN time_zone TEXT NOT NULL DEFAULT '',
+    ADD COLUMN catch_up_policy TEXT NOT NULL DEFAULT 'skip',
+    ADD COLUMN concurrency_policy TEXT NOT NULL DEFAULT 'allow',
+    ADD COLUMN jitter BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN last_scheduled_at TIMESTAMP WITH TIME ZONE;
+
+-- +goose Down
+ALTER TABLE cron_specs
+    DROP COLUMN time_zone,
+    DROP COLUMN catch_up_policy,
+    DROP COLUMN concurrency_policy,
+    DROP COLUMN jitter,
+    DROP COLUMN last_scheduled_at;
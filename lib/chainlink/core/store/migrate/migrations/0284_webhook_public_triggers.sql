@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE webhook_specs
+    ADD COLUMN public_trigger JSONB,
+    ADD COLUMN encrypted_public_trigger_secret BYTEA;
+
+-- +goose Down
+ALTER TABLE webhook_specs
+    DROP COLUMN public_trigger,
+    DROP COLUMN encrypted_public_trigger_secret;
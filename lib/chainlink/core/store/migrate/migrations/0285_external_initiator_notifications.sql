@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE external_initiator_notifications (
+    id BIGSERIAL PRIMARY KEY,
+    external_initiator_id BIGINT NOT NULL REFERENCES external_initiators (id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    job_id UUID NOT NULL,
+    spec JSONB NOT NULL DEFAULT 'null',
+    state TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_external_initiator_notifications_due ON external_initiator_notifications (next_attempt_at) WHERE state = 'pending';
+CREATE INDEX idx_external_initiator_notifications_external_initiator_id ON external_initiator_notifications (external_initiator_id);
+
+-- +goose Down
+DROP TABLE external_initiator_notifications;
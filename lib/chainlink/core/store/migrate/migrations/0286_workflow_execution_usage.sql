@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE workflow_execution_usage (
+    sender TEXT NOT NULL,
+    period TEXT NOT NULL,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    executions BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (sender, period, period_start)
+);
+
+CREATE INDEX idx_workflow_execution_usage_period_start ON workflow_execution_usage (period_start);
+
+-- +goose Down
+DROP TABLE workflow_execution_usage;
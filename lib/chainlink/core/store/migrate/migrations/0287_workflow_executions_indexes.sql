@@ -0,0 +1,7 @@
+-- +goose Up
+CREATE INDEX idx_workflow_executions_workflow_id_started ON workflow_executions (workflow_id) WHERE status = 'started';
+CREATE INDEX idx_workflow_executions_finished_at ON workflow_executions (finished_at);
+
+-- +goose Down
+DROP INDEX idx_workflow_executions_finished_at;
+DROP INDEX idx_workflow_executions_workflow_id_started;
@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TYPE workflow_status ADD VALUE 'canceled';
+ALTER TABLE workflow_steps ADD COLUMN created_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX idx_workflow_executions_created_at ON workflow_executions (created_at);
+
+-- +goose Down
+DROP INDEX idx_workflow_executions_created_at;
+ALTER TABLE workflow_steps DROP COLUMN created_at;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE custom_roles (
+    name TEXT PRIMARY KEY,
+    permissions TEXT[] NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE user_role_grants (
+    id BIGSERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    role_name TEXT NOT NULL REFERENCES custom_roles (name) ON DELETE CASCADE,
+    scope_type TEXT NOT NULL,
+    scope_value TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT chk_scope_type CHECK (scope_type IN ('node', 'job', 'job_type', 'key'))
+);
+
+CREATE UNIQUE INDEX idx_user_role_grants_unique ON user_role_grants (lower(email), role_name, scope_type, scope_value);
+
+-- +goose Down
+DROP TABLE user_role_grants;
+DROP TABLE custom_roles;
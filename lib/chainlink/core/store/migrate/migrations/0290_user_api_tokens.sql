@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE user_api_tokens (
+    id BIGSERIAL PRIMARY KEY,
+    email TEXT NOT NULL,
+    name TEXT NOT NULL,
+    access_key TEXT NOT NULL UNIQUE,
+    salt TEXT NOT NULL,
+    hashed_secret TEXT NOT NULL,
+    role user_roles NOT NULL,
+    ip_allowlist TEXT[] NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_user_api_tokens_email_name ON user_api_tokens (lower(email), name);
+
+-- +goose Down
+DROP TABLE user_api_tokens;
@@ -0,0 +1,34 @@
+-- +goose Up
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    event_id TEXT NOT NULL,
+    user_email TEXT NOT NULL DEFAULT '',
+    -- JSON, not JSONB, keeps the data as it was hashed
+    data JSON NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    prev_hash TEXT NOT NULL,
+    hash TEXT NOT NULL UNIQUE
+);
+
+CREATE INDEX idx_audit_log_event_id_created_at ON audit_log (event_id, created_at);
+CREATE INDEX idx_audit_log_user_email_created_at ON audit_log (lower(user_email), created_at);
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
+
+-- +goose StatementBegin
+CREATE FUNCTION audit_log_append_only() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+        BEGIN
+        RAISE EXCEPTION 'audit_log is append-only';
+        END
+        $$;
+-- +goose StatementEnd
+
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
+
+-- +goose Down
+DROP TABLE audit_log;
+DROP FUNCTION audit_log_append_only();
@@ -0,0 +1,9 @@
+-- +goose Up
+CREATE TABLE encrypted_key_ring_backups (
+    id BIGSERIAL PRIMARY KEY,
+    encrypted_keys JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- +goose Down
+DROP TABLE encrypted_key_ring_backups;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- A job may rotate back to a key it used before, e.g. A -> B -> A -> B.
+DROP INDEX IF EXISTS idx_vrf_key_rotations_job_id_old_public_key;
+
+-- +goose Down
+CREATE UNIQUE INDEX idx_vrf_key_rotations_job_id_old_public_key ON vrf_key_rotations (job_id, old_public_key);
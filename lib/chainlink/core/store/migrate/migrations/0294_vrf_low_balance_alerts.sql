@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE vrf_low_balance_alerts (
+    job_id INTEGER NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    sub_id TEXT NOT NULL,
+    currency TEXT NOT NULL,
+    target TEXT NOT NULL,
+    alerted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (job_id, sub_id, currency, target)
+);
+
+-- +goose Down
+DROP TABLE vrf_low_balance_alerts;
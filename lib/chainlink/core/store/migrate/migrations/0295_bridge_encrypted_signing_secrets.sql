@@ -0,0 +1,16 @@
+-- +goose Up
+-- Signing secrets are encrypted with a key that is not stored in the database. The plaintext
+-- secrets can't be encrypted here, so signing is disabled for bridges that had one, until
+-- their secret is rotated.
+ALTER TABLE bridge_types
+    DROP COLUMN signing_secret,
+    DROP COLUMN previous_signing_secret,
+    ADD COLUMN encrypted_signing_secret BYTEA,
+    ADD COLUMN encrypted_previous_signing_secret BYTEA;
+
+-- +goose Down
+ALTER TABLE bridge_types
+    DROP COLUMN encrypted_signing_secret,
+    DROP COLUMN encrypted_previous_signing_secret,
+    ADD COLUMN signing_secret TEXT,
+    ADD COLUMN previous_signing_secret TEXT;
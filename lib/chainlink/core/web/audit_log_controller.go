@@ -0,0 +1,62 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// AuditLogController queries and verifies the local audit log.
+type AuditLogController struct {
+	App chainlink.Application
+}
+
+// Index lists the entries of the audit log, most recent first, optionally filtered by event ID,
+// user and time range. Times are RFC3339, from is inclusive and to is exclusive.
+// Example:
+// "GET <application>/audit?eventID=JOB_DELETED&user=<email>&from=<time>&to=<time>"
+func (alc *AuditLogController) Index(c *gin.Context, size, page, offset int) {
+	filter := audit.Filter{
+		EventID: audit.EventID(c.Query("eventID")),
+		User:    c.Query("user"),
+	}
+	var err error
+	if filter.From, err = parseAuditLogTime(c.Query("from")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid from"))
+		return
+	}
+	if filter.To, err = parseAuditLogTime(c.Query("to")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "invalid to"))
+		return
+	}
+
+	entries, count, err := alc.App.GetAuditLogStore().List(c.Request.Context(), filter, offset, size)
+
+	paginatedResponse(c, "auditLogEntries", size, page, presenters.NewAuditLogEntryResources(entries), count, err)
+}
+
+// Verify checks the hash chain of the whole audit log. The optional head is the last hash of a
+// previous verification, which the chain must still contain.
+// Example:
+// "GET <application>/audit/verify?head=<hash>"
+func (alc *AuditLogController) Verify(c *gin.Context) {
+	result, err := alc.App.GetAuditLogStore().Verify(c.Request.Context(), c.Query("head"))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewAuditLogVerificationResource(result), "auditLogVerification")
+}
+
+func parseAuditLogTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
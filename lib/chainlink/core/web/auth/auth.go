@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"net/http"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/auth"
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	clsessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/static"
 )
@@ -33,6 +35,9 @@ const (
 
 	// SessionExternalInitiatorKey is the External Initiator key in the session map
 	SessionExternalInitiatorKey = "external_initiator"
+
+	// SessionAPITokenKey is the named API token key in the session map
+	SessionAPITokenKey = "api_token"
 )
 
 // Authenticator defines the interface to authenticate requests against a
@@ -113,6 +118,97 @@ func AuthenticateByToken(c *gin.Context, authr Authenticator) error {
 
 var _ authMethod = AuthenticateByToken
 
+// APITokenStore defines the interface to look up the named API tokens of users.
+type APITokenStore interface {
+	FindAPIToken(ctx context.Context, accessKey string) (clsessions.APIToken, error)
+	MarkAPITokenUsed(ctx context.Context, id int64) error
+}
+
+// AuthenticateByAPIToken authenticates a User by one of their named API tokens. The role of the
+// user is capped by the role of the token. Unknown access keys fail with auth.ErrorAuthFailed, so
+// that the legacy single token of the user can be tried next.
+func AuthenticateByAPIToken(tokens APITokenStore, auditLogger audit.AuditLogger) authMethod {
+	return func(c *gin.Context, authr Authenticator) error {
+		ctx := c.Request.Context()
+		token := &auth.Token{
+			AccessKey: c.GetHeader(APIKey),
+			Secret:    c.GetHeader(APISecret),
+		}
+		if token.AccessKey == "" {
+			return auth.ErrorAuthFailed
+		}
+		// Nested groups authenticate the request again, the token was already checked and audited
+		if prev, ok := c.Get(SessionAPITokenKey); ok {
+			if prevToken, ok := prev.(*clsessions.APIToken); ok && prevToken.AccessKey == token.AccessKey {
+				return nil
+			}
+		}
+
+		apiToken, err := tokens.FindAPIToken(ctx, token.AccessKey)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return auth.ErrorAuthFailed
+			}
+			return err
+		}
+
+		reject := func(reason string) error {
+			auditLogger.Audit(audit.APITokenRejected, map[string]interface{}{
+				"user":   apiToken.Email,
+				"token":  apiToken.Name,
+				"id":     apiToken.ID,
+				"ip":     c.ClientIP(),
+				"reason": reason,
+			})
+			return auth.ErrorAuthFailed
+		}
+
+		ok, err := apiToken.Authenticate(token)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return reject("invalid secret")
+		}
+		if apiToken.Expired(time.Now()) {
+			return reject("expired")
+		}
+		if !apiToken.AllowsIP(c.ClientIP()) {
+			return reject("ip not allowed")
+		}
+
+		user, err := authr.FindUser(ctx, apiToken.Email)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return reject("user not found")
+			}
+			return err
+		}
+		if scoped := apiToken.ScopeUser(user); scoped.Role != user.Role {
+			// the custom role grants of the user would bypass the role of the token
+			c.Request = c.Request.WithContext(clsessions.WithAPITokenRole(ctx, apiToken.Role))
+			user = scoped
+		}
+
+		if err = tokens.MarkAPITokenUsed(ctx, apiToken.ID); err != nil {
+			return errors.Wrap(err, "marking API token used")
+		}
+		auditLogger.Audit(audit.APITokenUsed, map[string]interface{}{
+			"user":   user.Email,
+			"token":  apiToken.Name,
+			"id":     apiToken.ID,
+			"ip":     c.ClientIP(),
+			"method": c.Request.Method,
+			"path":   c.Request.URL.Path,
+		})
+
+		c.Set(SessionAPITokenKey, &apiToken)
+		c.Set(SessionUserKey, &user)
+
+		return nil
+	}
+}
+
 // AuthenticateExternalInitiator authenticates an external initiator request.
 //
 // Implements authMethod
@@ -253,3 +349,96 @@ func RequiresAdminRole(handler func(*gin.Context)) func(*gin.Context) {
 		handler(c)
 	}
 }
+
+// PermissionChecker checks the permissions users are granted by their built-in and custom roles.
+type PermissionChecker interface {
+	HasPermission(ctx context.Context, user *clsessions.User, perm clsessions.Permission, resources ...clsessions.Scope) (bool, error)
+}
+
+// ScopeFunc returns the resources a request acts on, for the permission checks of custom roles.
+type ScopeFunc func(c *gin.Context) ([]clsessions.Scope, error)
+
+// ParamScope returns the resource named by a path parameter, e.g. the address of a key.
+func ParamScope(scopeType clsessions.ScopeType, param string) ScopeFunc {
+	return func(c *gin.Context) ([]clsessions.Scope, error) {
+		return []clsessions.Scope{{Type: scopeType, Value: c.Param(param)}}, nil
+	}
+}
+
+// RequiresPermission extracts the user object from the context, and asserts the user's built-in
+// role implies the permission, or that the user is granted a custom role with the permission on
+// the whole node.
+func RequiresPermission(checker PermissionChecker, perm clsessions.Permission, handler func(*gin.Context)) func(*gin.Context) {
+	return func(c *gin.Context) {
+		if !CheckPermission(c, checker, perm, clsessions.NodeScope()) {
+			c.Abort()
+			return
+		}
+		handler(c)
+	}
+}
+
+// RequiresScopedPermission is RequiresPermission for the resources returned by scope. With a nil
+// scope, any grant of the permission is accepted, and the handler must call CheckPermission once
+// it knows the resource, e.g. the type of a job from its spec.
+func RequiresScopedPermission(checker PermissionChecker, perm clsessions.Permission, scope ScopeFunc, handler func(*gin.Context)) func(*gin.Context) {
+	return func(c *gin.Context) {
+		if user, ok := GetAuthenticatedUser(c); ok && clsessions.RoleAllows(user.Role, perm) {
+			// skip loading the resources, the built-in role allows all of them
+			handler(c)
+			return
+		}
+		var resources []clsessions.Scope
+		if scope != nil {
+			var err error
+			if resources, err = scope(c); err != nil {
+				c.Abort()
+				jsonAPIError(c, http.StatusInternalServerError, err)
+				return
+			}
+		}
+		if !CheckPermission(c, checker, perm, resources...) {
+			c.Abort()
+			return
+		}
+		handler(c)
+	}
+}
+
+// CheckPermission asserts the authenticated user has the permission on one of the resources, or
+// on any resource if none are given, and writes the error response when it does not.
+func CheckPermission(c *gin.Context, checker PermissionChecker, perm clsessions.Permission, resources ...clsessions.Scope) bool {
+	user, ok := GetAuthenticatedUser(c)
+	if !ok {
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("not a valid session"))
+		return false
+	}
+	allowed, err := checker.HasPermission(c.Request.Context(), user, perm, resources...)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return false
+	}
+	if allowed {
+		return true
+	}
+
+	requiredRole := clsessions.RequiredRole(perm)
+	if requiredRole != clsessions.UserRoleAdmin && !isScoped(resources) {
+		// same response as RequiresRunRole and RequiresEditRole
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("Unauthorized"))
+		return false
+	}
+	addForbiddenErrorHeaders(c, string(requiredRole), string(user.Role), user.Email)
+	c.Header("forbidden-required-permission", string(perm))
+	jsonAPIError(c, http.StatusForbidden, errors.New("Forbidden"))
+	return false
+}
+
+func isScoped(resources []clsessions.Scope) bool {
+	for _, r := range resources {
+		if r.Type != clsessions.ScopeTypeNode {
+			return true
+		}
+	}
+	return false
+}
@@ -222,14 +222,30 @@ var routesRolesMap = [...]routeRules{
 	{"POST", "/v2/users", false, false, false},
 	{"PATCH", "/v2/users", false, false, false},
 	{"DELETE", "/v2/users/MOCK", false, false, false},
+	{"GET", "/v2/roles", false, false, false},
+	{"POST", "/v2/roles", false, false, false},
+	{"DELETE", "/v2/roles/MOCK", false, false, false},
+	{"GET", "/v2/roles/grants", false, false, false},
+	{"POST", "/v2/roles/grants", false, false, false},
+	{"DELETE", "/v2/roles/grants/MOCK", false, false, false},
 	{"PATCH", "/v2/user/password", true, true, true},
 	{"POST", "/v2/user/token", true, true, true},
 	{"POST", "/v2/user/token/delete", true, true, true},
+	{"GET", "/v2/user/tokens", true, true, true},
+	{"POST", "/v2/user/tokens", true, true, true},
+	{"DELETE", "/v2/user/tokens/MOCK", true, true, true},
 	{"GET", "/v2/enroll_webauthn", true, true, true},
 	{"POST", "/v2/enroll_webauthn", true, true, true},
 	{"GET", "/v2/external_initiators", true, true, true},
 	{"POST", "/v2/external_initiators", false, false, true},
 	{"DELETE", "/v2/external_initiators/MOCK", false, false, true},
+	{"GET", "/v2/workflows/limits", false, false, false},
+	{"GET", "/v2/workflows/executions", true, true, true},
+	{"GET", "/v2/workflows/executions/MOCK", true, true, true},
+	{"POST", "/v2/workflows/executions/MOCK/cancel", false, false, true},
+	{"GET", "/v2/audit", false, false, false},
+	{"GET", "/v2/audit/verify", false, false, false},
+	{"PATCH", "/v2/keystore/password", false, false, false},
 	{"GET", "/v2/bridge_types", true, true, true},
 	{"POST", "/v2/bridge_types", false, false, true},
 	{"GET", "/v2/bridge_types/MOCK", true, true, true},
@@ -310,7 +326,11 @@ var routesRolesMap = [...]routeRules{
 	{"GET", "/v2/jobs", true, true, true},
 	{"GET", "/v2/jobs/MOCK", true, true, true},
 	{"POST", "/v2/jobs", false, false, true},
+	{"POST", "/v2/jobs/validate", false, false, true},
+	{"POST", "/v2/jobs/simulate", false, false, true},
+	{"PUT", "/v2/jobs/MOCK", false, false, true},
 	{"DELETE", "/v2/jobs/MOCK", false, false, true},
+	{"POST", "/v2/jobs/MOCK/public_trigger_secret", false, false, true},
 	{"GET", "/v2/pipeline/runs", true, true, true},
 	{"GET", "/v2/jobs/MOCK/runs", true, true, true},
 	{"GET", "/v2/jobs/MOCK/runs/MOCK", true, true, true},
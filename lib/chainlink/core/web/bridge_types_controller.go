@@ -14,6 +14,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 
 	"github.com/gin-gonic/gin"
@@ -50,6 +51,9 @@ func ValidateBridgeType(bt *bridges.BridgeTypeRequest) error {
 		bt.MinimumContractPayment.Cmp(assets.NewLinkFromJuels(0)) < 0 {
 		fe.Add("MinimumContractPayment must be positive")
 	}
+	if err := bt.PoolConfig.Validate(); err != nil {
+		fe.Merge(err)
+	}
 	return fe.CoerceEmptyToNil()
 }
 
@@ -231,3 +235,51 @@ func (btc *BridgeTypesController) Destroy(c *gin.Context) {
 
 	jsonAPIResponse(c, presenters.NewBridgeResource(bt), "bridge")
 }
+
+// RotateSigningSecret generates a new signing secret for a bridge, enabling the signing of its
+// requests and callbacks. The previous secret is still accepted on callbacks until the next
+// rotation. The secret is only returned here.
+func (btc *BridgeTypesController) RotateSigningSecret(c *gin.Context) {
+	btc.updateSigningSecret(c, utils.NewSecret(utils.DefaultSecretSize))
+}
+
+// DisableSigning removes the signing secrets of a bridge.
+func (btc *BridgeTypesController) DisableSigning(c *gin.Context) {
+	btc.updateSigningSecret(c, "")
+}
+
+func (btc *BridgeTypesController) updateSigningSecret(c *gin.Context, secret string) {
+	ctx := c.Request.Context()
+	name := c.Param("BridgeName")
+
+	taskType, err := bridges.ParseBridgeName(name)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	orm := btc.App.BridgeORM()
+	bt, err := orm.FindBridge(ctx, taskType)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("bridge not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if err = orm.UpdateBridgeSigningSecret(ctx, &bt, secret); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	resource := presenters.NewBridgeResource(bt)
+	if secret != "" {
+		resource.SigningSecret = secret
+		btc.App.GetAuditLogger().Audit(audit.BridgeSigningSecretRotated, map[string]interface{}{"bridgeName": bt.Name})
+	} else {
+		btc.App.GetAuditLogger().Audit(audit.BridgeSigningDisabled, map[string]interface{}{"bridgeName": bt.Name})
+	}
+
+	jsonAPIResponse(c, resource, "bridge")
+}
@@ -333,6 +333,16 @@ func (ekc *ETHKeysController) Chain(c *gin.Context) {
 		return
 	}
 
+	if abandon || enabledStr != "" {
+		ekc.app.GetAuditLogger().Audit(audit.KeyUpdated, map[string]interface{}{
+			"type":       "ethereum",
+			"id":         keyID,
+			"evmChainID": cid,
+			"abandon":    abandon,
+			"enabled":    enabledStr,
+		})
+	}
+
 	c.Set("key", key)
 	c.Set("state", state)
 	c.Status(http.StatusOK)
@@ -50,9 +50,22 @@ type ExternalInitiatorsController struct {
 func (eic *ExternalInitiatorsController) Index(c *gin.Context, size, page, offset int) {
 	ctx := c.Request.Context()
 	eis, count, err := eic.App.BridgeORM().ExternalInitiators(ctx, offset, size)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	ids := make([]int64, len(eis))
+	for i, ei := range eis {
+		ids[i] = ei.ID
+	}
+	stats, err := eic.App.GetExternalInitiatorManager().NotificationStats(ctx, ids)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
 	var resources []presenters.ExternalInitiatorResource
 	for _, ei := range eis {
-		resources = append(resources, presenters.NewExternalInitiatorResource(ei))
+		resources = append(resources, presenters.NewExternalInitiatorResource(ei, stats[ei.ID]))
 	}
 
 	paginatedResponse(c, "externalInitiators", size, page, resources, count, err)
@@ -116,3 +129,25 @@ func (eic *ExternalInitiatorsController) Destroy(c *gin.Context) {
 	eic.App.GetAuditLogger().Audit(audit.ExternalInitiatorDeleted, map[string]interface{}{"name": name})
 	jsonAPIResponseWithStatus(c, nil, "external initiator", http.StatusNoContent)
 }
+
+// Resync announces all the jobs of an ExternalInitiator to it again
+// Example:
+// "<application>/external_initiators/:Name/resync"
+func (eic *ExternalInitiatorsController) Resync(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("Name")
+	count, err := eic.App.GetExternalInitiatorManager().Resync(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("external initiator not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	eic.App.GetAuditLogger().Audit(audit.ExternalInitiatorResynced, map[string]interface{}{
+		"name": name,
+		"jobs": count,
+	})
+	jsonAPIResponse(c, presenters.NewExternalInitiatorResyncResource(name, count), "external initiator resync")
+}
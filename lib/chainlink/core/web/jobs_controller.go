@@ -27,17 +27,22 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/validate"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrbootstrap"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/standardcapabilities"
 	"github.com/smartcontractkit/chainlink/v2/core/services/streams"
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
 
 // JobsController manages jobs
 type JobsController struct {
-	App chainlink.Application
+	App        chainlink.Application
+	WebhookORM webhook.ORM
 }
 
 // Index lists all jobs
@@ -112,6 +117,9 @@ func (jc *JobsController) Create(c *gin.Context) {
 		jsonAPIError(c, status, err)
 		return
 	}
+	if !auth.CheckPermission(c, jc.App.RolesORM(), sessions.PermissionJobsCreate, sessions.JobTypeScope(jb.Type.String())) {
+		return
+	}
 
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
@@ -135,6 +143,68 @@ func (jc *JobsController) Create(c *gin.Context) {
 	jsonAPIResponse(c, presenters.NewJobResource(jb), jb.Type.String())
 }
 
+// Validate validates a job spec and statically analyzes its pipeline, without creating the job.
+// Example:
+// "POST <application>/jobs/validate"
+func (jc *JobsController) Validate(c *gin.Context) {
+	request := CreateJobRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jb, status, err := jc.validateJobSpec(c.Request.Context(), request.TOML)
+	if err != nil {
+		jsonAPIError(c, status, err)
+		return
+	}
+
+	var issues pipeline.LintIssues
+	if jb.Pipeline.Source != "" {
+		issues = jb.Pipeline.Lint()
+	}
+	jsonAPIResponse(c, presenters.NewJobValidationResource(jb, issues), "job_validations")
+}
+
+// SimulateJobRequest represents a request to simulate a run of the pipeline of a job, with the
+// tasks that interact with the outside world served from fixtures, or recorded to fixtures.
+type SimulateJobRequest struct {
+	TOML     string                 `json:"toml"`
+	Vars     map[string]interface{} `json:"vars"`
+	Fixtures pipeline.Fixtures      `json:"fixtures"`
+	Record   bool                   `json:"record"`
+}
+
+// Simulate runs the pipeline of a job spec in memory, without creating the job or saving the run.
+// Example:
+// "POST <application>/jobs/simulate"
+func (jc *JobsController) Simulate(c *gin.Context) {
+	request := SimulateJobRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jb, status, err := jc.validateJobSpec(c.Request.Context(), request.TOML)
+	if err != nil {
+		jsonAPIError(c, status, err)
+		return
+	}
+
+	sim := pipeline.NewSimulation(request.Fixtures, request.Record)
+	run, _, err := jc.App.SimulateJobV2(c.Request.Context(), jb, request.Vars, sim)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if _, err = sim.Recorded(); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewJobSimulationResource(jb.ExternalJobID.String(), run, sim, jc.App.GetLogger()), "job_simulations")
+}
+
 // Delete hard deletes a job spec.
 // Example:
 // "DELETE <application>/specs/:ID"
@@ -161,6 +231,45 @@ func (jc *JobsController) Delete(c *gin.Context) {
 	jsonAPIResponseWithStatus(c, nil, "job", http.StatusNoContent)
 }
 
+// RotatePublicTriggerSecret generates a new secret for the HMAC authenticated public trigger of
+// a webhook job. The secret is only returned once.
+// Example:
+// "POST <application>/jobs/:ID/public_trigger_secret"
+func (jc *JobsController) RotatePublicTriggerSecret(c *gin.Context) {
+	ctx := c.Request.Context()
+	var err error
+	jb := job.Job{}
+	if externalJobID, pErr := uuid.Parse(c.Param("ID")); pErr == nil {
+		jb, err = jc.App.JobORM().FindJobByExternalJobID(ctx, externalJobID)
+	} else if pErr = jb.SetID(c.Param("ID")); pErr == nil {
+		jb, err = jc.App.JobORM().FindJob(ctx, jb.ID)
+	} else {
+		jsonAPIError(c, http.StatusUnprocessableEntity, pErr)
+		return
+	}
+	if err != nil {
+		if errors.Is(errors.Cause(err), sql.ErrNoRows) {
+			jsonAPIError(c, http.StatusNotFound, errors.New("job not found"))
+		} else {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if jb.WebhookSpec == nil || jb.WebhookSpec.PublicTrigger == nil || jb.WebhookSpec.PublicTrigger.Auth != job.WebhookPublicTriggerAuthHMAC {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("job is not a webhook job with an hmac public trigger"))
+		return
+	}
+
+	secret := utils.NewSecret(utils.DefaultSecretSize)
+	if err = jc.WebhookORM.UpdatePublicTriggerSecret(ctx, jb.WebhookSpec.ID, secret); err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jc.App.GetAuditLogger().Audit(audit.WebhookPublicTriggerSecretRotated, map[string]interface{}{"id": jb.ID})
+	jsonAPIResponse(c, presenters.WebhookPublicTriggerSecretResource{JAID: presenters.NewJAIDInt32(jb.ID), Secret: secret}, "webhookPublicTriggerSecrets")
+}
+
 // UpdateJobRequest represents a request to update a job with new toml and start a job (V2).
 type UpdateJobRequest struct {
 	TOML string `json:"toml"`
@@ -188,6 +297,16 @@ func (jc *JobsController) Update(c *gin.Context) {
 		return
 	}
 
+	// The existing job is checked by the route, the new spec must be allowed for the same job too.
+	// A grant on the job does not extend to changing its type, which requires a grant on the new type.
+	scopes := []sessions.Scope{sessions.JobScope(jb.ID), sessions.JobTypeScope(jb.Type.String())}
+	if existing, findErr := jc.App.JobORM().FindJob(c.Request.Context(), jb.ID); findErr == nil && existing.Type != jb.Type {
+		scopes = []sessions.Scope{sessions.JobTypeScope(jb.Type.String())}
+	}
+	if !auth.CheckPermission(c, jc.App.RolesORM(), sessions.PermissionJobsUpdate, scopes...) {
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
 	defer cancel()
 
@@ -213,6 +332,13 @@ func (jc *JobsController) Update(c *gin.Context) {
 		return
 	}
 
+	jbj, err := json.Marshal(jb)
+	if err == nil {
+		jc.App.GetAuditLogger().Audit(audit.JobUpdated, map[string]interface{}{"id": jb.ID, "job": string(jbj)})
+	} else {
+		jc.App.GetLogger().Errorw("Could not send audit log for JobUpdate", "err", err)
+	}
+
 	jsonAPIResponse(c, presenters.NewJobResource(jb), jb.Type.String())
 }
 
@@ -270,3 +396,27 @@ func (jc *JobsController) validateJobSpec(ctx context.Context, tomlString string
 	}
 	return jb, 0, nil
 }
+
+// jobScopes returns the job of the :ID path parameter, by ID or external job ID, and its type,
+// for the permission checks of custom roles. Unknown jobs are checked against the whole node,
+// the handler then responds with not found.
+func jobScopes(app chainlink.Application) auth.ScopeFunc {
+	return func(c *gin.Context) ([]sessions.Scope, error) {
+		ctx := c.Request.Context()
+		var err error
+		jb := job.Job{}
+		if externalJobID, pErr := uuid.Parse(c.Param("ID")); pErr == nil {
+			jb, err = app.JobORM().FindJobByExternalJobID(ctx, externalJobID)
+		} else if pErr = jb.SetID(c.Param("ID")); pErr == nil {
+			jb, err = app.JobORM().FindJob(ctx, jb.ID)
+		} else {
+			return []sessions.Scope{sessions.NodeScope()}, nil
+		}
+		if errors.Is(errors.Cause(err), sql.ErrNoRows) {
+			return []sessions.Scope{sessions.NodeScope()}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return []sessions.Scope{sessions.JobScope(jb.ID), sessions.JobTypeScope(jb.Type.String())}, nil
+	}
+}
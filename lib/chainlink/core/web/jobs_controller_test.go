@@ -38,6 +38,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/testdata/testspecs"
 	"github.com/smartcontractkit/chainlink/v2/core/utils/tomlutils"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
@@ -693,6 +694,78 @@ func TestJobsController_Update_HappyPath(t *testing.T) {
 	cltest.AssertServerResponse(t, response, http.StatusOK)
 }
 
+func TestJobsController_Update_ScopedGrant(t *testing.T) {
+	ctx := testutils.Context(t)
+	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.OCR.Enabled = ptr(true)
+		c.P2P.V2.Enabled = ptr(true)
+		c.P2P.V2.ListenAddresses = &[]string{fmt.Sprintf("127.0.0.1:%d", freeport.GetOne(t))}
+		c.P2P.PeerID = &cltest.DefaultP2PPeerID
+	})
+	app := cltest.NewApplicationWithConfigAndKey(t, cfg, cltest.DefaultP2PKey)
+
+	require.NoError(t, app.KeyStore.OCR().Add(ctx, cltest.DefaultOCRKey))
+	require.NoError(t, app.Start(ctx))
+
+	_, bridge := cltest.MustCreateBridge(t, app.GetDB(), cltest.BridgeOpts{})
+	_, bridge2 := cltest.MustCreateBridge(t, app.GetDB(), cltest.BridgeOpts{})
+
+	var jb job.Job
+	ocrspec := testspecs.GenerateOCRSpec(testspecs.OCRSpecParams{
+		DS1BridgeName: bridge.Name.String(),
+		DS2BridgeName: bridge2.Name.String(),
+		Name:          "old OCR job",
+	})
+	require.NoError(t, toml.Unmarshal([]byte(ocrspec.Toml()), &jb))
+	require.NoError(t, utils.JustError(
+		app.GetDB().ExecContext(ctx, `SET CONSTRAINTS job_spec_errors_v2_job_id_fkey DEFERRED`)))
+	var ocrSpec job.OCROracleSpec
+	require.NoError(t, toml.Unmarshal([]byte(ocrspec.Toml()), &ocrSpec))
+	jb.OCROracleSpec = &ocrSpec
+	jb.OCROracleSpec.TransmitterAddress = &app.Keys[0].EIP55Address
+	require.NoError(t, app.AddJobV2(ctx, &jb))
+
+	_, err := app.RolesORM().CreateRole(ctx, sessions.CustomRole{Name: "updater", Permissions: []sessions.Permission{sessions.PermissionJobsUpdate}})
+	require.NoError(t, err)
+	owner := &cltest.User{Email: "owner@chainlink.test", Role: sessions.UserRoleView}
+	_, err = app.RolesORM().CreateGrant(ctx, owner.Email, "updater", sessions.JobScope(jb.ID))
+	require.NoError(t, err)
+	other := &cltest.User{Email: "other@chainlink.test", Role: sessions.UserRoleView}
+	_, err = app.RolesORM().CreateGrant(ctx, other.Email, "updater", sessions.JobScope(jb.ID+1))
+	require.NoError(t, err)
+
+	updatedSpec := testspecs.GenerateOCRSpec(testspecs.OCRSpecParams{
+		DS1BridgeName:      bridge2.Name.String(),
+		DS2BridgeName:      bridge.Name.String(),
+		Name:               "updated OCR job",
+		TransmitterAddress: app.Keys[0].Address.Hex(),
+	})
+	body, err := json.Marshal(web.UpdateJobRequest{TOML: updatedSpec.Toml()})
+	require.NoError(t, err)
+
+	response, cleanup := app.NewHTTPClient(other).Put("/v2/jobs/"+strconv.Itoa(int(jb.ID)), bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, response, http.StatusForbidden)
+
+	// the grant on the job does not allow changing its type
+	webhookBody, err := json.Marshal(web.UpdateJobRequest{TOML: testspecs.GenerateWebhookSpec(testspecs.WebhookSpecParams{}).Toml()})
+	require.NoError(t, err)
+	response, cleanup = app.NewHTTPClient(owner).Put("/v2/jobs/"+strconv.Itoa(int(jb.ID)), bytes.NewReader(webhookBody))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, response, http.StatusForbidden)
+	dbJb, err := app.JobORM().FindJob(ctx, jb.ID)
+	require.NoError(t, err)
+	require.Equal(t, job.OffchainReporting, dbJb.Type)
+
+	response, cleanup = app.NewHTTPClient(owner).Put("/v2/jobs/"+strconv.Itoa(int(jb.ID)), bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, response, http.StatusOK)
+
+	dbJb, err = app.JobORM().FindJob(ctx, jb.ID)
+	require.NoError(t, err)
+	require.Equal(t, updatedSpec.Name, dbJb.Name.String)
+}
+
 func TestJobsController_Update_NonExistentID(t *testing.T) {
 	ctx := testutils.Context(t)
 	cfg := configtest.NewGeneralConfig(t, func(c *chainlink.Config, s *chainlink.Secrets) {
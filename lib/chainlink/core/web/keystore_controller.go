@@ -0,0 +1,75 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	webauth "github.com/smartcontractkit/chainlink/v2/core/web/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// KeystoreController manages the encryption of the node's keystore.
+type KeystoreController struct {
+	App chainlink.Application
+}
+
+// RotateKeystorePasswordRequest defines the request to re-encrypt the keystore under a new
+// password. Zero scrypt parameters keep the current ones. PurgeBackups deletes the backups
+// encrypted under previous passwords once the keystore is re-encrypted.
+type RotateKeystorePasswordRequest struct {
+	OldPassword  string `json:"oldPassword"`
+	NewPassword  string `json:"newPassword"`
+	ScryptN      int    `json:"scryptN"`
+	ScryptP      int    `json:"scryptP"`
+	PurgeBackups bool   `json:"purgeBackups"`
+}
+
+// RotatePassword re-encrypts the keystore under a new password, optionally raising the scrypt
+// parameters.
+// Example:
+// "PATCH <application>/keystore/password"
+func (kc *KeystoreController) RotatePassword(c *gin.Context) {
+	var request RotateKeystorePasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	var email string
+	if user, ok := webauth.GetAuthenticatedUser(c); ok {
+		email = user.Email
+	}
+	if err := utils.VerifyPasswordComplexity(request.NewPassword); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	params, err := kc.App.GetKeyStore().RotatePassword(c.Request.Context(), request.OldPassword, request.NewPassword,
+		utils.ScryptParams{N: request.ScryptN, P: request.ScryptP}, request.PurgeBackups)
+	switch {
+	case errors.Is(err, keystore.ErrPasswordMismatch):
+		kc.App.GetAuditLogger().Audit(audit.KeystorePasswordRotateAttemptFailedMismatch, map[string]interface{}{"user": email})
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	case errors.Is(err, keystore.ErrInvalidScryptParams):
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	case err != nil:
+		kc.App.GetLogger().Errorw("Failed to rotate keystore password", "err", err)
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	kc.App.GetAuditLogger().Audit(audit.KeystorePasswordRotated, map[string]interface{}{
+		"user":          email,
+		"scryptN":       params.N,
+		"scryptP":       params.P,
+		"purgedBackups": request.PurgeBackups,
+	})
+	jsonAPIResponse(c, presenters.NewKeystorePasswordResource(params), "keystorePassword")
+}
@@ -91,10 +91,12 @@ func (cc *LogController) Patch(c *gin.Context) {
 		LogLevel:    lvls,
 	}
 
-	cc.App.GetAuditLogger().Audit(audit.GlobalLogLevelSet, map[string]interface{}{"logLevel": request.Level})
+	if request.Level != "" {
+		cc.App.GetAuditLogger().Audit(audit.GlobalLogLevelSet, map[string]interface{}{"logLevel": request.Level})
+	}
 
-	if request.Level == "debug" {
-		if request.SqlEnabled != nil && *request.SqlEnabled {
+	if request.SqlEnabled != nil {
+		if *request.SqlEnabled {
 			cc.App.GetAuditLogger().Audit(audit.ConfigSqlLoggingEnabled, map[string]interface{}{})
 		} else {
 			cc.App.GetAuditLogger().Audit(audit.ConfigSqlLoggingDisabled, map[string]interface{}{})
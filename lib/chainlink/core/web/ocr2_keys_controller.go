@@ -4,12 +4,14 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/chaintype"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/ocr2key"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
 
@@ -30,13 +32,29 @@ func (ocr2kc *OCR2KeysController) Index(c *gin.Context) {
 	jsonAPIResponse(c, presenters.NewOCR2KeysBundleResources(ekbs), "offChainReporting2KeyBundle")
 }
 
-// Create and return an OCR2 key bundle
+// Create and return an OCR2 key bundle. For EVM, the onchain key can be the key
+// of the external signer with the signing address externalOnchainAddress.
 // Example:
 // "POST <application>/keys/ocr"
+// "POST <application>/keys/ocr2/evm?externalOnchainAddress=0x..."
 func (ocr2kc *OCR2KeysController) Create(c *gin.Context) {
 	ctx := c.Request.Context()
 	chainType := chaintype.ChainType(c.Param("chainType"))
-	key, err := ocr2kc.App.GetKeyStore().OCR2().Create(ctx, chainType)
+	var key ocr2key.KeyBundle
+	var err error
+	if addr := c.Query("externalOnchainAddress"); addr != "" {
+		if chainType != chaintype.EVM {
+			jsonAPIError(c, http.StatusBadRequest, errors.Errorf("external onchain keys are not supported for chain type %s", chainType))
+			return
+		}
+		if !common.IsHexAddress(addr) {
+			jsonAPIError(c, http.StatusBadRequest, errors.Errorf("invalid externalOnchainAddress %q", addr))
+			return
+		}
+		key, err = ocr2kc.App.GetKeyStore().OCR2().CreateWithExternalOnchainKey(ctx, common.HexToAddress(addr))
+	} else {
+		key, err = ocr2kc.App.GetKeyStore().OCR2().Create(ctx, chainType)
+	}
 	if errors.Is(errors.Cause(err), chaintype.ErrInvalidChainType) {
 		jsonAPIError(c, http.StatusBadRequest, err)
 		return
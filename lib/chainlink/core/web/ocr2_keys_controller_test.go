@@ -71,6 +71,26 @@ func TestOCR2KeysController_Create_HappyPath(t *testing.T) {
 	}
 }
 
+func TestOCR2KeysController_Create_ExternalOnchainKey(t *testing.T) {
+	client, _ := setupOCR2KeysControllerTests(t)
+
+	for _, test := range []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"invalid address", "/v2/keys/ocr2/evm?externalOnchainAddress=0x123", http.StatusBadRequest},
+		{"unsupported chain type", "/v2/keys/ocr2/solana?externalOnchainAddress=" + testutils.NewAddress().Hex(), http.StatusBadRequest},
+		{"no external signer", "/v2/keys/ocr2/evm?externalOnchainAddress=" + testutils.NewAddress().Hex(), http.StatusInternalServerError},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			response, cleanup := client.Post(test.path, nil)
+			t.Cleanup(cleanup)
+			cltest.AssertServerResponse(t, response, test.status)
+		})
+	}
+}
+
 func TestOCR2KeysController_Delete_NonExistentOCRKeyID(t *testing.T) {
 	client, _ := setupOCR2KeysControllerTests(t)
 
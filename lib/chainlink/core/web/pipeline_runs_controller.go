@@ -1,6 +1,7 @@
 package web
 
 import (
+	"database/sql"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
 	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
@@ -23,6 +25,8 @@ import (
 // PipelineRunsController manages V2 job run requests.
 type PipelineRunsController struct {
 	App chainlink.Application
+	// CallbackGuard rejects replayed signed callbacks from bridges
+	CallbackGuard *bridges.ReplayGuard
 }
 
 // Index returns all pipeline runs for a job.
@@ -165,9 +169,22 @@ func (prc *PipelineRunsController) Resume(c *gin.Context) {
 		return
 	}
 
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "failed to read body"))
+		return
+	}
+	if err = prc.verifyCallback(c, taskID, body); err != nil {
+		if errors.Is(err, bridges.ErrMissingSignature) || errors.Is(err, bridges.ErrInvalidSignature) || errors.Is(err, bridges.ErrReplayedRequest) {
+			jsonAPIError(c, http.StatusUnauthorized, err)
+		} else {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
 	rr := pipeline.ResumeRequest{}
-	decoder := json.NewDecoder(c.Request.Body)
-	err = errors.Wrap(decoder.Decode(&rr), "failed to unmarshal JSON body")
+	err = errors.Wrap(json.Unmarshal(body, &rr), "failed to unmarshal JSON body")
 	if err != nil {
 		jsonAPIError(c, http.StatusUnprocessableEntity, err)
 		return
@@ -186,3 +203,32 @@ func (prc *PipelineRunsController) Resume(c *gin.Context) {
 	prc.App.GetAuditLogger().Audit(audit.UnauthedRunResumed, map[string]interface{}{"runID": c.Param("runID")})
 	c.Status(http.StatusOK)
 }
+
+// verifyCallback checks the signature of the callback if the bridge called by the task has
+// a signing secret. Callbacks to bridges without one are not signed.
+func (prc *PipelineRunsController) verifyCallback(c *gin.Context, taskID uuid.UUID, body []byte) error {
+	ctx := c.Request.Context()
+	name, err := prc.App.PipelineORM().FindTaskRunBridgeName(ctx, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Not a bridge task run, resuming it fails as usual
+		return nil
+	} else if err != nil {
+		return err
+	}
+	bridgeName, err := bridges.ParseBridgeName(name)
+	if err != nil {
+		return err
+	}
+	bt, err := prc.App.BridgeORM().FindBridge(ctx, bridgeName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to find bridge %s", bridgeName)
+	}
+	if !bt.SigningEnabled() {
+		return nil
+	}
+	secrets, err := prc.App.BridgeORM().SigningSecrets(ctx, bt)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decrypt signing secrets of bridge %s", bridgeName)
+	}
+	return bridges.VerifyCallbackSignature(secrets, c.Request.Method, c.Request.URL.Path, taskID, c.Request.Header, body, prc.CallbackGuard)
+}
@@ -0,0 +1,55 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+// APITokenResource represents a named API token JSONAPI resource. The secret is only set when the
+// token is created.
+type APITokenResource struct {
+	JAID
+	Name        string            `json:"name"`
+	AccessKey   string            `json:"accessKey"`
+	Secret      string            `json:"secret,omitempty"`
+	Role        sessions.UserRole `json:"role"`
+	IPAllowlist []string          `json:"ipAllowlist"`
+	ExpiresAt   time.Time         `json:"expiresAt"`
+	LastUsedAt  null.Time         `json:"lastUsedAt"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r APITokenResource) GetName() string {
+	return "apiTokens"
+}
+
+// NewAPITokenResource constructs a new APITokenResource.
+func NewAPITokenResource(token sessions.APIToken) *APITokenResource {
+	ipAllowlist := token.IPAllowlist
+	if ipAllowlist == nil {
+		ipAllowlist = []string{}
+	}
+	return &APITokenResource{
+		JAID:        NewJAID(strconv.FormatInt(token.ID, 10)),
+		Name:        token.Name,
+		AccessKey:   token.AccessKey,
+		Role:        token.Role,
+		IPAllowlist: ipAllowlist,
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
+
+func NewAPITokenResources(tokens []sessions.APIToken) []APITokenResource {
+	rs := []APITokenResource{}
+	for _, token := range tokens {
+		rs = append(rs, *NewAPITokenResource(token))
+	}
+	return rs
+}
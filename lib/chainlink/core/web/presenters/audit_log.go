@@ -0,0 +1,76 @@
+package presenters
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+)
+
+// AuditLogEntryResource represents an entry of the local audit log JSONAPI resource.
+type AuditLogEntryResource struct {
+	JAID
+	EventID   audit.EventID   `json:"eventID"`
+	User      string          `json:"user"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"createdAt"`
+	PrevHash  string          `json:"prevHash"`
+	Hash      string          `json:"hash"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r AuditLogEntryResource) GetName() string {
+	return "auditLogEntries"
+}
+
+// NewAuditLogEntryResource constructs a new AuditLogEntryResource.
+func NewAuditLogEntryResource(entry audit.Entry) *AuditLogEntryResource {
+	return &AuditLogEntryResource{
+		JAID:      NewJAID(strconv.FormatInt(entry.ID, 10)),
+		EventID:   entry.EventID,
+		User:      entry.User,
+		Data:      entry.Data,
+		CreatedAt: entry.CreatedAt,
+		PrevHash:  entry.PrevHash,
+		Hash:      entry.Hash,
+	}
+}
+
+func NewAuditLogEntryResources(entries []audit.Entry) []AuditLogEntryResource {
+	rs := []AuditLogEntryResource{}
+	for _, entry := range entries {
+		rs = append(rs, *NewAuditLogEntryResource(entry))
+	}
+	return rs
+}
+
+// AuditLogVerificationResource represents the verification of the chain of the audit log
+// JSONAPI resource.
+type AuditLogVerificationResource struct {
+	JAID
+	Entries        int64  `json:"entries"`
+	Valid          bool   `json:"valid"`
+	LastID         int64  `json:"lastID"`
+	LastHash       string `json:"lastHash"`
+	FirstInvalidID int64  `json:"firstInvalidID,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r AuditLogVerificationResource) GetName() string {
+	return "auditLogVerifications"
+}
+
+// NewAuditLogVerificationResource constructs a new AuditLogVerificationResource.
+func NewAuditLogVerificationResource(result audit.VerifyResult) *AuditLogVerificationResource {
+	return &AuditLogVerificationResource{
+		JAID:           NewJAID("audit_log"),
+		Entries:        result.Entries,
+		Valid:          result.Valid,
+		LastID:         result.LastID,
+		LastHash:       result.LastHash,
+		FirstInvalidID: result.FirstInvalidID,
+		Error:          result.Error,
+	}
+}
@@ -14,10 +14,19 @@ type BridgeResource struct {
 	URL           string `json:"url"`
 	Confirmations uint32 `json:"confirmations"`
 	// The IncomingToken is only provided when creating a Bridge
-	IncomingToken          string       `json:"incomingToken,omitempty"`
-	OutgoingToken          string       `json:"outgoingToken"`
-	MinimumContractPayment *assets.Link `json:"minimumContractPayment"`
-	CreatedAt              time.Time    `json:"createdAt"`
+	IncomingToken           string       `json:"incomingToken,omitempty"`
+	OutgoingToken           string       `json:"outgoingToken"`
+	MinimumContractPayment  *assets.Link `json:"minimumContractPayment"`
+	BackendURLs             []string     `json:"backendURLs"`
+	SelectionPolicy         string       `json:"selectionPolicy"`
+	HealthCheckPath         string       `json:"healthCheckPath"`
+	HealthCheckInterval     string       `json:"healthCheckInterval"`
+	CircuitBreakerThreshold uint32       `json:"circuitBreakerThreshold"`
+	CircuitBreakerCooldown  string       `json:"circuitBreakerCooldown"`
+	SigningEnabled          bool         `json:"signingEnabled"`
+	// The SigningSecret is only provided when rotating it
+	SigningSecret string    `json:"signingSecret,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
 }
 
 // GetName implements the api2go EntityNamer interface
@@ -27,14 +36,25 @@ func (r BridgeResource) GetName() string {
 
 // NewBridgeResource constructs a new BridgeResource
 func NewBridgeResource(b bridges.BridgeType) *BridgeResource {
+	backendURLs := make([]string, len(b.BackendURLs))
+	for i, u := range b.BackendURLs {
+		backendURLs[i] = u.String()
+	}
 	return &BridgeResource{
 		// Uses the name as the id...Should change this to the id
-		JAID:                   NewJAID(b.Name.String()),
-		Name:                   b.Name.String(),
-		URL:                    b.URL.String(),
-		Confirmations:          b.Confirmations,
-		OutgoingToken:          b.OutgoingToken,
-		MinimumContractPayment: b.MinimumContractPayment,
-		CreatedAt:              b.CreatedAt,
+		JAID:                    NewJAID(b.Name.String()),
+		Name:                    b.Name.String(),
+		URL:                     b.URL.String(),
+		Confirmations:           b.Confirmations,
+		OutgoingToken:           b.OutgoingToken,
+		MinimumContractPayment:  b.MinimumContractPayment,
+		BackendURLs:             backendURLs,
+		SelectionPolicy:         string(b.SelectionPolicy),
+		HealthCheckPath:         b.HealthCheckPath,
+		HealthCheckInterval:     b.HealthCheckInterval.Duration().String(),
+		CircuitBreakerThreshold: b.CircuitBreakerThreshold,
+		CircuitBreakerCooldown:  b.CircuitBreakerCooldown.Duration().String(),
+		SigningEnabled:          b.SigningEnabled(),
+		CreatedAt:               b.CreatedAt,
 	}
 }
@@ -18,6 +18,8 @@ func TestBridgeResource(t *testing.T) {
 	t.Parallel()
 
 	timestamp := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	backupURL, err := url.Parse("https://backup.example.com/api")
+	require.NoError(t, err)
 	url, err := url.Parse("https://bridge.example.com/api")
 	require.NoError(t, err)
 
@@ -27,7 +29,15 @@ func TestBridgeResource(t *testing.T) {
 		Confirmations:          1,
 		OutgoingToken:          "vjNL7X8Ea6GFJoa6PBsvK2ECzNK3b8IZ",
 		MinimumContractPayment: assets.NewLinkFromJuels(1),
-		CreatedAt:              timestamp,
+		PoolConfig: bridges.PoolConfig{
+			BackendURLs:             bridges.BackendURLs{models.WebURL(*backupURL)},
+			SelectionPolicy:         bridges.SelectionPolicyFailover,
+			HealthCheckPath:         "/health",
+			HealthCheckInterval:     models.Interval(30 * time.Second),
+			CircuitBreakerThreshold: 3,
+			CircuitBreakerCooldown:  models.Interval(time.Minute),
+		},
+		CreatedAt: timestamp,
 	}
 
 	r := NewBridgeResource(bridge)
@@ -46,6 +56,13 @@ func TestBridgeResource(t *testing.T) {
 			"confirmations":1,
 			"outgoingToken":"vjNL7X8Ea6GFJoa6PBsvK2ECzNK3b8IZ",
 			"minimumContractPayment":"1",
+			"backendURLs":["https://backup.example.com/api"],
+			"selectionPolicy":"failover",
+			"healthCheckPath":"/health",
+			"healthCheckInterval":"30s",
+			"circuitBreakerThreshold":3,
+			"circuitBreakerCooldown":"1m0s",
+			"signingEnabled":false,
 			"createdAt":"2000-01-01T00:00:00Z"
 		}
 	}
@@ -71,6 +88,13 @@ func TestBridgeResource(t *testing.T) {
 			"incomingToken": "cd+OfGXy3UHEDAlD0y27F6/rJE14X1UI",
 			"outgoingToken":"vjNL7X8Ea6GFJoa6PBsvK2ECzNK3b8IZ",
 			"minimumContractPayment":"1",
+			"backendURLs":["https://backup.example.com/api"],
+			"selectionPolicy":"failover",
+			"healthCheckPath":"/health",
+			"healthCheckInterval":"30s",
+			"circuitBreakerThreshold":3,
+			"circuitBreakerCooldown":"1m0s",
+			"signingEnabled":false,
 			"createdAt":"2000-01-01T00:00:00Z"
 		}
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink/v2/core/auth"
 	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 )
 
@@ -56,23 +57,29 @@ func (ei *ExternalInitiatorAuthentication) SetID(name string) error {
 
 type ExternalInitiatorResource struct {
 	JAID
-	Name          string         `json:"name"`
-	URL           *models.WebURL `json:"url"`
-	AccessKey     string         `json:"accessKey"`
-	OutgoingToken string         `json:"outgoingToken"`
-	CreatedAt     time.Time      `json:"createdAt"`
-	UpdatedAt     time.Time      `json:"updatedAt"`
+	Name                  string         `json:"name"`
+	URL                   *models.WebURL `json:"url"`
+	AccessKey             string         `json:"accessKey"`
+	OutgoingToken         string         `json:"outgoingToken"`
+	PendingNotifications  int64          `json:"pendingNotifications"`
+	DeadNotifications     int64          `json:"deadNotifications"`
+	LastNotificationError *string        `json:"lastNotificationError"`
+	CreatedAt             time.Time      `json:"createdAt"`
+	UpdatedAt             time.Time      `json:"updatedAt"`
 }
 
-func NewExternalInitiatorResource(ei bridges.ExternalInitiator) ExternalInitiatorResource {
+func NewExternalInitiatorResource(ei bridges.ExternalInitiator, stats webhook.NotificationStats) ExternalInitiatorResource {
 	return ExternalInitiatorResource{
-		JAID:          NewJAID(strconv.FormatInt(ei.ID, 10)),
-		Name:          ei.Name,
-		URL:           ei.URL,
-		AccessKey:     ei.AccessKey,
-		OutgoingToken: ei.OutgoingToken,
-		CreatedAt:     ei.CreatedAt,
-		UpdatedAt:     ei.UpdatedAt,
+		JAID:                  NewJAID(strconv.FormatInt(ei.ID, 10)),
+		Name:                  ei.Name,
+		URL:                   ei.URL,
+		AccessKey:             ei.AccessKey,
+		OutgoingToken:         ei.OutgoingToken,
+		PendingNotifications:  stats.Pending,
+		DeadNotifications:     stats.Dead,
+		LastNotificationError: stats.LastError.Ptr(),
+		CreatedAt:             ei.CreatedAt,
+		UpdatedAt:             ei.UpdatedAt,
 	}
 }
 
@@ -80,3 +87,23 @@ func NewExternalInitiatorResource(ei bridges.ExternalInitiator) ExternalInitiato
 func (ExternalInitiatorResource) GetName() string {
 	return "externalInitiators"
 }
+
+// ExternalInitiatorResyncResource is the result of announcing all the jobs of
+// an External Initiator to it again.
+type ExternalInitiatorResyncResource struct {
+	JAID
+	Jobs int64 `json:"jobs"`
+}
+
+// NewExternalInitiatorResyncResource constructs an ExternalInitiatorResyncResource.
+func NewExternalInitiatorResyncResource(name string, jobs int64) *ExternalInitiatorResyncResource {
+	return &ExternalInitiatorResyncResource{
+		JAID: NewJAID(name),
+		Jobs: jobs,
+	}
+}
+
+// GetName returns the collection name for jsonapi.
+func (ExternalInitiatorResyncResource) GetName() string {
+	return "externalInitiatorResyncs"
+}
@@ -242,56 +242,95 @@ func NewKeeperSpec(spec *job.KeeperSpec) *KeeperSpec {
 
 // WebhookSpec defines the spec details of a Webhook Job
 type WebhookSpec struct {
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	PublicTrigger *job.WebhookPublicTrigger `json:"publicTrigger"`
+	CreatedAt     time.Time                 `json:"createdAt"`
+	UpdatedAt     time.Time                 `json:"updatedAt"`
 }
 
 // NewWebhookSpec generates a new WebhookSpec from a job.WebhookSpec
 func NewWebhookSpec(spec *job.WebhookSpec) *WebhookSpec {
 	return &WebhookSpec{
-		CreatedAt: spec.CreatedAt,
-		UpdatedAt: spec.UpdatedAt,
+		PublicTrigger: spec.PublicTrigger,
+		CreatedAt:     spec.CreatedAt,
+		UpdatedAt:     spec.UpdatedAt,
 	}
 }
 
+// WebhookPublicTriggerSecretResource is the new secret of the public trigger of a webhook job.
+type WebhookPublicTriggerSecretResource struct {
+	JAID
+	Secret string `json:"secret"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (WebhookPublicTriggerSecretResource) GetName() string {
+	return "webhookPublicTriggerSecrets"
+}
+
+// WebhookPublicTriggerRunResource is the run of a webhook job triggered by its public trigger.
+type WebhookPublicTriggerRunResource struct {
+	JAID
+}
+
+// GetName implements the api2go EntityNamer interface
+func (WebhookPublicTriggerRunResource) GetName() string {
+	return "pipelineRun"
+}
+
 // CronSpec defines the spec details of a Cron Job
 type CronSpec struct {
-	CronSchedule string    `json:"schedule"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
-	EVMChainID   *big.Big  `json:"evmChainID"`
+	CronSchedule      string                    `json:"schedule"`
+	TimeZone          string                    `json:"timeZone"`
+	CatchUpPolicy     job.CronCatchUpPolicy     `json:"catchUpPolicy"`
+	ConcurrencyPolicy job.CronConcurrencyPolicy `json:"concurrencyPolicy"`
+	Jitter            models.Interval           `json:"jitter"`
+	LastScheduledAt   *time.Time                `json:"lastScheduledAt"`
+	CreatedAt         time.Time                 `json:"createdAt"`
+	UpdatedAt         time.Time                 `json:"updatedAt"`
+	EVMChainID        *big.Big                  `json:"evmChainID"`
 }
 
 // NewCronSpec generates a new CronSpec from a job.CronSpec
 func NewCronSpec(spec *job.CronSpec) *CronSpec {
 	return &CronSpec{
-		CronSchedule: spec.CronSchedule,
-		CreatedAt:    spec.CreatedAt,
-		UpdatedAt:    spec.UpdatedAt,
-		EVMChainID:   spec.EVMChainID,
+		CronSchedule:      spec.CronSchedule,
+		TimeZone:          spec.TimeZone,
+		CatchUpPolicy:     spec.CatchUpPolicy,
+		ConcurrencyPolicy: spec.ConcurrencyPolicy,
+		Jitter:            spec.Jitter,
+		LastScheduledAt:   spec.LastScheduledAt,
+		CreatedAt:         spec.CreatedAt,
+		UpdatedAt:         spec.UpdatedAt,
+		EVMChainID:        spec.EVMChainID,
 	}
 }
 
 type VRFSpec struct {
-	BatchCoordinatorAddress       *types.EIP55Address   `json:"batchCoordinatorAddress"`
-	BatchFulfillmentEnabled       bool                  `json:"batchFulfillmentEnabled"`
-	CustomRevertsPipelineEnabled  *bool                 `json:"customRevertsPipelineEnabled,omitempty"`
-	BatchFulfillmentGasMultiplier float64               `json:"batchFulfillmentGasMultiplier"`
-	CoordinatorAddress            types.EIP55Address    `json:"coordinatorAddress"`
-	PublicKey                     secp256k1.PublicKey   `json:"publicKey"`
-	FromAddresses                 []types.EIP55Address  `json:"fromAddresses"`
-	PollPeriod                    commonconfig.Duration `json:"pollPeriod"`
-	MinIncomingConfirmations      uint32                `json:"confirmations"`
-	CreatedAt                     time.Time             `json:"createdAt"`
-	UpdatedAt                     time.Time             `json:"updatedAt"`
-	EVMChainID                    *big.Big              `json:"evmChainID"`
-	ChunkSize                     uint32                `json:"chunkSize"`
-	RequestTimeout                commonconfig.Duration `json:"requestTimeout"`
-	BackoffInitialDelay           commonconfig.Duration `json:"backoffInitialDelay"`
-	BackoffMaxDelay               commonconfig.Duration `json:"backoffMaxDelay"`
-	GasLanePrice                  *assets.Wei           `json:"gasLanePrice"`
-	RequestedConfsDelay           int64                 `json:"requestedConfsDelay"`
-	VRFOwnerAddress               *types.EIP55Address   `json:"vrfOwnerAddress,omitempty"`
+	BatchCoordinatorAddress       *types.EIP55Address           `json:"batchCoordinatorAddress"`
+	BatchFulfillmentEnabled       bool                          `json:"batchFulfillmentEnabled"`
+	CustomRevertsPipelineEnabled  *bool                         `json:"customRevertsPipelineEnabled,omitempty"`
+	BatchFulfillmentGasMultiplier float64                       `json:"batchFulfillmentGasMultiplier"`
+	CoordinatorAddress            types.EIP55Address            `json:"coordinatorAddress"`
+	PublicKey                     secp256k1.PublicKey           `json:"publicKey"`
+	FromAddresses                 []types.EIP55Address          `json:"fromAddresses"`
+	PollPeriod                    commonconfig.Duration         `json:"pollPeriod"`
+	MinIncomingConfirmations      uint32                        `json:"confirmations"`
+	CreatedAt                     time.Time                     `json:"createdAt"`
+	UpdatedAt                     time.Time                     `json:"updatedAt"`
+	EVMChainID                    *big.Big                      `json:"evmChainID"`
+	ChunkSize                     uint32                        `json:"chunkSize"`
+	RequestTimeout                commonconfig.Duration         `json:"requestTimeout"`
+	BackoffInitialDelay           commonconfig.Duration         `json:"backoffInitialDelay"`
+	BackoffMaxDelay               commonconfig.Duration         `json:"backoffMaxDelay"`
+	GasLanePrice                  *assets.Wei                   `json:"gasLanePrice"`
+	RequestedConfsDelay           int64                         `json:"requestedConfsDelay"`
+	VRFOwnerAddress               *types.EIP55Address           `json:"vrfOwnerAddress,omitempty"`
+	FulfillmentScheduler          string                        `json:"fulfillmentScheduler,omitempty"`
+	SubscriptionSchedules         []job.VRFSubscriptionSchedule `json:"subscriptionSchedules,omitempty"`
+	KeyRotationOverlap            commonconfig.Duration         `json:"keyRotationOverlap"`
+	LowBalanceAlertThreshold      commonconfig.Duration         `json:"lowBalanceAlertThreshold"`
+	LowBalanceWebhookURL          string                        `json:"lowBalanceWebhookURL,omitempty"`
+	LowBalanceExternalInitiator   string                        `json:"lowBalanceExternalInitiator,omitempty"`
 }
 
 func NewVRFSpec(spec *job.VRFSpec) *VRFSpec {
@@ -315,6 +354,12 @@ func NewVRFSpec(spec *job.VRFSpec) *VRFSpec {
 		GasLanePrice:                  spec.GasLanePrice,
 		RequestedConfsDelay:           spec.RequestedConfsDelay,
 		VRFOwnerAddress:               spec.VRFOwnerAddress,
+		FulfillmentScheduler:          string(spec.FulfillmentScheduler),
+		SubscriptionSchedules:         spec.SubscriptionSchedules,
+		KeyRotationOverlap:            *commonconfig.MustNewDuration(spec.KeyRotationOverlap),
+		LowBalanceAlertThreshold:      *commonconfig.MustNewDuration(spec.LowBalanceAlertThreshold),
+		LowBalanceWebhookURL:          spec.LowBalanceWebhookURL,
+		LowBalanceExternalInitiator:   spec.LowBalanceExternalInitiator,
 	}
 }
 
@@ -520,6 +565,7 @@ type JobResource struct {
 	SchemaVersion            uint32                    `json:"schemaVersion"`
 	GasLimit                 clnull.Uint32             `json:"gasLimit"`
 	ForwardingAllowed        bool                      `json:"forwardingAllowed"`
+	CheckpointTasks          bool                      `json:"checkpointTasks,omitempty"`
 	MaxTaskDuration          models.Interval           `json:"maxTaskDuration"`
 	ExternalJobID            uuid.UUID                 `json:"externalJobID"`
 	DirectRequestSpec        *DirectRequestSpec        `json:"directRequestSpec"`
@@ -551,6 +597,7 @@ func NewJobResource(j job.Job) *JobResource {
 		SchemaVersion:     j.SchemaVersion,
 		GasLimit:          j.GasLimit,
 		ForwardingAllowed: j.ForwardingAllowed,
+		CheckpointTasks:   j.CheckpointTasks,
 		MaxTaskDuration:   j.MaxTaskDuration,
 		PipelineSpec:      NewPipelineSpec(j.PipelineSpec),
 		ExternalJobID:     j.ExternalJobID,
@@ -606,3 +653,31 @@ func NewJobResource(j job.Job) *JobResource {
 func (r JobResource) GetName() string {
 	return "jobs"
 }
+
+// JobValidationResource represents the result of validating a job spec, without creating the job
+type JobValidationResource struct {
+	JAID
+	Name   string               `json:"name"`
+	Type   JobSpecType          `json:"type"`
+	Valid  bool                 `json:"valid"`
+	Issues []pipeline.LintIssue `json:"issues"`
+}
+
+// NewJobValidationResource initializes a new JSONAPI job validation resource
+func NewJobValidationResource(j job.Job, issues pipeline.LintIssues) *JobValidationResource {
+	if issues == nil {
+		issues = pipeline.LintIssues{}
+	}
+	return &JobValidationResource{
+		JAID:   NewJAID(j.ExternalJobID.String()),
+		Name:   j.Name.ValueOrZero(),
+		Type:   JobSpecType(j.Type),
+		Valid:  !issues.HasErrors(),
+		Issues: issues,
+	}
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobValidationResource) GetName() string {
+	return "job_validations"
+}
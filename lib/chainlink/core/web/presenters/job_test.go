@@ -408,6 +408,11 @@ func TestJob(t *testing.T) {
                         },
                         "cronSpec": {
                             "schedule": "%s",
+                            "timeZone": "",
+                            "catchUpPolicy": "",
+                            "concurrencyPolicy": "",
+                            "jitter": "0s",
+                            "lastScheduledAt": null,
                             "createdAt":"2000-01-01T00:00:00Z",
                             "updatedAt":"2000-01-01T00:00:00Z",
                             "evmChainID":"42"
@@ -468,6 +473,7 @@ func TestJob(t *testing.T) {
 							"jobID": 0
 						},
 						"webhookSpec": {
+							"publicTrigger": null,
 							"createdAt":"2000-01-01T00:00:00Z",
 							"updatedAt":"2000-01-01T00:00:00Z"
 						},
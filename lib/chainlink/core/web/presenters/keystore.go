@@ -0,0 +1,26 @@
+package presenters
+
+import (
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+)
+
+// KeystorePasswordResource represents the encryption of the keystore JSONAPI resource.
+type KeystorePasswordResource struct {
+	JAID
+	ScryptN int `json:"scryptN"`
+	ScryptP int `json:"scryptP"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r KeystorePasswordResource) GetName() string {
+	return "keystorePasswords"
+}
+
+// NewKeystorePasswordResource constructs a new KeystorePasswordResource.
+func NewKeystorePasswordResource(params utils.ScryptParams) *KeystorePasswordResource {
+	return &KeystorePasswordResource{
+		JAID:    NewJAID("keystore"),
+		ScryptN: params.N,
+		ScryptP: params.P,
+	}
+}
@@ -104,3 +104,80 @@ func NewPipelineRunResources(prs []pipeline.Run, lggr logger.Logger) []PipelineR
 
 	return out
 }
+
+// JobSimulationResource represents a simulated run of the pipeline of a job, which is not saved
+type JobSimulationResource struct {
+	JAID
+	State       pipeline.RunStatus         `json:"state"`
+	Outputs     []*string                  `json:"outputs"`
+	FatalErrors []*string                  `json:"fatalErrors"`
+	TaskRuns    []SimulatedTaskRunResource `json:"taskRuns"`
+	Fixtures    pipeline.Fixtures          `json:"fixtures,omitempty"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobSimulationResource) GetName() string {
+	return "job_simulations"
+}
+
+// SimulatedTaskRunResource represents a task run of a simulated run
+type SimulatedTaskRunResource struct {
+	Type       pipeline.TaskType `json:"type"`
+	DotID      string            `json:"dotId"`
+	Simulated  bool              `json:"simulated"`
+	Inputs     []string          `json:"inputs"`
+	Output     *string           `json:"output"`
+	Error      *string           `json:"error"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	FinishedAt null.Time         `json:"finishedAt"`
+}
+
+// NewJobSimulationResource initializes a new JSONAPI job simulation resource. The fixtures
+// recorded by the simulation, if any, are included.
+func NewJobSimulationResource(id string, run *pipeline.Run, sim *pipeline.Simulation, lggr logger.Logger) *JobSimulationResource {
+	lggr = lggr.Named("JobSimulationResource")
+	outputs, err := run.StringOutputs()
+	if err != nil {
+		lggr.Errorw(err.Error(), "out", run.Outputs)
+	}
+
+	var trs []SimulatedTaskRunResource
+	for _, tr := range run.PipelineTaskRuns {
+		taskRun := NewPipelineTaskRunResource(tr)
+		var inputs []string
+		for _, input := range sim.Inputs(taskRun.DotID) {
+			if input.Error != nil {
+				inputs = append(inputs, "error: "+input.Error.Error())
+				continue
+			}
+			inputBytes, _ := input.OutputDB().MarshalJSON()
+			inputs = append(inputs, string(inputBytes))
+		}
+		trs = append(trs, SimulatedTaskRunResource{
+			Type:       taskRun.Type,
+			DotID:      taskRun.DotID,
+			Simulated:  sim.IsSimulated(taskRun.Type),
+			Inputs:     inputs,
+			Output:     taskRun.Output,
+			Error:      taskRun.Error,
+			CreatedAt:  taskRun.CreatedAt,
+			FinishedAt: taskRun.FinishedAt,
+		})
+	}
+
+	recorded, err := sim.Recorded()
+	if err != nil {
+		lggr.Errorw("Failed to record fixtures", "err", err)
+	}
+	if len(recorded) == 0 {
+		recorded = nil
+	}
+	return &JobSimulationResource{
+		JAID:        NewJAID(id),
+		State:       run.State,
+		Outputs:     outputs,
+		FatalErrors: run.StringFatalErrors(),
+		TaskRuns:    trs,
+		Fixtures:    recorded,
+	}
+}
@@ -0,0 +1,72 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+)
+
+// RoleResource represents a custom role JSONAPI resource.
+type RoleResource struct {
+	JAID
+	Permissions []sessions.Permission `json:"permissions"`
+	CreatedAt   time.Time             `json:"createdAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r RoleResource) GetName() string {
+	return "roles"
+}
+
+// NewRoleResource constructs a new RoleResource. Custom roles are identified by their name.
+func NewRoleResource(role sessions.CustomRole) *RoleResource {
+	return &RoleResource{
+		JAID:        NewJAID(role.Name),
+		Permissions: role.Permissions,
+		CreatedAt:   role.CreatedAt,
+	}
+}
+
+func NewRoleResources(roles []sessions.CustomRole) []RoleResource {
+	rs := []RoleResource{}
+	for _, role := range roles {
+		rs = append(rs, *NewRoleResource(role))
+	}
+	return rs
+}
+
+// RoleGrantResource represents the grant of a custom role to a user JSONAPI resource.
+type RoleGrantResource struct {
+	JAID
+	Email      string             `json:"email"`
+	Role       string             `json:"role"`
+	ScopeType  sessions.ScopeType `json:"scopeType"`
+	ScopeValue string             `json:"scopeValue"`
+	CreatedAt  time.Time          `json:"createdAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r RoleGrantResource) GetName() string {
+	return "roleGrants"
+}
+
+// NewRoleGrantResource constructs a new RoleGrantResource.
+func NewRoleGrantResource(grant sessions.RoleGrant) *RoleGrantResource {
+	return &RoleGrantResource{
+		JAID:       NewJAID(strconv.FormatInt(grant.ID, 10)),
+		Email:      grant.Email,
+		Role:       grant.RoleName,
+		ScopeType:  grant.Scope.Type,
+		ScopeValue: grant.Scope.Value,
+		CreatedAt:  grant.CreatedAt,
+	}
+}
+
+func NewRoleGrantResources(grants []sessions.RoleGrant) []RoleGrantResource {
+	rs := []RoleGrantResource{}
+	for _, grant := range grants {
+		rs = append(rs, *NewRoleGrantResource(grant))
+	}
+	return rs
+}
@@ -1,8 +1,11 @@
 package presenters
 
 import (
+	"time"
+
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 )
 
 type VRFKeyResource struct {
@@ -38,3 +41,48 @@ func NewVRFKeyResources(keys []vrfkey.KeyV2, lggr logger.Logger) []VRFKeyResourc
 
 	return rs
 }
+
+// VRFKeyRotationResource represents the rotation of a VRF job's key.
+type VRFKeyRotationResource struct {
+	JAID
+	JobID           int32      `json:"jobID"`
+	OldPublicKey    string     `json:"oldPublicKey"`
+	OldKeyHash      string     `json:"oldKeyHash"`
+	NewPublicKey    string     `json:"newPublicKey"`
+	NewKeyHash      string     `json:"newKeyHash"`
+	Status          string     `json:"status"`
+	OverlapUntil    time.Time  `json:"overlapUntil"`
+	PendingRequests int64      `json:"pendingRequests"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	RetiredAt       *time.Time `json:"retiredAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFKeyRotationResource) GetName() string {
+	return "vrfKeyRotations"
+}
+
+func NewVRFKeyRotationResource(r vrfcommon.KeyRotation, now time.Time) *VRFKeyRotationResource {
+	return &VRFKeyRotationResource{
+		JAID:            NewJAIDInt64(r.ID),
+		JobID:           r.JobID,
+		OldPublicKey:    r.OldPublicKey.String(),
+		OldKeyHash:      r.OldPublicKey.MustHash().String(),
+		NewPublicKey:    r.NewPublicKey.String(),
+		NewKeyHash:      r.NewPublicKey.MustHash().String(),
+		Status:          string(r.Status(now)),
+		OverlapUntil:    r.OverlapUntil,
+		PendingRequests: r.PendingRequests,
+		CreatedAt:       r.CreatedAt,
+		RetiredAt:       r.RetiredAt,
+	}
+}
+
+func NewVRFKeyRotationResources(rotations []vrfcommon.KeyRotation, now time.Time) []VRFKeyRotationResource {
+	rs := []VRFKeyRotationResource{}
+	for _, r := range rotations {
+		rs = append(rs, *NewVRFKeyRotationResource(r, now))
+	}
+
+	return rs
+}
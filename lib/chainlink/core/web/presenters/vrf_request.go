@@ -0,0 +1,193 @@
+package presenters
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+	vrfv2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// VRFRequestLifecycleEventResource represents a single stage a VRF request went through.
+type VRFRequestLifecycleEventResource struct {
+	EVMChainID  big.Big                    `json:"evmChainID"`
+	JobID       int32                      `json:"jobID"`
+	Stage       vrfcommon.LifecycleStage   `json:"stage"`
+	Details     vrfcommon.LifecycleDetails `json:"details"`
+	Occurrences int64                      `json:"occurrences"`
+	FirstSeenAt time.Time                  `json:"firstSeenAt"`
+	LastSeenAt  time.Time                  `json:"lastSeenAt"`
+}
+
+// VRFRequestLifecycleResource represents the lifecycle of a VRF request JSONAPI resource.
+type VRFRequestLifecycleResource struct {
+	JAID
+	RequestID    string                             `json:"requestID"`
+	CurrentStage vrfcommon.LifecycleStage           `json:"currentStage"`
+	Events       []VRFRequestLifecycleEventResource `json:"events"`
+	Transactions []EthTxResource                    `json:"transactions"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFRequestLifecycleResource) GetName() string {
+	return "vrf_requests"
+}
+
+// NewVRFRequestLifecycleResource generates a VRFRequestLifecycleResource from the
+// recorded events of a request and the fulfillment transactions they reference.
+func NewVRFRequestLifecycleResource(requestID string, events []vrfcommon.LifecycleEvent, txs []txmgr.Tx) VRFRequestLifecycleResource {
+	r := VRFRequestLifecycleResource{
+		JAID:         NewJAID(requestID),
+		RequestID:    requestID,
+		Events:       []VRFRequestLifecycleEventResource{},
+		Transactions: []EthTxResource{},
+	}
+
+	var lastSeen time.Time
+	for _, e := range events {
+		er := VRFRequestLifecycleEventResource{
+			JobID:       e.JobID,
+			Stage:       e.Stage,
+			Details:     e.Details,
+			Occurrences: e.Occurrences,
+			FirstSeenAt: e.FirstSeenAt,
+			LastSeenAt:  e.LastSeenAt,
+		}
+		if e.EVMChainID != nil {
+			er.EVMChainID = *e.EVMChainID
+		}
+		r.Events = append(r.Events, er)
+
+		if !e.LastSeenAt.Before(lastSeen) {
+			lastSeen = e.LastSeenAt
+			r.CurrentStage = e.Stage
+		}
+	}
+
+	for _, tx := range txs {
+		if len(tx.TxAttempts) == 0 {
+			r.Transactions = append(r.Transactions, NewEthTxResource(tx))
+			continue
+		}
+		// Attempts are ordered newest first.
+		attempt := tx.TxAttempts[0]
+		attempt.Tx = tx
+		r.Transactions = append(r.Transactions, NewEthTxResourceFromAttempt(attempt))
+	}
+
+	return r
+}
+
+// VRFProofResource represents the proof of a VRF v2plus fulfillment.
+type VRFProofResource struct {
+	PK            [2]string `json:"pk"`
+	Gamma         [2]string `json:"gamma"`
+	C             string    `json:"c"`
+	S             string    `json:"s"`
+	Seed          string    `json:"seed"`
+	UWitness      string    `json:"uWitness"`
+	CGammaWitness [2]string `json:"cGammaWitness"`
+	SHashWitness  [2]string `json:"sHashWitness"`
+	ZInv          string    `json:"zInv"`
+}
+
+// VRFDryRunResource represents the outcome of a VRF v2plus dry-run fulfillment JSONAPI resource.
+type VRFDryRunResource struct {
+	JAID
+	JobID                   int32             `json:"jobID"`
+	RequestID               string            `json:"requestID"`
+	SubID                   string            `json:"subID"`
+	Sender                  string            `json:"sender"`
+	Payload                 string            `json:"payload,omitempty"`
+	Proof                   *VRFProofResource `json:"proof,omitempty"`
+	RandomWords             []string          `json:"randomWords"`
+	EstimatedFee            string            `json:"estimatedFee,omitempty"`
+	MaxFee                  string            `json:"maxFee,omitempty"`
+	GasLimit                uint64            `json:"gasLimit,omitempty"`
+	SimulationError         string            `json:"simulationError,omitempty"`
+	FulfillmentRevertReason string            `json:"fulfillmentRevertReason,omitempty"`
+	CallbackRevertReason    string            `json:"callbackRevertReason,omitempty"`
+	CallbackWouldRevert     bool              `json:"callbackWouldRevert"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFDryRunResource) GetName() string {
+	return "vrf_dry_runs"
+}
+
+// NewVRFDryRunResource generates a VRFDryRunResource from the result of a dry-run fulfillment.
+func NewVRFDryRunResource(res vrfv2.DryRunResult) VRFDryRunResource {
+	r := VRFDryRunResource{
+		JAID:                    NewJAID(res.RequestID.String()),
+		JobID:                   res.JobID,
+		RequestID:               res.RequestID.String(),
+		SubID:                   res.SubID.String(),
+		Sender:                  res.Sender.Hex(),
+		Payload:                 res.Payload,
+		RandomWords:             []string{},
+		GasLimit:                res.GasLimit,
+		SimulationError:         res.SimulationError,
+		FulfillmentRevertReason: res.FulfillmentRevertReason,
+		CallbackRevertReason:    res.CallbackRevertReason,
+		CallbackWouldRevert:     res.CallbackRevertReason != "",
+	}
+	if res.EstimatedFee != nil {
+		r.EstimatedFee = res.EstimatedFee.String()
+	}
+	if res.MaxFee != nil {
+		r.MaxFee = res.MaxFee.String()
+	}
+	for _, w := range res.RandomWords {
+		r.RandomWords = append(r.RandomWords, w.String())
+	}
+	if res.Proof != nil {
+		p := res.Proof.V2Plus
+		r.Proof = &VRFProofResource{
+			PK:            [2]string{p.Pk[0].String(), p.Pk[1].String()},
+			Gamma:         [2]string{p.Gamma[0].String(), p.Gamma[1].String()},
+			C:             p.C.String(),
+			S:             p.S.String(),
+			Seed:          p.Seed.String(),
+			UWitness:      p.UWitness.Hex(),
+			CGammaWitness: [2]string{p.CGammaWitness[0].String(), p.CGammaWitness[1].String()},
+			SHashWitness:  [2]string{p.SHashWitness[0].String(), p.SHashWitness[1].String()},
+			ZInv:          p.ZInv.String(),
+		}
+	}
+	return r
+}
+
+// VRFProofAttestationResource represents a signed attestation of the verification of
+// a VRF fulfillment JSONAPI resource.
+type VRFProofAttestationResource struct {
+	JAID
+	RequestID   string                    `json:"requestID"`
+	Valid       bool                      `json:"valid"`
+	Checks      []proof.VerificationCheck `json:"checks"`
+	Attestation json.RawMessage           `json:"attestation"`
+	PublicKey   string                    `json:"publicKey"`
+	Signature   string                    `json:"signature"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFProofAttestationResource) GetName() string {
+	return "vrf_proof_attestations"
+}
+
+// NewVRFProofAttestationResource generates a VRFProofAttestationResource from a
+// verification and its signed attestation.
+func NewVRFProofAttestationResource(v proof.FulfillmentVerification, signed proof.SignedAttestation) VRFProofAttestationResource {
+	return VRFProofAttestationResource{
+		JAID:        NewJAID(v.RequestID.String()),
+		RequestID:   v.RequestID.String(),
+		Valid:       v.Valid,
+		Checks:      v.Checks,
+		Attestation: signed.Attestation,
+		PublicKey:   signed.PublicKey,
+		Signature:   signed.Signature,
+	}
+}
@@ -0,0 +1,175 @@
+package presenters
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/workflows/v2"
+)
+
+// WorkflowExecutionResource is an execution of a workflow. The trigger, capability calls,
+// spend and user logs are only set when showing a single execution.
+type WorkflowExecutionResource struct {
+	JAID
+	WorkflowID      string                    `json:"workflowID"`
+	Status          string                    `json:"status"`
+	CreatedAt       *time.Time                `json:"createdAt"`
+	UpdatedAt       *time.Time                `json:"updatedAt"`
+	FinishedAt      *time.Time                `json:"finishedAt"`
+	Trigger         *WorkflowExecutionTrigger `json:"trigger,omitempty"`
+	CapabilityCalls []WorkflowCapabilityCall  `json:"capabilityCalls,omitempty"`
+	Spend           []WorkflowExecutionSpend  `json:"spend,omitempty"`
+	UserLogs        []WorkflowUserLog         `json:"userLogs,omitempty"`
+	// DecodeError is set when the saved steps of the execution could not be decoded.
+	DecodeError string `json:"decodeError,omitempty"`
+}
+
+// WorkflowExecutionTrigger is the trigger event that started an execution.
+type WorkflowExecutionTrigger struct {
+	TriggerID   string           `json:"triggerID"`
+	EventID     string           `json:"eventID"`
+	TriggerType string           `json:"triggerType"`
+	Payload     *WorkflowPayload `json:"payload"`
+	CreatedAt   *time.Time       `json:"createdAt"`
+}
+
+// WorkflowCapabilityCall is a capability call made by an execution.
+type WorkflowCapabilityCall struct {
+	CallbackID   int32            `json:"callbackID"`
+	CapabilityID string           `json:"capabilityID"`
+	Method       string           `json:"method"`
+	Status       string           `json:"status"`
+	Error        string           `json:"error,omitempty"`
+	Request      *WorkflowPayload `json:"request"`
+	Response     *WorkflowPayload `json:"response"`
+	CreatedAt    *time.Time       `json:"createdAt"`
+	UpdatedAt    *time.Time       `json:"updatedAt"`
+}
+
+// WorkflowPayload describes a payload of an execution. Value is omitted when the payload is
+// redacted.
+type WorkflowPayload struct {
+	TypeURL  string `json:"typeURL"`
+	Size     int    `json:"size"`
+	SHA256   string `json:"sha256"`
+	Value    []byte `json:"value,omitempty"`
+	Redacted bool   `json:"redacted"`
+}
+
+// WorkflowExecutionSpend is the spend of a node for a step of an execution, from its metering
+// report.
+type WorkflowExecutionSpend struct {
+	Step   string `json:"step"`
+	PeerID string `json:"peerID"`
+	Unit   string `json:"unit"`
+	Value  string `json:"value"`
+}
+
+// WorkflowUserLog is a log line emitted by the workflow during an execution.
+type WorkflowUserLog struct {
+	Timestamp string `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r WorkflowExecutionResource) GetName() string {
+	return "workflowExecutions"
+}
+
+// NewWorkflowExecutionResource constructs a new WorkflowExecutionResource, with the contents of
+// the payloads removed if redact is true.
+func NewWorkflowExecutionResource(execution store.WorkflowExecution, redact bool) *WorkflowExecutionResource {
+	r := &WorkflowExecutionResource{
+		JAID:       NewJAID(execution.ExecutionID),
+		WorkflowID: execution.WorkflowID,
+		Status:     execution.Status,
+		CreatedAt:  execution.CreatedAt,
+		UpdatedAt:  execution.UpdatedAt,
+		FinishedAt: execution.FinishedAt,
+	}
+	if len(execution.Steps) == 0 {
+		return r
+	}
+
+	details, err := v2.DecodeExecution(execution)
+	if err != nil {
+		r.DecodeError = err.Error()
+		return r
+	}
+	if t := details.Trigger; t != nil {
+		r.Trigger = &WorkflowExecutionTrigger{
+			TriggerID:   t.TriggerID,
+			EventID:     t.EventID,
+			TriggerType: t.TriggerType,
+			Payload:     NewWorkflowPayload(t.Payload, redact),
+			CreatedAt:   t.CreatedAt,
+		}
+	}
+	for _, call := range details.CapabilityCalls {
+		c := WorkflowCapabilityCall{
+			CallbackID:   call.CallbackID,
+			CapabilityID: call.CapabilityID,
+			Method:       call.Method,
+			Status:       call.Status,
+			Request:      NewWorkflowPayload(call.Request, redact),
+			Response:     NewWorkflowPayload(call.Response, redact),
+			CreatedAt:    call.CreatedAt,
+			UpdatedAt:    call.UpdatedAt,
+		}
+		if call.Error != nil {
+			c.Error = call.Error.Error()
+		}
+		r.CapabilityCalls = append(r.CapabilityCalls, c)
+	}
+	if details.MeteringReport != nil {
+		for step, s := range details.MeteringReport.Steps {
+			for _, n := range s.Nodes {
+				r.Spend = append(r.Spend, WorkflowExecutionSpend{
+					Step:   step,
+					PeerID: n.Peer_2PeerId,
+					Unit:   n.SpendUnit,
+					Value:  n.SpendValue,
+				})
+			}
+		}
+		sort.SliceStable(r.Spend, func(i, j int) bool {
+			return r.Spend[i].Step < r.Spend[j].Step
+		})
+	}
+	for _, line := range details.UserLogs {
+		r.UserLogs = append(r.UserLogs, WorkflowUserLog{Timestamp: line.Timestamp, Message: line.Message})
+	}
+	return r
+}
+
+// NewWorkflowExecutionResources initializes a slice of JSONAPI workflow execution resources
+func NewWorkflowExecutionResources(executions []store.WorkflowExecution, redact bool) []WorkflowExecutionResource {
+	rs := []WorkflowExecutionResource{}
+	for _, e := range executions {
+		rs = append(rs, *NewWorkflowExecutionResource(e, redact))
+	}
+	return rs
+}
+
+// NewWorkflowPayload describes the payload, and includes its contents unless redact is true.
+func NewWorkflowPayload(payload *anypb.Any, redact bool) *WorkflowPayload {
+	if payload == nil {
+		return nil
+	}
+	h := sha256.Sum256(payload.Value)
+	p := &WorkflowPayload{
+		TypeURL:  payload.TypeUrl,
+		Size:     len(payload.Value),
+		SHA256:   hex.EncodeToString(h[:]),
+		Redacted: redact,
+	}
+	if !redact {
+		p.Value = payload.Value
+	}
+	return p
+}
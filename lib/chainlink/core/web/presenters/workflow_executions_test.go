@@ -0,0 +1,65 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+)
+
+func TestWorkflowExecutionResource(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := NewWorkflowExecutionResource(store.WorkflowExecution{
+		ExecutionID: "exec-1",
+		WorkflowID:  "w1",
+		Status:      store.StatusCanceled,
+		CreatedAt:   &timestamp,
+		UpdatedAt:   &timestamp,
+		FinishedAt:  &timestamp,
+	}, true)
+
+	b, err := jsonapi.Marshal(r)
+	require.NoError(t, err)
+
+	expected := `
+{
+	"data": {
+		"type":"workflowExecutions",
+		"id":"exec-1",
+		"attributes":{
+			"workflowID":"w1",
+			"status":"canceled",
+			"createdAt":"2000-01-01T00:00:00Z",
+			"updatedAt":"2000-01-01T00:00:00Z",
+			"finishedAt":"2000-01-01T00:00:00Z"
+		}
+	}
+}`
+	assert.JSONEq(t, expected, string(b))
+}
+
+func TestNewWorkflowPayload(t *testing.T) {
+	t.Parallel()
+
+	payload := &anypb.Any{TypeUrl: "type.googleapis.com/test", Value: []byte("secret")}
+
+	redacted := NewWorkflowPayload(payload, true)
+	assert.Equal(t, "type.googleapis.com/test", redacted.TypeURL)
+	assert.Equal(t, 6, redacted.Size)
+	assert.Equal(t, "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", redacted.SHA256)
+	assert.True(t, redacted.Redacted)
+	assert.Nil(t, redacted.Value)
+
+	shown := NewWorkflowPayload(payload, false)
+	assert.False(t, shown.Redacted)
+	assert.Equal(t, []byte("secret"), shown.Value)
+
+	assert.Nil(t, NewWorkflowPayload(nil, false))
+}
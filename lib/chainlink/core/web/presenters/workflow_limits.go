@@ -0,0 +1,82 @@
+package presenters
+
+import (
+	"sort"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/ratelimiter"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/syncerlimiter"
+)
+
+// WorkflowLimitsResource is the current usage of the workflow limits of an owner.
+type WorkflowLimitsResource struct {
+	JAID
+	// Executions is nil when the owner has not started executions recently.
+	Executions *WorkflowExecutionLimits `json:"executions"`
+	// Workflows is nil when the owner has no running workflows.
+	Workflows *WorkflowCountLimits `json:"workflows"`
+}
+
+// WorkflowExecutionLimits is the usage of the execution rate limit and quotas of an owner.
+type WorkflowExecutionLimits struct {
+	RPS               float64   `json:"rps"`
+	Burst             int       `json:"burst"`
+	Tokens            float64   `json:"tokens"`
+	LastSeen          time.Time `json:"lastSeen"`
+	DailyExecutions   int64     `json:"dailyExecutions"`
+	DailyQuota        int64     `json:"dailyQuota"`
+	MonthlyExecutions int64     `json:"monthlyExecutions"`
+	MonthlyQuota      int64     `json:"monthlyQuota"`
+}
+
+// WorkflowCountLimits is the number of running workflows of an owner, and its limit.
+type WorkflowCountLimits struct {
+	Running int32 `json:"running"`
+	Limit   int32 `json:"limit"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r WorkflowLimitsResource) GetName() string {
+	return "workflowLimits"
+}
+
+// NewWorkflowLimitsResources merges the usage of the execution rate limiter and of the
+// running workflows limiter by owner.
+func NewWorkflowLimitsResources(senders []ratelimiter.SenderUsage, owners []syncerlimiter.OwnerUsage) []WorkflowLimitsResource {
+	byOwner := map[string]*WorkflowLimitsResource{}
+	var rs []*WorkflowLimitsResource
+	get := func(owner string) *WorkflowLimitsResource {
+		r, ok := byOwner[owner]
+		if !ok {
+			r = &WorkflowLimitsResource{JAID: NewJAID(owner)}
+			byOwner[owner] = r
+			rs = append(rs, r)
+		}
+		return r
+	}
+	for _, s := range senders {
+		get(s.Sender).Executions = &WorkflowExecutionLimits{
+			RPS:               s.RPS,
+			Burst:             s.Burst,
+			Tokens:            s.Tokens,
+			LastSeen:          s.LastSeen,
+			DailyExecutions:   s.DailyExecutions,
+			DailyQuota:        s.DailyQuota,
+			MonthlyExecutions: s.MonthlyExecutions,
+			MonthlyQuota:      s.MonthlyQuota,
+		}
+	}
+	for _, o := range owners {
+		get(o.Owner).Workflows = &WorkflowCountLimits{
+			Running: o.Workflows,
+			Limit:   o.Limit,
+		}
+	}
+
+	resources := make([]WorkflowLimitsResource, 0, len(rs))
+	for _, r := range rs {
+		resources = append(resources, *r)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+	return resources
+}
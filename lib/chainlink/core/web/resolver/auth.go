@@ -2,8 +2,11 @@ package resolver
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
 )
@@ -16,18 +19,6 @@ func authenticateUser(ctx context.Context) error {
 	return nil
 }
 
-// Authenticates the user from the session cookie and asserts at least 'run' role.
-func authenticateUserCanRun(ctx context.Context) error {
-	session, ok := auth.GetGQLAuthenticatedSession(ctx)
-	if !ok {
-		return unauthorizedError{}
-	}
-	if session.User.Role == sessions.UserRoleView {
-		return RoleNotPermittedErr{session.User.Role}
-	}
-	return nil
-}
-
 // Authenticates the user from the session cookie and asserts at least 'edit' role.
 func authenticateUserCanEdit(ctx context.Context) error {
 	session, ok := auth.GetGQLAuthenticatedSession(ctx)
@@ -54,6 +45,27 @@ func authenticateUserIsAdmin(ctx context.Context) error {
 	return nil
 }
 
+// Authenticates the user from the session cookie and asserts the built-in role or a custom role
+// grant of the user allows the permission on the job or its type.
+func authenticateUserHasJobPermission(ctx context.Context, app chainlink.Application, perm sessions.Permission, jobID int32) error {
+	session, ok := auth.GetGQLAuthenticatedSession(ctx)
+	if !ok {
+		return unauthorizedError{}
+	}
+	if sessions.RoleAllows(session.User.Role, perm) {
+		return nil
+	}
+	// unknown jobs are checked against the whole node, the resolver then returns not found
+	resources := []sessions.Scope{sessions.NodeScope()}
+	j, err := app.JobORM().FindJobWithoutSpecErrors(ctx, jobID)
+	if err == nil {
+		resources = []sessions.Scope{sessions.JobScope(j.ID), sessions.JobTypeScope(j.Type.String())}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return authenticateUserHasPermission(ctx, app, perm, resources...)
+}
+
 type unauthorizedError struct{}
 
 func (e unauthorizedError) Error() string {
@@ -73,3 +85,24 @@ type RoleNotPermittedErr struct {
 func (e RoleNotPermittedErr) Error() string {
 	return fmt.Sprintf("Not permitted with current role: %s", e.Role)
 }
+
+// Authenticates the user from the session cookie and asserts the built-in role or a custom role
+// grant of the user allows the permission on one of the resources, or on any resource if none
+// are given. The grants are only loaded when the built-in role is not enough.
+func authenticateUserHasPermission(ctx context.Context, app chainlink.Application, perm sessions.Permission, resources ...sessions.Scope) error {
+	session, ok := auth.GetGQLAuthenticatedSession(ctx)
+	if !ok {
+		return unauthorizedError{}
+	}
+	if sessions.RoleAllows(session.User.Role, perm) {
+		return nil
+	}
+	allowed, err := app.RolesORM().HasPermission(ctx, session.User, perm, resources...)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return RoleNotPermittedErr{session.User.Role}
+	}
+	return nil
+}
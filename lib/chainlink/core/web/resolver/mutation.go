@@ -43,6 +43,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 	"github.com/smartcontractkit/chainlink/v2/core/utils/crypto"
@@ -63,7 +64,7 @@ type createBridgeInput struct {
 
 // CreateBridge creates a new bridge.
 func (r *Resolver) CreateBridge(ctx context.Context, args struct{ Input createBridgeInput }) (*CreateBridgePayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserHasPermission(ctx, r.App, sessions.PermissionBridgesCreate, sessions.NodeScope()); err != nil {
 		return nil, err
 	}
 
@@ -458,7 +459,7 @@ func (r *Resolver) UpdateBridge(ctx context.Context, args struct {
 	ID    graphql.ID
 	Input updateBridgeInput
 }) (*UpdateBridgePayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserHasPermission(ctx, r.App, sessions.PermissionBridgesUpdate, sessions.NodeScope()); err != nil {
 		return nil, err
 	}
 
@@ -497,6 +498,9 @@ func (r *Resolver) UpdateBridge(ctx context.Context, args struct {
 		return nil, err
 	}
 
+	// The pool config can't be set through GraphQL, so keep the bridge's
+	btr.PoolConfig = bridge.PoolConfig
+
 	// Update the bridge
 	if err := ValidateBridgeType(btr); err != nil {
 		return nil, err
@@ -654,7 +658,7 @@ func (r *Resolver) DeleteOCRKeyBundle(ctx context.Context, args struct {
 func (r *Resolver) DeleteBridge(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DeleteBridgePayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserHasPermission(ctx, r.App, sessions.PermissionBridgesDelete, sessions.NodeScope()); err != nil {
 		return nil, err
 	}
 
@@ -857,6 +861,33 @@ func (r *Resolver) CancelJobProposalSpec(ctx context.Context, args struct {
 	return NewCancelJobProposalSpecPayload(spec, err), nil
 }
 
+// CancelWorkflowExecution stops a workflow execution that has not finished.
+func (r *Resolver) CancelWorkflowExecution(ctx context.Context, args struct {
+	ID graphql.ID
+}) (*CancelWorkflowExecutionPayloadResolver, error) {
+	if err := authenticateUserCanEdit(ctx); err != nil {
+		return nil, err
+	}
+
+	id := string(args.ID)
+	if err := r.App.CancelWorkflowExecution(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, chainlink.ErrWorkflowExecutionFinished) {
+			return NewCancelWorkflowExecutionPayload(nil, true, err), nil
+		}
+
+		return nil, err
+	}
+
+	r.App.GetAuditLogger().Audit(audit.WorkflowExecutionCanceled, map[string]interface{}{"executionID": id})
+
+	execution, err := r.App.GetWorkflowExecutionsStore().Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCancelWorkflowExecutionPayload(&execution, !canViewWorkflowPayloads(ctx), nil), nil
+}
+
 // RejectJobProposalSpec rejects the job proposal spec.
 func (r *Resolver) RejectJobProposalSpec(ctx context.Context, args struct {
 	ID graphql.ID
@@ -1062,7 +1093,7 @@ func (r *Resolver) CreateJob(ctx context.Context, args struct {
 		TOML string
 	}
 }) (*CreateJobPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserHasPermission(ctx, r.App, sessions.PermissionJobsCreate); err != nil {
 		return nil, err
 	}
 
@@ -1122,6 +1153,9 @@ func (r *Resolver) CreateJob(ctx context.Context, args struct {
 	if err != nil {
 		return nil, err
 	}
+	if err = authenticateUserHasPermission(ctx, r.App, sessions.PermissionJobsCreate, sessions.JobTypeScope(jb.Type.String())); err != nil {
+		return nil, err
+	}
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -1140,7 +1174,7 @@ func (r *Resolver) CreateJob(ctx context.Context, args struct {
 func (r *Resolver) DeleteJob(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*DeleteJobPayloadResolver, error) {
-	if err := authenticateUserCanEdit(ctx); err != nil {
+	if err := authenticateUserHasPermission(ctx, r.App, sessions.PermissionJobsDelete); err != nil {
 		return nil, err
 	}
 
@@ -1157,6 +1191,9 @@ func (r *Resolver) DeleteJob(ctx context.Context, args struct {
 
 		return nil, err
 	}
+	if err = authenticateUserHasJobPermission(ctx, r.App, sessions.PermissionJobsDelete, j.ID); err != nil {
+		return nil, err
+	}
 
 	err = r.App.DeleteJob(ctx, id)
 	if err != nil {
@@ -1208,7 +1245,7 @@ func (r *Resolver) DismissJobError(ctx context.Context, args struct {
 func (r *Resolver) RunJob(ctx context.Context, args struct {
 	ID graphql.ID
 }) (*RunJobPayloadResolver, error) {
-	if err := authenticateUserCanRun(ctx); err != nil {
+	if err := authenticateUserHasPermission(ctx, r.App, sessions.PermissionJobsRun); err != nil {
 		return nil, err
 	}
 
@@ -1216,6 +1253,9 @@ func (r *Resolver) RunJob(ctx context.Context, args struct {
 	if err != nil {
 		return nil, err
 	}
+	if err = authenticateUserHasJobPermission(ctx, r.App, sessions.PermissionJobsRun, jobID); err != nil {
+		return nil, err
+	}
 
 	jobRunID, err := r.App.RunJobV2(ctx, jobID, nil)
 	if err != nil {
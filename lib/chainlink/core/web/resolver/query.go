@@ -19,6 +19,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
 	evmrelay "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
 	"github.com/smartcontractkit/chainlink/v2/core/utils/stringutils"
 	"github.com/smartcontractkit/chainlink/v2/core/web/loader"
 )
@@ -677,3 +678,51 @@ func (r *Resolver) OCR2KeyBundles(ctx context.Context) (*OCR2KeyBundlesPayloadRe
 
 	return NewOCR2KeyBundlesPayload(ekbs), nil
 }
+
+// WorkflowExecution retrieves a workflow execution with its steps. The contents of its payloads
+// are only shown to admins.
+func (r *Resolver) WorkflowExecution(ctx context.Context, args struct {
+	ID graphql.ID
+}) (*WorkflowExecutionPayloadResolver, error) {
+	if err := authenticateUser(ctx); err != nil {
+		return nil, err
+	}
+
+	execution, err := r.App.GetWorkflowExecutionsStore().Get(ctx, string(args.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return NewWorkflowExecutionPayload(nil, true, err), nil
+		}
+
+		return nil, err
+	}
+
+	return NewWorkflowExecutionPayload(&execution, !canViewWorkflowPayloads(ctx), nil), nil
+}
+
+// WorkflowExecutions retrieves a paginated list of workflow executions, most recent first.
+func (r *Resolver) WorkflowExecutions(ctx context.Context, args struct {
+	Offset     *int32
+	Limit      *int32
+	WorkflowID *string
+	Status     *string
+}) (*WorkflowExecutionsPayloadResolver, error) {
+	if err := authenticateUser(ctx); err != nil {
+		return nil, err
+	}
+
+	filter := store.ExecutionsFilter{}
+	if args.WorkflowID != nil {
+		filter.WorkflowID = *args.WorkflowID
+	}
+	if args.Status != nil {
+		filter.Status = *args.Status
+	}
+
+	executions, count, err := r.App.GetWorkflowExecutionsStore().List(ctx, filter, pageOffset(args.Offset), pageLimit(args.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWorkflowExecutionsPayload(executions, int32(count)), nil
+}
@@ -0,0 +1,384 @@
+package resolver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/graph-gophers/graphql-go"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/workflows/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
+)
+
+// canViewWorkflowPayloads is the redaction policy of the payloads of workflow executions, which
+// may contain sensitive data: only admins can see their contents.
+func canViewWorkflowPayloads(ctx context.Context) bool {
+	session, ok := auth.GetGQLAuthenticatedSession(ctx)
+	return ok && session.User.Role == sessions.UserRoleAdmin
+}
+
+// WorkflowExecutionResolver resolves the WorkflowExecution type.
+type WorkflowExecutionResolver struct {
+	execution store.WorkflowExecution
+	details   v2.ExecutionDetails
+	err       error
+	redact    bool
+}
+
+func NewWorkflowExecution(execution store.WorkflowExecution, redact bool) *WorkflowExecutionResolver {
+	r := &WorkflowExecutionResolver{execution: execution, redact: redact}
+	if len(execution.Steps) > 0 {
+		r.details, r.err = v2.DecodeExecution(execution)
+	}
+	return r
+}
+
+func NewWorkflowExecutions(executions []store.WorkflowExecution, redact bool) []*WorkflowExecutionResolver {
+	var resolvers []*WorkflowExecutionResolver
+	for _, e := range executions {
+		resolvers = append(resolvers, NewWorkflowExecution(e, redact))
+	}
+	return resolvers
+}
+
+func (r *WorkflowExecutionResolver) ID() graphql.ID {
+	return graphql.ID(r.execution.ExecutionID)
+}
+
+func (r *WorkflowExecutionResolver) WorkflowID() string {
+	return r.execution.WorkflowID
+}
+
+func (r *WorkflowExecutionResolver) Status() string {
+	return r.execution.Status
+}
+
+func (r *WorkflowExecutionResolver) CreatedAt() *graphql.Time {
+	return optionalTime(r.execution.CreatedAt)
+}
+
+func (r *WorkflowExecutionResolver) UpdatedAt() *graphql.Time {
+	return optionalTime(r.execution.UpdatedAt)
+}
+
+func (r *WorkflowExecutionResolver) FinishedAt() *graphql.Time {
+	return optionalTime(r.execution.FinishedAt)
+}
+
+// Trigger resolves the trigger event that started the execution.
+func (r *WorkflowExecutionResolver) Trigger() (*WorkflowExecutionTriggerResolver, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.details.Trigger == nil {
+		return nil, nil
+	}
+	return &WorkflowExecutionTriggerResolver{trigger: *r.details.Trigger, redact: r.redact}, nil
+}
+
+// CapabilityCalls resolves the capability calls made by the execution.
+func (r *WorkflowExecutionResolver) CapabilityCalls() ([]*WorkflowCapabilityCallResolver, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	calls := []*WorkflowCapabilityCallResolver{}
+	for _, call := range r.details.CapabilityCalls {
+		calls = append(calls, &WorkflowCapabilityCallResolver{call: call, redact: r.redact})
+	}
+	return calls, nil
+}
+
+// Spend resolves the spend of each node for each step, from the metering report.
+func (r *WorkflowExecutionResolver) Spend() ([]*WorkflowExecutionSpendResolver, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	spend := []*WorkflowExecutionSpendResolver{}
+	if r.details.MeteringReport == nil {
+		return spend, nil
+	}
+	for step, s := range r.details.MeteringReport.Steps {
+		for _, n := range s.Nodes {
+			spend = append(spend, &WorkflowExecutionSpendResolver{
+				step:   step,
+				peerID: n.Peer_2PeerId,
+				unit:   n.SpendUnit,
+				value:  n.SpendValue,
+			})
+		}
+	}
+	sort.SliceStable(spend, func(i, j int) bool {
+		return spend[i].step < spend[j].step
+	})
+	return spend, nil
+}
+
+// UserLogs resolves the log lines emitted by the workflow.
+func (r *WorkflowExecutionResolver) UserLogs() ([]*WorkflowUserLogResolver, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	logs := []*WorkflowUserLogResolver{}
+	for _, line := range r.details.UserLogs {
+		logs = append(logs, &WorkflowUserLogResolver{line: line})
+	}
+	return logs, nil
+}
+
+// WorkflowExecutionTriggerResolver resolves the WorkflowExecutionTrigger type.
+type WorkflowExecutionTriggerResolver struct {
+	trigger v2.TriggerDetails
+	redact  bool
+}
+
+func (r *WorkflowExecutionTriggerResolver) TriggerID() string {
+	return r.trigger.TriggerID
+}
+
+func (r *WorkflowExecutionTriggerResolver) EventID() string {
+	return r.trigger.EventID
+}
+
+func (r *WorkflowExecutionTriggerResolver) TriggerType() string {
+	return r.trigger.TriggerType
+}
+
+func (r *WorkflowExecutionTriggerResolver) Payload() *WorkflowPayloadResolver {
+	return NewWorkflowPayload(r.trigger.Payload, r.redact)
+}
+
+func (r *WorkflowExecutionTriggerResolver) CreatedAt() *graphql.Time {
+	return optionalTime(r.trigger.CreatedAt)
+}
+
+// WorkflowCapabilityCallResolver resolves the WorkflowCapabilityCall type.
+type WorkflowCapabilityCallResolver struct {
+	call   v2.CapabilityCallDetails
+	redact bool
+}
+
+func (r *WorkflowCapabilityCallResolver) CallbackID() int32 {
+	return r.call.CallbackID
+}
+
+func (r *WorkflowCapabilityCallResolver) CapabilityID() string {
+	return r.call.CapabilityID
+}
+
+func (r *WorkflowCapabilityCallResolver) Method() string {
+	return r.call.Method
+}
+
+func (r *WorkflowCapabilityCallResolver) Status() string {
+	return r.call.Status
+}
+
+func (r *WorkflowCapabilityCallResolver) Error() *string {
+	if r.call.Error == nil {
+		return nil
+	}
+	msg := r.call.Error.Error()
+	return &msg
+}
+
+func (r *WorkflowCapabilityCallResolver) Request() *WorkflowPayloadResolver {
+	return NewWorkflowPayload(r.call.Request, r.redact)
+}
+
+func (r *WorkflowCapabilityCallResolver) Response() *WorkflowPayloadResolver {
+	return NewWorkflowPayload(r.call.Response, r.redact)
+}
+
+func (r *WorkflowCapabilityCallResolver) CreatedAt() *graphql.Time {
+	return optionalTime(r.call.CreatedAt)
+}
+
+func (r *WorkflowCapabilityCallResolver) UpdatedAt() *graphql.Time {
+	return optionalTime(r.call.UpdatedAt)
+}
+
+// WorkflowPayloadResolver resolves the WorkflowPayload type.
+type WorkflowPayloadResolver struct {
+	payload *anypb.Any
+	redact  bool
+}
+
+// NewWorkflowPayload returns nil if there is no payload.
+func NewWorkflowPayload(payload *anypb.Any, redact bool) *WorkflowPayloadResolver {
+	if payload == nil {
+		return nil
+	}
+	return &WorkflowPayloadResolver{payload: payload, redact: redact}
+}
+
+func (r *WorkflowPayloadResolver) TypeURL() string {
+	return r.payload.TypeUrl
+}
+
+func (r *WorkflowPayloadResolver) Size() int32 {
+	return int32(len(r.payload.Value))
+}
+
+func (r *WorkflowPayloadResolver) Sha256() string {
+	h := sha256.Sum256(r.payload.Value)
+	return hex.EncodeToString(h[:])
+}
+
+func (r *WorkflowPayloadResolver) Value() *hexutil.Bytes {
+	if r.redact {
+		return nil
+	}
+	b := hexutil.Bytes(r.payload.Value)
+	return &b
+}
+
+func (r *WorkflowPayloadResolver) Redacted() bool {
+	return r.redact
+}
+
+// WorkflowExecutionSpendResolver resolves the WorkflowExecutionSpend type.
+type WorkflowExecutionSpendResolver struct {
+	step   string
+	peerID string
+	unit   string
+	value  string
+}
+
+func (r *WorkflowExecutionSpendResolver) Step() string {
+	return r.step
+}
+
+func (r *WorkflowExecutionSpendResolver) PeerID() string {
+	return r.peerID
+}
+
+func (r *WorkflowExecutionSpendResolver) Unit() string {
+	return r.unit
+}
+
+func (r *WorkflowExecutionSpendResolver) Value() string {
+	return r.value
+}
+
+// WorkflowUserLogResolver resolves the WorkflowUserLog type.
+type WorkflowUserLogResolver struct {
+	line v2.UserLogLine
+}
+
+func (r *WorkflowUserLogResolver) Timestamp() string {
+	return r.line.Timestamp
+}
+
+func (r *WorkflowUserLogResolver) Message() string {
+	return r.line.Message
+}
+
+func optionalTime(t *time.Time) *graphql.Time {
+	if t == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *t}
+}
+
+// -- WorkflowExecution Query --
+
+type WorkflowExecutionPayloadResolver struct {
+	execution *store.WorkflowExecution
+	redact    bool
+	NotFoundErrorUnionType
+}
+
+func NewWorkflowExecutionPayload(execution *store.WorkflowExecution, redact bool, err error) *WorkflowExecutionPayloadResolver {
+	e := NotFoundErrorUnionType{err: err, message: "workflow execution not found"}
+
+	return &WorkflowExecutionPayloadResolver{execution: execution, redact: redact, NotFoundErrorUnionType: e}
+}
+
+// ToWorkflowExecution implements the WorkflowExecution union type of the payload
+func (r *WorkflowExecutionPayloadResolver) ToWorkflowExecution() (*WorkflowExecutionResolver, bool) {
+	if r.err != nil {
+		return nil, false
+	}
+
+	return NewWorkflowExecution(*r.execution, r.redact), true
+}
+
+// -- WorkflowExecutions Query --
+
+// WorkflowExecutionsPayloadResolver resolves a page of workflow executions
+type WorkflowExecutionsPayloadResolver struct {
+	executions []store.WorkflowExecution
+	total      int32
+}
+
+func NewWorkflowExecutionsPayload(executions []store.WorkflowExecution, total int32) *WorkflowExecutionsPayloadResolver {
+	return &WorkflowExecutionsPayloadResolver{executions: executions, total: total}
+}
+
+// Results returns the workflow executions, without their steps.
+func (r *WorkflowExecutionsPayloadResolver) Results() []*WorkflowExecutionResolver {
+	return NewWorkflowExecutions(r.executions, true)
+}
+
+// Metadata returns the pagination metadata.
+func (r *WorkflowExecutionsPayloadResolver) Metadata() *PaginationMetadataResolver {
+	return NewPaginationMetadata(r.total)
+}
+
+// -- CancelWorkflowExecution Mutation --
+
+type CancelWorkflowExecutionPayloadResolver struct {
+	execution *store.WorkflowExecution
+	redact    bool
+	NotFoundErrorUnionType
+}
+
+func NewCancelWorkflowExecutionPayload(execution *store.WorkflowExecution, redact bool, err error) *CancelWorkflowExecutionPayloadResolver {
+	e := NotFoundErrorUnionType{err: err, message: "workflow execution not found"}
+
+	return &CancelWorkflowExecutionPayloadResolver{execution: execution, redact: redact, NotFoundErrorUnionType: e}
+}
+
+func (r *CancelWorkflowExecutionPayloadResolver) ToCancelWorkflowExecutionSuccess() (*CancelWorkflowExecutionSuccessResolver, bool) {
+	if r.execution == nil {
+		return nil, false
+	}
+
+	return &CancelWorkflowExecutionSuccessResolver{execution: *r.execution, redact: r.redact}, true
+}
+
+func (r *CancelWorkflowExecutionPayloadResolver) ToWorkflowExecutionFinishedError() (*WorkflowExecutionFinishedErrorResolver, bool) {
+	if r.err == nil || isNotFoundError(r.err) {
+		return nil, false
+	}
+
+	return &WorkflowExecutionFinishedErrorResolver{message: r.err.Error()}, true
+}
+
+type CancelWorkflowExecutionSuccessResolver struct {
+	execution store.WorkflowExecution
+	redact    bool
+}
+
+func (r *CancelWorkflowExecutionSuccessResolver) WorkflowExecution() *WorkflowExecutionResolver {
+	return NewWorkflowExecution(r.execution, r.redact)
+}
+
+type WorkflowExecutionFinishedErrorResolver struct {
+	message string
+}
+
+func (r *WorkflowExecutionFinishedErrorResolver) Message() string {
+	return r.message
+}
+
+func (r *WorkflowExecutionFinishedErrorResolver) Code() ErrorCode {
+	return ErrorCodeUnprocessable
+}
@@ -0,0 +1,350 @@
+package resolver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/artifacts"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	clsessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/web/loader"
+)
+
+// newTestWorkflowExecutionsStore returns a store with the started execution "e1", triggered by
+// an event with the payload "secret", and the more recent completed execution "e2".
+func newTestWorkflowExecutionsStore(t *testing.T, now time.Time) *store.DBStore {
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	lggr := logger.TestLogger(t)
+	_, err := artifacts.NewWorkflowRegistryDS(db, lggr).UpsertWorkflowSpec(ctx, &job.WorkflowSpec{
+		Workflow:      "workflow",
+		WorkflowID:    "w1",
+		WorkflowOwner: "owner",
+		WorkflowName:  "name",
+		Status:        job.WorkflowSpecStatusActive,
+		CreatedAt:     now,
+		SpecType:      job.WASMFile,
+	})
+	require.NoError(t, err)
+
+	inputs, err := values.NewMap(map[string]any{
+		"TriggerID":    "trigger@1.0.0",
+		"TriggerIndex": int64(0),
+		"EventID":      "event-1",
+		"TriggerType":  "basic-trigger@1.0.0",
+	})
+	require.NoError(t, err)
+	payload, err := proto.Marshal(&anypb.Any{TypeUrl: "type.googleapis.com/test", Value: []byte("secret")})
+	require.NoError(t, err)
+
+	clock := clockwork.NewFakeClockAt(now)
+	s := store.NewDBStore(db, lggr, clock)
+	_, err = s.Add(ctx, map[string]*store.WorkflowExecutionStep{
+		"trigger": {Ref: "trigger", Status: store.StatusCompleted, Inputs: inputs, Outputs: store.StepOutput{Value: values.NewBytes(payload)}},
+	}, "e1", "w1", store.StatusStarted)
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+	_, err = s.Add(ctx, nil, "e2", "w1", store.StatusStarted)
+	require.NoError(t, err)
+	_, err = s.FinishExecution(ctx, "e2", store.StatusCompleted)
+	require.NoError(t, err)
+	return s
+}
+
+func TestResolver_WorkflowExecutions(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		query GetWorkflowExecutions($status: String) {
+			workflowExecutions(status: $status) {
+				results {
+					id
+					workflowID
+					status
+					trigger {
+						eventID
+					}
+				}
+				metadata {
+					total
+				}
+			}
+		}`
+
+	testCases := []GQLTestCase{
+		unauthorizedTestCase(GQLTestCase{query: query}, "workflowExecutions"),
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				f.App.On("GetWorkflowExecutionsStore").Return(newTestWorkflowExecutionsStore(f.t, f.Timestamp()))
+			},
+			query: query,
+			result: `
+				{
+					"workflowExecutions": {
+						"results": [{
+							"id": "e2",
+							"workflowID": "w1",
+							"status": "completed",
+							"trigger": null
+						}, {
+							"id": "e1",
+							"workflowID": "w1",
+							"status": "started",
+							"trigger": null
+						}],
+						"metadata": {
+							"total": 2
+						}
+					}
+				}`,
+		},
+		{
+			name:          "filtered by status",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				f.App.On("GetWorkflowExecutionsStore").Return(newTestWorkflowExecutionsStore(f.t, f.Timestamp()))
+			},
+			query:     query,
+			variables: map[string]interface{}{"status": "started"},
+			result: `
+				{
+					"workflowExecutions": {
+						"results": [{
+							"id": "e1",
+							"workflowID": "w1",
+							"status": "started",
+							"trigger": null
+						}],
+						"metadata": {
+							"total": 1
+						}
+					}
+				}`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
+
+const workflowExecutionQuery = `
+	query GetWorkflowExecution($id: ID!) {
+		workflowExecution(id: $id) {
+			... on WorkflowExecution {
+				id
+				status
+				trigger {
+					triggerID
+					eventID
+					payload {
+						typeURL
+						size
+						sha256
+						value
+						redacted
+					}
+				}
+			}
+			... on NotFoundError {
+				code
+				message
+			}
+		}
+	}`
+
+func TestResolver_WorkflowExecution(t *testing.T) {
+	t.Parallel()
+
+	testCases := []GQLTestCase{
+		unauthorizedTestCase(GQLTestCase{query: workflowExecutionQuery, variables: map[string]interface{}{"id": "e1"}}, "workflowExecution"),
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				f.App.On("GetWorkflowExecutionsStore").Return(newTestWorkflowExecutionsStore(f.t, f.Timestamp()))
+			},
+			query:     workflowExecutionQuery,
+			variables: map[string]interface{}{"id": "e1"},
+			result: `
+				{
+					"workflowExecution": {
+						"id": "e1",
+						"status": "started",
+						"trigger": {
+							"triggerID": "trigger@1.0.0",
+							"eventID": "event-1",
+							"payload": {
+								"typeURL": "type.googleapis.com/test",
+								"size": 6,
+								"sha256": "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+								"value": "0x736563726574",
+								"redacted": false
+							}
+						}
+					}
+				}`,
+		},
+		{
+			name:          "not found",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				f.App.On("GetWorkflowExecutionsStore").Return(newTestWorkflowExecutionsStore(f.t, f.Timestamp()))
+			},
+			query:     workflowExecutionQuery,
+			variables: map[string]interface{}{"id": "missing"},
+			result: `
+				{
+					"workflowExecution": {
+						"code": "NOT_FOUND",
+						"message": "workflow execution not found"
+					}
+				}`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
+
+func TestResolver_WorkflowExecution_RedactedForNonAdmins(t *testing.T) {
+	t.Parallel()
+
+	for _, role := range []clsessions.UserRole{clsessions.UserRoleView, clsessions.UserRoleRun, clsessions.UserRoleEdit} {
+		t.Run(string(role), func(t *testing.T) {
+			t.Parallel()
+
+			f := setupFramework(t)
+			f.App.On("GetWorkflowExecutionsStore").Return(newTestWorkflowExecutionsStore(t, f.Timestamp()))
+			ctx := loader.InjectDataloader(testutils.Context(t), f.App)
+			ctx = auth.WithGQLAuthenticatedSession(ctx, clsessions.User{Email: "gqltester@chain.link", Role: role}, "gqltesterSession")
+
+			gqltesting.RunTest(t, &gqltesting.Test{
+				Context:   ctx,
+				Schema:    f.RootSchema,
+				Query:     workflowExecutionQuery,
+				Variables: map[string]interface{}{"id": "e1"},
+				ExpectedResult: `
+					{
+						"workflowExecution": {
+							"id": "e1",
+							"status": "started",
+							"trigger": {
+								"triggerID": "trigger@1.0.0",
+								"eventID": "event-1",
+								"payload": {
+									"typeURL": "type.googleapis.com/test",
+									"size": 6,
+									"sha256": "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+									"value": null,
+									"redacted": true
+								}
+							}
+						}
+					}`,
+			})
+		})
+	}
+}
+
+func TestResolver_CancelWorkflowExecution(t *testing.T) {
+	t.Parallel()
+
+	mutation := `
+		mutation CancelWorkflowExecution($id: ID!) {
+			cancelWorkflowExecution(id: $id) {
+				... on CancelWorkflowExecutionSuccess {
+					workflowExecution {
+						id
+						status
+					}
+				}
+				... on NotFoundError {
+					code
+					message
+				}
+				... on WorkflowExecutionFinishedError {
+					code
+					message
+				}
+			}
+		}`
+	variables := map[string]interface{}{"id": "e1"}
+
+	testCases := []GQLTestCase{
+		unauthorizedTestCase(GQLTestCase{query: mutation, variables: variables}, "cancelWorkflowExecution"),
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				s := newTestWorkflowExecutionsStore(f.t, f.Timestamp())
+				f.App.On("CancelWorkflowExecution", mock.Anything, "e1").Return(nil).Run(func(args mock.Arguments) {
+					_, err := s.FinishExecution(args.Get(0).(context.Context), "e1", store.StatusCanceled)
+					require.NoError(f.t, err)
+				})
+				f.App.On("GetWorkflowExecutionsStore").Return(s)
+			},
+			query:     mutation,
+			variables: variables,
+			result: `
+				{
+					"cancelWorkflowExecution": {
+						"workflowExecution": {
+							"id": "e1",
+							"status": "canceled"
+						}
+					}
+				}`,
+		},
+		{
+			name:          "not found",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				f.App.On("CancelWorkflowExecution", mock.Anything, "e1").Return(sql.ErrNoRows)
+			},
+			query:     mutation,
+			variables: variables,
+			result: `
+				{
+					"cancelWorkflowExecution": {
+						"code": "NOT_FOUND",
+						"message": "workflow execution not found"
+					}
+				}`,
+		},
+		{
+			name:          "already finished",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				f.App.On("CancelWorkflowExecution", mock.Anything, "e1").Return(chainlink.ErrWorkflowExecutionFinished)
+			},
+			query:     mutation,
+			variables: variables,
+			result: `
+				{
+					"cancelWorkflowExecution": {
+						"code": "UNPROCESSABLE",
+						"message": "workflow execution has already finished"
+					}
+				}`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
@@ -0,0 +1,157 @@
+package web
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// RolesController manages the custom roles and their grants to users.
+type RolesController struct {
+	App chainlink.Application
+}
+
+// CreateRoleRequest defines the request to create a custom role, or to replace its permissions.
+type CreateRoleRequest struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
+
+// CreateRoleGrantRequest defines the request to grant a custom role to a user. The scope type is
+// one of node, job, job_type or key, and defaults to node.
+type CreateRoleGrantRequest struct {
+	Email      string `json:"email"`
+	Role       string `json:"role"`
+	ScopeType  string `json:"scopeType"`
+	ScopeValue string `json:"scopeValue"`
+}
+
+// Index lists the custom roles.
+// Example:
+// "GET <application>/roles"
+func (rc *RolesController) Index(c *gin.Context) {
+	roles, err := rc.App.RolesORM().ListRoles(c.Request.Context())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewRoleResources(roles), "roles")
+}
+
+// Create creates a custom role, or replaces the permissions of an existing one.
+// Example:
+// "POST <application>/roles"
+func (rc *RolesController) Create(c *gin.Context) {
+	var request CreateRoleRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	role, err := sessions.NewCustomRole(request.Name, request.Permissions)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	role, err = rc.App.RolesORM().CreateRole(c.Request.Context(), role)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	rc.App.GetAuditLogger().Audit(audit.RoleCreated, map[string]interface{}{"role": role.Name, "permissions": role.Permissions})
+	jsonAPIResponse(c, presenters.NewRoleResource(role), "role")
+}
+
+// Destroy deletes a custom role, which revokes its grants.
+// Example:
+// "DELETE <application>/roles/:Name"
+func (rc *RolesController) Destroy(c *gin.Context) {
+	name := c.Param("Name")
+	err := rc.App.RolesORM().DeleteRole(c.Request.Context(), name)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("role not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	rc.App.GetAuditLogger().Audit(audit.RoleDeleted, map[string]interface{}{"role": name})
+	jsonAPIResponseWithStatus(c, nil, "role", http.StatusNoContent)
+}
+
+// IndexGrants lists the grants of custom roles, optionally of a single user.
+// Example:
+// "GET <application>/roles/grants?email=<email>"
+func (rc *RolesController) IndexGrants(c *gin.Context) {
+	grants, err := rc.App.RolesORM().ListGrants(c.Request.Context(), c.Query("email"))
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewRoleGrantResources(grants), "roleGrants")
+}
+
+// CreateGrant grants a custom role to a user on a scope.
+// Example:
+// "POST <application>/roles/grants"
+func (rc *RolesController) CreateGrant(c *gin.Context) {
+	var request CreateRoleGrantRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	scope, err := sessions.ParseScope(request.ScopeType, request.ScopeValue)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	if verr := sessions.ValidateEmail(request.Email); verr != nil {
+		jsonAPIError(c, http.StatusBadRequest, verr)
+		return
+	}
+	grant, err := rc.App.RolesORM().CreateGrant(c.Request.Context(), request.Email, request.Role, scope)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	rc.App.GetAuditLogger().Audit(audit.RoleGrantCreated, map[string]interface{}{
+		"user":  grant.Email,
+		"role":  grant.RoleName,
+		"scope": grant.Scope.String(),
+	})
+	jsonAPIResponse(c, presenters.NewRoleGrantResource(grant), "roleGrant")
+}
+
+// DestroyGrant revokes a grant of a custom role.
+// Example:
+// "DELETE <application>/roles/grants/:ID"
+func (rc *RolesController) DestroyGrant(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	err = rc.App.RolesORM().DeleteGrant(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("grant not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	rc.App.GetAuditLogger().Audit(audit.RoleGrantDeleted, map[string]interface{}{"id": id})
+	jsonAPIResponseWithStatus(c, nil, "roleGrant", http.StatusNoContent)
+}
@@ -35,9 +35,11 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.opentelemetry.io/otel"
 
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
 	"github.com/smartcontractkit/chainlink/v2/core/build"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	clsessions "github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
 	"github.com/smartcontractkit/chainlink/v2/core/web/loader"
 	"github.com/smartcontractkit/chainlink/v2/core/web/resolver"
@@ -238,15 +240,21 @@ func loopRoutes(app chainlink.Application, r *gin.RouterGroup) {
 func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 	unauthedv2 := r.Group("/v2")
 
-	prc := PipelineRunsController{app}
+	prc := PipelineRunsController{App: app, CallbackGuard: bridges.NewReplayGuard(bridges.DefaultSignatureReplayWindow)}
 	psec := PipelineJobSpecErrorsController{app}
 	unauthedv2.PATCH("/resume/:runID", prc.Resume)
 
+	wtc := WebhookTriggersController{app}
+	unauthedv2.POST("/webhooks/:ID", wtc.Create)
+
 	authv2 := r.Group("/v2", auth.Authenticate(app.AuthenticationProvider(),
+		auth.AuthenticateByAPIToken(app.APITokensORM(), app.GetAuditLogger()),
 		auth.AuthenticateByToken,
 		auth.AuthenticateBySession,
 	))
 	{
+		roles := app.RolesORM()
+
 		uc := UserController{app}
 		authv2.GET("/users", auth.RequiresAdminRole(uc.Index))
 		authv2.POST("/users", auth.RequiresAdminRole(uc.Create))
@@ -255,6 +263,17 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.PATCH("/user/password", uc.UpdatePassword)
 		authv2.POST("/user/token", uc.NewAPIToken)
 		authv2.POST("/user/token/delete", uc.DeleteAPIToken)
+		authv2.GET("/user/tokens", uc.ListAPITokens)
+		authv2.POST("/user/tokens", uc.CreateNamedAPIToken)
+		authv2.DELETE("/user/tokens/:ID", uc.RevokeAPIToken)
+
+		rolec := RolesController{app}
+		authv2.GET("/roles", auth.RequiresAdminRole(rolec.Index))
+		authv2.POST("/roles", auth.RequiresAdminRole(rolec.Create))
+		authv2.DELETE("/roles/:Name", auth.RequiresAdminRole(rolec.Destroy))
+		authv2.GET("/roles/grants", auth.RequiresAdminRole(rolec.IndexGrants))
+		authv2.POST("/roles/grants", auth.RequiresAdminRole(rolec.CreateGrant))
+		authv2.DELETE("/roles/grants/:ID", auth.RequiresAdminRole(rolec.DestroyGrant))
 
 		wa := NewWebAuthnController(app)
 		authv2.GET("/enroll_webauthn", wa.BeginRegistration)
@@ -264,17 +283,35 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/external_initiators", paginatedRequest(eia.Index))
 		authv2.POST("/external_initiators", auth.RequiresEditRole(eia.Create))
 		authv2.DELETE("/external_initiators/:Name", auth.RequiresEditRole(eia.Destroy))
+		authv2.POST("/external_initiators/:Name/resync", auth.RequiresEditRole(eia.Resync))
+
+		wlc := WorkflowLimitsController{app}
+		authv2.GET("/workflows/limits", auth.RequiresAdminRole(wlc.Index))
+
+		wec := WorkflowExecutionsController{app}
+		authv2.GET("/workflows/executions", paginatedRequest(wec.Index))
+		authv2.GET("/workflows/executions/:ID", wec.Show)
+		authv2.POST("/workflows/executions/:ID/cancel", auth.RequiresScopedPermission(roles, clsessions.PermissionJobsUpdate, workflowExecutionScopes(app), wec.Cancel))
+
+		alc := AuditLogController{app}
+		authv2.GET("/audit", auth.RequiresAdminRole(paginatedRequest(alc.Index)))
+		authv2.GET("/audit/verify", auth.RequiresAdminRole(alc.Verify))
+
+		kc := KeystoreController{app}
+		authv2.PATCH("/keystore/password", auth.RequiresAdminRole(kc.RotatePassword))
 
 		bt := BridgeTypesController{app}
 		authv2.GET("/bridge_types", paginatedRequest(bt.Index))
-		authv2.POST("/bridge_types", auth.RequiresEditRole(bt.Create))
+		authv2.POST("/bridge_types", auth.RequiresPermission(roles, clsessions.PermissionBridgesCreate, bt.Create))
 		authv2.GET("/bridge_types/:BridgeName", bt.Show)
-		authv2.PATCH("/bridge_types/:BridgeName", auth.RequiresEditRole(bt.Update))
-		authv2.DELETE("/bridge_types/:BridgeName", auth.RequiresEditRole(bt.Destroy))
+		authv2.PATCH("/bridge_types/:BridgeName", auth.RequiresPermission(roles, clsessions.PermissionBridgesUpdate, bt.Update))
+		authv2.DELETE("/bridge_types/:BridgeName", auth.RequiresPermission(roles, clsessions.PermissionBridgesDelete, bt.Destroy))
+		authv2.POST("/bridge_types/:BridgeName/signing_secret", auth.RequiresPermission(roles, clsessions.PermissionBridgesUpdate, bt.RotateSigningSecret))
+		authv2.DELETE("/bridge_types/:BridgeName/signing_secret", auth.RequiresPermission(roles, clsessions.PermissionBridgesUpdate, bt.DisableSigning))
 
 		ets := EVMTransfersController{app}
-		authv2.POST("/transfers", auth.RequiresAdminRole(ets.Create))
-		authv2.POST("/transfers/evm", auth.RequiresAdminRole(ets.Create))
+		authv2.POST("/transfers", auth.RequiresPermission(roles, clsessions.PermissionTransfersEVM, ets.Create))
+		authv2.POST("/transfers/evm", auth.RequiresPermission(roles, clsessions.PermissionTransfersEVM, ets.Create))
 		tts := CosmosTransfersController{app}
 		authv2.POST("/transfers/cosmos", auth.RequiresAdminRole(tts.Create))
 		sts := SolanaTransfersController{app}
@@ -303,18 +340,19 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/keys/csa", csakc.Index)
 		authv2.POST("/keys/csa", auth.RequiresEditRole(csakc.Create))
 		authv2.POST("/keys/csa/import", auth.RequiresAdminRole(csakc.Import))
-		authv2.POST("/keys/csa/export/:ID", auth.RequiresAdminRole(csakc.Export))
+		authv2.POST("/keys/csa/export/:ID", auth.RequiresScopedPermission(roles, clsessions.PermissionKeysCSAExport, auth.ParamScope(clsessions.ScopeTypeKey, "ID"), csakc.Export))
 
 		ekc := NewETHKeysController(app)
 		authv2.GET("/keys/eth", ekc.Index)
 		authv2.POST("/keys/eth", auth.RequiresEditRole(ekc.Create))
 		authv2.DELETE("/keys/eth/:keyID", auth.RequiresAdminRole(ekc.Delete))
 		authv2.POST("/keys/eth/import", auth.RequiresAdminRole(ekc.Import))
-		authv2.POST("/keys/eth/export/:address", auth.RequiresAdminRole(ekc.Export))
+		authv2.POST("/keys/eth/export/:address", auth.RequiresScopedPermission(roles, clsessions.PermissionKeysETHExport, auth.ParamScope(clsessions.ScopeTypeKey, "address"), ekc.Export))
 		// duplicated from above, with `evm` instead of `eth`
 		// legacy ones remain for backwards compatibility
 
 		ethKeysGroup := authv2.Group("", auth.Authenticate(app.AuthenticationProvider(),
+			auth.AuthenticateByAPIToken(app.APITokensORM(), app.GetAuditLogger()),
 			auth.AuthenticateByToken,
 			auth.AuthenticateBySession,
 		))
@@ -324,7 +362,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		ethKeysGroup.POST("/keys/evm", auth.RequiresEditRole(ekc.Create))
 		ethKeysGroup.DELETE("/keys/evm/:address", auth.RequiresAdminRole(ekc.Delete))
 		ethKeysGroup.POST("/keys/evm/import", auth.RequiresAdminRole(ekc.Import))
-		authv2.POST("/keys/evm/export/:address", auth.RequiresAdminRole(ekc.Export))
+		authv2.POST("/keys/evm/export/:address", auth.RequiresScopedPermission(roles, clsessions.PermissionKeysETHExport, auth.ParamScope(clsessions.ScopeTypeKey, "address"), ekc.Export))
 		ethKeysGroup.POST("/keys/evm/chain", auth.RequiresAdminRole(ekc.Chain))
 
 		ocrkc := OCRKeysController{app}
@@ -332,21 +370,21 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/keys/ocr", auth.RequiresEditRole(ocrkc.Create))
 		authv2.DELETE("/keys/ocr/:keyID", auth.RequiresAdminRole(ocrkc.Delete))
 		authv2.POST("/keys/ocr/import", auth.RequiresAdminRole(ocrkc.Import))
-		authv2.POST("/keys/ocr/export/:ID", auth.RequiresAdminRole(ocrkc.Export))
+		authv2.POST("/keys/ocr/export/:ID", auth.RequiresScopedPermission(roles, clsessions.PermissionKeysOCRExport, auth.ParamScope(clsessions.ScopeTypeKey, "ID"), ocrkc.Export))
 
 		ocr2kc := OCR2KeysController{app}
 		authv2.GET("/keys/ocr2", ocr2kc.Index)
 		authv2.POST("/keys/ocr2/:chainType", auth.RequiresEditRole(ocr2kc.Create))
 		authv2.DELETE("/keys/ocr2/:keyID", auth.RequiresAdminRole(ocr2kc.Delete))
 		authv2.POST("/keys/ocr2/import", auth.RequiresAdminRole(ocr2kc.Import))
-		authv2.POST("/keys/ocr2/export/:ID", auth.RequiresAdminRole(ocr2kc.Export))
+		authv2.POST("/keys/ocr2/export/:ID", auth.RequiresScopedPermission(roles, clsessions.PermissionKeysOCRExport, auth.ParamScope(clsessions.ScopeTypeKey, "ID"), ocr2kc.Export))
 
 		p2pkc := P2PKeysController{app}
 		authv2.GET("/keys/p2p", p2pkc.Index)
 		authv2.POST("/keys/p2p", auth.RequiresEditRole(p2pkc.Create))
 		authv2.DELETE("/keys/p2p/:keyID", auth.RequiresAdminRole(p2pkc.Delete))
 		authv2.POST("/keys/p2p/import", auth.RequiresAdminRole(p2pkc.Import))
-		authv2.POST("/keys/p2p/export/:ID", auth.RequiresAdminRole(p2pkc.Export))
+		authv2.POST("/keys/p2p/export/:ID", auth.RequiresScopedPermission(roles, clsessions.PermissionKeysP2PExport, auth.ParamScope(clsessions.ScopeTypeKey, "ID"), p2pkc.Export))
 
 		for _, keys := range []struct {
 			path string
@@ -371,14 +409,24 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/keys/vrf", auth.RequiresEditRole(vrfkc.Create))
 		authv2.DELETE("/keys/vrf/:keyID", auth.RequiresAdminRole(vrfkc.Delete))
 		authv2.POST("/keys/vrf/import", auth.RequiresAdminRole(vrfkc.Import))
-		authv2.POST("/keys/vrf/export/:keyID", auth.RequiresAdminRole(vrfkc.Export))
+		authv2.POST("/keys/vrf/export/:keyID", auth.RequiresScopedPermission(roles, clsessions.PermissionKeysVRFExport, auth.ParamScope(clsessions.ScopeTypeKey, "keyID"), vrfkc.Export))
+		authv2.POST("/keys/vrf/:keyID/rotate", auth.RequiresAdminRole(vrfkc.Rotate))
+		authv2.GET("/keys/vrf/rotations", vrfkc.Rotations)
+
+		vrfrc := VRFRequestsController{app}
+		authv2.GET("/vrf/requests/:requestID", vrfrc.Show)
+		authv2.POST("/vrf/fulfillments/dry_run", auth.RequiresAdminRole(vrfrc.DryRunFulfillment))
+		authv2.POST("/vrf/proofs/verify", auth.RequiresEditRole(vrfrc.VerifyProof))
 
-		jc := JobsController{app}
+		jc := JobsController{App: app, WebhookORM: app.WebhookORM()}
 		authv2.GET("/jobs", paginatedRequest(jc.Index))
 		authv2.GET("/jobs/:ID", jc.Show)
-		authv2.POST("/jobs", auth.RequiresEditRole(jc.Create))
-		authv2.PUT("/jobs/:ID", auth.RequiresEditRole(jc.Update))
-		authv2.DELETE("/jobs/:ID", auth.RequiresEditRole(jc.Delete))
+		authv2.POST("/jobs", auth.RequiresScopedPermission(roles, clsessions.PermissionJobsCreate, nil, jc.Create))
+		authv2.POST("/jobs/validate", auth.RequiresScopedPermission(roles, clsessions.PermissionJobsCreate, jobScopes(app), jc.Validate))
+		authv2.POST("/jobs/simulate", auth.RequiresScopedPermission(roles, clsessions.PermissionJobsCreate, jobScopes(app), jc.Simulate))
+		authv2.PUT("/jobs/:ID", auth.RequiresScopedPermission(roles, clsessions.PermissionJobsUpdate, jobScopes(app), jc.Update))
+		authv2.DELETE("/jobs/:ID", auth.RequiresScopedPermission(roles, clsessions.PermissionJobsDelete, jobScopes(app), jc.Delete))
+		authv2.POST("/jobs/:ID/public_trigger_secret", auth.RequiresScopedPermission(roles, clsessions.PermissionJobsUpdate, jobScopes(app), jc.RotatePublicTriggerSecret))
 
 		// PipelineRunsController
 		authv2.GET("/pipeline/runs", paginatedRequest(prc.Index))
@@ -430,11 +478,12 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 	ping := PingController{app}
 	userOrEI := r.Group("/v2", auth.Authenticate(app.AuthenticationProvider(),
 		auth.AuthenticateExternalInitiator,
+		auth.AuthenticateByAPIToken(app.APITokensORM(), app.GetAuditLogger()),
 		auth.AuthenticateByToken,
 		auth.AuthenticateBySession,
 	))
 	userOrEI.GET("/ping", ping.Show)
-	userOrEI.POST("/jobs/:ID/runs", auth.RequiresRunRole(prc.Create))
+	userOrEI.POST("/jobs/:ID/runs", auth.RequiresScopedPermission(app.RolesORM(), clsessions.PermissionJobsRun, jobScopes(app), prc.Create))
 }
 
 // This is higher because it serves main.js and any static images. There are
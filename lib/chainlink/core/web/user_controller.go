@@ -1,8 +1,11 @@
 package web
 
 import (
+	"database/sql"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
@@ -329,6 +332,129 @@ func (u *UserController) DeleteAPIToken(c *gin.Context) {
 	}
 }
 
+// defaultAPITokenExpiry is the expiry of named API tokens created without one.
+const defaultAPITokenExpiry = 30 * 24 * time.Hour
+
+// ListAPITokens lists the named API tokens of the current user.
+// Example:
+// "GET <application>/user/tokens"
+func (u *UserController) ListAPITokens(c *gin.Context) {
+	sessionUser, ok := webauth.GetAuthenticatedUser(c)
+	if !ok {
+		jsonAPIError(c, http.StatusInternalServerError, errors.New("failed to obtain current user from context"))
+		return
+	}
+	tokens, err := u.App.APITokensORM().ListAPITokens(c.Request.Context(), sessionUser.Email)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewAPITokenResources(tokens), "apiTokens")
+}
+
+// CreateNamedAPIToken creates a named API token of the current user, with a role no higher than
+// the role of the current user. The secret is only returned in the response.
+// Example:
+// "POST <application>/user/tokens"
+func (u *UserController) CreateNamedAPIToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	var request clsession.NewAPITokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	sessionUser, ok := webauth.GetAuthenticatedUser(c)
+	if !ok {
+		jsonAPIError(c, http.StatusInternalServerError, errors.New("failed to obtain current user from context"))
+		return
+	}
+	err := u.App.AuthenticationProvider().TestPassword(ctx, sessionUser.Email, request.Password)
+	if err != nil {
+		u.App.GetAuditLogger().Audit(audit.APITokenCreateAttemptPasswordMismatch, map[string]interface{}{"user": sessionUser.Email})
+		jsonAPIError(c, http.StatusUnauthorized, errors.New("incorrect password"))
+		return
+	}
+
+	role := sessionUser.Role
+	if request.Role != "" {
+		role, err = clsession.GetUserRole(request.Role)
+		if err != nil {
+			jsonAPIError(c, http.StatusBadRequest, err)
+			return
+		}
+	}
+	expiresIn := defaultAPITokenExpiry
+	if request.ExpiresIn != "" {
+		expiresIn, err = time.ParseDuration(request.ExpiresIn)
+		if err != nil {
+			jsonAPIError(c, http.StatusBadRequest, errors.Wrap(err, "invalid expiry"))
+			return
+		}
+	}
+	// The current user is scoped by the token it authenticated with, if any, so a token can not
+	// create another one with a higher role
+	if err = clsession.ValidateAPIToken(*sessionUser, request.Name, role, expiresIn, request.IPAllowlist); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	token, secret, err := u.App.APITokensORM().CreateAPIToken(ctx, clsession.APIToken{
+		Email:       sessionUser.Email,
+		Name:        request.Name,
+		Role:        role,
+		IPAllowlist: request.IPAllowlist,
+		ExpiresAt:   time.Now().Add(expiresIn),
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			jsonAPIError(c, http.StatusBadRequest, errors.Errorf("a token named %s already exists", request.Name))
+			return
+		}
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	u.App.GetAuditLogger().Audit(audit.APITokenCreated, map[string]interface{}{
+		"user":      sessionUser.Email,
+		"token":     token.Name,
+		"id":        token.ID,
+		"role":      token.Role,
+		"expiresAt": token.ExpiresAt,
+	})
+	resource := presenters.NewAPITokenResource(token)
+	resource.Secret = secret.Secret
+	jsonAPIResponseWithStatus(c, resource, "apiToken", http.StatusCreated)
+}
+
+// RevokeAPIToken revokes a named API token of the current user.
+// Example:
+// "DELETE <application>/user/tokens/:ID"
+func (u *UserController) RevokeAPIToken(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("ID"), 10, 64)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	sessionUser, ok := webauth.GetAuthenticatedUser(c)
+	if !ok {
+		jsonAPIError(c, http.StatusInternalServerError, errors.New("failed to obtain current user from context"))
+		return
+	}
+	err = u.App.APITokensORM().DeleteAPIToken(c.Request.Context(), sessionUser.Email, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("API token not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	u.App.GetAuditLogger().Audit(audit.APITokenDeleted, map[string]interface{}{"user": sessionUser.Email, "id": id})
+	jsonAPIResponseWithStatus(c, nil, "apiToken", http.StatusNoContent)
+}
+
 func getCurrentSessionID(c *gin.Context) (string, error) {
 	session := sessions.Default(c)
 	sessionID, ok := session.Get(webauth.SessionIDKey).(string)
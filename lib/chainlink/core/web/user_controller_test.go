@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +18,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/sessions"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	webauth "github.com/smartcontractkit/chainlink/v2/core/web/auth"
 )
 
 func TestUserController_UpdatePassword(t *testing.T) {
@@ -325,3 +327,76 @@ func TestUserController_DeleteAPIKey_unauthorized(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 }
+
+func TestUserController_NamedAPITokens(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+
+	user := cltest.User{Email: "tokens@chainlink.test", Role: sessions.UserRoleEdit}
+	client := app.NewHTTPClient(&user)
+	_, err := app.RolesORM().CreateRole(ctx, sessions.CustomRole{Name: "exporter", Permissions: []sessions.Permission{sessions.PermissionKeysETHExport}})
+	require.NoError(t, err)
+	_, err = app.RolesORM().CreateGrant(ctx, user.Email, "exporter", sessions.NodeScope())
+	require.NoError(t, err)
+
+	newToken := func(name string, role sessions.UserRole, expiresAt time.Time, ipAllowlist ...string) (sessions.APIToken, *auth.Token) {
+		apiToken, token, err := app.APITokensORM().CreateAPIToken(ctx, sessions.APIToken{
+			Email:       user.Email,
+			Name:        name,
+			Role:        role,
+			ExpiresAt:   expiresAt,
+			IPAllowlist: ipAllowlist,
+		})
+		require.NoError(t, err)
+		return apiToken, token
+	}
+	do := func(method, path string, token *auth.Token) int {
+		req, err := http.NewRequestWithContext(ctx, method, app.Server.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set(webauth.APIKey, token.AccessKey)
+		req.Header.Set(webauth.APISecret, token.Secret)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+	exportPath := "/v2/keys/eth/export/0x0000000000000000000000000000000000000001"
+	notAuthorized := []int{http.StatusUnauthorized, http.StatusForbidden}
+
+	editID, edit := newToken("edit", sessions.UserRoleEdit, time.Now().Add(time.Hour))
+	_, view := newToken("view", sessions.UserRoleView, time.Now().Add(time.Hour))
+	_, expired := newToken("expired", sessions.UserRoleEdit, time.Now().Add(-time.Minute))
+	_, local := newToken("local", sessions.UserRoleView, time.Now().Add(time.Hour), "127.0.0.1")
+	_, remote := newToken("remote", sessions.UserRoleView, time.Now().Add(time.Hour), "10.0.0.0/8")
+
+	t.Run("role of the user", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, "/v2/jobs", edit))
+		assert.NotContains(t, notAuthorized, do(http.MethodPost, "/v2/bridge_types", edit))
+		assert.NotContains(t, notAuthorized, do(http.MethodPost, exportPath, edit), "the grants of the user apply")
+	})
+
+	t.Run("role capped by the token", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, "/v2/jobs", view))
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodPost, "/v2/bridge_types", view))
+		assert.Equal(t, http.StatusForbidden, do(http.MethodPost, exportPath, view), "the grants of the user don't apply")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/v2/jobs", expired))
+	})
+
+	t.Run("ip allowlist", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, "/v2/jobs", local))
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/v2/jobs", remote))
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		resp, cleanup := client.Delete(fmt.Sprintf("/v2/user/tokens/%d", editID.ID))
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/v2/jobs", edit))
+	})
+}
@@ -3,11 +3,15 @@ package web
 import (
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
 
@@ -126,3 +130,103 @@ func (vrfkc *VRFKeysController) Export(c *gin.Context) {
 
 	c.Data(http.StatusOK, MediaType, bytes)
 }
+
+// Rotate starts rotating every VRF job using the given key to a new key, which is
+// created unless an existing one is given. Requests for both keys are answered
+// until the overlap passes and no pending request references the old key, after
+// which the old key is retired. The overlap defaults to each job's keyRotationOverlap.
+// Only the node's jobs and keystore change, registering the new key on the
+// coordinator is left to its owner.
+// Example:
+// "POST <application>/keys/vrf/:keyID/rotate"
+// "POST <application>/keys/vrf/:keyID/rotate?newKeyID=<keyID>&overlap=1h"
+func (vrfkc *VRFKeysController) Rotate(c *gin.Context) {
+	ctx := c.Request.Context()
+	keyStore := vrfkc.App.GetKeyStore().VRF()
+
+	var overlap time.Duration
+	if o := c.Query("overlap"); o != "" {
+		var err error
+		overlap, err = time.ParseDuration(o)
+		if err != nil || overlap < 0 {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("invalid overlap %q", o))
+			return
+		}
+	}
+
+	keyID := c.Param("keyID")
+	oldKey, err := keyStore.Get(keyID)
+	if err != nil {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	}
+
+	var newKey vrfkey.KeyV2
+	newKeyID := c.Query("newKeyID")
+	if newKeyID != "" {
+		newKey, err = keyStore.Get(newKeyID)
+		if err != nil {
+			jsonAPIError(c, http.StatusNotFound, errors.Wrap(err, "new key"))
+			return
+		}
+	} else {
+		newKey, err = keyStore.Rotate(ctx, keyID)
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		vrfkc.App.GetAuditLogger().Audit(audit.KeyCreated, map[string]interface{}{
+			"type":                "vrf",
+			"id":                  newKey.ID(),
+			"vrfPublicKey":        newKey.PublicKey,
+			"vrfPublicKeyAddress": newKey.PublicKey.Address(),
+		})
+	}
+
+	orm := vrfcommon.NewKeyRotationORM(vrfkc.App.GetDB())
+	rotations, err := orm.StartKeyRotations(ctx, oldKey.PublicKey, newKey.PublicKey, overlap)
+	if err != nil {
+		if newKeyID == "" {
+			// Don't leave the key created for the rotation behind.
+			if _, err2 := keyStore.Delete(ctx, newKey.ID()); err2 != nil {
+				vrfkc.App.GetLogger().Errorw("Failed to delete VRF key created for rotation", "id", newKey.ID(), "err", err2)
+			}
+		}
+		switch {
+		case errors.Is(err, vrfcommon.ErrNoJobsForKey):
+			jsonAPIError(c, http.StatusNotFound, err)
+		case errors.Is(err, vrfcommon.ErrKeyRotationInProgress):
+			jsonAPIError(c, http.StatusConflict, err)
+		case errors.Is(err, vrfcommon.ErrKeyRotationKeysNotUnique):
+			jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		default:
+			jsonAPIError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	jobIDs := make([]int32, len(rotations))
+	for i, r := range rotations {
+		jobIDs[i] = r.JobID
+	}
+	vrfkc.App.GetAuditLogger().Audit(audit.KeyRotated, map[string]interface{}{
+		"type":   "vrf",
+		"id":     keyID,
+		"newID":  newKey.ID(),
+		"jobIDs": jobIDs,
+	})
+
+	jsonAPIResponse(c, presenters.NewVRFKeyRotationResources(rotations, time.Now()), "vrfKeyRotation")
+}
+
+// Rotations lists VRF key rotations, most recent first.
+// Example:
+// "GET <application>/keys/vrf/rotations"
+func (vrfkc *VRFKeysController) Rotations(c *gin.Context) {
+	rotations, err := vrfcommon.NewKeyRotationORM(vrfkc.App.GetDB()).FindKeyRotations(c.Request.Context())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewVRFKeyRotationResources(rotations, time.Now()), "vrfKeyRotation")
+}
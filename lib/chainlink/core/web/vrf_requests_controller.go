@@ -0,0 +1,184 @@
+package web
+
+import (
+	"database/sql"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+	vrfv2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// VRFRequestsController shows the lifecycle of VRF requests handled by this node,
+// dry-runs their fulfillment and verifies fulfilled proofs.
+type VRFRequestsController struct {
+	App chainlink.Application
+}
+
+// Show returns the recorded lifecycle of a VRF request, together with the
+// fulfillment transactions enqueued for it.
+// The request ID may be given in decimal or 0x prefixed hex.
+// Example:
+// "GET <application>/vrf/requests/:requestID"
+func (vrc *VRFRequestsController) Show(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID, ok := new(big.Int).SetString(c.Param("requestID"), 0)
+	if !ok || requestID.Sign() < 0 {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("invalid request ID %q", c.Param("requestID")))
+		return
+	}
+
+	orm := vrfcommon.NewLifecycleORM(vrc.App.GetDB())
+	events, err := orm.FindLifecycleEvents(ctx, requestID.String())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if len(events) == 0 {
+		jsonAPIError(c, http.StatusNotFound, errors.New("VRF request not found"))
+		return
+	}
+
+	var txs []txmgr.Tx
+	seen := make(map[int64]struct{})
+	for _, e := range events {
+		txID, ok := e.TxID()
+		if !ok {
+			continue
+		}
+		if _, ok := seen[txID]; ok {
+			continue
+		}
+		seen[txID] = struct{}{}
+
+		tx, err := vrc.App.TxmStorageService().FindTxWithAttempts(ctx, txID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The transaction may have been reaped already.
+			continue
+		}
+		if err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		txs = append(txs, tx)
+	}
+
+	jsonAPIResponse(c, presenters.NewVRFRequestLifecycleResource(requestID.String(), events, txs), "vrf_request")
+}
+
+// DryRunVRFFulfillmentRequest is a JSONAPI request for dry-running the fulfillment of a
+// VRF v2plus request.
+type DryRunVRFFulfillmentRequest struct {
+	EVMChainID         *ubig.Big      `json:"evmChainID"`
+	CoordinatorAddress common.Address `json:"coordinatorAddress"`
+	TxHash             common.Hash    `json:"txHash"`
+	LogIndex           uint           `json:"logIndex"`
+	BlockNumber        *ubig.Big      `json:"blockNumber"`
+}
+
+// DryRunFulfillment runs the fulfillment pipeline of the job serving the given request
+// log and simulates the resulting fulfillment and consumer callback. Nothing is
+// broadcast. The response includes the proof, and so reveals the randomness of a
+// possibly still pending request.
+// Example:
+// "POST <application>/vrf/fulfillments/dry_run"
+func (vrc *VRFRequestsController) DryRunFulfillment(c *gin.Context) {
+	request := DryRunVRFFulfillmentRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if request.CoordinatorAddress == (common.Address{}) || request.TxHash == (common.Hash{}) {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("coordinatorAddress and txHash are required"))
+		return
+	}
+
+	res, err := vrc.App.GetVRFDryRunner().DryRunFulfillment(c.Request.Context(), vrfv2.DryRunRequest{
+		EVMChainID:  request.EVMChainID.ToInt(),
+		Coordinator: request.CoordinatorAddress,
+		TxHash:      request.TxHash,
+		LogIndex:    request.LogIndex,
+		BlockNumber: request.BlockNumber.ToInt(),
+	})
+	if errors.Is(err, vrfv2.ErrDryRunnerNotFound) || errors.Is(err, vrfv2.ErrDryRunLogNotFound) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewVRFDryRunResource(res), "vrf_dry_run")
+}
+
+// VerifyVRFProofRequest is a JSONAPI request for verifying the proof of a fulfilled
+// VRF v2 or v2plus request.
+type VerifyVRFProofRequest struct {
+	PublicKey  secp256k1.PublicKey     `json:"publicKey"`
+	RequestID  *ubig.Big               `json:"requestID"`
+	BlockHash  common.Hash             `json:"blockHash"`
+	Commitment proof.RequestCommitment `json:"commitment"`
+	Calldata   hexutil.Bytes           `json:"calldata"`
+}
+
+// VerifyProof decodes the proof in the calldata of a fulfillment of a request to the
+// given VRF public key, and checks it against the request commitment the same way the
+// coordinator does. The key does not need to be held by this node, so that proofs of
+// other nodes can be audited. The outcome is returned as an attestation signed with
+// the node's CSA key.
+// Example:
+// "POST <application>/vrf/proofs/verify"
+func (vrc *VRFRequestsController) VerifyProof(c *gin.Context) {
+	request := VerifyVRFProofRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if request.PublicKey.IsZero() || len(request.Calldata) == 0 {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("publicKey and calldata are required"))
+		return
+	}
+
+	csaKeys, err := vrc.App.GetKeyStore().CSA().GetAll()
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if len(csaKeys) == 0 {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("the node has no CSA key to sign the attestation with, create one with `chainlink keys csa create`"))
+		return
+	}
+	csaKey := csaKeys[0]
+
+	v, err := proof.VerifyFulfillment(proof.FulfillmentVerificationRequest{
+		PublicKey:  request.PublicKey,
+		RequestID:  request.RequestID.ToInt(),
+		BlockHash:  request.BlockHash,
+		Commitment: request.Commitment,
+		Calldata:   request.Calldata,
+	})
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+	signed, err := proof.NewSignedAttestation(v, csaKey, time.Now())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewVRFProofAttestationResource(v, signed), "vrf_proof_attestation")
+}
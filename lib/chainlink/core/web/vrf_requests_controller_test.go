@@ -0,0 +1,66 @@
+package web_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+)
+
+func setupVRFRequestsControllerTest(t *testing.T) cltest.HTTPClientCleaner {
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	return app.NewHTTPClient(nil)
+}
+
+func TestVRFRequestsController_Show(t *testing.T) {
+	t.Parallel()
+
+	client := setupVRFRequestsControllerTest(t)
+
+	resp, cleanup := client.Get("/v2/vrf/requests/not-a-number")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusUnprocessableEntity)
+
+	resp, cleanup = client.Get("/v2/vrf/requests/0x1234")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+}
+
+func TestVRFRequestsController_DryRunFulfillment(t *testing.T) {
+	t.Parallel()
+
+	client := setupVRFRequestsControllerTest(t)
+
+	for _, tc := range []struct {
+		name   string
+		body   string
+		status int
+		errMsg string
+	}{
+		{"malformed body", `{"logIndex": "one"}`, http.StatusUnprocessableEntity, ""},
+		{"missing tx hash", `{"coordinatorAddress": "0x0000000000000000000000000000000000000001"}`, http.StatusUnprocessableEntity, "coordinatorAddress and txHash are required"},
+		{"missing coordinator", `{"txHash": "0x0000000000000000000000000000000000000000000000000000000000000001"}`, http.StatusUnprocessableEntity, "coordinatorAddress and txHash are required"},
+		{
+			"no job for coordinator",
+			`{"coordinatorAddress": "0x0000000000000000000000000000000000000001", "txHash": "0x0000000000000000000000000000000000000000000000000000000000000001"}`,
+			http.StatusNotFound,
+			"no running VRF v2plus job found for coordinator",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, cleanup := client.Post("/v2/vrf/fulfillments/dry_run", bytes.NewBufferString(tc.body))
+			t.Cleanup(cleanup)
+			require.Equal(t, tc.status, resp.StatusCode)
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(b), tc.errMsg)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package web
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// WebhookTriggersController triggers webhook jobs from their public trigger, which is not
+// authenticated by a session or an External Initiator, but by the signature or the token of
+// the request.
+type WebhookTriggersController struct {
+	App chainlink.Application
+}
+
+// Create triggers a run of a webhook job with a public trigger.
+// Example:
+// "POST <application>/webhooks/:ID"
+func (wtc *WebhookTriggersController) Create(c *gin.Context) {
+	jobUUID, err := uuid.Parse(c.Param("ID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("bad job ID"))
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, errors.Wrap(err, "failed to read body"))
+		return
+	}
+
+	runID, err := wtc.App.RunPublicWebhookJobV2(c.Request.Context(), jobUUID, c.Request.Header, body)
+	switch {
+	case errors.Is(err, webhook.ErrJobNotExists):
+		jsonAPIError(c, http.StatusNotFound, err)
+	case errors.Is(err, webhook.ErrPublicTriggerUnauthorized):
+		jsonAPIError(c, http.StatusUnauthorized, err)
+	case errors.Is(err, webhook.ErrPublicTriggerRateLimited):
+		jsonAPIError(c, http.StatusTooManyRequests, err)
+	case errors.Is(err, webhook.ErrPublicTriggerInvalidRequest):
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+	case err != nil:
+		jsonAPIError(c, http.StatusInternalServerError, err)
+	default:
+		// Callers are not authenticated users, so they only get the ID of the run, not its results.
+		jsonAPIResponse(c, presenters.WebhookPublicTriggerRunResource{JAID: presenters.NewJAIDInt64(runID)}, "pipelineRun")
+	}
+}
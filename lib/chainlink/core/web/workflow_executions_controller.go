@@ -0,0 +1,101 @@
+package web
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web/auth"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// WorkflowExecutionsController lets operators inspect and cancel workflow executions.
+type WorkflowExecutionsController struct {
+	App chainlink.Application
+}
+
+// Index lists the workflow executions, most recent first, optionally filtered by workflow ID
+// and status.
+// Example:
+// "GET <application>/workflows/executions?workflowID=<id>&status=started"
+func (wec *WorkflowExecutionsController) Index(c *gin.Context, size, page, offset int) {
+	executions, count, err := wec.App.GetWorkflowExecutionsStore().List(c.Request.Context(), store.ExecutionsFilter{
+		WorkflowID: c.Query("workflowID"),
+		Status:     c.Query("status"),
+	}, offset, size)
+
+	paginatedResponse(c, "workflowExecutions", size, page, presenters.NewWorkflowExecutionResources(executions, true), count, err)
+}
+
+// Show returns a workflow execution with its trigger, capability calls, spend and user logs.
+// The contents of the payloads are only shown to admins.
+// Example:
+// "GET <application>/workflows/executions/:ID"
+func (wec *WorkflowExecutionsController) Show(c *gin.Context) {
+	execution, err := wec.App.GetWorkflowExecutionsStore().Get(c.Request.Context(), c.Param("ID"))
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("workflow execution not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewWorkflowExecutionResource(execution, !canViewWorkflowPayloads(c)), "workflowExecution")
+}
+
+// Cancel stops a workflow execution that has not finished.
+// Example:
+// "POST <application>/workflows/executions/:ID/cancel"
+func (wec *WorkflowExecutionsController) Cancel(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("ID")
+	err := wec.App.CancelWorkflowExecution(ctx, id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		jsonAPIError(c, http.StatusNotFound, errors.New("workflow execution not found"))
+		return
+	case errors.Is(err, chainlink.ErrWorkflowExecutionFinished):
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	case err != nil:
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	wec.App.GetAuditLogger().Audit(audit.WorkflowExecutionCanceled, map[string]interface{}{"executionID": id})
+
+	execution, err := wec.App.GetWorkflowExecutionsStore().Get(ctx, id)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	jsonAPIResponse(c, presenters.NewWorkflowExecutionResource(execution, !canViewWorkflowPayloads(c)), "workflowExecution")
+}
+
+// workflowExecutionScopes returns the resource of the workflow execution named by the ID path
+// parameter. Workflows deployed from the registry have no job, so executions are scoped by the
+// workflow job type. Executions which can't be found are scoped by the node, and the handler
+// reports them.
+func workflowExecutionScopes(app chainlink.Application) auth.ScopeFunc {
+	return func(c *gin.Context) ([]sessions.Scope, error) {
+		if _, err := app.GetWorkflowExecutionsStore().Get(c.Request.Context(), c.Param("ID")); err != nil {
+			return []sessions.Scope{sessions.NodeScope()}, nil
+		}
+		return []sessions.Scope{sessions.JobTypeScope(job.Workflow.String())}, nil
+	}
+}
+
+// canViewWorkflowPayloads is the redaction policy of the payloads of workflow executions, which
+// may contain sensitive data: only admins can see their contents.
+func canViewWorkflowPayloads(c *gin.Context) bool {
+	user, ok := auth.GetAuthenticatedUser(c)
+	return ok && user.Role == sessions.UserRoleAdmin
+}
@@ -0,0 +1,169 @@
+package web_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/values"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/artifacts"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/store"
+	"github.com/smartcontractkit/chainlink/v2/core/sessions"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// setupWorkflowExecutions saves the started execution "e1", triggered by an event with the
+// payload "secret", and the completed execution "e2".
+func setupWorkflowExecutions(t *testing.T, app *cltest.TestApplication) {
+	ctx := testutils.Context(t)
+	_, err := artifacts.NewWorkflowRegistryDS(app.GetDB(), logger.TestLogger(t)).UpsertWorkflowSpec(ctx, &job.WorkflowSpec{
+		Workflow:      "workflow",
+		WorkflowID:    "w1",
+		WorkflowOwner: "owner",
+		WorkflowName:  "name",
+		Status:        job.WorkflowSpecStatusActive,
+		CreatedAt:     time.Now(),
+		SpecType:      job.WASMFile,
+	})
+	require.NoError(t, err)
+
+	inputs, err := values.NewMap(map[string]any{
+		"TriggerID":    "trigger@1.0.0",
+		"TriggerIndex": int64(0),
+		"EventID":      "event-1",
+		"TriggerType":  "basic-trigger@1.0.0",
+	})
+	require.NoError(t, err)
+	payload, err := proto.Marshal(&anypb.Any{TypeUrl: "type.googleapis.com/test", Value: []byte("secret")})
+	require.NoError(t, err)
+
+	s := app.GetWorkflowExecutionsStore()
+	_, err = s.Add(ctx, map[string]*store.WorkflowExecutionStep{
+		"trigger": {Ref: "trigger", Status: store.StatusCompleted, Inputs: inputs, Outputs: store.StepOutput{Value: values.NewBytes(payload)}},
+	}, "e1", "w1", store.StatusStarted)
+	require.NoError(t, err)
+	_, err = s.Add(ctx, nil, "e2", "w1", store.StatusStarted)
+	require.NoError(t, err)
+	_, err = s.FinishExecution(ctx, "e2", store.StatusCompleted)
+	require.NoError(t, err)
+}
+
+func TestWorkflowExecutionsController_Index(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	setupWorkflowExecutions(t, app)
+	client := app.NewHTTPClient(nil)
+
+	resp, cleanup := client.Get("/v2/workflows/executions?size=10")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var links jsonapi.Links
+	var resources []presenters.WorkflowExecutionResource
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &resources, &links))
+	require.Len(t, resources, 2)
+	for _, r := range resources {
+		assert.Equal(t, "w1", r.WorkflowID)
+		assert.Nil(t, r.Trigger, "the steps are only shown for a single execution")
+	}
+
+	resp, cleanup = client.Get("/v2/workflows/executions?size=10&workflowID=w1&status=started")
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	resources = nil
+	require.NoError(t, web.ParsePaginatedResponse(cltest.ParseResponseBody(t, resp), &resources, &links))
+	require.Len(t, resources, 1)
+	assert.Equal(t, "e1", resources[0].ID)
+	assert.Equal(t, store.StatusStarted, resources[0].Status)
+}
+
+func TestWorkflowExecutionsController_Show(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	setupWorkflowExecutions(t, app)
+
+	t.Run("shows payloads to admins", func(t *testing.T) {
+		client := app.NewHTTPClient(nil)
+		resp, cleanup := client.Get("/v2/workflows/executions/e1")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var r presenters.WorkflowExecutionResource
+		cltest.ParseJSONAPIResponse(t, resp, &r)
+		assert.Equal(t, "e1", r.ID)
+		require.NotNil(t, r.Trigger)
+		assert.Equal(t, "event-1", r.Trigger.EventID)
+		require.NotNil(t, r.Trigger.Payload)
+		assert.False(t, r.Trigger.Payload.Redacted)
+		assert.Equal(t, []byte("secret"), r.Trigger.Payload.Value)
+	})
+
+	t.Run("redacts payloads for other users", func(t *testing.T) {
+		client := app.NewHTTPClient(&cltest.User{Role: sessions.UserRoleView})
+		resp, cleanup := client.Get("/v2/workflows/executions/e1")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+		var r presenters.WorkflowExecutionResource
+		cltest.ParseJSONAPIResponse(t, resp, &r)
+		require.NotNil(t, r.Trigger)
+		require.NotNil(t, r.Trigger.Payload)
+		assert.True(t, r.Trigger.Payload.Redacted)
+		assert.Empty(t, r.Trigger.Payload.Value)
+		assert.Equal(t, 6, r.Trigger.Payload.Size)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := app.NewHTTPClient(nil)
+		resp, cleanup := client.Get("/v2/workflows/executions/missing")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	})
+}
+
+func TestWorkflowExecutionsController_Cancel(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+	setupWorkflowExecutions(t, app)
+	client := app.NewHTTPClient(nil)
+
+	resp, cleanup := client.Post("/v2/workflows/executions/missing/cancel", nil)
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+
+	resp, cleanup = client.Post("/v2/workflows/executions/e2/cancel", nil)
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+	resp, cleanup = client.Post("/v2/workflows/executions/e1/cancel", nil)
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var r presenters.WorkflowExecutionResource
+	cltest.ParseJSONAPIResponse(t, resp, &r)
+	assert.Equal(t, store.StatusCanceled, r.Status)
+	assert.NotNil(t, r.FinishedAt)
+
+	resp, cleanup = client.Post("/v2/workflows/executions/e1/cancel", nil)
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusConflict)
+}
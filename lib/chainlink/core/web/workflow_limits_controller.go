@@ -0,0 +1,32 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/ratelimiter"
+	"github.com/smartcontractkit/chainlink/v2/core/services/workflows/syncerlimiter"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// WorkflowLimitsController shows the usage of the workflow limits.
+type WorkflowLimitsController struct {
+	App chainlink.Application
+}
+
+// Index lists the current usage of the execution rate limits and quotas, and of the
+// running workflow limits, of each workflow owner.
+// Example:
+// "GET <application>/workflows/limits"
+func (wlc *WorkflowLimitsController) Index(c *gin.Context) {
+	var senders []ratelimiter.SenderUsage
+	if rl := wlc.App.GetWorkflowRateLimiter(); rl != nil {
+		senders = rl.Usage()
+	}
+	var owners []syncerlimiter.OwnerUsage
+	if l := wlc.App.GetWorkflowLimits(); l != nil {
+		owners, _ = l.Usage()
+	}
+
+	jsonAPIResponse(c, presenters.NewWorkflowLimitsResources(senders, owners), "workflowLimits")
+}